@@ -2,40 +2,141 @@ package main
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"log"
+	nethttp "net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	eventController "github.com/aaydin-tr/ddd-api-example/controller/event"
+	orderController "github.com/aaydin-tr/ddd-api-example/controller/order"
+	outboxController "github.com/aaydin-tr/ddd-api-example/controller/outbox"
+	promotionController "github.com/aaydin-tr/ddd-api-example/controller/promotion"
+	purchaseController "github.com/aaydin-tr/ddd-api-example/controller/purchase"
+	reservationController "github.com/aaydin-tr/ddd-api-example/controller/reservation"
 	controller "github.com/aaydin-tr/ddd-api-example/controller/ticket"
-	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	venueController "github.com/aaydin-tr/ddd-api-example/controller/venue"
+	waitlistController "github.com/aaydin-tr/ddd-api-example/controller/waitlist"
+	webhookController "github.com/aaydin-tr/ddd-api-example/controller/webhook"
+	transaction "github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db/migration"
 	"github.com/aaydin-tr/ddd-api-example/infrastructure/db/postgresql"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/idempotency"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/outbox"
 	"github.com/aaydin-tr/ddd-api-example/interface/http"
 
+	eventRepository "github.com/aaydin-tr/ddd-api-example/domain/event/repository"
+	orderRepository "github.com/aaydin-tr/ddd-api-example/domain/order/repository"
+	promotionRepository "github.com/aaydin-tr/ddd-api-example/domain/promotion/repository"
+	purchaseRepository "github.com/aaydin-tr/ddd-api-example/domain/purchase/repository"
+	reservationRepository "github.com/aaydin-tr/ddd-api-example/domain/reservation/repository"
+	seatRepository "github.com/aaydin-tr/ddd-api-example/domain/seat/repository"
 	"github.com/aaydin-tr/ddd-api-example/domain/ticket/repository"
+	venueRepository "github.com/aaydin-tr/ddd-api-example/domain/venue/repository"
+	waitlistRepository "github.com/aaydin-tr/ddd-api-example/domain/waitlist/repository"
+	webhookRepository "github.com/aaydin-tr/ddd-api-example/domain/webhook/repository"
 	"github.com/aaydin-tr/ddd-api-example/pkg/env"
+	"github.com/aaydin-tr/ddd-api-example/pkg/eventbus"
+	eventService "github.com/aaydin-tr/ddd-api-example/service/event"
+	orderService "github.com/aaydin-tr/ddd-api-example/service/order"
+	promotionService "github.com/aaydin-tr/ddd-api-example/service/promotion"
+	purchaseService "github.com/aaydin-tr/ddd-api-example/service/purchase"
+	reservationService "github.com/aaydin-tr/ddd-api-example/service/reservation"
 	service "github.com/aaydin-tr/ddd-api-example/service/ticket"
+	venueService "github.com/aaydin-tr/ddd-api-example/service/venue"
+	waitlistService "github.com/aaydin-tr/ddd-api-example/service/waitlist"
+	webhookService "github.com/aaydin-tr/ddd-api-example/service/webhook"
+)
+
+const (
+	storagePostgres = "postgres"
+	storageMemory   = "memory"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		migrate(os.Args[2:])
+		return
+	}
+
 	config := env.ParseEnv()
-	db, sqlDB, err := postgresql.NewPostgresDB(config.PostgresHost, config.PostgresUser, config.PostgresPassword, config.PostgresDB, config.PostgresPort)
+	store, err := newStorage(config)
+	if err != nil {
+		panic(err)
+	}
+
+	locking, err := service.ParseLockingMode(config.PurchaseLocking)
 	if err != nil {
 		panic(err)
 	}
 
-	if err := db.AutoMigrate(&ticket.Ticket{}); err != nil {
+	outboxPublisher, err := outbox.NewPublisher(config.OutboxPublisher, config.OutboxWebhookURL, config.OutboxFilePath)
+	if err != nil {
 		panic(err)
 	}
 
-	repo := repository.NewTicketRepository(db)
-	service := service.NewTicketService(repo)
+	// Events are written to the outbox in the same transaction as the change
+	// and handed to in-process subscribers once it has committed.
+	bus := eventbus.New()
+	events := eventbus.Chain(outbox.NewRecorder(store.outbox), transaction.PublishAfterCommit(bus))
+
+	service := service.NewTicketService(store.uow, store.tickets, store.purchases, store.events, store.seats, store.promotions, events, locking)
 	cont := controller.NewTicketController(service)
 
+	purchaseSvc := purchaseService.NewPurchaseService(store.uow, store.purchases, store.tickets, store.seats, events)
+	purchaseCont := purchaseController.NewPurchaseController(purchaseSvc)
+
+	reservationSvc := reservationService.NewReservationService(store.uow, store.reservations, store.tickets, store.purchases, store.seats, events, config.ReservationTTL)
+	reservationCont := reservationController.NewReservationController(reservationSvc)
+
+	orderCont := orderController.NewOrderController(orderService.NewOrderService(store.uow, store.orders, store.tickets, store.purchases, store.seats, store.promotions, events))
+
+	venueCont := venueController.NewVenueController(venueService.NewVenueService(store.uow, store.venues, store.events))
+	eventCont := eventController.NewEventController(eventService.NewEventService(store.uow, store.events, store.venues, store.tickets))
+	// Units returned to a sold out ticket are offered to its waitlist once the
+	// change that returned them has committed.
+	waitlistSvc := waitlistService.NewWaitlistService(store.uow, store.waitlist, store.tickets, store.reservations, store.purchases, store.seats, events, config.WaitlistOfferTTL)
+	waitlistCont := waitlistController.NewWaitlistController(waitlistSvc)
+	eventbus.Subscribe(bus, waitlistService.OfferOnRestore(waitlistSvc))
+
+	promotionCont := promotionController.NewPromotionController(promotionService.NewPromotionService(store.uow, store.promotions, store.tickets))
+
+	webhookSvc := webhookService.NewWebhookService(store.uow, store.webhooks)
+	webhookCont := webhookController.NewWebhookController(webhookSvc)
+	webhookSender := webhookService.NewSender(store.uow, store.webhooks, &nethttp.Client{Timeout: config.WebhookTimeout}, transaction.RetryPolicy{
+		MaxAttempts: config.WebhookMaxAttempts,
+		BaseDelay:   config.WebhookRetryBaseDelay,
+		MaxDelay:    config.WebhookRetryMaxDelay,
+	}, config.WebhookBatchSize)
+
+	// Relayed messages go to the configured publisher first and are then
+	// queued for the webhook subscriptions.
+	relayPublisher := outbox.Chain(outboxPublisher, webhookService.NewDispatcher(store.webhooks))
+
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
 	defer stop()
 
-	svc := http.NewEchoServer(cont, config.Host, config.Port)
+	go idempotency.RunJanitor(ctx, store.idempotency, time.Hour)
+	go reservationService.NewSweeper(reservationSvc, config.ReservationSweepInterval).Run(ctx)
+	go waitlistService.NewSweeper(waitlistSvc, config.WaitlistSweepInterval).Run(ctx)
+	go outbox.NewRelay(store.uow, store.outbox, relayPublisher, config.OutboxBatchSize, config.OutboxRetryBaseDelay, config.OutboxRetryMaxDelay).Run(ctx, config.OutboxPollInterval)
+	go webhookSender.Run(ctx, config.WebhookPollInterval)
+
+	svc := http.NewEchoServer(http.Controllers{
+		Ticket:      cont,
+		Purchase:    purchaseCont,
+		Reservation: reservationCont,
+		Order:       orderCont,
+		Outbox:      outboxController.NewOutboxController(store.outbox),
+		Webhook:     webhookCont,
+		Venue:       venueCont,
+		Event:       eventCont,
+		Promotion:   promotionCont,
+		Waitlist:    waitlistCont,
+	}, store.idempotency, config.IdempotencyKeyTTL, config.Host, config.Port)
 	go svc.Start()
 
 	<-ctx.Done()
@@ -45,10 +146,131 @@ func main() {
 	}
 
 	log.Println("Shutting down the database")
-	if err := sqlDB.Close(); err != nil {
+	if err := store.close(); err != nil {
 		panic(err)
 	}
 
 	log.Println("Server and database are down")
 	log.Println("Goodbye!")
 }
+
+type storage struct {
+	uow          transaction.UnitOfWork
+	tickets      repository.TicketRepository
+	purchases    purchaseRepository.PurchaseRepository
+	reservations reservationRepository.ReservationRepository
+	idempotency  idempotency.Store
+	outbox       outbox.Store
+	webhooks     webhookRepository.WebhookRepository
+	venues       venueRepository.VenueRepository
+	events       eventRepository.EventRepository
+	seats        seatRepository.SeatRepository
+	orders       orderRepository.OrderRepository
+	promotions   promotionRepository.PromotionRepository
+	waitlist     waitlistRepository.WaitlistRepository
+	close        func() error
+}
+
+func newStorage(config *env.ENV) (*storage, error) {
+	switch config.Storage {
+	case storagePostgres:
+		return newPostgresStorage(config)
+	case storageMemory:
+		// Nothing is persisted, idempotency keys are kept in memory as well.
+		return &storage{
+			uow:          transaction.NewMemoryUnitOfWork(),
+			tickets:      repository.NewMemoryTicketRepository(),
+			purchases:    purchaseRepository.NewMemoryPurchaseRepository(),
+			reservations: reservationRepository.NewMemoryReservationRepository(),
+			idempotency:  idempotency.NewMemoryStore(),
+			outbox:       outbox.NewMemoryStore(),
+			webhooks:     webhookRepository.NewMemoryWebhookRepository(),
+			venues:       venueRepository.NewMemoryVenueRepository(),
+			events:       eventRepository.NewMemoryEventRepository(),
+			seats:        seatRepository.NewMemorySeatRepository(),
+			orders:       orderRepository.NewMemoryOrderRepository(),
+			promotions:   promotionRepository.NewMemoryPromotionRepository(),
+			waitlist:     waitlistRepository.NewMemoryWaitlistRepository(),
+			close:        func() error { return nil },
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown storage %q", config.Storage)
+}
+
+func newPostgresStorage(config *env.ENV) (*storage, error) {
+	db, sqlDB, err := postgresql.NewPostgresDB(config.PostgresHost, config.PostgresUser, config.PostgresPassword, config.PostgresDB, config.PostgresPort)
+	if err != nil {
+		return nil, err
+	}
+
+	migrator, err := migration.NewMigrator(sqlDB)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := migrator.Pending(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pending) > 0 {
+		return nil, fmt.Errorf("database schema is behind by %d migration(s), run `migrate up` first", len(pending))
+	}
+
+	isolation, err := transaction.ParseIsolationLevel(config.TxIsolationLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	idempotencyStore, err := idempotency.NewStore(config.IdempotencyStore, db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storage{
+		uow: transaction.NewUnitOfWork(db, transaction.RetryPolicy{
+			MaxAttempts: config.TxMaxAttempts,
+			BaseDelay:   config.TxRetryBaseDelay,
+			MaxDelay:    config.TxRetryMaxDelay,
+		}, isolation),
+		tickets:      repository.NewTicketRepository(db),
+		purchases:    purchaseRepository.NewPurchaseRepository(db),
+		reservations: reservationRepository.NewReservationRepository(db),
+		idempotency:  idempotencyStore,
+		outbox:       outbox.NewPostgresStore(db),
+		webhooks:     webhookRepository.NewWebhookRepository(db),
+		venues:       venueRepository.NewVenueRepository(db),
+		events:       eventRepository.NewEventRepository(db),
+		seats:        seatRepository.NewSeatRepository(db),
+		orders:       orderRepository.NewOrderRepository(db),
+		promotions:   promotionRepository.NewPromotionRepository(db),
+		waitlist:     waitlistRepository.NewWaitlistRepository(db),
+		close:        sqlDB.Close,
+	}, nil
+}
+
+func migrate(args []string) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	var sqlDB *sql.DB
+	err := migration.Run(ctx, args, os.Stdout, func() (*migration.Migrator, error) {
+		config := env.ParseEnv()
+		_, conn, err := postgresql.NewPostgresDB(config.PostgresHost, config.PostgresUser, config.PostgresPassword, config.PostgresDB, config.PostgresPort)
+		if err != nil {
+			return nil, err
+		}
+
+		sqlDB = conn
+		return migration.NewMigrator(sqlDB)
+	})
+
+	if sqlDB != nil {
+		sqlDB.Close()
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,204 @@
+package event
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/event"
+	"github.com/aaydin-tr/ddd-api-example/domain/venue"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/response"
+	"github.com/aaydin-tr/ddd-api-example/pkg/pagination"
+	service "github.com/aaydin-tr/ddd-api-example/service/event"
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrIDIsRequired = errors.New("id is required")
+)
+
+type EventController struct {
+	service service.EventService
+}
+
+func NewEventController(service service.EventService) *EventController {
+	return &EventController{service: service}
+}
+
+// Create godoc
+// @Summary      Create a new event
+// @Description  Create an event at a venue, its tickets together cannot allocate more units than its capacity
+// @Tags         events
+// @Accept       json
+// @Produce      json
+// @Param        event body request.CreateEventRequest true "event"
+// @Success      201  {object}  event.EventDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      422  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /events [post]
+func (e *EventController) Create(c echo.Context) error {
+	var req request.CreateEventRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	created, err := e.service.Create(c.Request().Context(), req)
+	if err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.JSON(http.StatusCreated, created)
+}
+
+// FindByID godoc
+// @Summary      Find event by ID
+// @Description  Find event by ID, its dates are shown in the timezone of its venue
+// @Tags         events
+// @Produce      json
+// @Param        id path int true "event ID"
+// @Success      200  {object}  event.EventDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /events/{id} [get]
+func (e *EventController) FindByID(c echo.Context) error {
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	found, err := e.service.FindByID(c.Request().Context(), id)
+	if err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.JSON(http.StatusOK, found)
+}
+
+// List godoc
+// @Summary      List events
+// @Description  List events ordered by start date with cursor pagination
+// @Tags         events
+// @Produce      json
+// @Param        venue_id  query  int     false  "only events at this venue"
+// @Param        from      query  string  false  "only events that end after this time (RFC3339)"
+// @Param        to        query  string  false  "only events that start before this time (RFC3339)"
+// @Param        limit     query  int     false  "page size (max 100)"
+// @Param        cursor    query  string  false  "cursor returned as next_cursor by the previous page"
+// @Success      200  {object}  event.EventListDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /events [get]
+func (e *EventController) List(c echo.Context) error {
+	var req request.ListEventsRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	events, err := e.service.List(c.Request().Context(), req)
+	if err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.JSON(http.StatusOK, events)
+}
+
+// Update godoc
+// @Summary      Update event
+// @Description  Update the name, description, dates or capacity of an event, fields that are not sent are kept. The capacity cannot be lowered below the units its tickets allocate
+// @Tags         events
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "event ID"
+// @Param        event body request.UpdateEventRequest true "event"
+// @Success      200  {object}  event.EventDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      422  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /events/{id} [patch]
+func (e *EventController) Update(c echo.Context) error {
+	var req request.UpdateEventRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	updated, err := e.service.Update(c.Request().Context(), id, req)
+	if err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.JSON(http.StatusOK, updated)
+}
+
+// Delete godoc
+// @Summary      Delete event
+// @Description  Delete an event, events with tickets cannot be deleted
+// @Tags         events
+// @Param        id path int true "event ID"
+// @Success      204
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /events/{id} [delete]
+func (e *EventController) Delete(c echo.Context) error {
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := e.service.Delete(c.Request().Context(), id); err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, event.ErrEventNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, event.ErrEventHasTickets), errors.Is(err, event.ErrCapacityBelowAllocated), errors.Is(err, db.ErrTransactionConflict):
+		return http.StatusConflict
+	case errors.Is(err, pagination.ErrInvalidCursor):
+		return http.StatusBadRequest
+	case errors.Is(err, venue.ErrVenueNotFound), errors.Is(err, event.ErrVenueIsRequired), errors.Is(err, event.ErrInvalidCapacity),
+		errors.Is(err, valueobject.ErrNameCannotBeEmpty), errors.Is(err, valueobject.ErrDescriptionCannotBeEmpty),
+		errors.Is(err, valueobject.ErrInvalidDateRange):
+		return http.StatusUnprocessableEntity
+	}
+
+	return http.StatusInternalServerError
+}
+
+func parseID(c echo.Context) (int, error) {
+	id := c.Param("id")
+	if id == "" {
+		return 0, ErrIDIsRequired
+	}
+
+	return strconv.Atoi(id)
+}
@@ -0,0 +1,216 @@
+package event
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/event"
+	"github.com/aaydin-tr/ddd-api-example/domain/venue"
+	mockservice "github.com/aaydin-tr/ddd-api-example/mock/service/event"
+	"github.com/aaydin-tr/ddd-api-example/pkg/validator"
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEventController_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockEventService(ctrl)
+	controller := NewEventController(mockService)
+
+	e := echo.New()
+	e.Validator = validator.New()
+
+	body := `{"venue_id": 1, "name": "Concert", "description": "Test Description", "starts_at": "2026-11-01T19:00:00Z", "ends_at": "2026-11-01T22:00:00Z", "capacity": 100}`
+	tests := []struct {
+		name         string
+		requestBody  string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:        "success",
+			requestBody: body,
+			mock: func() {
+				mockService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&event.EventDTO{ID: 1}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "missing capacity",
+			requestBody:  `{"venue_id": 1, "name": "Concert", "description": "Test Description", "starts_at": "2026-11-01T19:00:00Z", "ends_at": "2026-11-01T22:00:00Z"}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "unknown venue",
+			requestBody: body,
+			mock: func() {
+				mockService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, venue.ErrVenueNotFound)
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "invalid dates",
+			requestBody: body,
+			mock: func() {
+				mockService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, valueobject.ErrInvalidDateRange)
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			tt.mock()
+			err := controller.Create(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestEventController_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockEventService(ctrl)
+	controller := NewEventController(mockService)
+
+	e := echo.New()
+	e.Validator = validator.New()
+
+	tests := []struct {
+		name         string
+		paramID      string
+		requestBody  string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:        "success",
+			paramID:     "1",
+			requestBody: `{"capacity": 50}`,
+			mock: func() {
+				mockService.EXPECT().Update(gomock.Any(), 1, gomock.Any()).Return(&event.EventDTO{ID: 1, Capacity: 50}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "invalid capacity",
+			paramID:      "1",
+			requestBody:  `{"capacity": 0}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "capacity below allocated",
+			paramID:     "1",
+			requestBody: `{"capacity": 50}`,
+			mock: func() {
+				mockService.EXPECT().Update(gomock.Any(), 1, gomock.Any()).Return(nil, event.ErrCapacityBelowAllocated)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:        "not found",
+			paramID:     "1",
+			requestBody: `{"capacity": 50}`,
+			mock: func() {
+				mockService.EXPECT().Update(gomock.Any(), 1, gomock.Any()).Return(nil, event.ErrEventNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/events/:id")
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := controller.Update(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestEventController_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockEventService(ctrl)
+	controller := NewEventController(mockService)
+	e := echo.New()
+
+	tests := []struct {
+		name         string
+		paramID      string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:    "success",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), 1).Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "invalid id",
+			paramID:      "invalid",
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:    "has tickets",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), 1).Return(event.ErrEventHasTickets)
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/events/:id")
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := controller.Delete(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package order
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/order"
+	"github.com/aaydin-tr/ddd-api-example/domain/purchase"
+	"github.com/aaydin-tr/ddd-api-example/domain/seat"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/response"
+	service "github.com/aaydin-tr/ddd-api-example/service/order"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrIDIsRequired = errors.New("id is required")
+)
+
+type OrderController struct {
+	service service.OrderService
+}
+
+func NewOrderController(service service.OrderService) *OrderController {
+	return &OrderController{service: service}
+}
+
+// Create godoc
+// @Summary      Create order
+// @Description  Buy several tickets at once, either every line is bought or none of them. A ticket can only be in one line, lines of tickets with assigned seating can send seat_ids. When lines fail the response lists each of them with its own status. A promo_code is redeemed once for the whole order.
+// @Tags         orders
+// @Accept       json
+// @Produce      json
+// @Param        order body request.CreateOrderRequest true "order"
+// @Param        Idempotency-Key header string false "retries with the same key replay the original response"
+// @Success      201  {object}  order.OrderDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      422  {object}  response.OrderErrorResponse
+// @Router       /orders [post]
+func (o *OrderController) Create(c echo.Context) error {
+	var req request.CreateOrderRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	created, err := o.service.Create(c.Request().Context(), req)
+	var checkoutErr *order.CheckoutError
+	if errors.As(err, &checkoutErr) {
+		return response.NewOrderErrorResponse(c, err, http.StatusUnprocessableEntity, lineErrors(checkoutErr))
+	}
+
+	if err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.JSON(http.StatusCreated, created)
+}
+
+// FindByID godoc
+// @Summary      Find order by ID
+// @Description  Find order by ID
+// @Tags         orders
+// @Produce      json
+// @Param        id path int true "order ID"
+// @Success      200  {object}  order.OrderDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /orders/{id} [get]
+func (o *OrderController) FindByID(c echo.Context) error {
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	found, err := o.service.FindByID(c.Request().Context(), id)
+	if errors.Is(err, order.ErrOrderNotFound) {
+		return response.NewErrorRespone(c, err, http.StatusNotFound)
+	}
+
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, found)
+}
+
+func lineErrors(err *order.CheckoutError) []*response.OrderLineError {
+	lines := make([]*response.OrderLineError, 0, len(err.Lines))
+	for _, line := range err.Lines {
+		lineErr := &response.OrderLineError{
+			Line:     line.Line,
+			TicketID: line.TicketID,
+			Message:  line.Err.Error(),
+			Status:   statusFor(line.Err),
+		}
+
+		var limitErr *ticket.PurchaseLimitError
+		if errors.As(line.Err, &limitErr) {
+			lineErr.Limit = &limitErr.Limit
+			lineErr.Remaining = &limitErr.Remaining
+		}
+
+		lines = append(lines, lineErr)
+	}
+
+	return lines
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, ticket.ErrTicketNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, db.ErrTransactionConflict), errors.Is(err, seat.ErrSeatUnavailable), errors.Is(err, seat.ErrSeatConflict):
+		return http.StatusConflict
+	case errors.Is(err, purchase.ErrInvalidQuantity):
+		return http.StatusBadRequest
+	}
+
+	return http.StatusUnprocessableEntity
+}
+
+func parseID(c echo.Context) (int, error) {
+	id := c.Param("id")
+	if id == "" {
+		return 0, ErrIDIsRequired
+	}
+
+	return strconv.Atoi(id)
+}
@@ -0,0 +1,171 @@
+package order
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/order"
+	"github.com/aaydin-tr/ddd-api-example/domain/seat"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	mockservice "github.com/aaydin-tr/ddd-api-example/mock/service/order"
+	"github.com/aaydin-tr/ddd-api-example/pkg/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOrderController_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockOrderService(ctrl)
+	controller := NewOrderController(mockService)
+
+	e := echo.New()
+	e.Validator = validator.New()
+
+	userID := "1250052d-c061-4a1f-81f0-d88af3dcb3d5"
+	body := `{"user_id":"` + userID + `","lines":[{"ticket_id":1,"quantity":2},{"ticket_id":2,"quantity":1}]}`
+	req := request.CreateOrderRequest{UserID: userID, Lines: []request.OrderLineRequest{
+		{TicketID: 1, Quantity: 2},
+		{TicketID: 2, Quantity: 1},
+	}}
+
+	tests := []struct {
+		name         string
+		requestBody  string
+		mock         func()
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:        "success",
+			requestBody: body,
+			mock: func() {
+				mockService.EXPECT().Create(gomock.Any(), req).Return(&order.OrderDTO{ID: 1}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "no lines",
+			requestBody:  `{"user_id":"` + userID + `","lines":[]}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "line without quantity",
+			requestBody:  `{"user_id":"` + userID + `","lines":[{"ticket_id":1}]}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "failed lines",
+			requestBody: body,
+			mock: func() {
+				mockService.EXPECT().Create(gomock.Any(), req).Return(nil, &order.CheckoutError{Lines: []*order.LineError{
+					{Line: 0, TicketID: 1, Err: &ticket.PurchaseLimitError{Limit: 4, Remaining: 1}},
+					{Line: 1, TicketID: 2, Err: seat.ErrSeatUnavailable},
+				}})
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{
+				"message": "order cannot be completed: line 0 (ticket 1): purchase limit per user exceeded: limit is 4, 1 remaining; line 1 (ticket 2): seat is not available",
+				"status": 422,
+				"lines": [
+					{"line": 0, "ticket_id": 1, "message": "purchase limit per user exceeded: limit is 4, 1 remaining", "status": 422, "limit": 4, "remaining": 1},
+					{"line": 1, "ticket_id": 2, "message": "seat is not available", "status": 409}
+				]
+			}`,
+		},
+		{
+			name:        "service error",
+			requestBody: body,
+			mock: func() {
+				mockService.EXPECT().Create(gomock.Any(), req).Return(nil, errors.New("service error"))
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			tt.mock()
+			err := controller.Create(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestOrderController_FindByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockOrderService(ctrl)
+	controller := NewOrderController(mockService)
+
+	e := echo.New()
+
+	tests := []struct {
+		name         string
+		paramID      string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:    "success",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().FindByID(gomock.Any(), 1).Return(&order.OrderDTO{ID: 1}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "invalid id",
+			paramID:      "invalid",
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:    "not found",
+			paramID: "2",
+			mock: func() {
+				mockService.EXPECT().FindByID(gomock.Any(), 2).Return(nil, order.ErrOrderNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/orders/"+tt.paramID, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := controller.FindByID(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package outbox
+
+import (
+	"net/http"
+
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/outbox"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/response"
+	"github.com/aaydin-tr/ddd-api-example/pkg/pagination"
+	"github.com/labstack/echo/v4"
+)
+
+type OutboxController struct {
+	store outbox.Store
+}
+
+func NewOutboxController(store outbox.Store) *OutboxController {
+	return &OutboxController{store: store}
+}
+
+// ListStuck godoc
+// @Summary      List stuck outbox messages
+// @Description  List unpublished outbox messages whose delivery failed at least once, oldest first, with the last error and when they are tried again
+// @Tags         admin
+// @Produce      json
+// @Param        limit  query  int  false  "maximum number of messages (max 100)"
+// @Success      200  {object}  outbox.MessageListDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /admin/outbox/stuck [get]
+func (o *OutboxController) ListStuck(c echo.Context) error {
+	var req request.ListOutboxMessagesRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = pagination.DefaultLimit
+	}
+
+	messages, err := o.store.Stuck(c.Request().Context(), limit)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, outbox.NewMessageListDTOFromEntities(messages))
+}
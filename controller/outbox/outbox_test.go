@@ -0,0 +1,71 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/outbox"
+	"github.com/aaydin-tr/ddd-api-example/pkg/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type sold struct{}
+
+func (e sold) EventName() string     { return "ticket.sold_out" }
+func (e sold) OccurredAt() time.Time { return time.Time{} }
+
+func TestOutboxController_ListStuck(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	store := outbox.NewMemoryStore()
+	for i := 0; i < 3; i++ {
+		m, _ := outbox.NewMessage(sold{}, now)
+		if i > 0 {
+			m.MarkFailed(errors.New("timeout"), now.Add(time.Minute))
+		}
+		assert.NoError(t, store.Add(ctx, m))
+	}
+
+	controller := NewOutboxController(store)
+	e := echo.New()
+	e.Validator = validator.New()
+
+	tests := []struct {
+		name         string
+		query        string
+		expectedCode int
+		expectedIDs  []int64
+	}{
+		{name: "default limit", query: "", expectedCode: http.StatusOK, expectedIDs: []int64{2, 3}},
+		{name: "limit", query: "?limit=1", expectedCode: http.StatusOK, expectedIDs: []int64{2}},
+		{name: "invalid limit", query: "?limit=abc", expectedCode: http.StatusBadRequest},
+		{name: "limit too large", query: "?limit=101", expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin/outbox/stuck"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, controller.ListStuck(c))
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedCode == http.StatusOK {
+				var body outbox.MessageListDTO
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+
+				var ids []int64
+				for _, item := range body.Items {
+					ids = append(ids, item.ID)
+				}
+				assert.Equal(t, tt.expectedIDs, ids)
+			}
+		})
+	}
+}
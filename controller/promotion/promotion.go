@@ -0,0 +1,205 @@
+package promotion
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/promotion"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/response"
+	"github.com/aaydin-tr/ddd-api-example/pkg/pagination"
+	service "github.com/aaydin-tr/ddd-api-example/service/promotion"
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrIDIsRequired = errors.New("id is required")
+)
+
+type PromotionController struct {
+	service service.PromotionService
+}
+
+func NewPromotionController(service service.PromotionService) *PromotionController {
+	return &PromotionController{service: service}
+}
+
+// Create godoc
+// @Summary      Create a new promotion
+// @Description  Create a promotion code with a percentage or fixed discount, for one ticket or every ticket, with optional redemption limits and validity. Codes are case insensitive.
+// @Tags         promotions
+// @Accept       json
+// @Produce      json
+// @Param        promotion body request.CreatePromotionRequest true "promotion"
+// @Success      201  {object}  promotion.PromotionDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      422  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /admin/promotions [post]
+func (p *PromotionController) Create(c echo.Context) error {
+	var req request.CreatePromotionRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	created, err := p.service.Create(c.Request().Context(), req)
+	if err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.JSON(http.StatusCreated, created)
+}
+
+// FindByID godoc
+// @Summary      Find promotion by ID
+// @Description  Find promotion by ID with the number of times it was redeemed
+// @Tags         promotions
+// @Produce      json
+// @Param        id path int true "promotion ID"
+// @Success      200  {object}  promotion.PromotionDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /admin/promotions/{id} [get]
+func (p *PromotionController) FindByID(c echo.Context) error {
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	found, err := p.service.FindByID(c.Request().Context(), id)
+	if err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.JSON(http.StatusOK, found)
+}
+
+// List godoc
+// @Summary      List promotions
+// @Description  List promotions ordered by ID with cursor pagination
+// @Tags         promotions
+// @Produce      json
+// @Param        limit   query  int     false  "page size (max 100)"
+// @Param        cursor  query  string  false  "cursor returned as next_cursor by the previous page"
+// @Success      200  {object}  promotion.PromotionListDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /admin/promotions [get]
+func (p *PromotionController) List(c echo.Context) error {
+	var req request.ListPromotionsRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	promotions, err := p.service.List(c.Request().Context(), req)
+	if err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.JSON(http.StatusOK, promotions)
+}
+
+// Update godoc
+// @Summary      Update promotion
+// @Description  Update a promotion, fields that are not sent are kept. A ticket_id, max_redemptions or max_per_user of 0 removes the restriction.
+// @Tags         promotions
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "promotion ID"
+// @Param        promotion body request.UpdatePromotionRequest true "promotion"
+// @Success      200  {object}  promotion.PromotionDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      422  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /admin/promotions/{id} [patch]
+func (p *PromotionController) Update(c echo.Context) error {
+	var req request.UpdatePromotionRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	updated, err := p.service.Update(c.Request().Context(), id, req)
+	if err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.JSON(http.StatusOK, updated)
+}
+
+// Delete godoc
+// @Summary      Delete promotion
+// @Description  Delete a promotion, promotions that were redeemed cannot be deleted but can be ended by changing ends_at
+// @Tags         promotions
+// @Param        id path int true "promotion ID"
+// @Success      204
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /admin/promotions/{id} [delete]
+func (p *PromotionController) Delete(c echo.Context) error {
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := p.service.Delete(c.Request().Context(), id); err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, promotion.ErrPromotionNotFound), errors.Is(err, ticket.ErrTicketNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, promotion.ErrCodeExists), errors.Is(err, promotion.ErrPromotionRedeemed), errors.Is(err, db.ErrTransactionConflict):
+		return http.StatusConflict
+	case errors.Is(err, pagination.ErrInvalidCursor):
+		return http.StatusBadRequest
+	case errors.Is(err, promotion.ErrCodeIsRequired), errors.Is(err, promotion.ErrInvalidCode),
+		errors.Is(err, promotion.ErrInvalidKind), errors.Is(err, promotion.ErrInvalidPercentage),
+		errors.Is(err, promotion.ErrInvalidAmount), errors.Is(err, promotion.ErrInvalidMaxRedemptions),
+		errors.Is(err, promotion.ErrInvalidMaxPerUser), errors.Is(err, promotion.ErrMaxBelowRedemptions),
+		errors.Is(err, promotion.ErrInvalidValidity), errors.Is(err, valueobject.ErrInvalidCurrency):
+		return http.StatusUnprocessableEntity
+	}
+
+	return http.StatusInternalServerError
+}
+
+func parseID(c echo.Context) (int, error) {
+	id := c.Param("id")
+	if id == "" {
+		return 0, ErrIDIsRequired
+	}
+
+	return strconv.Atoi(id)
+}
@@ -0,0 +1,162 @@
+package promotion
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/promotion"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	mockservice "github.com/aaydin-tr/ddd-api-example/mock/service/promotion"
+	"github.com/aaydin-tr/ddd-api-example/pkg/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPromotionController_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockPromotionService(ctrl)
+	controller := NewPromotionController(mockService)
+
+	e := echo.New()
+	e.Validator = validator.New()
+
+	tests := []struct {
+		name         string
+		requestBody  string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:        "success",
+			requestBody: `{"code": "SPRING10", "kind": "percentage", "value": 10, "max_redemptions": 100}`,
+			mock: func() {
+				mockService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&promotion.PromotionDTO{ID: 1}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "unknown kind",
+			requestBody:  `{"code": "SPRING10", "kind": "bogo", "value": 10}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "fixed discount without currency",
+			requestBody:  `{"code": "FIVE", "kind": "fixed", "value": 500}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "code exists",
+			requestBody: `{"code": "SPRING10", "kind": "percentage", "value": 10}`,
+			mock: func() {
+				mockService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, promotion.ErrCodeExists)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:        "ticket not found",
+			requestBody: `{"code": "SPRING10", "kind": "percentage", "value": 10, "ticket_id": 9}`,
+			mock: func() {
+				mockService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, ticket.ErrTicketNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:        "invalid percentage",
+			requestBody: `{"code": "SPRING10", "kind": "percentage", "value": 110}`,
+			mock: func() {
+				mockService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, promotion.ErrInvalidPercentage)
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/admin/promotions", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			tt.mock()
+			err := controller.Create(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestPromotionController_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockPromotionService(ctrl)
+	controller := NewPromotionController(mockService)
+	e := echo.New()
+
+	tests := []struct {
+		name         string
+		paramID      string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:    "success",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), 1).Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "invalid id",
+			paramID:      "invalid",
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:    "not found",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), 1).Return(promotion.ErrPromotionNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:    "redeemed",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), 1).Return(promotion.ErrPromotionRedeemed)
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/admin/promotions/:id")
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := controller.Delete(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package purchase
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/purchase"
+	"github.com/aaydin-tr/ddd-api-example/domain/seat"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/response"
+	service "github.com/aaydin-tr/ddd-api-example/service/purchase"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrIDIsRequired = errors.New("id is required")
+)
+
+type PurchaseController struct {
+	service service.PurchaseService
+}
+
+func NewPurchaseController(service service.PurchaseService) *PurchaseController {
+	return &PurchaseController{service: service}
+}
+
+// Refund godoc
+// @Summary      Refund purchase
+// @Description  Refund the whole purchase or a part of it, refunded units are returned to the ticket allocation. Purchases of archived tickets cannot be refunded, purchases of deleted tickets return 404 until the ticket is restored.
+// @Tags         purchases
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "purchase ID"
+// @Param        refund body request.RefundPurchaseRequest false "refund, omit quantity to refund everything that is left"
+// @Success      201  {object}  purchase.RefundDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      422  {object}  response.ErrorResponse
+// @Router       /purchases/{id}/refunds [post]
+func (p *PurchaseController) Refund(c echo.Context) error {
+	var req request.RefundPurchaseRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	refund, err := p.service.Refund(c.Request().Context(), id, req)
+	if err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.JSON(http.StatusCreated, refund)
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, purchase.ErrPurchaseNotFound), errors.Is(err, ticket.ErrTicketNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, purchase.ErrPurchaseAlreadyRefunded), errors.Is(err, ticket.ErrTicketArchived),
+		errors.Is(err, db.ErrTransactionConflict), errors.Is(err, seat.ErrSeatConflict):
+		return http.StatusConflict
+	case errors.Is(err, purchase.ErrInvalidQuantity):
+		return http.StatusBadRequest
+	}
+
+	return http.StatusUnprocessableEntity
+}
+
+func parseID(c echo.Context) (int, error) {
+	id := c.Param("id")
+	if id == "" {
+		return 0, ErrIDIsRequired
+	}
+
+	return strconv.Atoi(id)
+}
@@ -0,0 +1,143 @@
+package purchase
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/purchase"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	mockservice "github.com/aaydin-tr/ddd-api-example/mock/service/purchase"
+	"github.com/aaydin-tr/ddd-api-example/pkg/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPurchaseController_Refund(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockPurchaseService(ctrl)
+	controller := NewPurchaseController(mockService)
+
+	e := echo.New()
+	e.Validator = validator.New()
+
+	tests := []struct {
+		name         string
+		paramID      string
+		requestBody  string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:        "success",
+			paramID:     "1",
+			requestBody: `{"quantity": 1}`,
+			mock: func() {
+				mockService.EXPECT().Refund(gomock.Any(), 1, gomock.Any()).Return(&purchase.RefundDTO{ID: 1, PurchaseID: 1, Quantity: 1}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:        "full refund without body",
+			paramID:     "1",
+			requestBody: ``,
+			mock: func() {
+				mockService.EXPECT().Refund(gomock.Any(), 1, gomock.Any()).Return(&purchase.RefundDTO{ID: 1, PurchaseID: 1, Quantity: 4}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "validation error",
+			paramID:      "1",
+			requestBody:  `{"quantity": 0}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid id",
+			paramID:      "invalid",
+			requestBody:  `{"quantity": 1}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "purchase not found",
+			paramID:     "1",
+			requestBody: `{"quantity": 1}`,
+			mock: func() {
+				mockService.EXPECT().Refund(gomock.Any(), 1, gomock.Any()).Return(nil, purchase.ErrPurchaseNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:        "ticket not found",
+			paramID:     "1",
+			requestBody: `{"quantity": 1}`,
+			mock: func() {
+				mockService.EXPECT().Refund(gomock.Any(), 1, gomock.Any()).Return(nil, ticket.ErrTicketNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:        "already refunded",
+			paramID:     "1",
+			requestBody: `{"quantity": 1}`,
+			mock: func() {
+				mockService.EXPECT().Refund(gomock.Any(), 1, gomock.Any()).Return(nil, purchase.ErrPurchaseAlreadyRefunded)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:        "archived ticket",
+			paramID:     "1",
+			requestBody: `{"quantity": 1}`,
+			mock: func() {
+				mockService.EXPECT().Refund(gomock.Any(), 1, gomock.Any()).Return(nil, ticket.ErrTicketArchived)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:        "refund exceeds quantity",
+			paramID:     "1",
+			requestBody: `{"quantity": 10}`,
+			mock: func() {
+				mockService.EXPECT().Refund(gomock.Any(), 1, gomock.Any()).Return(nil, purchase.ErrRefundExceedsQuantity)
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "service error",
+			paramID:     "1",
+			requestBody: `{"quantity": 1}`,
+			mock: func() {
+				mockService.EXPECT().Refund(gomock.Any(), 1, gomock.Any()).Return(nil, errors.New("service error"))
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/purchases/:id/refunds")
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := controller.Refund(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
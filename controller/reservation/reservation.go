@@ -0,0 +1,180 @@
+package reservation
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/reservation"
+	"github.com/aaydin-tr/ddd-api-example/domain/seat"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/response"
+	service "github.com/aaydin-tr/ddd-api-example/service/reservation"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrIDIsRequired = errors.New("id is required")
+)
+
+type ReservationController struct {
+	service service.ReservationService
+}
+
+func NewReservationController(service service.ReservationService) *ReservationController {
+	return &ReservationController{service: service}
+}
+
+// Create godoc
+// @Summary      Reserve tickets
+// @Description  Hold tickets for a limited time, the hold has to be confirmed before it expires. Tickets with assigned seating hold the seats seat_ids or the best available adjacent seats.
+// @Tags         reservations
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "ticket ID"
+// @Param        reservation body request.CreateReservationRequest true "reservation"
+// @Param        Idempotency-Key header string false "retries with the same key replay the original response"
+// @Success      201  {object}  reservation.ReservationDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      422  {object}  response.PurchaseLimitErrorResponse
+// @Router       /tickets/{id}/reservations [post]
+func (r *ReservationController) Create(c echo.Context) error {
+	var req request.CreateReservationRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	created, err := r.service.Create(c.Request().Context(), id, req)
+	if errors.Is(err, ticket.ErrTicketNotFound) {
+		return response.NewErrorRespone(c, err, http.StatusNotFound)
+	}
+
+	var limitErr *ticket.PurchaseLimitError
+	if errors.As(err, &limitErr) {
+		return response.NewPurchaseLimitErrorResponse(c, err, limitErr.Limit, limitErr.Remaining)
+	}
+
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusUnprocessableEntity)
+	}
+
+	return c.JSON(http.StatusCreated, created)
+}
+
+// FindByID godoc
+// @Summary      Find reservation by ID
+// @Description  Find reservation by ID
+// @Tags         reservations
+// @Produce      json
+// @Param        id path int true "reservation ID"
+// @Success      200  {object}  reservation.ReservationDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Router       /reservations/{id} [get]
+func (r *ReservationController) FindByID(c echo.Context) error {
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	found, err := r.service.FindByID(c.Request().Context(), id)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusNotFound)
+	}
+
+	return c.JSON(http.StatusOK, found)
+}
+
+// Confirm godoc
+// @Summary      Confirm reservation
+// @Description  Turn an active reservation into a purchase, the created purchase is referenced by purchase_id
+// @Tags         reservations
+// @Produce      json
+// @Param        id path int true "reservation ID"
+// @Success      200  {object}  reservation.ReservationDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      410  {object}  response.ErrorResponse
+// @Failure      422  {object}  response.PurchaseLimitErrorResponse
+// @Router       /reservations/{id}/confirm [post]
+func (r *ReservationController) Confirm(c echo.Context) error {
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	confirmed, err := r.service.Confirm(c.Request().Context(), id)
+	var limitErr *ticket.PurchaseLimitError
+	if errors.As(err, &limitErr) {
+		return response.NewPurchaseLimitErrorResponse(c, err, limitErr.Limit, limitErr.Remaining)
+	}
+
+	if err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.JSON(http.StatusOK, confirmed)
+}
+
+// Cancel godoc
+// @Summary      Cancel reservation
+// @Description  Cancel an active reservation and return the held tickets to the allocation
+// @Tags         reservations
+// @Produce      json
+// @Param        id path int true "reservation ID"
+// @Success      200  {object}  reservation.ReservationDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      422  {object}  response.ErrorResponse
+// @Router       /reservations/{id}/cancel [post]
+func (r *ReservationController) Cancel(c echo.Context) error {
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	cancelled, err := r.service.Cancel(c.Request().Context(), id)
+	if err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.JSON(http.StatusOK, cancelled)
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, reservation.ErrReservationNotFound), errors.Is(err, ticket.ErrTicketNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, reservation.ErrReservationNotActive), errors.Is(err, db.ErrTransactionConflict),
+		errors.Is(err, seat.ErrSeatUnavailable), errors.Is(err, seat.ErrSeatConflict):
+		return http.StatusConflict
+	case errors.Is(err, reservation.ErrReservationExpired):
+		return http.StatusGone
+	}
+
+	return http.StatusUnprocessableEntity
+}
+
+func parseID(c echo.Context) (int, error) {
+	id := c.Param("id")
+	if id == "" {
+		return 0, ErrIDIsRequired
+	}
+
+	return strconv.Atoi(id)
+}
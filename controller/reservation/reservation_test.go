@@ -0,0 +1,302 @@
+package reservation
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/reservation"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	mockservice "github.com/aaydin-tr/ddd-api-example/mock/service/reservation"
+	"github.com/aaydin-tr/ddd-api-example/pkg/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReservationController_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockReservationService(ctrl)
+	controller := NewReservationController(mockService)
+
+	e := echo.New()
+	e.Validator = validator.New()
+
+	tests := []struct {
+		name         string
+		paramID      string
+		requestBody  string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:        "success",
+			paramID:     "1",
+			requestBody: `{"quantity": 2, "user_id": "1250052d-c061-4a1f-81f0-d88af3dcb3d5"}`,
+			mock: func() {
+				mockService.EXPECT().Create(gomock.Any(), 1, gomock.Any()).Return(&reservation.ReservationDTO{ID: 1, TicketID: 1, Quantity: 2, Status: "active"}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "validation error",
+			paramID:      "1",
+			requestBody:  `{"quantity": 0, "user_id": "1250052d-c061-4a1f-81f0-d88af3dcb3d5"}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid id",
+			paramID:      "invalid",
+			requestBody:  `{"quantity": 2, "user_id": "1250052d-c061-4a1f-81f0-d88af3dcb3d5"}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "ticket not found",
+			paramID:     "1",
+			requestBody: `{"quantity": 2, "user_id": "1250052d-c061-4a1f-81f0-d88af3dcb3d5"}`,
+			mock: func() {
+				mockService.EXPECT().Create(gomock.Any(), 1, gomock.Any()).Return(nil, ticket.ErrTicketNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:        "insufficient allocation",
+			paramID:     "1",
+			requestBody: `{"quantity": 2, "user_id": "1250052d-c061-4a1f-81f0-d88af3dcb3d5"}`,
+			mock: func() {
+				mockService.EXPECT().Create(gomock.Any(), 1, gomock.Any()).Return(nil, ticket.ErrInsufficientAllocation)
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "purchase limit exceeded",
+			paramID:     "1",
+			requestBody: `{"quantity": 2, "user_id": "1250052d-c061-4a1f-81f0-d88af3dcb3d5"}`,
+			mock: func() {
+				mockService.EXPECT().Create(gomock.Any(), 1, gomock.Any()).Return(nil, &ticket.PurchaseLimitError{Limit: 4, Remaining: 1})
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/tickets/:id/reservations")
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := controller.Create(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestReservationController_FindByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockReservationService(ctrl)
+	controller := NewReservationController(mockService)
+
+	e := echo.New()
+
+	tests := []struct {
+		name         string
+		paramID      string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:    "success",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().FindByID(gomock.Any(), 1).Return(&reservation.ReservationDTO{ID: 1}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "id is required",
+			paramID:      "",
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:    "not found",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().FindByID(gomock.Any(), 1).Return(nil, reservation.ErrReservationNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/reservations/:id")
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := controller.FindByID(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestReservationController_Confirm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockReservationService(ctrl)
+	controller := NewReservationController(mockService)
+
+	e := echo.New()
+
+	tests := []struct {
+		name         string
+		paramID      string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:    "success",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Confirm(gomock.Any(), 1).Return(&reservation.ReservationDTO{ID: 1, Status: "confirmed"}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "invalid id",
+			paramID:      "invalid",
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:    "not found",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Confirm(gomock.Any(), 1).Return(nil, reservation.ErrReservationNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:    "not active",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Confirm(gomock.Any(), 1).Return(nil, reservation.ErrReservationNotActive)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:    "expired",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Confirm(gomock.Any(), 1).Return(nil, reservation.ErrReservationExpired)
+			},
+			expectedCode: http.StatusGone,
+		},
+		{
+			name:    "service error",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Confirm(gomock.Any(), 1).Return(nil, errors.New("service error"))
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/reservations/:id/confirm")
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := controller.Confirm(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestReservationController_Cancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockReservationService(ctrl)
+	controller := NewReservationController(mockService)
+
+	e := echo.New()
+
+	tests := []struct {
+		name         string
+		paramID      string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:    "success",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Cancel(gomock.Any(), 1).Return(&reservation.ReservationDTO{ID: 1, Status: "cancelled"}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:    "not active",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Cancel(gomock.Any(), 1).Return(nil, reservation.ErrReservationNotActive)
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/reservations/:id/cancel")
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := controller.Cancel(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
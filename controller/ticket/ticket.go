@@ -4,10 +4,16 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/aaydin-tr/ddd-api-example/domain/event"
+	"github.com/aaydin-tr/ddd-api-example/domain/purchase"
+	"github.com/aaydin-tr/ddd-api-example/domain/seat"
 	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
 	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
 	"github.com/aaydin-tr/ddd-api-example/interface/http/response"
+	"github.com/aaydin-tr/ddd-api-example/pkg/pagination"
 	service "github.com/aaydin-tr/ddd-api-example/service/ticket"
 	"github.com/labstack/echo/v4"
 )
@@ -26,7 +32,7 @@ func NewTicketController(service service.TicketService) *TicketController {
 
 // Create godoc
 // @Summary      Create a new ticket
-// @Description  Create a new ticket
+// @Description  Create a new ticket, a ticket of an event cannot allocate more units than the event has capacity left
 // @Tags         tickets
 // @Accept       json
 // @Produce      json
@@ -60,41 +66,345 @@ func (t *TicketController) Create(c echo.Context) error {
 // @Produce      json
 // @Param        id path int true "ticket ID"
 // @Success      200  {object}  ticket.TicketDTO
+// @Header       200  {string}  ETag  "version of the ticket, send it back as If-Match to make conditional changes"
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      404  {object}  response.ErrorResponse
 // @Failure      500  {object}  response.ErrorResponse
 // @Router       /tickets/{id} [get]
 func (t *TicketController) FindByID(c echo.Context) error {
-	id := c.Param("id")
-	if id == "" {
-		return response.NewErrorRespone(c, ErrIDIsRequired, http.StatusBadRequest)
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	ticket, err := t.service.FindByID(c.Request().Context(), id)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusNotFound)
+	}
+
+	setETag(c, ticket.Version)
+	return c.JSON(http.StatusOK, ticket)
+}
+
+// List godoc
+// @Summary      List tickets
+// @Description  List tickets with filtering, sorting and cursor pagination
+// @Tags         tickets
+// @Produce      json
+// @Param        name            query  string  false  "name substring"
+// @Param        min_allocation  query  int     false  "minimum allocation"
+// @Param        max_allocation  query  int     false  "maximum allocation"
+// @Param        sold_out        query  bool    false  "only sold out (true) or only available (false) tickets"
+// @Param        created_after   query  string  false  "created at or after (RFC3339)"
+// @Param        created_before  query  string  false  "created before (RFC3339)"
+// @Param        sort_by         query  string  false  "sort field" Enums(allocation, created_at)
+// @Param        order           query  string  false  "sort order" Enums(asc, desc)
+// @Param        limit           query  int     false  "page size (max 100)"
+// @Param        cursor          query  string  false  "cursor returned as next_cursor by the previous page"
+// @Success      200  {object}  ticket.TicketListDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /tickets [get]
+func (t *TicketController) List(c echo.Context) error {
+	var req request.ListTicketsRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	tickets, err := t.service.List(c.Request().Context(), req)
+	if errors.Is(err, pagination.ErrInvalidCursor) {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, tickets)
+}
+
+// Update godoc
+// @Summary      Update ticket
+// @Description  Rename a ticket, change its description or change its total allocation. total_allocation counts sold and held units too, unlike the allocation of the returned ticket, which only counts the units that are still available. It cannot be lower than the already sold and held quantity. Archived tickets are read-only and rejected with 409.
+// @Tags         tickets
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "ticket ID"
+// @Param        ticket body request.UpdateTicketRequest true "ticket"
+// @Param        If-Match header string false "ETag of the ticket, the update is rejected with 412 when the ticket changed since"
+// @Success      200  {object}  ticket.TicketDTO
+// @Header       200  {string}  ETag  "version of the updated ticket"
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      412  {object}  response.ErrorResponse
+// @Failure      422  {object}  response.ErrorResponse
+// @Router       /tickets/{id} [patch]
+func (t *TicketController) Update(c echo.Context) error {
+	var req request.UpdateTicketRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
 	}
 
-	idInt, err := strconv.Atoi(id)
+	id, err := parseID(c)
 	if err != nil {
 		return response.NewErrorRespone(c, err, http.StatusBadRequest)
 	}
 
-	ticket, err := t.service.FindByID(c.Request().Context(), idInt)
+	version, err := parseIfMatch(c)
 	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusPreconditionFailed)
+	}
+
+	updated, err := t.service.Update(c.Request().Context(), id, req, version)
+	if errors.Is(err, ticket.ErrTicketNotFound) {
 		return response.NewErrorRespone(c, err, http.StatusNotFound)
 	}
 
-	return c.JSON(http.StatusOK, ticket)
+	if status, ok := versionStatus(err); ok {
+		return response.NewErrorRespone(c, err, status)
+	}
+
+	if errors.Is(err, ticket.ErrTicketArchived) {
+		return response.NewErrorRespone(c, err, http.StatusConflict)
+	}
+
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusUnprocessableEntity)
+	}
+
+	setETag(c, updated.Version)
+	return c.JSON(http.StatusOK, updated)
+}
+
+// Delete godoc
+// @Summary      Delete ticket
+// @Description  Soft delete a ticket, it can be restored later. A ticket with held units is rejected with 409 until the reservations are confirmed, cancelled or expired.
+// @Tags         tickets
+// @Param        id path int true "ticket ID"
+// @Param        If-Match header string false "ETag of the ticket, the delete is rejected with 412 when the ticket changed since"
+// @Success      204  "No Content"
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      412  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /tickets/{id} [delete]
+func (t *TicketController) Delete(c echo.Context) error {
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	version, err := parseIfMatch(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusPreconditionFailed)
+	}
+
+	err = t.service.Delete(c.Request().Context(), id, version)
+	if errors.Is(err, ticket.ErrTicketNotFound) {
+		return response.NewErrorRespone(c, err, http.StatusNotFound)
+	}
+
+	if status, ok := versionStatus(err); ok {
+		return response.NewErrorRespone(c, err, status)
+	}
+
+	if errors.Is(err, ticket.ErrTicketHasHolds) {
+		return response.NewErrorRespone(c, err, http.StatusConflict)
+	}
+
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusInternalServerError)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Restore godoc
+// @Summary      Restore ticket
+// @Description  Restore a soft deleted ticket
+// @Tags         tickets
+// @Produce      json
+// @Param        id path int true "ticket ID"
+// @Param        If-Match header string false "ETag of the ticket, the restore is rejected with 412 when the ticket changed since"
+// @Success      200  {object}  ticket.TicketDTO
+// @Header       200  {string}  ETag  "version of the restored ticket"
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      412  {object}  response.ErrorResponse
+// @Failure      422  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /tickets/{id}/restore [post]
+func (t *TicketController) Restore(c echo.Context) error {
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	version, err := parseIfMatch(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusPreconditionFailed)
+	}
+
+	restored, err := t.service.Restore(c.Request().Context(), id, version)
+	if errors.Is(err, ticket.ErrTicketNotFound) {
+		return response.NewErrorRespone(c, err, http.StatusNotFound)
+	}
+
+	if status, ok := versionStatus(err); ok {
+		return response.NewErrorRespone(c, err, status)
+	}
+
+	if errors.Is(err, ticket.ErrTicketNotDeleted) {
+		return response.NewErrorRespone(c, err, http.StatusConflict)
+	}
+
+	if errors.Is(err, event.ErrCapacityExceeded) {
+		return response.NewErrorRespone(c, err, http.StatusUnprocessableEntity)
+	}
+
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusInternalServerError)
+	}
+
+	setETag(c, restored.Version)
+	return c.JSON(http.StatusOK, restored)
+}
+
+// Publish godoc
+// @Summary      Publish a ticket
+// @Description  Makes a draft ticket available for purchase
+// @Tags         tickets
+// @Produce      json
+// @Param        id path int true "ticket ID"
+// @Param        If-Match header string false "ETag of the ticket, the request is rejected with 412 when the ticket changed since"
+// @Success      200  {object}  ticket.TicketDTO
+// @Header       200  {string}  ETag  "version of the changed ticket"
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      412  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /tickets/{id}/publish [post]
+func (t *TicketController) Publish(c echo.Context) error {
+	return t.transition(c, ticket.TransitionPublish)
+}
+
+// Pause godoc
+// @Summary      Pause the sales of a ticket
+// @Description  Stops the sales of a published ticket until it is resumed
+// @Tags         tickets
+// @Produce      json
+// @Param        id path int true "ticket ID"
+// @Param        If-Match header string false "ETag of the ticket, the request is rejected with 412 when the ticket changed since"
+// @Success      200  {object}  ticket.TicketDTO
+// @Header       200  {string}  ETag  "version of the changed ticket"
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      412  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /tickets/{id}/pause [post]
+func (t *TicketController) Pause(c echo.Context) error {
+	return t.transition(c, ticket.TransitionPause)
+}
+
+// Resume godoc
+// @Summary      Resume the sales of a ticket
+// @Description  Makes a paused ticket available for purchase again
+// @Tags         tickets
+// @Produce      json
+// @Param        id path int true "ticket ID"
+// @Param        If-Match header string false "ETag of the ticket, the request is rejected with 412 when the ticket changed since"
+// @Success      200  {object}  ticket.TicketDTO
+// @Header       200  {string}  ETag  "version of the changed ticket"
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      412  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /tickets/{id}/resume [post]
+func (t *TicketController) Resume(c echo.Context) error {
+	return t.transition(c, ticket.TransitionResume)
+}
+
+// Archive godoc
+// @Summary      Archive a ticket
+// @Description  Ends the lifecycle of a ticket, archived tickets can not be changed any more. A ticket with held units is rejected with 409 until the reservations are confirmed, cancelled or expired.
+// @Tags         tickets
+// @Produce      json
+// @Param        id path int true "ticket ID"
+// @Param        If-Match header string false "ETag of the ticket, the request is rejected with 412 when the ticket changed since"
+// @Success      200  {object}  ticket.TicketDTO
+// @Header       200  {string}  ETag  "version of the changed ticket"
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      412  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /tickets/{id}/archive [post]
+func (t *TicketController) Archive(c echo.Context) error {
+	return t.transition(c, ticket.TransitionArchive)
+}
+
+// transition answers 409 when the transition is not allowed from the
+// current status of the ticket or a ticket with held units is archived.
+func (t *TicketController) transition(c echo.Context, transition ticket.Transition) error {
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	version, err := parseIfMatch(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusPreconditionFailed)
+	}
+
+	changed, err := t.service.Transition(c.Request().Context(), id, transition, version)
+	if errors.Is(err, ticket.ErrTicketNotFound) {
+		return response.NewErrorRespone(c, err, http.StatusNotFound)
+	}
+
+	if status, ok := versionStatus(err); ok {
+		return response.NewErrorRespone(c, err, status)
+	}
+
+	if errors.Is(err, ticket.ErrInvalidTransition) || errors.Is(err, ticket.ErrTicketHasHolds) {
+		return response.NewErrorRespone(c, err, http.StatusConflict)
+	}
+
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusInternalServerError)
+	}
+
+	setETag(c, changed.Version)
+	return c.JSON(http.StatusOK, changed)
 }
 
 // Purchases godoc
 // @Summary      Purchase tickets
-// @Description  Purchase tickets
+// @Description  Purchase tickets, tickets with assigned seating sell the seats seat_ids or the best available adjacent seats. A seat that is not available any more is rejected with 409. A promo_code that cannot be redeemed fails the purchase with 422.
 // @Tags         tickets
 // @Accept       json
 // @Produce      json
 // @Param        id path int true "ticket ID"
 // @Param        purchase body request.PurchaseTicketRequest true "purchase"
-// @Success      200  {object}  response.EmptyBody "No content"
+// @Param        Idempotency-Key header string false "retries with the same key replay the original response"
+// @Success      201  {object}  purchase.PurchaseDTO
 // @Failure      400  {object}  response.ErrorResponse
 // @Failure      404  {object}  response.ErrorResponse
-// @Failure      422  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      422  {object}  response.PurchaseLimitErrorResponse
 // @Router       /tickets/{id}/purchases [post]
 func (t *TicketController) Purchases(c echo.Context) error {
 	var req request.PurchaseTicketRequest
@@ -106,24 +416,253 @@ func (t *TicketController) Purchases(c echo.Context) error {
 		return response.NewErrorRespone(c, err, http.StatusBadRequest)
 	}
 
-	id := c.Param("id")
-	if id == "" {
-		return response.NewErrorRespone(c, ErrIDIsRequired, http.StatusBadRequest)
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	p, err := t.service.Purchase(c.Request().Context(), id, req)
+	if errors.Is(err, ticket.ErrTicketNotFound) {
+		return response.NewErrorRespone(c, err, http.StatusNotFound)
+	}
+
+	if errors.Is(err, purchase.ErrInvalidQuantity) || errors.Is(err, purchase.ErrUserIDIsRequired) {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if errors.Is(err, ticket.ErrVersionConflict) || errors.Is(err, db.ErrTransactionConflict) ||
+		errors.Is(err, seat.ErrSeatUnavailable) || errors.Is(err, seat.ErrSeatConflict) {
+		return response.NewErrorRespone(c, err, http.StatusConflict)
+	}
+
+	var limitErr *ticket.PurchaseLimitError
+	if errors.As(err, &limitErr) {
+		return response.NewPurchaseLimitErrorResponse(c, err, limitErr.Limit, limitErr.Remaining)
+	}
+
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusUnprocessableEntity)
+	}
+
+	return c.JSON(http.StatusCreated, p)
+}
+
+// Ledger godoc
+// @Summary      Allocation ledger of a ticket
+// @Description  Every change of the allocation of a ticket in the order it was made, with the user (X-User-ID) and request (X-Request-ID) it was made in
+// @Tags         tickets
+// @Produce      json
+// @Param        id      path   int     true   "ticket ID"
+// @Param        limit   query  int     false  "page size (max 100)"
+// @Param        cursor  query  string  false  "cursor returned as next_cursor by the previous page"
+// @Success      200  {object}  ticket.LedgerDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /tickets/{id}/ledger [get]
+func (t *TicketController) Ledger(c echo.Context) error {
+	var req request.ListLedgerRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	ledger, err := t.service.Ledger(c.Request().Context(), id, req)
+	if errors.Is(err, ticket.ErrTicketNotFound) {
+		return response.NewErrorRespone(c, err, http.StatusNotFound)
+	}
+
+	if errors.Is(err, pagination.ErrInvalidCursor) {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, ledger)
+}
+
+// CheckLedger godoc
+// @Summary      Check the allocation ledger
+// @Description  Replay the ledger of every ticket and report the tickets whose ledger does not add up to their current allocation
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  ticket.LedgerCheckDTO
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /admin/ledger/check [get]
+func (t *TicketController) CheckLedger(c echo.Context) error {
+	report, err := t.service.CheckLedger(c.Request().Context())
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// CreateSeatMap godoc
+// @Summary      Create the seat map of a ticket
+// @Description  Switch a ticket to assigned seating, its seats replace the allocation. Only tickets without sold or held units that are not archived can get a seat map.
+// @Tags         tickets
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "ticket ID"
+// @Param        seatmap body request.CreateSeatMapRequest true "seat map"
+// @Success      201  {object}  seat.SeatMapDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      422  {object}  response.ErrorResponse
+// @Router       /tickets/{id}/seat-map [post]
+func (t *TicketController) CreateSeatMap(c echo.Context) error {
+	var req request.CreateSeatMapRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
 	}
 
-	idInt, err := strconv.Atoi(id)
+	id, err := parseID(c)
 	if err != nil {
 		return response.NewErrorRespone(c, err, http.StatusBadRequest)
 	}
 
-	err = t.service.DecrementAllocation(c.Request().Context(), idInt, req.Quantity)
+	seatMap, err := t.service.CreateSeatMap(c.Request().Context(), id, req)
 	if errors.Is(err, ticket.ErrTicketNotFound) {
 		return response.NewErrorRespone(c, err, http.StatusNotFound)
 	}
 
+	if errors.Is(err, ticket.ErrSeatMapExists) || errors.Is(err, ticket.ErrSeatMapAfterSales) ||
+		errors.Is(err, ticket.ErrTicketArchived) || errors.Is(err, ticket.ErrVersionConflict) || errors.Is(err, db.ErrTransactionConflict) {
+		return response.NewErrorRespone(c, err, http.StatusConflict)
+	}
+
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusUnprocessableEntity)
+	}
+
+	return c.JSON(http.StatusCreated, seatMap)
+}
+
+// SeatMap godoc
+// @Summary      Seat map of a ticket
+// @Description  Sections, rows and seats of a ticket with assigned seating and the state of every seat
+// @Tags         tickets
+// @Produce      json
+// @Param        id path int true "ticket ID"
+// @Success      200  {object}  seat.SeatMapDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /tickets/{id}/seat-map [get]
+func (t *TicketController) SeatMap(c echo.Context) error {
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	seatMap, err := t.service.SeatMap(c.Request().Context(), id)
+	if errors.Is(err, ticket.ErrTicketNotFound) || errors.Is(err, ticket.ErrNoSeatMap) {
+		return response.NewErrorRespone(c, err, http.StatusNotFound)
+	}
+
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, seatMap)
+}
+
+// BestAvailableSeats godoc
+// @Summary      Best available seats
+// @Description  Suggest the best available adjacent seats in the front-most row, closest to its middle. The seats are not held.
+// @Tags         tickets
+// @Produce      json
+// @Param        id        path   int  true  "ticket ID"
+// @Param        quantity  query  int  true  "number of adjacent seats (max 100)"
+// @Success      200  {object}  seat.SeatListDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      422  {object}  response.ErrorResponse
+// @Router       /tickets/{id}/seat-map/best-available [get]
+func (t *TicketController) BestAvailableSeats(c echo.Context) error {
+	var req request.BestAvailableSeatsRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	seats, err := t.service.BestAvailableSeats(c.Request().Context(), id, req)
+	if errors.Is(err, ticket.ErrTicketNotFound) || errors.Is(err, ticket.ErrNoSeatMap) {
+		return response.NewErrorRespone(c, err, http.StatusNotFound)
+	}
+
 	if err != nil {
 		return response.NewErrorRespone(c, err, http.StatusUnprocessableEntity)
 	}
 
-	return c.NoContent(http.StatusOK)
+	return c.JSON(http.StatusOK, seats)
+}
+
+func parseID(c echo.Context) (int, error) {
+	id := c.Param("id")
+	if id == "" {
+		return 0, ErrIDIsRequired
+	}
+
+	return strconv.Atoi(id)
+}
+
+func setETag(c echo.Context, version int) {
+	c.Response().Header().Set("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// parseIfMatch returns the ticket version expected by the client, nil when
+// the If-Match header is missing or "*". Weak or malformed tags never match.
+func parseIfMatch(c echo.Context) (*int, error) {
+	value := strings.TrimSpace(c.Request().Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return nil, nil
+	}
+
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		return nil, ticket.ErrVersionMismatch
+	}
+
+	version, err := strconv.Atoi(unquoted)
+	if err != nil {
+		return nil, ticket.ErrVersionMismatch
+	}
+
+	return &version, nil
+}
+
+func versionStatus(err error) (int, bool) {
+	switch {
+	case errors.Is(err, ticket.ErrVersionMismatch):
+		return http.StatusPreconditionFailed, true
+	case errors.Is(err, ticket.ErrVersionConflict), errors.Is(err, db.ErrTransactionConflict):
+		return http.StatusConflict, true
+	}
+
+	return 0, false
 }
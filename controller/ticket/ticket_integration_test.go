@@ -1,6 +1,7 @@
 package ticket
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -10,12 +11,25 @@ import (
 	"strconv"
 	"strings"
 	"testing"
-
+	"time"
+
+	purchaseController "github.com/aaydin-tr/ddd-api-example/controller/purchase"
+	eventRepository "github.com/aaydin-tr/ddd-api-example/domain/event/repository"
+	promotionRepository "github.com/aaydin-tr/ddd-api-example/domain/promotion/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/purchase"
+	purchaseRepository "github.com/aaydin-tr/ddd-api-example/domain/purchase/repository"
+	seatRepository "github.com/aaydin-tr/ddd-api-example/domain/seat/repository"
 	domain "github.com/aaydin-tr/ddd-api-example/domain/ticket"
 	"github.com/aaydin-tr/ddd-api-example/domain/ticket/repository"
+	transaction "github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db/migration"
 	"github.com/aaydin-tr/ddd-api-example/infrastructure/db/postgresql"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/idempotency"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/middleware"
 	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	"github.com/aaydin-tr/ddd-api-example/pkg/eventbus"
 	"github.com/aaydin-tr/ddd-api-example/pkg/validator"
+	purchaseService "github.com/aaydin-tr/ddd-api-example/service/purchase"
 	service "github.com/aaydin-tr/ddd-api-example/service/ticket"
 	"github.com/labstack/echo/v4"
 	"github.com/ory/dockertest/v3"
@@ -27,10 +41,12 @@ import (
 
 type ticketTestSuite struct {
 	suite.Suite
-	pool       *dockertest.Pool
-	resource   *dockertest.Resource
-	controller *TicketController
-	sqlDB      *sql.DB
+	pool               *dockertest.Pool
+	resource           *dockertest.Resource
+	controller         *TicketController
+	purchaseController *purchaseController.PurchaseController
+	idempotencyStore   idempotency.Store
+	sqlDB              *sql.DB
 }
 
 type ticketTestCase struct {
@@ -45,25 +61,25 @@ var (
 	createTicketTestCases = []ticketTestCase{
 		{
 			name:               "Create ticket successfully",
-			request:            strToPointer(`{ "name": "example", "description": "sample description", "allocation": 100 }`),
-			expectedResponse:   strToPointer(`{ "id": 1, "name": "example", "description": "sample description", "allocation": 100 }`),
+			request:            strToPointer(`{ "name": "example", "description": "sample description", "allocation": 100, "sold": 0, "price": { "amount": 1500, "currency": "EUR" } }`),
+			expectedResponse:   strToPointer(`{ "id": 1, "event_id": null, "name": "example", "description": "sample description", "allocation": 100, "sold": 0, "held": 0, "price": { "amount": 1500, "currency": "EUR" }, "max_per_user": null, "sales_start_at": null, "sales_end_at": null, "sale_status": "on_sale", "status": "published", "seating": "general", "version": 1 }`),
 			expectedStatusCode: http.StatusCreated,
 		},
 		{
 			name:               "Create ticket with invalid request (empty name)",
-			request:            strToPointer(`{ "name": "", "description": "sample description", "allocation": 100 }`),
+			request:            strToPointer(`{ "name": "", "description": "sample description", "allocation": 100, "sold": 0, "price": { "amount": 1500, "currency": "EUR" } }`),
 			expectedResponse:   strToPointer(`{ "message": "Validation error", "errors": [ { "failed_field": "Name", "tag": "required", "message": "This field is required" } ], "status": 400 }`),
 			expectedStatusCode: http.StatusBadRequest,
 		},
 		{
 			name:               "Create ticket with invalid request (empty desc)",
-			request:            strToPointer(`{ "name": "example", "description": "", "allocation": 100 }`),
+			request:            strToPointer(`{ "name": "example", "description": "", "allocation": 100, "sold": 0, "price": { "amount": 1500, "currency": "EUR" } }`),
 			expectedResponse:   strToPointer(`{ "message": "Validation error", "errors": [ { "failed_field": "Description", "tag": "required", "message": "This field is required" } ], "status": 400 }`),
 			expectedStatusCode: http.StatusBadRequest,
 		},
 		{
 			name:               "Create ticket with invalid request (empty allocation)",
-			request:            strToPointer(`{ "name": "example", "description": "sample description", "allocation": 0 }`),
+			request:            strToPointer(`{ "name": "example", "description": "sample description", "allocation": 0, "price": { "amount": 1500, "currency": "EUR" } }`),
 			expectedResponse:   strToPointer(`{ "message": "Validation error", "errors": [ { "failed_field": "Allocation", "tag": "required", "message": "This field is required" } ], "status": 400 }`),
 			expectedStatusCode: http.StatusBadRequest,
 		},
@@ -74,7 +90,7 @@ var (
 			name:               "Get ticket successfully",
 			request:            nil,
 			ticketID:           1,
-			expectedResponse:   strToPointer(`{ "id": 1, "name": "example", "description": "sample description", "allocation": 100 }`),
+			expectedResponse:   strToPointer(`{ "id": 1, "event_id": null, "name": "example", "description": "sample description", "allocation": 100, "sold": 0, "held": 0, "price": { "amount": 1500, "currency": "EUR" }, "max_per_user": null, "sales_start_at": null, "sales_end_at": null, "sale_status": "on_sale", "status": "published", "seating": "general", "version": 1 }`),
 			expectedStatusCode: http.StatusOK,
 		},
 		{
@@ -86,12 +102,44 @@ var (
 		},
 	}
 
+	listTicketTestCases = []struct {
+		name               string
+		query              string
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:               "List tickets successfully",
+			query:              "",
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{ "items": [ { "id": 1, "event_id": null, "name": "example", "description": "sample description", "allocation": 100, "sold": 0, "held": 0, "price": { "amount": 1500, "currency": "EUR" }, "max_per_user": null, "sales_start_at": null, "sales_end_at": null, "sale_status": "on_sale", "status": "published", "seating": "general", "version": 1 } ], "total": 1, "next_cursor": "" }`,
+		},
+		{
+			name:               "List tickets filtered by name",
+			query:              "?name=EXAM",
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{ "items": [ { "id": 1, "event_id": null, "name": "example", "description": "sample description", "allocation": 100, "sold": 0, "held": 0, "price": { "amount": 1500, "currency": "EUR" }, "max_per_user": null, "sales_start_at": null, "sales_end_at": null, "sale_status": "on_sale", "status": "published", "seating": "general", "version": 1 } ], "total": 1, "next_cursor": "" }`,
+		},
+		{
+			name:               "List sold out tickets",
+			query:              "?sold_out=true",
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{ "items": [], "total": 0, "next_cursor": "" }`,
+		},
+		{
+			name:               "List tickets with invalid cursor",
+			query:              "?cursor=invalid",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{ "message": "invalid cursor", "status": 400, "errors": null }`,
+		},
+	}
+
 	purchasesTicketTestCases = []ticketTestCase{
 		{
 			name:               "Purchase ticket successfully",
 			request:            strToPointer(`{ "quantity": 10, "user_id": "406c1d05-bbb2-4e94-b183-7d208c2692e1" }`),
 			expectedResponse:   nil,
-			expectedStatusCode: http.StatusOK,
+			expectedStatusCode: http.StatusCreated,
 			ticketID:           1,
 		},
 		{
@@ -167,7 +215,18 @@ func (s *ticketTestSuite) SetupSuite() {
 		}
 
 		dbClient = db
-		return dbClient.AutoMigrate(&domain.Ticket{})
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+
+		migrator, err := migration.NewMigrator(sqlDB)
+		if err != nil {
+			return err
+		}
+
+		_, err = migrator.Up(context.Background())
+		return err
 	})
 	if err != nil {
 		s.FailNow("Could not complete postgres migrations: %s", err)
@@ -179,11 +238,15 @@ func (s *ticketTestSuite) SetupSuite() {
 	}
 
 	s.sqlDB = sqlDB
+	uow := transaction.NewUnitOfWork(dbClient, transaction.NoRetry, sql.LevelDefault)
 	repos := repository.NewTicketRepository(dbClient)
-	svc := service.NewTicketService(repos)
+	purchaseRepos := purchaseRepository.NewPurchaseRepository(dbClient)
+	svc := service.NewTicketService(uow, repos, purchaseRepos, eventRepository.NewEventRepository(dbClient), seatRepository.NewSeatRepository(dbClient), promotionRepository.NewPromotionRepository(dbClient), eventbus.New(), service.LockingPessimistic)
 	controller := NewTicketController(svc)
 
 	s.controller = controller
+	s.purchaseController = purchaseController.NewPurchaseController(purchaseService.NewPurchaseService(uow, purchaseRepos, repos, seatRepository.NewSeatRepository(dbClient), eventbus.New()))
+	s.idempotencyStore = idempotency.NewPostgresStore(dbClient)
 }
 
 func (s *ticketTestSuite) TearDownSuite() {
@@ -257,6 +320,23 @@ func (s *ticketTestSuite) TestFindByID() {
 	}
 }
 
+func (s *ticketTestSuite) TestList() {
+	for _, tc := range listTicketTestCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			api := echo.New()
+			api.Validator = validator.New()
+			api.GET("/tickets", s.controller.List)
+
+			req := httptest.NewRequest(http.MethodGet, "/tickets"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			api.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
 func (s *ticketTestSuite) TestPurchases() {
 	for _, tc := range purchasesTicketTestCases {
 		s.T().Run(tc.name, func(t *testing.T) {
@@ -275,11 +355,9 @@ func (s *ticketTestSuite) TestPurchases() {
 			assert.Equal(t, tc.expectedStatusCode, rec.Code)
 			if tc.expectedResponse != nil {
 				assert.JSONEq(t, *tc.expectedResponse, rec.Body.String())
-			} else {
-				assert.Empty(t, rec.Body.String())
 			}
 
-			if rec.Code == http.StatusOK {
+			if rec.Code == http.StatusCreated {
 				var ticket domain.Ticket
 				var req request.PurchaseTicketRequest
 				err := json.Unmarshal([]byte(*tc.request), &req)
@@ -287,6 +365,30 @@ func (s *ticketTestSuite) TestPurchases() {
 					t.Fatalf("could not unmarshal expected response: %s", err)
 				}
 
+				var created purchase.PurchaseDTO
+				if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+					t.Fatalf("could not unmarshal response: %s", err)
+				}
+
+				assert.NotZero(t, created.ID)
+				assert.Equal(t, tc.ticketID, created.TicketID)
+				assert.Equal(t, req.UserID, created.UserID)
+				assert.Equal(t, req.Quantity, created.Quantity)
+				assert.Equal(t, string(purchase.StatusCompleted), created.Status)
+				assert.Equal(t, int64(1500), created.UnitPrice.Amount)
+				assert.Equal(t, int64(1500*req.Quantity), created.Total.Amount)
+
+				var purchasedQuantity int
+				var purchasedBy, unitPrice, total string
+				if err := s.sqlDB.QueryRow("SELECT quantity, user_id, unit_price, total FROM purchases WHERE id = $1", created.ID).Scan(&purchasedQuantity, &purchasedBy, &unitPrice, &total); err != nil {
+					t.Fatalf("could not query purchase: %s", err)
+				}
+
+				assert.Equal(t, req.Quantity, purchasedQuantity)
+				assert.Equal(t, req.UserID, purchasedBy)
+				assert.Equal(t, "1500 EUR", unitPrice)
+				assert.Equal(t, fmt.Sprintf("%d EUR", 1500*req.Quantity), total)
+
 				if err := s.sqlDB.QueryRow("SELECT id, name, description, allocation FROM tickets WHERE id = $1", tc.ticketID).Scan(&ticket.ID, &ticket.Name, &ticket.Description, &ticket.Allocation); err != nil {
 					t.Fatalf("could not query ticket: %s", err)
 				}
@@ -299,6 +401,135 @@ func (s *ticketTestSuite) TestPurchases() {
 	}
 }
 
+func (s *ticketTestSuite) TestPurchasesIdempotency() {
+	api := echo.New()
+	api.Validator = validator.New()
+	api.POST("/tickets/:id/purchases", s.controller.Purchases, middleware.Idempotency(s.idempotencyStore, time.Hour))
+
+	do := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/tickets/1/purchases", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(middleware.HeaderIdempotencyKey, key)
+		rec := httptest.NewRecorder()
+		api.ServeHTTP(rec, req)
+		return rec
+	}
+
+	var allocationBefore int
+	s.Require().NoError(s.sqlDB.QueryRow("SELECT allocation FROM tickets WHERE id = 1").Scan(&allocationBefore))
+
+	body := `{ "quantity": 1, "user_id": "406c1d05-bbb2-4e94-b183-7d208c2692e1" }`
+	first := do("integration-key", body)
+	second := do("integration-key", body)
+
+	s.Equal(http.StatusCreated, first.Code)
+	s.Equal(http.StatusCreated, second.Code)
+	s.JSONEq(first.Body.String(), second.Body.String())
+	s.Equal("true", second.Header().Get(middleware.HeaderIdempotencyReplayed))
+
+	var allocationAfter int
+	s.Require().NoError(s.sqlDB.QueryRow("SELECT allocation FROM tickets WHERE id = 1").Scan(&allocationAfter))
+	s.Equal(allocationBefore-1, allocationAfter)
+
+	reused := do("integration-key", `{ "quantity": 2, "user_id": "406c1d05-bbb2-4e94-b183-7d208c2692e1" }`)
+	s.Equal(http.StatusUnprocessableEntity, reused.Code)
+}
+
+func (s *ticketTestSuite) TestUpdateDeleteRestore() {
+	api := echo.New()
+	api.Validator = validator.New()
+	api.POST("/tickets", s.controller.Create)
+	api.GET("/tickets/:id", s.controller.FindByID)
+	api.PATCH("/tickets/:id", s.controller.Update)
+	api.DELETE("/tickets/:id", s.controller.Delete)
+	api.POST("/tickets/:id/restore", s.controller.Restore)
+
+	doIfMatch := func(method, target, body, ifMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		rec := httptest.NewRecorder()
+		api.ServeHTTP(rec, req)
+		return rec
+	}
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		return doIfMatch(method, target, body, "")
+	}
+
+	rec := do(http.MethodPost, "/tickets", `{ "name": "lifecycle", "description": "lifecycle description", "allocation": 10, "price": { "amount": 1500, "currency": "EUR" } }`)
+	s.Require().Equal(http.StatusCreated, rec.Code)
+
+	var created domain.TicketDTO
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &created))
+	target := fmt.Sprintf("/tickets/%d", created.ID)
+
+	_, err := s.sqlDB.Exec("UPDATE tickets SET allocation = 6, sold = 4 WHERE id = $1", created.ID)
+	s.Require().NoError(err)
+
+	s.T().Run("Update ticket successfully", func(t *testing.T) {
+		rec := do(http.MethodPatch, target, `{ "name": "renamed", "total_allocation": 20 }`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, fmt.Sprintf(`{ "id": %d, "event_id": null, "name": "renamed", "description": "lifecycle description", "allocation": 16, "sold": 4, "held": 0, "price": { "amount": 1500, "currency": "EUR" }, "max_per_user": null, "sales_start_at": null, "sales_end_at": null, "sale_status": "on_sale", "status": "published", "seating": "general", "version": 2 }`, created.ID), rec.Body.String())
+	})
+
+	s.T().Run("Get ticket returns its version as ETag", func(t *testing.T) {
+		rec := do(http.MethodGet, target, "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
+	})
+
+	s.T().Run("Update ticket with stale If-Match", func(t *testing.T) {
+		rec := doIfMatch(http.MethodPatch, target, `{ "name": "stale" }`, `"1"`)
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+		assert.JSONEq(t, `{ "message": "ticket version does not match", "status": 412, "errors": null }`, rec.Body.String())
+	})
+
+	s.T().Run("Update ticket with current If-Match", func(t *testing.T) {
+		rec := doIfMatch(http.MethodPatch, target, `{ "description": "conditional description" }`, `"2"`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+	})
+
+	s.T().Run("Update ticket allocation below sold", func(t *testing.T) {
+		rec := do(http.MethodPatch, target, `{ "total_allocation": 3 }`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.JSONEq(t, `{ "message": "allocation cannot be lower than sold and held quantity", "status": 422, "errors": null }`, rec.Body.String())
+	})
+
+	s.T().Run("Restore not deleted ticket", func(t *testing.T) {
+		rec := do(http.MethodPost, target+"/restore", "")
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	s.T().Run("Delete ticket with stale If-Match", func(t *testing.T) {
+		rec := doIfMatch(http.MethodDelete, target, "", `"2"`)
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	})
+
+	s.T().Run("Delete ticket successfully", func(t *testing.T) {
+		rec := doIfMatch(http.MethodDelete, target, "", `"3"`)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+
+		rec = do(http.MethodGet, target, "")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	s.T().Run("Restore ticket successfully", func(t *testing.T) {
+		rec := doIfMatch(http.MethodPost, target+"/restore", "", `"3"`)
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code, "the delete bumps the version")
+
+		rec = doIfMatch(http.MethodPost, target+"/restore", "", `"4"`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"5"`, rec.Header().Get("ETag"))
+
+		rec = do(http.MethodGet, target, "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
 func strToPointer(s string) *string {
 	if s == "" {
 		return nil
@@ -312,3 +543,85 @@ func pointerToStr(s *string) string {
 	}
 	return *s
 }
+
+func (s *ticketTestSuite) TestRefund() {
+	api := echo.New()
+	api.Validator = validator.New()
+	api.POST("/tickets", s.controller.Create)
+	api.GET("/tickets/:id", s.controller.FindByID)
+	api.POST("/tickets/:id/purchases", s.controller.Purchases)
+	api.POST("/purchases/:id/refunds", s.purchaseController.Refund)
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		api.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(http.MethodPost, "/tickets", `{ "name": "refund", "description": "refund description", "allocation": 10, "price": { "amount": 1500, "currency": "EUR" } }`)
+	s.Require().Equal(http.StatusCreated, rec.Code)
+
+	var created domain.TicketDTO
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &created))
+
+	rec = do(http.MethodPost, fmt.Sprintf("/tickets/%d/purchases", created.ID), `{ "quantity": 3, "user_id": "406c1d05-bbb2-4e94-b183-7d208c2692e1" }`)
+	s.Require().Equal(http.StatusCreated, rec.Code)
+
+	var bought purchase.PurchaseDTO
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &bought))
+	target := fmt.Sprintf("/purchases/%d/refunds", bought.ID)
+
+	allocation := func() (int, int) {
+		var allocation, sold int
+		s.Require().NoError(s.sqlDB.QueryRow("SELECT allocation, sold FROM tickets WHERE id = $1", created.ID).Scan(&allocation, &sold))
+		return allocation, sold
+	}
+
+	s.T().Run("Refund part of the purchase", func(t *testing.T) {
+		rec := do(http.MethodPost, target, `{ "quantity": 1 }`)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		var refund purchase.RefundDTO
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &refund))
+		assert.Equal(t, 1, refund.Quantity)
+		assert.Equal(t, int64(1500), refund.Amount.Amount)
+		assert.Equal(t, string(purchase.StatusPartiallyRefunded), refund.Purchase.Status)
+
+		remaining, sold := allocation()
+		assert.Equal(t, 8, remaining)
+		assert.Equal(t, 2, sold)
+	})
+
+	s.T().Run("Refund more than purchased", func(t *testing.T) {
+		rec := do(http.MethodPost, target, `{ "quantity": 3 }`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.JSONEq(t, `{ "message": "refund quantity exceeds the refundable quantity", "status": 422, "errors": null }`, rec.Body.String())
+	})
+
+	s.T().Run("Refund the rest of the purchase", func(t *testing.T) {
+		rec := do(http.MethodPost, target, "")
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		var refund purchase.RefundDTO
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &refund))
+		assert.Equal(t, 2, refund.Quantity)
+		assert.Equal(t, string(purchase.StatusRefunded), refund.Purchase.Status)
+
+		remaining, sold := allocation()
+		assert.Equal(t, 10, remaining)
+		assert.Equal(t, 0, sold)
+	})
+
+	s.T().Run("Refund twice", func(t *testing.T) {
+		rec := do(http.MethodPost, target, "")
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.JSONEq(t, `{ "message": "purchase is already refunded", "status": 409, "errors": null }`, rec.Body.String())
+	})
+
+	s.T().Run("Refund unknown purchase", func(t *testing.T) {
+		rec := do(http.MethodPost, "/purchases/999999/refunds", "")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
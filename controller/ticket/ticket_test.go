@@ -1,14 +1,20 @@
 package ticket
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/aaydin-tr/ddd-api-example/domain/purchase"
+	"github.com/aaydin-tr/ddd-api-example/domain/seat"
 	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
 	mockservice "github.com/aaydin-tr/ddd-api-example/mock/service/ticket"
+	"github.com/aaydin-tr/ddd-api-example/pkg/pagination"
 
 	"github.com/aaydin-tr/ddd-api-example/pkg/validator"
 	"github.com/labstack/echo/v4"
@@ -37,7 +43,8 @@ func TestTicketController_Create(t *testing.T) {
 			requestBody: `{
 				"name": "Test Ticket",
 				"description": "Test Description",
-				"allocation": 100
+				"allocation": 100,
+				"price": { "amount": 1500, "currency": "EUR" }
 			}`,
 			mock: func() {
 				mockService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&ticket.TicketDTO{
@@ -63,17 +70,40 @@ func TestTicketController_Create(t *testing.T) {
 			requestBody: `{
 				"name": "",
 				"description": "Test Description",
+				"allocation": 100,
+				"price": { "amount": 1500, "currency": "EUR" }
+			}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "missing price",
+			requestBody: `{
+				"name": "Test Ticket",
+				"description": "Test Description",
 				"allocation": 100
 			}`,
 			mock:         func() {},
 			expectedCode: http.StatusBadRequest,
 		},
+		{
+			name: "invalid currency",
+			requestBody: `{
+				"name": "Test Ticket",
+				"description": "Test Description",
+				"allocation": 100,
+				"price": { "amount": 1500, "currency": "euro" }
+			}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
 		{
 			name: "service error",
 			requestBody: `{
 				"name": "Test Ticket",
 				"description": "Test Description",
-				"allocation": 100
+				"allocation": 100,
+				"price": { "amount": 1500, "currency": "EUR" }
 			}`,
 			mock: func() {
 				mockService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("service error"))
@@ -113,6 +143,7 @@ func TestTicketController_FindByID(t *testing.T) {
 		paramID      string
 		mock         func()
 		expectedCode int
+		expectedETag string
 	}{
 		{
 			name:    "success",
@@ -123,9 +154,11 @@ func TestTicketController_FindByID(t *testing.T) {
 					Name:        "Test Ticket",
 					Description: "Test Description",
 					Allocation:  100,
+					Version:     3,
 				}, nil)
 			},
 			expectedCode: http.StatusOK,
+			expectedETag: `"3"`,
 		},
 		{
 			name:         "id is required",
@@ -163,6 +196,84 @@ func TestTicketController_FindByID(t *testing.T) {
 				t.Fatal(err)
 			}
 
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.Equal(t, tt.expectedETag, rec.Header().Get("ETag"))
+		})
+	}
+}
+func TestTicketController_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockTicketService(ctrl)
+	controller := NewTicketController(mockService)
+
+	e := echo.New()
+	e.Validator = validator.New()
+
+	tests := []struct {
+		name         string
+		query        string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:  "success",
+			query: "?name=concert&sold_out=true&sort_by=created_at&order=desc&limit=10",
+			mock: func() {
+				mockService.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, req request.ListTicketsRequest) (*ticket.TicketListDTO, error) {
+					assert.Equal(t, "concert", req.Name)
+					assert.True(t, *req.SoldOut)
+					assert.Equal(t, "created_at", req.SortBy)
+					assert.Equal(t, "desc", req.Order)
+					assert.Equal(t, 10, req.Limit)
+					return &ticket.TicketListDTO{Items: []*ticket.TicketDTO{}, Total: 0}, nil
+				})
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "bind error",
+			query:        "?limit=invalid",
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "validation error",
+			query:        "?sort_by=name",
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:  "invalid cursor",
+			query: "?cursor=invalid",
+			mock: func() {
+				mockService.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, pagination.ErrInvalidCursor)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:  "service error",
+			query: "",
+			mock: func() {
+				mockService.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, errors.New("service error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/tickets"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			tt.mock()
+			err := controller.List(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
 			assert.Equal(t, tt.expectedCode, rec.Code)
 		})
 	}
@@ -192,9 +303,18 @@ func TestTicketController_Purchases(t *testing.T) {
 				"user_id": "1250052d-c061-4a1f-81f0-d88af3dcb3d5"
 			}`,
 			mock: func() {
-				mockService.EXPECT().DecrementAllocation(gomock.Any(), 1, 2).Return(nil)
+				mockService.EXPECT().Purchase(gomock.Any(), 1, request.PurchaseTicketRequest{
+					Quantity: 2,
+					UserID:   "1250052d-c061-4a1f-81f0-d88af3dcb3d5",
+				}).Return(&purchase.PurchaseDTO{
+					ID:       1,
+					TicketID: 1,
+					UserID:   "1250052d-c061-4a1f-81f0-d88af3dcb3d5",
+					Quantity: 2,
+					Status:   "completed",
+				}, nil)
 			},
-			expectedCode: http.StatusOK,
+			expectedCode: http.StatusCreated,
 		},
 		{
 			name:         "bind error",
@@ -235,10 +355,31 @@ func TestTicketController_Purchases(t *testing.T) {
 				"user_id": "1250052d-c061-4a1f-81f0-d88af3dcb3d5"
 			}`,
 			mock: func() {
-				mockService.EXPECT().DecrementAllocation(gomock.Any(), 1, 2).Return(errors.New("service error"))
+				mockService.EXPECT().Purchase(gomock.Any(), 1, gomock.Any()).Return(nil, errors.New("service error"))
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "sales closed",
+			paramID:     "1",
+			requestBody: `{"quantity": 2, "user_id": "1250052d-c061-4a1f-81f0-d88af3dcb3d5"}`,
+			mock: func() {
+				mockService.EXPECT().Purchase(gomock.Any(), 1, gomock.Any()).Return(nil, ticket.ErrSalesClosed)
 			},
 			expectedCode: http.StatusUnprocessableEntity,
 		},
+		{
+			name:    "transaction conflict",
+			paramID: "1",
+			requestBody: `{
+				"quantity": 2,
+				"user_id": "1250052d-c061-4a1f-81f0-d88af3dcb3d5"
+			}`,
+			mock: func() {
+				mockService.EXPECT().Purchase(gomock.Any(), 1, gomock.Any()).Return(nil, db.ErrTransactionConflict)
+			},
+			expectedCode: http.StatusConflict,
+		},
 	}
 
 	for _, tt := range tests {
@@ -260,3 +401,871 @@ func TestTicketController_Purchases(t *testing.T) {
 		})
 	}
 }
+
+func TestTicketController_PurchasesLimitExceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockTicketService(ctrl)
+	controller := NewTicketController(mockService)
+
+	e := echo.New()
+	e.Validator = validator.New()
+
+	limitErr := &ticket.PurchaseLimitError{Limit: 4, Remaining: 1}
+	mockService.EXPECT().Purchase(gomock.Any(), 1, gomock.Any()).Return(nil, limitErr)
+
+	req := httptest.NewRequest(http.MethodPost, "/tickets/1/purchases", strings.NewReader(`{"quantity": 2, "user_id": "1250052d-c061-4a1f-81f0-d88af3dcb3d5"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	assert.NoError(t, controller.Purchases(c))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.JSONEq(t, `{"message": "`+limitErr.Error()+`", "status": 422, "limit": 4, "remaining": 1}`, rec.Body.String())
+}
+
+func TestTicketController_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockTicketService(ctrl)
+	controller := NewTicketController(mockService)
+
+	e := echo.New()
+	e.Validator = validator.New()
+
+	name := "Renamed Ticket"
+	total := 150
+
+	tests := []struct {
+		name         string
+		paramID      string
+		requestBody  string
+		ifMatch      string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:        "success",
+			paramID:     "1",
+			requestBody: `{"name": "Renamed Ticket", "total_allocation": 150}`,
+			mock: func() {
+				req := request.UpdateTicketRequest{Name: &name, TotalAllocation: &total}
+				mockService.EXPECT().Update(gomock.Any(), 1, req, gomock.Nil()).Return(&ticket.TicketDTO{
+					ID:          1,
+					Name:        "Renamed Ticket",
+					Description: "Test Description",
+					Allocation:  150,
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "bind error",
+			paramID:      "1",
+			requestBody:  `{"total_allocation": "invalid"}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "validation error",
+			paramID:      "1",
+			requestBody:  `{"total_allocation": -1}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid id",
+			paramID:      "invalid",
+			requestBody:  `{"name": "Renamed Ticket"}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "not found",
+			paramID:     "2",
+			requestBody: `{"name": "Renamed Ticket"}`,
+			mock: func() {
+				mockService.EXPECT().Update(gomock.Any(), 2, gomock.Any(), gomock.Nil()).Return(nil, ticket.ErrTicketNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:        "allocation below sold",
+			paramID:     "1",
+			requestBody: `{"total_allocation": 1}`,
+			mock: func() {
+				mockService.EXPECT().Update(gomock.Any(), 1, gomock.Any(), gomock.Nil()).Return(nil, ticket.ErrAllocationBelowSold)
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "archived",
+			paramID:     "1",
+			requestBody: `{"name": "Renamed Ticket"}`,
+			mock: func() {
+				mockService.EXPECT().Update(gomock.Any(), 1, gomock.Any(), gomock.Nil()).Return(nil, ticket.ErrTicketArchived)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:        "matching if-match",
+			paramID:     "1",
+			requestBody: `{"name": "Renamed Ticket"}`,
+			ifMatch:     `"3"`,
+			mock: func() {
+				mockService.EXPECT().Update(gomock.Any(), 1, gomock.Any(), gomock.Eq(intPtr(3))).Return(&ticket.TicketDTO{ID: 1, Version: 4}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:        "stale if-match",
+			paramID:     "1",
+			requestBody: `{"name": "Renamed Ticket"}`,
+			ifMatch:     `"2"`,
+			mock: func() {
+				mockService.EXPECT().Update(gomock.Any(), 1, gomock.Any(), gomock.Eq(intPtr(2))).Return(nil, ticket.ErrVersionMismatch)
+			},
+			expectedCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:         "weak if-match",
+			paramID:      "1",
+			requestBody:  `{"name": "Renamed Ticket"}`,
+			ifMatch:      `W/"3"`,
+			mock:         func() {},
+			expectedCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:        "wildcard if-match",
+			paramID:     "1",
+			requestBody: `{"name": "Renamed Ticket"}`,
+			ifMatch:     "*",
+			mock: func() {
+				mockService.EXPECT().Update(gomock.Any(), 1, gomock.Any(), gomock.Nil()).Return(&ticket.TicketDTO{ID: 1, Version: 4}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:        "concurrent update",
+			paramID:     "1",
+			requestBody: `{"name": "Renamed Ticket"}`,
+			mock: func() {
+				mockService.EXPECT().Update(gomock.Any(), 1, gomock.Any(), gomock.Nil()).Return(nil, ticket.ErrVersionConflict)
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/tickets/"+tt.paramID, strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := controller.Update(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestTicketController_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockTicketService(ctrl)
+	controller := NewTicketController(mockService)
+
+	e := echo.New()
+
+	tests := []struct {
+		name         string
+		paramID      string
+		ifMatch      string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:    "success",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), 1, gomock.Nil()).Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "id is required",
+			paramID:      "",
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:    "not found",
+			paramID: "2",
+			mock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), 2, gomock.Nil()).Return(ticket.ErrTicketNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:    "service error",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), 1, gomock.Nil()).Return(errors.New("service error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name:    "matching if-match",
+			paramID: "1",
+			ifMatch: `"3"`,
+			mock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), 1, gomock.Eq(intPtr(3))).Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:    "stale if-match",
+			paramID: "1",
+			ifMatch: `"2"`,
+			mock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), 1, gomock.Eq(intPtr(2))).Return(ticket.ErrVersionMismatch)
+			},
+			expectedCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:    "ticket has held units",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), 1, gomock.Nil()).Return(ticket.ErrTicketHasHolds)
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/tickets/"+tt.paramID, nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := controller.Delete(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestTicketController_Restore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockTicketService(ctrl)
+	controller := NewTicketController(mockService)
+
+	e := echo.New()
+
+	tests := []struct {
+		name         string
+		paramID      string
+		ifMatch      string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:    "success",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Restore(gomock.Any(), 1, gomock.Nil()).Return(&ticket.TicketDTO{ID: 1}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "invalid id",
+			paramID:      "invalid",
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:    "not found",
+			paramID: "2",
+			mock: func() {
+				mockService.EXPECT().Restore(gomock.Any(), 2, gomock.Nil()).Return(nil, ticket.ErrTicketNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:    "not deleted",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Restore(gomock.Any(), 1, gomock.Nil()).Return(nil, ticket.ErrTicketNotDeleted)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:    "stale if-match",
+			paramID: "1",
+			ifMatch: `"2"`,
+			mock: func() {
+				mockService.EXPECT().Restore(gomock.Any(), 1, gomock.Eq(intPtr(2))).Return(nil, ticket.ErrVersionMismatch)
+			},
+			expectedCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:         "malformed if-match",
+			paramID:      "1",
+			ifMatch:      `"abc"`,
+			mock:         func() {},
+			expectedCode: http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/tickets/"+tt.paramID+"/restore", nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := controller.Restore(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestTicketController_Transition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockTicketService(ctrl)
+	controller := NewTicketController(mockService)
+
+	e := echo.New()
+
+	tests := []struct {
+		name         string
+		handler      echo.HandlerFunc
+		paramID      string
+		ifMatch      string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:    "publish",
+			handler: controller.Publish,
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Transition(gomock.Any(), 1, ticket.TransitionPublish, gomock.Nil()).Return(&ticket.TicketDTO{ID: 1, Status: "published", Version: 2}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:    "pause",
+			handler: controller.Pause,
+			paramID: "1",
+			ifMatch: `"2"`,
+			mock: func() {
+				mockService.EXPECT().Transition(gomock.Any(), 1, ticket.TransitionPause, gomock.Eq(intPtr(2))).Return(&ticket.TicketDTO{ID: 1, Status: "paused", Version: 3}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:    "resume",
+			handler: controller.Resume,
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Transition(gomock.Any(), 1, ticket.TransitionResume, gomock.Nil()).Return(&ticket.TicketDTO{ID: 1, Status: "published", Version: 4}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:    "archive",
+			handler: controller.Archive,
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Transition(gomock.Any(), 1, ticket.TransitionArchive, gomock.Nil()).Return(&ticket.TicketDTO{ID: 1, Status: "archived", Version: 5}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "invalid id",
+			handler:      controller.Publish,
+			paramID:      "invalid",
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:    "not found",
+			handler: controller.Publish,
+			paramID: "2",
+			mock: func() {
+				mockService.EXPECT().Transition(gomock.Any(), 2, ticket.TransitionPublish, gomock.Nil()).Return(nil, ticket.ErrTicketNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:    "invalid transition",
+			handler: controller.Resume,
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Transition(gomock.Any(), 1, ticket.TransitionResume, gomock.Nil()).Return(nil, ticket.ErrInvalidTransition)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:    "archive with held units",
+			handler: controller.Archive,
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Transition(gomock.Any(), 1, ticket.TransitionArchive, gomock.Nil()).Return(nil, ticket.ErrTicketHasHolds)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:    "stale if-match",
+			handler: controller.Archive,
+			paramID: "1",
+			ifMatch: `"2"`,
+			mock: func() {
+				mockService.EXPECT().Transition(gomock.Any(), 1, ticket.TransitionArchive, gomock.Eq(intPtr(2))).Return(nil, ticket.ErrVersionMismatch)
+			},
+			expectedCode: http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/tickets/"+tt.paramID, nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := tt.handler(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestTicketController_Ledger(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockTicketService(ctrl)
+	controller := NewTicketController(mockService)
+
+	e := echo.New()
+	e.Validator = validator.New()
+
+	tests := []struct {
+		name         string
+		paramID      string
+		query        string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:    "success",
+			paramID: "1",
+			query:   "?limit=10",
+			mock: func() {
+				mockService.EXPECT().Ledger(gomock.Any(), 1, request.ListLedgerRequest{Limit: 10}).Return(&ticket.LedgerDTO{
+					TicketID: 1,
+					Items:    []*ticket.LedgerEntryDTO{{ID: 1, Delta: 10, Allocation: 10, Reason: "create"}},
+					Total:    1,
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "invalid id",
+			paramID:      "invalid",
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid limit",
+			paramID:      "1",
+			query:        "?limit=101",
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:    "invalid cursor",
+			paramID: "1",
+			query:   "?cursor=invalid",
+			mock: func() {
+				mockService.EXPECT().Ledger(gomock.Any(), 1, request.ListLedgerRequest{Cursor: "invalid"}).Return(nil, pagination.ErrInvalidCursor)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:    "ticket not found",
+			paramID: "2",
+			mock: func() {
+				mockService.EXPECT().Ledger(gomock.Any(), 2, request.ListLedgerRequest{}).Return(nil, ticket.ErrTicketNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:    "service error",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Ledger(gomock.Any(), 1, request.ListLedgerRequest{}).Return(nil, errors.New("service error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/tickets/"+tt.paramID+"/ledger"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := controller.Ledger(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestTicketController_CheckLedger(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockTicketService(ctrl)
+	controller := NewTicketController(mockService)
+
+	e := echo.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockService.EXPECT().CheckLedger(gomock.Any()).Return(&ticket.LedgerCheckDTO{
+			Checked:    2,
+			Mismatches: []*ticket.LedgerMismatchDTO{{TicketID: 2, Allocation: 6, LedgerAllocation: 10, Entries: 1, Error: "mismatch"}},
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/admin/ledger/check", nil), rec)
+		assert.NoError(t, controller.CheckLedger(c))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"checked":2,"mismatches":[{"ticket_id":2,"allocation":6,"ledger_allocation":10,"entries":1,"error":"mismatch"}]}`, rec.Body.String())
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mockService.EXPECT().CheckLedger(gomock.Any()).Return(nil, errors.New("service error"))
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/admin/ledger/check", nil), rec)
+		assert.NoError(t, controller.CheckLedger(c))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestTicketController_CreateSeatMap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockTicketService(ctrl)
+	controller := NewTicketController(mockService)
+
+	e := echo.New()
+	e.Validator = validator.New()
+
+	body := `{"sections":[{"name":"Stalls","rows":[{"name":"A","seats":2}]}]}`
+	req := request.CreateSeatMapRequest{Sections: []request.SeatSectionRequest{
+		{Name: "Stalls", Rows: []request.SeatRowRequest{{Name: "A", Seats: 2}}},
+	}}
+
+	tests := []struct {
+		name         string
+		paramID      string
+		requestBody  string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:        "success",
+			paramID:     "1",
+			requestBody: body,
+			mock: func() {
+				mockService.EXPECT().CreateSeatMap(gomock.Any(), 1, req).Return(&seat.SeatMapDTO{TicketID: 1, Available: 2}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "invalid id",
+			paramID:      "invalid",
+			requestBody:  body,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "row without seats",
+			paramID:      "1",
+			requestBody:  `{"sections":[{"name":"Stalls","rows":[{"name":"A","seats":0}]}]}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "no sections",
+			paramID:      "1",
+			requestBody:  `{"sections":[]}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "ticket not found",
+			paramID:     "2",
+			requestBody: body,
+			mock: func() {
+				mockService.EXPECT().CreateSeatMap(gomock.Any(), 2, req).Return(nil, ticket.ErrTicketNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:        "seat map exists",
+			paramID:     "1",
+			requestBody: body,
+			mock: func() {
+				mockService.EXPECT().CreateSeatMap(gomock.Any(), 1, req).Return(nil, ticket.ErrSeatMapExists)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:        "after sales",
+			paramID:     "1",
+			requestBody: body,
+			mock: func() {
+				mockService.EXPECT().CreateSeatMap(gomock.Any(), 1, req).Return(nil, ticket.ErrSeatMapAfterSales)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:        "archived",
+			paramID:     "1",
+			requestBody: body,
+			mock: func() {
+				mockService.EXPECT().CreateSeatMap(gomock.Any(), 1, req).Return(nil, ticket.ErrTicketArchived)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:        "invalid seat map",
+			paramID:     "1",
+			requestBody: body,
+			mock: func() {
+				mockService.EXPECT().CreateSeatMap(gomock.Any(), 1, req).Return(nil, seat.ErrDuplicateRow)
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/tickets/"+tt.paramID+"/seat-map", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := controller.CreateSeatMap(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestTicketController_SeatMap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockTicketService(ctrl)
+	controller := NewTicketController(mockService)
+
+	e := echo.New()
+
+	tests := []struct {
+		name         string
+		paramID      string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:    "success",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().SeatMap(gomock.Any(), 1).Return(&seat.SeatMapDTO{TicketID: 1}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "invalid id",
+			paramID:      "invalid",
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:    "no seat map",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().SeatMap(gomock.Any(), 1).Return(nil, ticket.ErrNoSeatMap)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:    "service error",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().SeatMap(gomock.Any(), 1).Return(nil, errors.New("service error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/tickets/"+tt.paramID+"/seat-map", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := controller.SeatMap(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestTicketController_BestAvailableSeats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockTicketService(ctrl)
+	controller := NewTicketController(mockService)
+
+	e := echo.New()
+	e.Validator = validator.New()
+
+	tests := []struct {
+		name         string
+		paramID      string
+		query        string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:    "success",
+			paramID: "1",
+			query:   "?quantity=2",
+			mock: func() {
+				mockService.EXPECT().BestAvailableSeats(gomock.Any(), 1, request.BestAvailableSeatsRequest{Quantity: 2}).Return(&seat.SeatListDTO{Items: []*seat.SeatDTO{{ID: 1}, {ID: 2}}}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "missing quantity",
+			paramID:      "1",
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:    "no seat map",
+			paramID: "1",
+			query:   "?quantity=2",
+			mock: func() {
+				mockService.EXPECT().BestAvailableSeats(gomock.Any(), 1, request.BestAvailableSeatsRequest{Quantity: 2}).Return(nil, ticket.ErrNoSeatMap)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:    "no adjacent seats",
+			paramID: "1",
+			query:   "?quantity=2",
+			mock: func() {
+				mockService.EXPECT().BestAvailableSeats(gomock.Any(), 1, request.BestAvailableSeatsRequest{Quantity: 2}).Return(nil, seat.ErrNoAdjacentSeats)
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/tickets/"+tt.paramID+"/seat-map/best-available"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := controller.BestAvailableSeats(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
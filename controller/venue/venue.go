@@ -0,0 +1,199 @@
+package venue
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/venue"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/response"
+	"github.com/aaydin-tr/ddd-api-example/pkg/pagination"
+	service "github.com/aaydin-tr/ddd-api-example/service/venue"
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrIDIsRequired = errors.New("id is required")
+)
+
+type VenueController struct {
+	service service.VenueService
+}
+
+func NewVenueController(service service.VenueService) *VenueController {
+	return &VenueController{service: service}
+}
+
+// Create godoc
+// @Summary      Create a new venue
+// @Description  Create a venue with its address and IANA timezone, the dates of its events are shown in that timezone
+// @Tags         venues
+// @Accept       json
+// @Produce      json
+// @Param        venue body request.CreateVenueRequest true "venue"
+// @Success      201  {object}  venue.VenueDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      422  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /venues [post]
+func (v *VenueController) Create(c echo.Context) error {
+	var req request.CreateVenueRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	created, err := v.service.Create(c.Request().Context(), req)
+	if err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.JSON(http.StatusCreated, created)
+}
+
+// FindByID godoc
+// @Summary      Find venue by ID
+// @Description  Find venue by ID
+// @Tags         venues
+// @Produce      json
+// @Param        id path int true "venue ID"
+// @Success      200  {object}  venue.VenueDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /venues/{id} [get]
+func (v *VenueController) FindByID(c echo.Context) error {
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	found, err := v.service.FindByID(c.Request().Context(), id)
+	if err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.JSON(http.StatusOK, found)
+}
+
+// List godoc
+// @Summary      List venues
+// @Description  List venues ordered by ID with cursor pagination
+// @Tags         venues
+// @Produce      json
+// @Param        limit   query  int     false  "page size (max 100)"
+// @Param        cursor  query  string  false  "cursor returned as next_cursor by the previous page"
+// @Success      200  {object}  venue.VenueListDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /venues [get]
+func (v *VenueController) List(c echo.Context) error {
+	var req request.ListVenuesRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	venues, err := v.service.List(c.Request().Context(), req)
+	if err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.JSON(http.StatusOK, venues)
+}
+
+// Update godoc
+// @Summary      Update venue
+// @Description  Update the name, address or timezone of a venue, fields that are not sent are kept
+// @Tags         venues
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "venue ID"
+// @Param        venue body request.UpdateVenueRequest true "venue"
+// @Success      200  {object}  venue.VenueDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      422  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /venues/{id} [patch]
+func (v *VenueController) Update(c echo.Context) error {
+	var req request.UpdateVenueRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	updated, err := v.service.Update(c.Request().Context(), id, req)
+	if err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.JSON(http.StatusOK, updated)
+}
+
+// Delete godoc
+// @Summary      Delete venue
+// @Description  Delete a venue, venues with events cannot be deleted
+// @Tags         venues
+// @Param        id path int true "venue ID"
+// @Success      204
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /venues/{id} [delete]
+func (v *VenueController) Delete(c echo.Context) error {
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := v.service.Delete(c.Request().Context(), id); err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, venue.ErrVenueNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, venue.ErrVenueHasEvents), errors.Is(err, db.ErrTransactionConflict):
+		return http.StatusConflict
+	case errors.Is(err, pagination.ErrInvalidCursor):
+		return http.StatusBadRequest
+	case errors.Is(err, venue.ErrAddressIsRequired), errors.Is(err, valueobject.ErrNameCannotBeEmpty),
+		errors.Is(err, valueobject.ErrStreetIsRequired), errors.Is(err, valueobject.ErrCityIsRequired),
+		errors.Is(err, valueobject.ErrInvalidCountry), errors.Is(err, valueobject.ErrInvalidTimezone):
+		return http.StatusUnprocessableEntity
+	}
+
+	return http.StatusInternalServerError
+}
+
+func parseID(c echo.Context) (int, error) {
+	id := c.Param("id")
+	if id == "" {
+		return 0, ErrIDIsRequired
+	}
+
+	return strconv.Atoi(id)
+}
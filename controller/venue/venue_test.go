@@ -0,0 +1,146 @@
+package venue
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/venue"
+	mockservice "github.com/aaydin-tr/ddd-api-example/mock/service/venue"
+	"github.com/aaydin-tr/ddd-api-example/pkg/validator"
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVenueController_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockVenueService(ctrl)
+	controller := NewVenueController(mockService)
+
+	e := echo.New()
+	e.Validator = validator.New()
+
+	tests := []struct {
+		name         string
+		requestBody  string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:        "success",
+			requestBody: `{"name": "Arena", "address": {"street": "Karl-Marx-Allee 1", "city": "Berlin", "country": "DE"}, "timezone": "Europe/Berlin"}`,
+			mock: func() {
+				mockService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&venue.VenueDTO{ID: 1}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "missing address",
+			requestBody:  `{"name": "Arena", "timezone": "Europe/Berlin"}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid country",
+			requestBody:  `{"name": "Arena", "address": {"street": "Karl-Marx-Allee 1", "city": "Berlin", "country": "XX"}, "timezone": "Europe/Berlin"}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "invalid timezone",
+			requestBody: `{"name": "Arena", "address": {"street": "Karl-Marx-Allee 1", "city": "Berlin", "country": "DE"}, "timezone": "Mars/Olympus"}`,
+			mock: func() {
+				mockService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, valueobject.ErrInvalidTimezone)
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/venues", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			tt.mock()
+			err := controller.Create(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestVenueController_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockVenueService(ctrl)
+	controller := NewVenueController(mockService)
+	e := echo.New()
+
+	tests := []struct {
+		name         string
+		paramID      string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:    "success",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), 1).Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "invalid id",
+			paramID:      "invalid",
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:    "not found",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), 1).Return(venue.ErrVenueNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:    "has events",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), 1).Return(venue.ErrVenueHasEvents)
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/venues/:id")
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := controller.Delete(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package waitlist
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	"github.com/aaydin-tr/ddd-api-example/domain/waitlist"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/response"
+	service "github.com/aaydin-tr/ddd-api-example/service/waitlist"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrIDIsRequired = errors.New("id is required")
+)
+
+type WaitlistController struct {
+	service service.WaitlistService
+}
+
+func NewWaitlistController(service service.WaitlistService) *WaitlistController {
+	return &WaitlistController{service: service}
+}
+
+// Join godoc
+// @Summary      Join waitlist
+// @Description  Wait for quantity units of a sold out ticket. When units are refunded, released or added, the waitlist is served in the order it was joined: the units are held in a reservation for the user, which has to be confirmed before offer_expires_at.
+// @Tags         waitlist
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "ticket ID"
+// @Param        entry body request.JoinWaitlistRequest true "waitlist entry"
+// @Success      201  {object}  waitlist.EntryDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      422  {object}  response.PurchaseLimitErrorResponse
+// @Router       /tickets/{id}/waitlist [post]
+func (w *WaitlistController) Join(c echo.Context) error {
+	var req request.JoinWaitlistRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	joined, err := w.service.Join(c.Request().Context(), id, req)
+	var limitErr *ticket.PurchaseLimitError
+	if errors.As(err, &limitErr) {
+		return response.NewPurchaseLimitErrorResponse(c, err, limitErr.Limit, limitErr.Remaining)
+	}
+
+	if err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.JSON(http.StatusCreated, joined)
+}
+
+// FindByUser godoc
+// @Summary      Find waitlist entry
+// @Description  Show the latest waitlist entry of a user with its position, 1 is served next, or its pending offer
+// @Tags         waitlist
+// @Produce      json
+// @Param        id path int true "ticket ID"
+// @Param        user_id query string true "user ID"
+// @Success      200  {object}  waitlist.EntryDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Router       /tickets/{id}/waitlist [get]
+func (w *WaitlistController) FindByUser(c echo.Context) error {
+	var req request.WaitlistEntryRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	found, err := w.service.FindByUser(c.Request().Context(), id, req.UserID)
+	if err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.JSON(http.StatusOK, found)
+}
+
+// Leave godoc
+// @Summary      Leave waitlist
+// @Description  Take a waiting entry off the waitlist. A pending offer is declined by cancelling its reservation.
+// @Tags         waitlist
+// @Param        id path int true "ticket ID"
+// @Param        user_id query string true "user ID"
+// @Success      204
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Router       /tickets/{id}/waitlist [delete]
+func (w *WaitlistController) Leave(c echo.Context) error {
+	var req request.WaitlistEntryRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := w.service.Leave(c.Request().Context(), id, req.UserID); err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, waitlist.ErrEntryNotFound), errors.Is(err, ticket.ErrTicketNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, waitlist.ErrAlreadyWaiting), errors.Is(err, waitlist.ErrNotWaiting),
+		errors.Is(err, waitlist.ErrOfferPending), errors.Is(err, waitlist.ErrTicketAvailable),
+		errors.Is(err, ticket.ErrTicketArchived), errors.Is(err, db.ErrTransactionConflict):
+		return http.StatusConflict
+	}
+
+	return http.StatusUnprocessableEntity
+}
+
+func parseID(c echo.Context) (int, error) {
+	id := c.Param("id")
+	if id == "" {
+		return 0, ErrIDIsRequired
+	}
+
+	return strconv.Atoi(id)
+}
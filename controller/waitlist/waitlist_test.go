@@ -0,0 +1,272 @@
+package waitlist
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	"github.com/aaydin-tr/ddd-api-example/domain/waitlist"
+	mockservice "github.com/aaydin-tr/ddd-api-example/mock/service/waitlist"
+	"github.com/aaydin-tr/ddd-api-example/pkg/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+const userID = "1250052d-c061-4a1f-81f0-d88af3dcb3d5"
+
+func TestWaitlistController_Join(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockWaitlistService(ctrl)
+	controller := NewWaitlistController(mockService)
+
+	e := echo.New()
+	e.Validator = validator.New()
+
+	tests := []struct {
+		name         string
+		paramID      string
+		requestBody  string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:        "success",
+			paramID:     "1",
+			requestBody: `{"quantity": 2, "user_id": "` + userID + `"}`,
+			mock: func() {
+				mockService.EXPECT().Join(gomock.Any(), 1, gomock.Any()).Return(&waitlist.EntryDTO{ID: 1, TicketID: 1, Quantity: 2, Status: "waiting", Position: 1}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "validation error",
+			paramID:      "1",
+			requestBody:  `{"quantity": 0, "user_id": "` + userID + `"}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid id",
+			paramID:      "invalid",
+			requestBody:  `{"quantity": 2, "user_id": "` + userID + `"}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "ticket not found",
+			paramID:     "1",
+			requestBody: `{"quantity": 2, "user_id": "` + userID + `"}`,
+			mock: func() {
+				mockService.EXPECT().Join(gomock.Any(), 1, gomock.Any()).Return(nil, ticket.ErrTicketNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:        "already waiting",
+			paramID:     "1",
+			requestBody: `{"quantity": 2, "user_id": "` + userID + `"}`,
+			mock: func() {
+				mockService.EXPECT().Join(gomock.Any(), 1, gomock.Any()).Return(nil, waitlist.ErrAlreadyWaiting)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:        "ticket available",
+			paramID:     "1",
+			requestBody: `{"quantity": 2, "user_id": "` + userID + `"}`,
+			mock: func() {
+				mockService.EXPECT().Join(gomock.Any(), 1, gomock.Any()).Return(nil, waitlist.ErrTicketAvailable)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:        "archived ticket",
+			paramID:     "1",
+			requestBody: `{"quantity": 2, "user_id": "` + userID + `"}`,
+			mock: func() {
+				mockService.EXPECT().Join(gomock.Any(), 1, gomock.Any()).Return(nil, ticket.ErrTicketArchived)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:        "purchase limit exceeded",
+			paramID:     "1",
+			requestBody: `{"quantity": 2, "user_id": "` + userID + `"}`,
+			mock: func() {
+				mockService.EXPECT().Join(gomock.Any(), 1, gomock.Any()).Return(nil, &ticket.PurchaseLimitError{Limit: 4, Remaining: 1})
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/tickets/:id/waitlist")
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := controller.Join(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestWaitlistController_FindByUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockWaitlistService(ctrl)
+	controller := NewWaitlistController(mockService)
+
+	e := echo.New()
+	e.Validator = validator.New()
+
+	tests := []struct {
+		name         string
+		paramID      string
+		query        string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:    "success",
+			paramID: "1",
+			query:   "?user_id=" + userID,
+			mock: func() {
+				mockService.EXPECT().FindByUser(gomock.Any(), 1, userID).Return(&waitlist.EntryDTO{ID: 1, Status: "waiting", Position: 3}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "user id is required",
+			paramID:      "1",
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:    "not found",
+			paramID: "1",
+			query:   "?user_id=" + userID,
+			mock: func() {
+				mockService.EXPECT().FindByUser(gomock.Any(), 1, userID).Return(nil, waitlist.ErrEntryNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/tickets/:id/waitlist")
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := controller.FindByUser(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestWaitlistController_Leave(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockWaitlistService(ctrl)
+	controller := NewWaitlistController(mockService)
+
+	e := echo.New()
+	e.Validator = validator.New()
+
+	tests := []struct {
+		name         string
+		paramID      string
+		query        string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:    "success",
+			paramID: "1",
+			query:   "?user_id=" + userID,
+			mock: func() {
+				mockService.EXPECT().Leave(gomock.Any(), 1, userID).Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "invalid user id",
+			paramID:      "1",
+			query:        "?user_id=invalid",
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:    "offer pending",
+			paramID: "1",
+			query:   "?user_id=" + userID,
+			mock: func() {
+				mockService.EXPECT().Leave(gomock.Any(), 1, userID).Return(waitlist.ErrOfferPending)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:    "not waiting",
+			paramID: "1",
+			query:   "?user_id=" + userID,
+			mock: func() {
+				mockService.EXPECT().Leave(gomock.Any(), 1, userID).Return(waitlist.ErrNotWaiting)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:    "leave error",
+			paramID: "1",
+			query:   "?user_id=" + userID,
+			mock: func() {
+				mockService.EXPECT().Leave(gomock.Any(), 1, userID).Return(errors.New("leave error"))
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/tickets/:id/waitlist")
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := controller.Leave(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/webhook"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/response"
+	"github.com/aaydin-tr/ddd-api-example/pkg/pagination"
+	service "github.com/aaydin-tr/ddd-api-example/service/webhook"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrIDIsRequired = errors.New("id is required")
+)
+
+type WebhookController struct {
+	service service.WebhookService
+}
+
+func NewWebhookController(service service.WebhookService) *WebhookController {
+	return &WebhookController{service: service}
+}
+
+// Create godoc
+// @Summary      Subscribe to events
+// @Description  Send the listed events to url. Deliveries are signed with the returned secret, it is not shown again
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        webhook body request.CreateWebhookRequest true "subscription"
+// @Success      201  {object}  webhook.SubscriptionDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      422  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /webhooks [post]
+func (w *WebhookController) Create(c echo.Context) error {
+	var req request.CreateWebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	subscription, err := w.service.Create(c.Request().Context(), req)
+	if err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.JSON(http.StatusCreated, subscription)
+}
+
+// List godoc
+// @Summary      List webhook subscriptions
+// @Description  List webhook subscriptions, without their secrets
+// @Tags         webhooks
+// @Produce      json
+// @Success      200  {object}  webhook.SubscriptionListDTO
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /webhooks [get]
+func (w *WebhookController) List(c echo.Context) error {
+	subscriptions, err := w.service.List(c.Request().Context())
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, subscriptions)
+}
+
+// Delete godoc
+// @Summary      Delete webhook subscription
+// @Description  Stop sending events to the subscription, its delivery log is kept
+// @Tags         webhooks
+// @Param        id path int true "subscription ID"
+// @Success      204
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /webhooks/{id} [delete]
+func (w *WebhookController) Delete(c echo.Context) error {
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := w.service.Delete(c.Request().Context(), id); err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListDeliveries godoc
+// @Summary      List webhook deliveries
+// @Description  List the latest deliveries of a subscription, newest first, with the status the receiver answered with
+// @Tags         webhooks
+// @Produce      json
+// @Param        id     path   int  true   "subscription ID"
+// @Param        limit  query  int  false  "maximum number of deliveries (max 100)"
+// @Success      200  {object}  webhook.DeliveryListDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /webhooks/{id}/deliveries [get]
+func (w *WebhookController) ListDeliveries(c echo.Context) error {
+	var req request.ListWebhookDeliveriesRequest
+	if err := c.Bind(&req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	if err := c.Validate(req); err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = pagination.DefaultLimit
+	}
+
+	deliveries, err := w.service.ListDeliveries(c.Request().Context(), id, limit)
+	if err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.JSON(http.StatusOK, deliveries)
+}
+
+// Replay godoc
+// @Summary      Replay webhook delivery
+// @Description  Send the payload of a succeeded or failed delivery again as a new delivery
+// @Tags         admin
+// @Produce      json
+// @Param        id path int true "delivery ID"
+// @Success      202  {object}  webhook.DeliveryDTO
+// @Failure      400  {object}  response.ErrorResponse
+// @Failure      404  {object}  response.ErrorResponse
+// @Failure      409  {object}  response.ErrorResponse
+// @Failure      500  {object}  response.ErrorResponse
+// @Router       /admin/webhooks/deliveries/{id}/replay [post]
+func (w *WebhookController) Replay(c echo.Context) error {
+	id, err := parseID(c)
+	if err != nil {
+		return response.NewErrorRespone(c, err, http.StatusBadRequest)
+	}
+
+	delivery, err := w.service.Replay(c.Request().Context(), id)
+	if err != nil {
+		return response.NewErrorRespone(c, err, statusFor(err))
+	}
+
+	return c.JSON(http.StatusAccepted, delivery)
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, webhook.ErrSubscriptionNotFound), errors.Is(err, webhook.ErrDeliveryNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, webhook.ErrDeliveryPending), errors.Is(err, db.ErrTransactionConflict):
+		return http.StatusConflict
+	case errors.Is(err, webhook.ErrInvalidURL), errors.Is(err, webhook.ErrEventsRequired), errors.Is(err, webhook.ErrUnknownEvent):
+		return http.StatusUnprocessableEntity
+	}
+
+	return http.StatusInternalServerError
+}
+
+func parseID(c echo.Context) (int, error) {
+	id := c.Param("id")
+	if id == "" {
+		return 0, ErrIDIsRequired
+	}
+
+	return strconv.Atoi(id)
+}
@@ -0,0 +1,266 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/webhook"
+	mockservice "github.com/aaydin-tr/ddd-api-example/mock/service/webhook"
+	"github.com/aaydin-tr/ddd-api-example/pkg/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWebhookController_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockWebhookService(ctrl)
+	controller := NewWebhookController(mockService)
+
+	e := echo.New()
+	e.Validator = validator.New()
+
+	tests := []struct {
+		name         string
+		requestBody  string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:        "success",
+			requestBody: `{"url": "https://partner.example.com/hooks", "events": ["ticket.sold_out"]}`,
+			mock: func() {
+				mockService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&webhook.SubscriptionDTO{ID: 1, Secret: "whsec_test"}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "missing events",
+			requestBody:  `{"url": "https://partner.example.com/hooks", "events": []}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid url",
+			requestBody:  `{"url": "not a url", "events": ["ticket.sold_out"]}`,
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "unknown event",
+			requestBody: `{"url": "https://partner.example.com/hooks", "events": ["ticket.renamed"]}`,
+			mock: func() {
+				mockService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, webhook.ErrUnknownEvent)
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			tt.mock()
+			err := controller.Create(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestWebhookController_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockWebhookService(ctrl)
+	controller := NewWebhookController(mockService)
+	e := echo.New()
+
+	tests := []struct {
+		name         string
+		paramID      string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name:    "success",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), 1).Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "invalid id",
+			paramID:      "invalid",
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:    "not found",
+			paramID: "1",
+			mock: func() {
+				mockService.EXPECT().Delete(gomock.Any(), 1).Return(webhook.ErrSubscriptionNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/webhooks/:id")
+			c.SetParamNames("id")
+			c.SetParamValues(tt.paramID)
+
+			tt.mock()
+			err := controller.Delete(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestWebhookController_ListDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockWebhookService(ctrl)
+	controller := NewWebhookController(mockService)
+	e := echo.New()
+	e.Validator = validator.New()
+
+	tests := []struct {
+		name         string
+		query        string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name: "default limit",
+			mock: func() {
+				mockService.EXPECT().ListDeliveries(gomock.Any(), 1, 20).Return(&webhook.DeliveryListDTO{}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:  "limit",
+			query: "?limit=5",
+			mock: func() {
+				mockService.EXPECT().ListDeliveries(gomock.Any(), 1, 5).Return(&webhook.DeliveryListDTO{}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "limit too large",
+			query:        "?limit=101",
+			mock:         func() {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "subscription not found",
+			mock: func() {
+				mockService.EXPECT().ListDeliveries(gomock.Any(), 1, 20).Return(nil, webhook.ErrSubscriptionNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/webhooks/:id/deliveries")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			tt.mock()
+			err := controller.ListDeliveries(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestWebhookController_Replay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockWebhookService(ctrl)
+	controller := NewWebhookController(mockService)
+	e := echo.New()
+
+	tests := []struct {
+		name         string
+		mock         func()
+		expectedCode int
+	}{
+		{
+			name: "success",
+			mock: func() {
+				mockService.EXPECT().Replay(gomock.Any(), 3).Return(&webhook.DeliveryDTO{ID: 4}, nil)
+			},
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name: "delivery not found",
+			mock: func() {
+				mockService.EXPECT().Replay(gomock.Any(), 3).Return(nil, webhook.ErrDeliveryNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "delivery still pending",
+			mock: func() {
+				mockService.EXPECT().Replay(gomock.Any(), 3).Return(nil, webhook.ErrDeliveryPending)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name: "internal error",
+			mock: func() {
+				mockService.EXPECT().Replay(gomock.Any(), 3).Return(nil, errors.New("connection reset"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/admin/webhooks/deliveries/:id/replay")
+			c.SetParamNames("id")
+			c.SetParamValues("3")
+
+			tt.mock()
+			err := controller.Replay(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
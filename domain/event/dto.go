@@ -0,0 +1,62 @@
+package event
+
+import (
+	"time"
+)
+
+type EventDTO struct {
+	ID          int    `json:"id"`
+	VenueID     int    `json:"venue_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// StartsAt and EndsAt are in the timezone of the venue.
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Timezone  string    `json:"timezone"`
+	Capacity  int       `json:"capacity"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+} // @Name EventDTO
+
+// NewEventDTOFromEntity shows the dates of the event in location, the
+// timezone of its venue.
+func NewEventDTOFromEntity(e *Event, location *time.Location) *EventDTO {
+	return &EventDTO{
+		ID:          e.ID,
+		VenueID:     e.VenueID,
+		Name:        e.Name.GetValue(),
+		Description: e.Description.GetValue(),
+		StartsAt:    e.Dates.GetStart().In(location),
+		EndsAt:      e.Dates.GetEnd().In(location),
+		Timezone:    location.String(),
+		Capacity:    e.Capacity,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
+
+type EventListDTO struct {
+	Items      []*EventDTO `json:"items"`
+	Total      int64       `json:"total"`
+	NextCursor string      `json:"next_cursor"`
+} // @Name EventListDTO
+
+// NewEventListDTOFromEntities looks up the location of every event by its
+// venue ID.
+func NewEventListDTOFromEntities(events []*Event, locations map[int]*time.Location, total int64, nextCursor string) *EventListDTO {
+	items := make([]*EventDTO, 0, len(events))
+	for _, e := range events {
+		location, ok := locations[e.VenueID]
+		if !ok {
+			location = time.UTC
+		}
+
+		items = append(items, NewEventDTOFromEntity(e, location))
+	}
+
+	return &EventListDTO{
+		Items:      items,
+		Total:      total,
+		NextCursor: nextCursor,
+	}
+}
@@ -0,0 +1,115 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+)
+
+var (
+	ErrEventNotFound          = errors.New("event not found")
+	ErrVenueIsRequired        = errors.New("venue is required")
+	ErrInvalidCapacity        = errors.New("capacity must be greater than zero")
+	ErrCapacityExceeded       = errors.New("event capacity exceeded")
+	ErrCapacityBelowAllocated = errors.New("capacity cannot be lower than the allocation of the event's tickets")
+	ErrEventHasTickets        = errors.New("event still has tickets")
+)
+
+// Event is a concert, match or show at a venue. Its tickets together can
+// not allocate more units than its capacity.
+type Event struct {
+	ID          int                      `json:"id" gorm:"primaryKey;autoIncrement"`
+	VenueID     int                      `json:"venue_id" gorm:"not null;index"`
+	Name        *valueobject.Name        `json:"name" gorm:"not null;type:varchar(255)"`
+	Description *valueobject.Description `json:"description" gorm:"not null;type:varchar(255)"`
+	Dates       *valueobject.DateRange   `json:"dates" gorm:"not null;type:tstzrange"`
+	Capacity    int                      `json:"capacity" gorm:"not null;type:int"`
+	CreatedAt   time.Time                `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt   time.Time                `json:"updated_at" gorm:"not null;default:current_timestamp;autoUpdateTime"`
+}
+
+func (e *Event) TableName() string {
+	return "events"
+}
+
+func (e *Event) Rename(name string) error {
+	eventName, err := valueobject.NewName(name)
+	if err != nil {
+		return err
+	}
+
+	e.Name = eventName
+	return nil
+}
+
+func (e *Event) ChangeDescription(description string) error {
+	eventDescription, err := valueobject.NewDescription(description)
+	if err != nil {
+		return err
+	}
+
+	e.Description = eventDescription
+	return nil
+}
+
+func (e *Event) Reschedule(start, end time.Time) error {
+	dates, err := valueobject.NewDateRange(start, end)
+	if err != nil {
+		return err
+	}
+
+	e.Dates = dates
+	return nil
+}
+
+// ChangeCapacity fails when the tickets of the event already allocate more
+// than capacity units.
+func (e *Event) ChangeCapacity(capacity int, allocated int) error {
+	if capacity < 1 {
+		return ErrInvalidCapacity
+	}
+
+	if allocated > capacity {
+		return fmt.Errorf("%w: %d allocated", ErrCapacityBelowAllocated, allocated)
+	}
+
+	e.Capacity = capacity
+	return nil
+}
+
+// CheckCapacity tells whether the tickets of the event can allocate
+// allocated units in total.
+func (e *Event) CheckCapacity(allocated int) error {
+	if allocated > e.Capacity {
+		return fmt.Errorf("%w: capacity is %d, %d would be allocated", ErrCapacityExceeded, e.Capacity, allocated)
+	}
+
+	return nil
+}
+
+func NewEvent(venueID int, name, description string, start, end time.Time, capacity int) (*Event, error) {
+	if venueID == 0 {
+		return nil, ErrVenueIsRequired
+	}
+
+	e := &Event{VenueID: venueID}
+	if err := e.Rename(name); err != nil {
+		return nil, err
+	}
+
+	if err := e.ChangeDescription(description); err != nil {
+		return nil, err
+	}
+
+	if err := e.Reschedule(start, end); err != nil {
+		return nil, err
+	}
+
+	if err := e.ChangeCapacity(capacity, 0); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
@@ -0,0 +1,96 @@
+package event_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/event"
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	start = time.Date(2026, 11, 1, 19, 0, 0, 0, time.UTC)
+	end   = start.Add(3 * time.Hour)
+)
+
+func TestNewEvent(t *testing.T) {
+	t.Run("should create event", func(t *testing.T) {
+		e, err := event.NewEvent(1, "Concert", "Open air", start, end, 500)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, e.VenueID)
+		assert.Equal(t, "Concert", e.Name.GetValue())
+		assert.Equal(t, "Open air", e.Description.GetValue())
+		assert.Equal(t, start, e.Dates.GetStart())
+		assert.Equal(t, end, e.Dates.GetEnd())
+		assert.Equal(t, 500, e.Capacity)
+	})
+
+	tests := []struct {
+		name     string
+		venueID  int
+		title    string
+		end      time.Time
+		capacity int
+		wantErr  error
+	}{
+		{name: "missing venue", venueID: 0, title: "Concert", end: end, capacity: 500, wantErr: event.ErrVenueIsRequired},
+		{name: "empty name", venueID: 1, title: "", end: end, capacity: 500, wantErr: valueobject.ErrNameCannotBeEmpty},
+		{name: "ends before it starts", venueID: 1, title: "Concert", end: start.Add(-time.Hour), capacity: 500, wantErr: valueobject.ErrInvalidDateRange},
+		{name: "zero capacity", venueID: 1, title: "Concert", end: end, capacity: 0, wantErr: event.ErrInvalidCapacity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := event.NewEvent(tt.venueID, tt.title, "Open air", start, tt.end, tt.capacity)
+			assert.Nil(t, e)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCapacity(t *testing.T) {
+	e, err := event.NewEvent(1, "Concert", "Open air", start, end, 100)
+	assert.NoError(t, err)
+
+	assert.NoError(t, e.CheckCapacity(0))
+	assert.NoError(t, e.CheckCapacity(100))
+	assert.ErrorIs(t, e.CheckCapacity(101), event.ErrCapacityExceeded)
+
+	assert.ErrorIs(t, e.ChangeCapacity(80, 90), event.ErrCapacityBelowAllocated)
+	assert.ErrorIs(t, e.ChangeCapacity(-1, 0), event.ErrInvalidCapacity)
+	assert.Equal(t, 100, e.Capacity)
+
+	assert.NoError(t, e.ChangeCapacity(90, 90))
+	assert.Equal(t, 90, e.Capacity)
+}
+
+func TestReschedule(t *testing.T) {
+	e, err := event.NewEvent(1, "Concert", "Open air", start, end, 100)
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, e.Reschedule(end, start), valueobject.ErrInvalidDateRange)
+	assert.Equal(t, start, e.Dates.GetStart())
+
+	assert.NoError(t, e.Reschedule(start.Add(24*time.Hour), end.Add(24*time.Hour)))
+	assert.Equal(t, start.Add(24*time.Hour), e.Dates.GetStart())
+}
+
+func TestNewEventDTOFromEntity(t *testing.T) {
+	e, err := event.NewEvent(1, "Concert", "Open air", start, end, 100)
+	assert.NoError(t, err)
+	e.ID = 3
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+
+	dto := event.NewEventDTOFromEntity(e, berlin)
+	assert.Equal(t, 3, dto.ID)
+	assert.Equal(t, "Europe/Berlin", dto.Timezone)
+	assert.Equal(t, 20, dto.StartsAt.Hour())
+	assert.True(t, dto.StartsAt.Equal(start))
+
+	list := event.NewEventListDTOFromEntities([]*event.Event{e}, map[int]*time.Location{}, 1, "")
+	assert.Equal(t, "UTC", list.Items[0].Timezone)
+	assert.Equal(t, int64(1), list.Total)
+}
@@ -0,0 +1,13 @@
+package event
+
+import "time"
+
+// ListFilter selects the events of a venue and the events that take place
+// at least partly between From and To.
+type ListFilter struct {
+	VenueID *int
+	From    *time.Time
+	To      *time.Time
+	Offset  int
+	Limit   int
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/event"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+)
+
+// MemoryRepository keeps events in memory, it has to be used together with
+// db.MemoryUnitOfWork so that row locks and rollbacks work.
+type MemoryRepository struct {
+	mu     sync.RWMutex
+	events map[int]event.Event
+	lastID int
+	locks  db.RowLocks
+	now    func() time.Time
+}
+
+func NewMemoryEventRepository() EventRepository {
+	return &MemoryRepository{events: make(map[int]event.Event), now: time.Now}
+}
+
+func (r *MemoryRepository) Create(ctx context.Context, e *event.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	e.ID = r.lastID
+
+	now := r.now()
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = now
+	}
+
+	if e.UpdatedAt.IsZero() {
+		e.UpdatedAt = now
+	}
+
+	id := e.ID
+	r.events[id] = *e
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		delete(r.events, id)
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (r *MemoryRepository) FindByID(ctx context.Context, id int) (*event.Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	e, ok := r.events[id]
+	if !ok {
+		return nil, event.ErrEventNotFound
+	}
+
+	return &e, nil
+}
+
+func (r *MemoryRepository) FindByIDForUpdate(ctx context.Context, id int) (*event.Event, error) {
+	if err := r.locks.Lock(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+func (r *MemoryRepository) List(ctx context.Context, filter event.ListFilter) ([]*event.Event, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var events []*event.Event
+	for _, stored := range r.events {
+		e := stored
+		switch {
+		case filter.VenueID != nil && e.VenueID != *filter.VenueID:
+			continue
+		case filter.From != nil && !e.Dates.GetEnd().After(*filter.From):
+			continue
+		case filter.To != nil && !e.Dates.GetStart().Before(*filter.To):
+			continue
+		}
+
+		events = append(events, &e)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if !a.Dates.GetStart().Equal(b.Dates.GetStart()) {
+			return a.Dates.GetStart().Before(b.Dates.GetStart())
+		}
+
+		return a.ID < b.ID
+	})
+
+	total := int64(len(events))
+	start := min(filter.Offset, len(events))
+	end := len(events)
+	if filter.Limit > 0 {
+		end = min(start+filter.Limit, len(events))
+	}
+
+	return events[start:end], total, nil
+}
+
+func (r *MemoryRepository) Update(ctx context.Context, e *event.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, ok := r.events[e.ID]
+	if !ok {
+		return event.ErrEventNotFound
+	}
+
+	e.UpdatedAt = r.now()
+	r.events[e.ID] = *e
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		r.events[previous.ID] = previous
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (r *MemoryRepository) Delete(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, ok := r.events[id]
+	if !ok {
+		return event.ErrEventNotFound
+	}
+
+	delete(r.events, id)
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		r.events[id] = previous
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (r *MemoryRepository) CountByVenue(ctx context.Context, venueID int) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, e := range r.events {
+		if e.VenueID == venueID {
+			count++
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/event"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -destination=../../../mock/repository/event/event.go -package=repository github.com/aaydin-tr/ddd-api-example/domain/event/repository EventRepository
+type EventRepository interface {
+	Create(ctx context.Context, e *event.Event) error
+	FindByID(ctx context.Context, id int) (*event.Event, error)
+	// FindByIDForUpdate locks the event until the unit of work ends. Changes
+	// to the allocation of its tickets take the lock to be checked against
+	// the capacity one at a time.
+	FindByIDForUpdate(ctx context.Context, id int) (*event.Event, error)
+	// List returns events ordered by start date with the total number of
+	// matching events.
+	List(ctx context.Context, filter event.ListFilter) ([]*event.Event, int64, error)
+	Update(ctx context.Context, e *event.Event) error
+	Delete(ctx context.Context, id int) error
+	// CountByVenue counts the events at a venue.
+	CountByVenue(ctx context.Context, venueID int) (int64, error)
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewEventRepository(db *gorm.DB) EventRepository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(ctx context.Context, e *event.Event) error {
+	return db.Conn(ctx, r.db).Create(e).Error
+}
+
+func (r *Repository) FindByID(ctx context.Context, id int) (*event.Event, error) {
+	return r.find(db.Conn(ctx, r.db), id)
+}
+
+func (r *Repository) FindByIDForUpdate(ctx context.Context, id int) (*event.Event, error) {
+	return r.find(db.Conn(ctx, r.db).Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}), id)
+}
+
+func (r *Repository) find(query *gorm.DB, id int) (*event.Event, error) {
+	var e event.Event
+	err := query.First(&e, id).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, event.ErrEventNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+func (r *Repository) List(ctx context.Context, filter event.ListFilter) ([]*event.Event, int64, error) {
+	query := db.Conn(ctx, r.db).Model(&event.Event{})
+	if filter.VenueID != nil {
+		query = query.Where("venue_id = ?", *filter.VenueID)
+	}
+
+	if filter.From != nil {
+		query = query.Where("upper(dates) > ?", *filter.From)
+	}
+
+	if filter.To != nil {
+		query = query.Where("lower(dates) < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var events []*event.Event
+	err := query.
+		Order("lower(dates)").
+		Order("id").
+		Offset(filter.Offset).
+		Limit(filter.Limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return events, total, nil
+}
+
+func (r *Repository) Update(ctx context.Context, e *event.Event) error {
+	result := db.Conn(ctx, r.db).Model(e).Select("*").Updates(e)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return event.ErrEventNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) Delete(ctx context.Context, id int) error {
+	result := db.Conn(ctx, r.db).Delete(&event.Event{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return event.ErrEventNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) CountByVenue(ctx context.Context, venueID int) (int64, error) {
+	var count int64
+	err := db.Conn(ctx, r.db).Model(&event.Event{}).Where("venue_id = ?", venueID).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,46 @@
+package order
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LineError is why a line of an order could not be bought. Line is the
+// position of the line in the request.
+type LineError struct {
+	Line     int
+	TicketID int
+	Err      error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d (ticket %d): %v", e.Line, e.TicketID, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// CheckoutError lists every line of an order that could not be bought, none
+// of the lines of the order were bought.
+type CheckoutError struct {
+	Lines []*LineError
+}
+
+func (e *CheckoutError) Error() string {
+	messages := make([]string, 0, len(e.Lines))
+	for _, line := range e.Lines {
+		messages = append(messages, line.Error())
+	}
+
+	return "order cannot be completed: " + strings.Join(messages, "; ")
+}
+
+func (e *CheckoutError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Lines))
+	for _, line := range e.Lines {
+		errs = append(errs, line)
+	}
+
+	return errs
+}
@@ -0,0 +1,58 @@
+package order
+
+import (
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+)
+
+type LineDTO struct {
+	TicketID   int                   `json:"ticket_id"`
+	PurchaseID int                   `json:"purchase_id"`
+	Quantity   int                   `json:"quantity"`
+	UnitPrice  *valueobject.MoneyDTO `json:"unit_price"`
+	Discount   *valueobject.MoneyDTO `json:"discount,omitempty"`
+	Total      *valueobject.MoneyDTO `json:"total"`
+	SeatIDs    []int                 `json:"seat_ids,omitempty"`
+} // @Name OrderLineDTO
+
+type OrderDTO struct {
+	ID        int                   `json:"id"`
+	UserID    string                `json:"user_id"`
+	Discount  *valueobject.MoneyDTO `json:"discount,omitempty"`
+	Total     *valueobject.MoneyDTO `json:"total"`
+	Lines     []*LineDTO            `json:"lines"`
+	CreatedAt time.Time             `json:"created_at"`
+} // @Name OrderDTO
+
+func NewOrderDTOFromEntity(o *Order) *OrderDTO {
+	lines := make([]*LineDTO, 0, len(o.Lines))
+	for _, line := range o.Lines {
+		lines = append(lines, &LineDTO{
+			TicketID:   line.TicketID,
+			PurchaseID: line.PurchaseID,
+			Quantity:   line.Quantity,
+			UnitPrice:  valueobject.NewMoneyDTO(line.UnitPrice),
+			Discount:   discountDTO(line.Discount),
+			Total:      valueobject.NewMoneyDTO(line.Total),
+		})
+	}
+
+	return &OrderDTO{
+		ID:        o.ID,
+		UserID:    o.UserID,
+		Discount:  discountDTO(o.Discount),
+		Total:     valueobject.NewMoneyDTO(o.Total),
+		Lines:     lines,
+		CreatedAt: o.CreatedAt,
+	}
+}
+
+// discountDTO leaves out discounts of orders without a promotion.
+func discountDTO(discount *valueobject.Money) *valueobject.MoneyDTO {
+	if discount == nil || discount.IsZero() {
+		return nil
+	}
+
+	return valueobject.NewMoneyDTO(discount)
+}
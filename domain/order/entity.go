@@ -0,0 +1,103 @@
+package order
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+)
+
+var (
+	ErrOrderNotFound    = errors.New("order not found")
+	ErrUserIDIsRequired = errors.New("user id is required")
+	ErrNoLines          = errors.New("order must have at least one line")
+	ErrDuplicateTicket  = errors.New("a ticket can only be in one line of an order")
+	ErrInvalidLine      = errors.New("order line must have a ticket, a quantity and a price")
+)
+
+// Order is a checkout of several tickets at once. Every line is bought with
+// a purchase of its own, so lines are refunded like any other purchase.
+type Order struct {
+	ID        int                `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID    string             `json:"user_id" gorm:"not null;type:uuid;index"`
+	Discount  *valueobject.Money `json:"discount" gorm:"not null;type:varchar(32);default:'0 XXX'"`
+	Total     *valueobject.Money `json:"total" gorm:"not null;type:varchar(32);default:'0 XXX'"`
+	Lines     []*Line            `json:"lines" gorm:"foreignKey:OrderID"`
+	CreatedAt time.Time          `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt time.Time          `json:"updated_at" gorm:"not null;default:current_timestamp;autoUpdateTime"`
+}
+
+func (o *Order) TableName() string {
+	return "orders"
+}
+
+// Line is one ticket of an order. Position keeps the order of the lines of
+// the request, Total is what the line costs after its Discount.
+type Line struct {
+	ID         int                `json:"id" gorm:"primaryKey;autoIncrement"`
+	OrderID    int                `json:"order_id" gorm:"not null;index"`
+	Position   int                `json:"position" gorm:"not null;type:int"`
+	TicketID   int                `json:"ticket_id" gorm:"not null;index"`
+	PurchaseID int                `json:"purchase_id" gorm:"not null;index"`
+	Quantity   int                `json:"quantity" gorm:"not null;type:int"`
+	UnitPrice  *valueobject.Money `json:"unit_price" gorm:"not null;type:varchar(32);default:'0 XXX'"`
+	Discount   *valueobject.Money `json:"discount" gorm:"not null;type:varchar(32);default:'0 XXX'"`
+	Total      *valueobject.Money `json:"total" gorm:"not null;type:varchar(32);default:'0 XXX'"`
+	CreatedAt  time.Time          `json:"created_at" gorm:"not null;default:current_timestamp"`
+}
+
+func (l *Line) TableName() string {
+	return "order_lines"
+}
+
+// NewOrder numbers the lines in the given order and adds up their totals
+// and discounts, all lines of an order have to be in the same currency. A
+// line without a discount is not discounted.
+func NewOrder(userID string, lines []*Line) (*Order, error) {
+	if userID == "" {
+		return nil, ErrUserIDIsRequired
+	}
+
+	if len(lines) == 0 {
+		return nil, ErrNoLines
+	}
+
+	var total, discount *valueobject.Money
+	tickets := make(map[int]bool, len(lines))
+	for i, line := range lines {
+		if line.TicketID == 0 || line.Quantity <= 0 || line.Total == nil || line.UnitPrice == nil {
+			return nil, ErrInvalidLine
+		}
+
+		if tickets[line.TicketID] {
+			return nil, ErrDuplicateTicket
+		}
+		tickets[line.TicketID] = true
+
+		var err error
+		if line.Discount == nil {
+			line.Discount, err = valueobject.NewMoney(0, line.Total.GetCurrency())
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		line.Position = i
+		if total == nil {
+			total, discount = line.Total, line.Discount
+			continue
+		}
+
+		total, err = total.Add(line.Total)
+		if err != nil {
+			return nil, err
+		}
+
+		discount, err = discount.Add(line.Discount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Order{UserID: userID, Discount: discount, Total: total, Lines: lines}, nil
+}
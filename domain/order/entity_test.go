@@ -0,0 +1,72 @@
+package order_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/order"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+	"github.com/stretchr/testify/assert"
+)
+
+func newLine(ticketID, quantity int, amount int64, currency string) *order.Line {
+	unitPrice, _ := valueobject.NewMoney(amount, currency)
+	total, _ := unitPrice.Multiply(quantity)
+	return &order.Line{TicketID: ticketID, Quantity: quantity, UnitPrice: unitPrice, Total: total}
+}
+
+func TestNewOrder(t *testing.T) {
+	o, err := order.NewOrder("1250052d-c061-4a1f-81f0-d88af3dcb3d5", []*order.Line{
+		newLine(2, 2, 1500, "EUR"),
+		newLine(1, 1, 800, "EUR"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "3800 EUR", o.Total.String())
+	assert.Equal(t, "0 EUR", o.Discount.String())
+	assert.Equal(t, 0, o.Lines[0].Position)
+	assert.Equal(t, 1, o.Lines[1].Position)
+
+	discounted := newLine(1, 1, 800, "EUR")
+	discounted.Discount, _ = valueobject.NewMoney(200, "EUR")
+	discounted.Total, _ = valueobject.NewMoney(600, "EUR")
+	o, err = order.NewOrder("1250052d-c061-4a1f-81f0-d88af3dcb3d5", []*order.Line{newLine(2, 2, 1500, "EUR"), discounted})
+	assert.NoError(t, err)
+	assert.Equal(t, "3600 EUR", o.Total.String())
+	assert.Equal(t, "200 EUR", o.Discount.String())
+
+	tests := []struct {
+		name   string
+		userID string
+		lines  []*order.Line
+		err    error
+	}{
+		{name: "no user", lines: []*order.Line{newLine(1, 1, 800, "EUR")}, err: order.ErrUserIDIsRequired},
+		{name: "no lines", userID: "user", err: order.ErrNoLines},
+		{name: "invalid line", userID: "user", lines: []*order.Line{{TicketID: 1}}, err: order.ErrInvalidLine},
+		{name: "duplicate ticket", userID: "user", lines: []*order.Line{newLine(1, 1, 800, "EUR"), newLine(1, 2, 800, "EUR")}, err: order.ErrDuplicateTicket},
+		{name: "mixed currencies", userID: "user", lines: []*order.Line{newLine(1, 1, 800, "EUR"), newLine(2, 1, 800, "USD")}, err: valueobject.ErrCurrencyMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := order.NewOrder(tt.userID, tt.lines)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestCheckoutError(t *testing.T) {
+	err := error(&order.CheckoutError{Lines: []*order.LineError{
+		{Line: 0, TicketID: 3, Err: ticket.ErrTicketNotFound},
+		{Line: 2, TicketID: 1, Err: ticket.ErrInsufficientAllocation},
+	}})
+
+	assert.ErrorIs(t, err, ticket.ErrTicketNotFound)
+	assert.ErrorIs(t, err, ticket.ErrInsufficientAllocation)
+	assert.Contains(t, err.Error(), "line 2 (ticket 1)")
+
+	var lineErr *order.LineError
+	assert.True(t, errors.As(err, &lineErr))
+	assert.Equal(t, 3, lineErr.TicketID)
+}
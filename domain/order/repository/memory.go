@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/order"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+)
+
+// MemoryRepository keeps orders in memory, it has to be used together with
+// db.MemoryUnitOfWork.
+type MemoryRepository struct {
+	mu         sync.RWMutex
+	orders     map[int]order.Order
+	lastID     int
+	lastLineID int
+	now        func() time.Time
+}
+
+func NewMemoryOrderRepository() OrderRepository {
+	return &MemoryRepository{orders: make(map[int]order.Order), now: time.Now}
+}
+
+func (r *MemoryRepository) Create(ctx context.Context, o *order.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	o.ID = r.lastID
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = r.now()
+	}
+	o.UpdatedAt = o.CreatedAt
+
+	for _, line := range o.Lines {
+		r.lastLineID++
+		line.ID = r.lastLineID
+		line.OrderID = o.ID
+		line.CreatedAt = o.CreatedAt
+	}
+
+	id := o.ID
+	r.orders[id] = clone(o)
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		delete(r.orders, id)
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (r *MemoryRepository) FindByID(ctx context.Context, id int) (*order.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	o, ok := r.orders[id]
+	if !ok {
+		return nil, order.ErrOrderNotFound
+	}
+
+	found := clone(&o)
+	return &found, nil
+}
+
+// clone copies the lines too, so callers cannot change stored orders.
+func clone(o *order.Order) order.Order {
+	c := *o
+	c.Lines = make([]*order.Line, 0, len(o.Lines))
+	for _, line := range o.Lines {
+		l := *line
+		c.Lines = append(c.Lines, &l)
+	}
+
+	return c
+}
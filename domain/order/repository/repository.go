@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/order"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -destination=../../../mock/repository/order/order.go -package=repository github.com/aaydin-tr/ddd-api-example/domain/order/repository OrderRepository
+type OrderRepository interface {
+	// Create stores the order together with its lines.
+	Create(ctx context.Context, o *order.Order) error
+	FindByID(ctx context.Context, id int) (*order.Order, error)
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewOrderRepository(db *gorm.DB) OrderRepository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(ctx context.Context, o *order.Order) error {
+	return db.Conn(ctx, r.db).Create(o).Error
+}
+
+func (r *Repository) FindByID(ctx context.Context, id int) (*order.Order, error) {
+	var o order.Order
+	err := db.Conn(ctx, r.db).Preload("Lines", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("position")
+	}).First(&o, id).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, order.ErrOrderNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
@@ -0,0 +1,56 @@
+package promotion
+
+import "time"
+
+type PromotionDTO struct {
+	ID             int        `json:"id"`
+	Code           string     `json:"code"`
+	Kind           Kind       `json:"kind"`
+	Value          int64      `json:"value"`
+	Currency       string     `json:"currency,omitempty"`
+	TicketID       *int       `json:"ticket_id"`
+	MaxRedemptions *int       `json:"max_redemptions"`
+	MaxPerUser     *int       `json:"max_per_user"`
+	Redemptions    int        `json:"redemptions"`
+	StartsAt       *time.Time `json:"starts_at"`
+	EndsAt         *time.Time `json:"ends_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+} // @Name PromotionDTO
+
+func NewPromotionDTOFromEntity(p *Promotion) *PromotionDTO {
+	return &PromotionDTO{
+		ID:             p.ID,
+		Code:           p.Code,
+		Kind:           p.Kind,
+		Value:          p.Value,
+		Currency:       p.Currency,
+		TicketID:       p.TicketID,
+		MaxRedemptions: p.MaxRedemptions,
+		MaxPerUser:     p.MaxPerUser,
+		Redemptions:    p.Redemptions,
+		StartsAt:       p.StartsAt,
+		EndsAt:         p.EndsAt,
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
+	}
+}
+
+type PromotionListDTO struct {
+	Items      []*PromotionDTO `json:"items"`
+	Total      int64           `json:"total"`
+	NextCursor string          `json:"next_cursor"`
+} // @Name PromotionListDTO
+
+func NewPromotionListDTOFromEntities(promotions []*Promotion, total int64, nextCursor string) *PromotionListDTO {
+	items := make([]*PromotionDTO, 0, len(promotions))
+	for _, p := range promotions {
+		items = append(items, NewPromotionDTOFromEntity(p))
+	}
+
+	return &PromotionListDTO{
+		Items:      items,
+		Total:      total,
+		NextCursor: nextCursor,
+	}
+}
@@ -0,0 +1,290 @@
+package promotion
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+)
+
+var (
+	ErrPromotionNotFound     = errors.New("promotion not found")
+	ErrCodeIsRequired        = errors.New("promotion code is required")
+	ErrInvalidCode           = errors.New("promotion code can only contain letters, digits, dashes and underscores")
+	ErrCodeExists            = errors.New("promotion code already exists")
+	ErrInvalidKind           = errors.New("discount kind must be percentage or fixed")
+	ErrInvalidPercentage     = errors.New("percentage must be between 1 and 100")
+	ErrInvalidAmount         = errors.New("fixed discount must be greater than zero")
+	ErrInvalidMaxRedemptions = errors.New("max redemptions must be greater than zero")
+	ErrInvalidMaxPerUser     = errors.New("max redemptions per user must be greater than zero")
+	ErrMaxBelowRedemptions   = errors.New("max redemptions cannot be lower than the redemptions so far")
+	ErrInvalidValidity       = errors.New("promotion must end after it starts")
+	ErrPromotionRedeemed     = errors.New("a redeemed promotion cannot be deleted")
+	ErrPromotionNotStarted   = errors.New("promotion is not valid yet")
+	ErrPromotionExpired      = errors.New("promotion has expired")
+	ErrPromotionExhausted    = errors.New("promotion has no redemptions left")
+	ErrUserLimitReached      = errors.New("user has redeemed the promotion the maximum number of times")
+	ErrNotApplicable         = errors.New("promotion does not apply to the purchase")
+)
+
+// MaxCodeLength is the longest promotion code.
+const MaxCodeLength = 64
+
+type Kind string
+
+const (
+	// KindPercentage takes Value percent off the price.
+	KindPercentage Kind = "percentage"
+	// KindFixed takes Value minor units of Currency off the price, at most
+	// the whole price.
+	KindFixed Kind = "fixed"
+)
+
+// Promotion is a discount code. A promotion of a ticket only discounts
+// that ticket, one without a ticket discounts the whole purchase or order.
+// Redemptions counts how often it was used, it is changed together with the
+// allocation of the tickets it was used for.
+type Promotion struct {
+	ID             int        `json:"id" gorm:"primaryKey;autoIncrement"`
+	Code           string     `json:"code" gorm:"not null;type:varchar(64);uniqueIndex"`
+	Kind           Kind       `json:"kind" gorm:"not null;type:varchar(32)"`
+	Value          int64      `json:"value" gorm:"not null;type:bigint"`
+	Currency       string     `json:"currency" gorm:"not null;type:varchar(3);default:''"`
+	TicketID       *int       `json:"ticket_id" gorm:"index"`
+	MaxRedemptions *int       `json:"max_redemptions" gorm:"type:int"`
+	MaxPerUser     *int       `json:"max_per_user" gorm:"type:int"`
+	Redemptions    int        `json:"redemptions" gorm:"not null;type:int;default:0"`
+	StartsAt       *time.Time `json:"starts_at"`
+	EndsAt         *time.Time `json:"ends_at"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"not null;default:current_timestamp;autoUpdateTime"`
+}
+
+func (p *Promotion) TableName() string {
+	return "promotions"
+}
+
+// NormalizeCode makes codes case insensitive, they are stored upper case.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func NewPromotion(code string, kind Kind, value int64, currency string) (*Promotion, error) {
+	p := &Promotion{}
+	if err := p.ChangeCode(code); err != nil {
+		return nil, err
+	}
+
+	if err := p.ChangeDiscount(kind, value, currency); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (p *Promotion) ChangeCode(code string) error {
+	code = NormalizeCode(code)
+	if code == "" {
+		return ErrCodeIsRequired
+	}
+
+	if len(code) > MaxCodeLength || strings.IndexFunc(code, invalidCodeRune) >= 0 {
+		return ErrInvalidCode
+	}
+
+	p.Code = code
+	return nil
+}
+
+func invalidCodeRune(r rune) bool {
+	return !(r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_')
+}
+
+// ChangeDiscount sets what the promotion takes off, currency is only used
+// by fixed discounts.
+func (p *Promotion) ChangeDiscount(kind Kind, value int64, currency string) error {
+	switch kind {
+	case KindPercentage:
+		if value < 1 || value > 100 {
+			return ErrInvalidPercentage
+		}
+		currency = ""
+	case KindFixed:
+		if value <= 0 {
+			return ErrInvalidAmount
+		}
+
+		// NewMoney validates the currency.
+		if _, err := valueobject.NewMoney(value, currency); err != nil {
+			return err
+		}
+	default:
+		return ErrInvalidKind
+	}
+
+	p.Kind = kind
+	p.Value = value
+	p.Currency = currency
+	return nil
+}
+
+// ChangeMaxRedemptions limits how often the promotion can be redeemed in
+// total, nil removes the limit.
+func (p *Promotion) ChangeMaxRedemptions(max *int) error {
+	if max != nil && *max <= 0 {
+		return ErrInvalidMaxRedemptions
+	}
+
+	if max != nil && *max < p.Redemptions {
+		return ErrMaxBelowRedemptions
+	}
+
+	p.MaxRedemptions = max
+	return nil
+}
+
+// ChangeMaxPerUser limits how often a user can redeem the promotion, nil
+// removes the limit.
+func (p *Promotion) ChangeMaxPerUser(max *int) error {
+	if max != nil && *max <= 0 {
+		return ErrInvalidMaxPerUser
+	}
+
+	p.MaxPerUser = max
+	return nil
+}
+
+// ChangeValidity sets when the promotion can be redeemed, the promotion is
+// valid from start until before end. Either side can be open.
+func (p *Promotion) ChangeValidity(start, end *time.Time) error {
+	if start != nil && end != nil && !end.After(*start) {
+		return ErrInvalidValidity
+	}
+
+	p.StartsAt = start
+	p.EndsAt = end
+	return nil
+}
+
+// CheckDeletable keeps the promotions of past purchases, a promotion that
+// should not be redeemed any more can be ended with ChangeValidity.
+func (p *Promotion) CheckDeletable() error {
+	if p.Redemptions > 0 {
+		return ErrPromotionRedeemed
+	}
+
+	return nil
+}
+
+// AppliesTo reports whether the promotion discounts the ticket.
+func (p *Promotion) AppliesTo(ticketID int) bool {
+	return p.TicketID == nil || *p.TicketID == ticketID
+}
+
+// CheckValidity checks the validity window and the redemptions left.
+func (p *Promotion) CheckValidity(now time.Time) error {
+	if p.StartsAt != nil && now.Before(*p.StartsAt) {
+		return ErrPromotionNotStarted
+	}
+
+	if p.EndsAt != nil && !now.Before(*p.EndsAt) {
+		return ErrPromotionExpired
+	}
+
+	if p.MaxRedemptions != nil && p.Redemptions >= *p.MaxRedemptions {
+		return ErrPromotionExhausted
+	}
+
+	return nil
+}
+
+// Discount returns what the promotion takes off subtotal, percentages are
+// rounded down to the minor unit.
+func (p *Promotion) Discount(subtotal *valueobject.Money) (*valueobject.Money, error) {
+	switch p.Kind {
+	case KindPercentage:
+		return valueobject.NewMoney(subtotal.GetAmount()*p.Value/100, subtotal.GetCurrency())
+	case KindFixed:
+		if p.Currency != subtotal.GetCurrency() {
+			return nil, ErrNotApplicable
+		}
+
+		return valueobject.NewMoney(min(p.Value, subtotal.GetAmount()), p.Currency)
+	}
+
+	return nil, ErrInvalidKind
+}
+
+// Redeem uses the promotion once for a user who redeemed it redeemed times
+// before. The caller has to hold the lock of the promotion until the
+// redemption is stored.
+func (p *Promotion) Redeem(userID string, redeemed int, discount *valueobject.Money, now time.Time) (*Redemption, error) {
+	if err := p.CheckValidity(now); err != nil {
+		return nil, err
+	}
+
+	if p.MaxPerUser != nil && redeemed >= *p.MaxPerUser {
+		return nil, ErrUserLimitReached
+	}
+
+	p.Redemptions++
+	return &Redemption{PromotionID: p.ID, UserID: userID, Discount: discount}, nil
+}
+
+// Allocate spreads the discount of the promotion over the lines it applies
+// to, in proportion to their totals. The rounding remainder goes to the last
+// of them. The returned discounts are in the order of ticketIDs, lines the
+// promotion does not apply to get a zero discount.
+func (p *Promotion) Allocate(ticketIDs []int, totals []*valueobject.Money) ([]*valueobject.Money, error) {
+	var (
+		subtotal *valueobject.Money
+		last     = -1
+	)
+	for i, ticketID := range ticketIDs {
+		if !p.AppliesTo(ticketID) {
+			continue
+		}
+
+		last = i
+		if subtotal == nil {
+			subtotal = totals[i]
+			continue
+		}
+
+		var err error
+		subtotal, err = subtotal.Add(totals[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if last < 0 {
+		return nil, ErrNotApplicable
+	}
+
+	discount, err := p.Discount(subtotal)
+	if err != nil {
+		return nil, err
+	}
+
+	discounts := make([]*valueobject.Money, len(ticketIDs))
+	remaining := discount.GetAmount()
+	for i, ticketID := range ticketIDs {
+		amount := int64(0)
+		if p.AppliesTo(ticketID) {
+			amount = remaining
+			if i != last && subtotal.GetAmount() > 0 {
+				amount = discount.GetAmount() * totals[i].GetAmount() / subtotal.GetAmount()
+			}
+			remaining -= amount
+		}
+
+		discounts[i], err = valueobject.NewMoney(amount, totals[i].GetCurrency())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return discounts, nil
+}
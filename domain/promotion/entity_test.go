@@ -0,0 +1,130 @@
+package promotion_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/promotion"
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+	"github.com/stretchr/testify/assert"
+)
+
+const userID = "406c1d05-bbb2-4e94-b183-7d208c2692e1"
+
+func money(amount int64) *valueobject.Money {
+	m, _ := valueobject.NewMoney(amount, "EUR")
+	return m
+}
+
+func TestNewPromotion(t *testing.T) {
+	p, err := promotion.NewPromotion(" spring-sale_10 ", promotion.KindPercentage, 10, "EUR")
+	assert.NoError(t, err)
+	assert.Equal(t, "SPRING-SALE_10", p.Code)
+	assert.Empty(t, p.Currency, "percentages have no currency")
+
+	tests := []struct {
+		name     string
+		code     string
+		kind     promotion.Kind
+		value    int64
+		currency string
+		err      error
+	}{
+		{name: "no code", code: " ", kind: promotion.KindPercentage, value: 10, err: promotion.ErrCodeIsRequired},
+		{name: "invalid code", code: "SPRING 10", kind: promotion.KindPercentage, value: 10, err: promotion.ErrInvalidCode},
+		{name: "unknown kind", code: "SPRING", kind: "bogo", value: 10, err: promotion.ErrInvalidKind},
+		{name: "percentage above 100", code: "SPRING", kind: promotion.KindPercentage, value: 101, err: promotion.ErrInvalidPercentage},
+		{name: "zero amount", code: "SPRING", kind: promotion.KindFixed, currency: "EUR", err: promotion.ErrInvalidAmount},
+		{name: "invalid currency", code: "SPRING", kind: promotion.KindFixed, value: 500, currency: "eur", err: valueobject.ErrInvalidCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := promotion.NewPromotion(tt.code, tt.kind, tt.value, tt.currency)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestPromotionChanges(t *testing.T) {
+	p, _ := promotion.NewPromotion("SPRING", promotion.KindFixed, 500, "EUR")
+	p.Redemptions = 3
+
+	two, three, zero := 2, 3, 0
+	assert.ErrorIs(t, p.ChangeMaxRedemptions(&two), promotion.ErrMaxBelowRedemptions)
+	assert.ErrorIs(t, p.ChangeMaxRedemptions(&zero), promotion.ErrInvalidMaxRedemptions)
+	assert.NoError(t, p.ChangeMaxRedemptions(&three))
+	assert.ErrorIs(t, p.ChangeMaxPerUser(&zero), promotion.ErrInvalidMaxPerUser)
+
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	assert.ErrorIs(t, p.ChangeValidity(&start, &start), promotion.ErrInvalidValidity)
+	assert.ErrorIs(t, p.CheckDeletable(), promotion.ErrPromotionRedeemed)
+}
+
+func TestPromotion_Redeem(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	p, _ := promotion.NewPromotion("SPRING", promotion.KindPercentage, 10, "")
+	assert.NoError(t, p.ChangeValidity(&start, &end))
+	limit, perUser := 2, 1
+	assert.NoError(t, p.ChangeMaxRedemptions(&limit))
+	assert.NoError(t, p.ChangeMaxPerUser(&perUser))
+
+	_, err := p.Redeem(userID, 0, money(100), start.Add(-time.Second))
+	assert.ErrorIs(t, err, promotion.ErrPromotionNotStarted)
+
+	_, err = p.Redeem(userID, 0, money(100), end)
+	assert.ErrorIs(t, err, promotion.ErrPromotionExpired)
+
+	_, err = p.Redeem(userID, 1, money(100), start)
+	assert.ErrorIs(t, err, promotion.ErrUserLimitReached)
+
+	r, err := p.Redeem(userID, 0, money(100), start)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, r.UserID)
+	assert.Equal(t, "100 EUR", r.Discount.String())
+	_, err = p.Redeem("other", 0, money(100), start)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, p.Redemptions)
+
+	_, err = p.Redeem("third", 0, money(100), start)
+	assert.ErrorIs(t, err, promotion.ErrPromotionExhausted)
+	assert.Equal(t, 2, p.Redemptions)
+}
+
+func TestPromotion_Discount(t *testing.T) {
+	percentage, _ := promotion.NewPromotion("TEN", promotion.KindPercentage, 15, "")
+	discount, err := percentage.Discount(money(999))
+	assert.NoError(t, err)
+	assert.Equal(t, "149 EUR", discount.String(), "percentages are rounded down")
+
+	fixed, _ := promotion.NewPromotion("FIVE", promotion.KindFixed, 500, "EUR")
+	discount, err = fixed.Discount(money(300))
+	assert.NoError(t, err)
+	assert.Equal(t, "300 EUR", discount.String(), "a fixed discount is capped at the price")
+
+	usd, _ := valueobject.NewMoney(1000, "USD")
+	_, err = fixed.Discount(usd)
+	assert.ErrorIs(t, err, promotion.ErrNotApplicable)
+}
+
+func TestPromotion_Allocate(t *testing.T) {
+	p, _ := promotion.NewPromotion("TEN", promotion.KindFixed, 100, "EUR")
+
+	discounts, err := p.Allocate([]int{1, 2, 3}, []*valueobject.Money{money(1000), money(1000), money(1000)})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(33), discounts[0].GetAmount())
+	assert.Equal(t, int64(33), discounts[1].GetAmount())
+	assert.Equal(t, int64(34), discounts[2].GetAmount(), "the last line gets the remainder")
+
+	ticketID := 2
+	p.TicketID = &ticketID
+	discounts, err = p.Allocate([]int{1, 2, 3}, []*valueobject.Money{money(1000), money(1000), money(1000)})
+	assert.NoError(t, err)
+	assert.True(t, discounts[0].IsZero())
+	assert.Equal(t, int64(100), discounts[1].GetAmount())
+	assert.True(t, discounts[2].IsZero())
+
+	_, err = p.Allocate([]int{1, 3}, []*valueobject.Money{money(1000), money(1000)})
+	assert.ErrorIs(t, err, promotion.ErrNotApplicable)
+}
@@ -0,0 +1,23 @@
+package promotion
+
+import (
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+)
+
+// Redemption is one use of a promotion, for a single purchase or for an
+// order. Refunds do not give redemptions back.
+type Redemption struct {
+	ID          int                `json:"id" gorm:"primaryKey;autoIncrement"`
+	PromotionID int                `json:"promotion_id" gorm:"not null;index"`
+	UserID      string             `json:"user_id" gorm:"not null;type:uuid;index"`
+	PurchaseID  *int               `json:"purchase_id" gorm:"index"`
+	OrderID     *int               `json:"order_id" gorm:"index"`
+	Discount    *valueobject.Money `json:"discount" gorm:"not null;type:varchar(32)"`
+	CreatedAt   time.Time          `json:"created_at" gorm:"not null;default:current_timestamp"`
+}
+
+func (r *Redemption) TableName() string {
+	return "promotion_redemptions"
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/promotion"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+)
+
+// MemoryRepository keeps promotions in memory, it has to be used together
+// with db.MemoryUnitOfWork so that row locks and rollbacks work.
+type MemoryRepository struct {
+	mu               sync.RWMutex
+	promotions       map[int]promotion.Promotion
+	redemptions      []promotion.Redemption
+	lastID           int
+	lastRedemptionID int
+	locks            db.RowLocks
+	now              func() time.Time
+}
+
+func NewMemoryPromotionRepository() PromotionRepository {
+	return &MemoryRepository{promotions: make(map[int]promotion.Promotion), now: time.Now}
+}
+
+func (r *MemoryRepository) Create(ctx context.Context, p *promotion.Promotion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.codeTaken(p.Code, 0) {
+		return promotion.ErrCodeExists
+	}
+
+	r.lastID++
+	p.ID = r.lastID
+
+	now := r.now()
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = now
+	}
+
+	if p.UpdatedAt.IsZero() {
+		p.UpdatedAt = now
+	}
+
+	id := p.ID
+	r.promotions[id] = *p
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		delete(r.promotions, id)
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+// codeTaken has to be called with mu held.
+func (r *MemoryRepository) codeTaken(code string, exceptID int) bool {
+	for id, stored := range r.promotions {
+		if id != exceptID && stored.Code == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *MemoryRepository) FindByID(ctx context.Context, id int) (*promotion.Promotion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.promotions[id]
+	if !ok {
+		return nil, promotion.ErrPromotionNotFound
+	}
+
+	return &p, nil
+}
+
+func (r *MemoryRepository) FindByIDForUpdate(ctx context.Context, id int) (*promotion.Promotion, error) {
+	if err := r.locks.Lock(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+func (r *MemoryRepository) FindByCodeForUpdate(ctx context.Context, code string) (*promotion.Promotion, error) {
+	id, ok := r.idByCode(promotion.NormalizeCode(code))
+	if !ok {
+		return nil, promotion.ErrPromotionNotFound
+	}
+
+	if err := r.locks.Lock(ctx, id); err != nil {
+		return nil, err
+	}
+
+	// The promotion can have been changed or deleted while waiting for the
+	// lock.
+	return r.FindByID(ctx, id)
+}
+
+func (r *MemoryRepository) idByCode(code string) (int, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for id, stored := range r.promotions {
+		if stored.Code == code {
+			return id, true
+		}
+	}
+
+	return 0, false
+}
+
+func (r *MemoryRepository) List(ctx context.Context, offset, limit int) ([]*promotion.Promotion, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	promotions := make([]*promotion.Promotion, 0, len(r.promotions))
+	for _, stored := range r.promotions {
+		p := stored
+		promotions = append(promotions, &p)
+	}
+
+	sort.Slice(promotions, func(i, j int) bool {
+		return promotions[i].ID < promotions[j].ID
+	})
+
+	total := int64(len(promotions))
+	start := min(offset, len(promotions))
+	end := len(promotions)
+	if limit > 0 {
+		end = min(start+limit, len(promotions))
+	}
+
+	return promotions[start:end], total, nil
+}
+
+func (r *MemoryRepository) Update(ctx context.Context, p *promotion.Promotion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, ok := r.promotions[p.ID]
+	if !ok {
+		return promotion.ErrPromotionNotFound
+	}
+
+	if r.codeTaken(p.Code, p.ID) {
+		return promotion.ErrCodeExists
+	}
+
+	p.UpdatedAt = r.now()
+	r.promotions[p.ID] = *p
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		r.promotions[previous.ID] = previous
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (r *MemoryRepository) Delete(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, ok := r.promotions[id]
+	if !ok {
+		return promotion.ErrPromotionNotFound
+	}
+
+	delete(r.promotions, id)
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		r.promotions[id] = previous
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (r *MemoryRepository) CreateRedemption(ctx context.Context, redemption *promotion.Redemption) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastRedemptionID++
+	redemption.ID = r.lastRedemptionID
+	if redemption.CreatedAt.IsZero() {
+		redemption.CreatedAt = r.now()
+	}
+
+	id := redemption.ID
+	r.redemptions = append(r.redemptions, *redemption)
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		for i, stored := range r.redemptions {
+			if stored.ID == id {
+				r.redemptions = append(r.redemptions[:i], r.redemptions[i+1:]...)
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+func (r *MemoryRepository) RedemptionCount(ctx context.Context, promotionID int, userID string) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int
+	for _, stored := range r.redemptions {
+		if stored.PromotionID == promotionID && stored.UserID == userID {
+			count++
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/promotion"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -destination=../../../mock/repository/promotion/promotion.go -package=repository github.com/aaydin-tr/ddd-api-example/domain/promotion/repository PromotionRepository
+type PromotionRepository interface {
+	Create(ctx context.Context, p *promotion.Promotion) error
+	FindByID(ctx context.Context, id int) (*promotion.Promotion, error)
+	FindByIDForUpdate(ctx context.Context, id int) (*promotion.Promotion, error)
+	// FindByCodeForUpdate locks the promotion until the unit of work ends,
+	// redemptions are counted under this lock.
+	FindByCodeForUpdate(ctx context.Context, code string) (*promotion.Promotion, error)
+	// List returns promotions ordered by ID with the total number of
+	// promotions.
+	List(ctx context.Context, offset, limit int) ([]*promotion.Promotion, int64, error)
+	Update(ctx context.Context, p *promotion.Promotion) error
+	Delete(ctx context.Context, id int) error
+	CreateRedemption(ctx context.Context, r *promotion.Redemption) error
+	// RedemptionCount returns how often a user redeemed a promotion.
+	RedemptionCount(ctx context.Context, promotionID int, userID string) (int, error)
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewPromotionRepository(db *gorm.DB) PromotionRepository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(ctx context.Context, p *promotion.Promotion) error {
+	err := db.Conn(ctx, r.db).Create(p).Error
+	if db.IsUniqueViolation(err) {
+		return promotion.ErrCodeExists
+	}
+
+	return err
+}
+
+func (r *Repository) FindByID(ctx context.Context, id int) (*promotion.Promotion, error) {
+	var p promotion.Promotion
+	err := db.Conn(ctx, r.db).First(&p, id).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, promotion.ErrPromotionNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func (r *Repository) FindByIDForUpdate(ctx context.Context, id int) (*promotion.Promotion, error) {
+	var p promotion.Promotion
+	err := db.Conn(ctx, r.db).Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).First(&p, id).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, promotion.ErrPromotionNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func (r *Repository) FindByCodeForUpdate(ctx context.Context, code string) (*promotion.Promotion, error) {
+	var p promotion.Promotion
+	err := db.Conn(ctx, r.db).Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("code = ?", promotion.NormalizeCode(code)).
+		First(&p).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, promotion.ErrPromotionNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func (r *Repository) List(ctx context.Context, offset, limit int) ([]*promotion.Promotion, int64, error) {
+	query := db.Conn(ctx, r.db).Model(&promotion.Promotion{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var promotions []*promotion.Promotion
+	if err := query.Order("id").Offset(offset).Limit(limit).Find(&promotions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return promotions, total, nil
+}
+
+func (r *Repository) Update(ctx context.Context, p *promotion.Promotion) error {
+	result := db.Conn(ctx, r.db).Model(p).Select("*").Updates(p)
+	if db.IsUniqueViolation(result.Error) {
+		return promotion.ErrCodeExists
+	}
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return promotion.ErrPromotionNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) Delete(ctx context.Context, id int) error {
+	result := db.Conn(ctx, r.db).Delete(&promotion.Promotion{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return promotion.ErrPromotionNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) CreateRedemption(ctx context.Context, redemption *promotion.Redemption) error {
+	return db.Conn(ctx, r.db).Create(redemption).Error
+}
+
+func (r *Repository) RedemptionCount(ctx context.Context, promotionID int, userID string) (int, error) {
+	var count int64
+	err := db.Conn(ctx, r.db).Model(&promotion.Redemption{}).
+		Where("promotion_id = ? AND user_id = ?", promotionID, userID).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
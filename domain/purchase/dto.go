@@ -0,0 +1,63 @@
+package purchase
+
+import (
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+)
+
+type PurchaseDTO struct {
+	ID               int                   `json:"id"`
+	TicketID         int                   `json:"ticket_id"`
+	UserID           string                `json:"user_id"`
+	Quantity         int                   `json:"quantity"`
+	Status           string                `json:"status"`
+	UnitPrice        *valueobject.MoneyDTO `json:"unit_price"`
+	Discount         *valueobject.MoneyDTO `json:"discount,omitempty"`
+	Total            *valueobject.MoneyDTO `json:"total"`
+	RefundedQuantity int                   `json:"refunded_quantity"`
+	SeatIDs          []int                 `json:"seat_ids,omitempty"`
+	CreatedAt        time.Time             `json:"created_at"`
+} // @Name PurchaseDTO
+
+func NewPurchaseDTOFromEntity(purchase *Purchase) *PurchaseDTO {
+	dto := &PurchaseDTO{
+		ID:               purchase.ID,
+		TicketID:         purchase.TicketID,
+		UserID:           purchase.UserID,
+		Quantity:         purchase.Quantity,
+		Status:           string(purchase.Status),
+		UnitPrice:        valueobject.NewMoneyDTO(purchase.UnitPrice),
+		Total:            valueobject.NewMoneyDTO(purchase.Total),
+		RefundedQuantity: purchase.RefundedQuantity,
+		CreatedAt:        purchase.CreatedAt,
+	}
+
+	// Purchases without a promotion do not show a discount.
+	if purchase.Discount != nil && !purchase.Discount.IsZero() {
+		dto.Discount = valueobject.NewMoneyDTO(purchase.Discount)
+	}
+
+	return dto
+}
+
+type RefundDTO struct {
+	ID         int                   `json:"id"`
+	PurchaseID int                   `json:"purchase_id"`
+	Quantity   int                   `json:"quantity"`
+	Amount     *valueobject.MoneyDTO `json:"amount"`
+	Purchase   *PurchaseDTO          `json:"purchase"`
+	SeatIDs    []int                 `json:"seat_ids,omitempty"`
+	CreatedAt  time.Time             `json:"created_at"`
+} // @Name RefundDTO
+
+func NewRefundDTOFromEntity(refund *Refund, purchase *Purchase) *RefundDTO {
+	return &RefundDTO{
+		ID:         refund.ID,
+		PurchaseID: refund.PurchaseID,
+		Quantity:   refund.Quantity,
+		Amount:     valueobject.NewMoneyDTO(refund.Amount),
+		Purchase:   NewPurchaseDTOFromEntity(purchase),
+		CreatedAt:  refund.CreatedAt,
+	}
+}
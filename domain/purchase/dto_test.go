@@ -0,0 +1,66 @@
+package purchase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPurchaseDTOFromEntity(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	unitPrice, _ := valueobject.NewMoney(1250, "EUR")
+	total, _ := valueobject.NewMoney(3750, "EUR")
+	purchase := &Purchase{
+		ID:        1,
+		TicketID:  2,
+		UserID:    "406c1d05-bbb2-4e94-b183-7d208c2692e1",
+		Quantity:  3,
+		Status:    StatusCompleted,
+		UnitPrice: unitPrice,
+		Total:     total,
+		CreatedAt: createdAt,
+	}
+
+	expected := &PurchaseDTO{
+		ID:        1,
+		TicketID:  2,
+		UserID:    "406c1d05-bbb2-4e94-b183-7d208c2692e1",
+		Quantity:  3,
+		Status:    "completed",
+		UnitPrice: &valueobject.MoneyDTO{Amount: 1250, Currency: "EUR"},
+		Total:     &valueobject.MoneyDTO{Amount: 3750, Currency: "EUR"},
+		CreatedAt: createdAt,
+	}
+
+	result := NewPurchaseDTOFromEntity(purchase)
+	assert.Equal(t, expected, result)
+}
+
+func TestNewRefundDTOFromEntity(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	unitPrice, _ := valueobject.NewMoney(1250, "EUR")
+	total, _ := valueobject.NewMoney(2500, "EUR")
+	amount, _ := valueobject.NewMoney(1250, "EUR")
+	purchase := &Purchase{
+		ID:               1,
+		TicketID:         2,
+		UserID:           "406c1d05-bbb2-4e94-b183-7d208c2692e1",
+		Quantity:         2,
+		Status:           StatusPartiallyRefunded,
+		UnitPrice:        unitPrice,
+		Total:            total,
+		RefundedQuantity: 1,
+	}
+	refund := &Refund{ID: 3, PurchaseID: 1, Quantity: 1, Amount: amount, CreatedAt: createdAt}
+
+	result := NewRefundDTOFromEntity(refund, purchase)
+	assert.Equal(t, 3, result.ID)
+	assert.Equal(t, 1, result.PurchaseID)
+	assert.Equal(t, 1, result.Quantity)
+	assert.Equal(t, &valueobject.MoneyDTO{Amount: 1250, Currency: "EUR"}, result.Amount)
+	assert.Equal(t, createdAt, result.CreatedAt)
+	assert.Equal(t, "partially_refunded", result.Purchase.Status)
+	assert.Equal(t, 1, result.Purchase.RefundedQuantity)
+}
@@ -0,0 +1,214 @@
+package purchase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/pkg/eventbus"
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+)
+
+var (
+	ErrInvalidQuantity         = errors.New("quantity must be greater than zero")
+	ErrUserIDIsRequired        = errors.New("user id is required")
+	ErrTicketIDRequired        = errors.New("ticket id is required")
+	ErrUnitPriceRequired       = errors.New("unit price is required")
+	ErrPurchaseNotFound        = errors.New("purchase not found")
+	ErrPurchaseAlreadyRefunded = errors.New("purchase is already refunded")
+	ErrRefundExceedsQuantity   = errors.New("refund quantity exceeds the refundable quantity")
+	ErrDiscountExceedsTotal    = errors.New("discount exceeds the total of the purchase")
+)
+
+type Status string
+
+const (
+	StatusCompleted         Status = "completed"
+	StatusPartiallyRefunded Status = "partially_refunded"
+	StatusRefunded          Status = "refunded"
+)
+
+type Purchase struct {
+	ID               int                `json:"id" gorm:"primaryKey;autoIncrement"`
+	TicketID         int                `json:"ticket_id" gorm:"not null;index"`
+	UserID           string             `json:"user_id" gorm:"not null;type:uuid;index"`
+	Quantity         int                `json:"quantity" gorm:"not null;type:int"`
+	Status           Status             `json:"status" gorm:"not null;type:varchar(32)"`
+	UnitPrice        *valueobject.Money `json:"unit_price" gorm:"not null;type:varchar(32);default:'0 XXX'"`
+	Discount         *valueobject.Money `json:"discount" gorm:"not null;type:varchar(32);default:'0 XXX'"`
+	Total            *valueobject.Money `json:"total" gorm:"not null;type:varchar(32);default:'0 XXX'"`
+	RefundedQuantity int                `json:"refunded_quantity" gorm:"not null;type:int;default:0"`
+	CreatedAt        time.Time          `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt        time.Time          `json:"updated_at" gorm:"not null;default:current_timestamp;autoUpdateTime"`
+
+	events []eventbus.Event
+}
+
+func (p *Purchase) TableName() string {
+	return "purchases"
+}
+
+func (p *Purchase) RefundableQuantity() int {
+	return p.Quantity - p.RefundedQuantity
+}
+
+// Refund gives back quantity units of the purchase at the unit price they
+// were bought with, less their share of the discount. A purchase can be
+// refunded in several parts until every unit is refunded.
+func (p *Purchase) Refund(quantity int) (*Refund, error) {
+	if p.Status == StatusRefunded {
+		return nil, ErrPurchaseAlreadyRefunded
+	}
+
+	if quantity <= 0 {
+		return nil, ErrInvalidQuantity
+	}
+
+	if quantity > p.RefundableQuantity() {
+		return nil, ErrRefundExceedsQuantity
+	}
+
+	amount, err := p.refundAmount(quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	p.RefundedQuantity += quantity
+	p.Status = StatusPartiallyRefunded
+	if p.RefundableQuantity() == 0 {
+		p.Status = StatusRefunded
+	}
+
+	p.record(PurchaseRefunded{
+		PurchaseID: p.ID,
+		TicketID:   p.TicketID,
+		Quantity:   quantity,
+		Amount:     valueobject.NewMoneyDTO(amount),
+		Status:     p.Status,
+		At:         time.Now(),
+	})
+
+	return &Refund{
+		PurchaseID: p.ID,
+		Quantity:   quantity,
+		Amount:     amount,
+	}, nil
+}
+
+func (p *Purchase) refundAmount(quantity int) (*valueobject.Money, error) {
+	if p.Discount == nil || p.Discount.IsZero() {
+		return p.UnitPrice.Multiply(quantity)
+	}
+
+	// The discount is spread over the units, the rounding remainder goes to
+	// the last unit refunded so that a full refund gives back the total.
+	refunded, err := p.share(p.RefundedQuantity)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := p.share(p.RefundedQuantity + quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	return after.Subtract(refunded)
+}
+
+// share returns what units of the purchase cost after the discount.
+func (p *Purchase) share(units int) (*valueobject.Money, error) {
+	amount, err := p.Total.Multiply(units)
+	if err != nil {
+		return nil, err
+	}
+
+	return valueobject.NewMoney(amount.GetAmount()/int64(p.Quantity), amount.GetCurrency())
+}
+
+// ApplyDiscount takes discount off the total of a purchase that is not
+// stored yet.
+func (p *Purchase) ApplyDiscount(discount *valueobject.Money) error {
+	total, err := p.Total.Subtract(discount)
+	if errors.Is(err, valueobject.ErrInvalidAmount) {
+		return ErrDiscountExceedsTotal
+	}
+
+	if err != nil {
+		return err
+	}
+
+	applied, err := p.Discount.Add(discount)
+	if err != nil {
+		return err
+	}
+
+	p.Discount = applied
+	p.Total = total
+	return nil
+}
+
+// NewPurchase copies the unit price of the ticket at the time of the purchase,
+// so later price changes do not rewrite the purchase history.
+func NewPurchase(ticketID int, userID string, quantity int, unitPrice *valueobject.Money) (*Purchase, error) {
+	if ticketID == 0 {
+		return nil, ErrTicketIDRequired
+	}
+
+	if userID == "" {
+		return nil, ErrUserIDIsRequired
+	}
+
+	if quantity <= 0 {
+		return nil, ErrInvalidQuantity
+	}
+
+	if unitPrice == nil {
+		return nil, ErrUnitPriceRequired
+	}
+
+	total, err := unitPrice.Multiply(quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	discount, err := valueobject.NewMoney(0, unitPrice.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	price := *unitPrice
+	p := &Purchase{
+		TicketID:  ticketID,
+		UserID:    userID,
+		Quantity:  quantity,
+		Status:    StatusCompleted,
+		UnitPrice: &price,
+		Discount:  discount,
+		Total:     total,
+	}
+
+	p.record(PurchaseCompleted{TicketID: ticketID, UserID: userID, Quantity: quantity, At: time.Now()})
+	return p, nil
+}
+
+// PullEvents returns the events recorded since the last call and forgets
+// them, so that every event is published once. The purchase is completed
+// with the ID and the total it was stored with.
+func (p *Purchase) PullEvents() []eventbus.Event {
+	events := make([]eventbus.Event, 0, len(p.events))
+	for _, e := range p.events {
+		if completed, ok := e.(PurchaseCompleted); ok && completed.PurchaseID == 0 {
+			completed.PurchaseID = p.ID
+			completed.Total = valueobject.NewMoneyDTO(p.Total)
+			e = completed
+		}
+
+		events = append(events, e)
+	}
+
+	p.events = nil
+	return events
+}
+
+func (p *Purchase) record(e eventbus.Event) {
+	p.events = append(p.events, e)
+}
@@ -0,0 +1,192 @@
+package purchase_test
+
+import (
+	"testing"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/purchase"
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPurchase(t *testing.T) {
+	userID := "406c1d05-bbb2-4e94-b183-7d208c2692e1"
+	unitPrice, _ := valueobject.NewMoney(1250, "EUR")
+
+	t.Run("should create a new purchase successfully", func(t *testing.T) {
+		p, err := purchase.NewPurchase(1, userID, 2, unitPrice)
+		assert.NoError(t, err)
+		assert.NotNil(t, p)
+		assert.Equal(t, 1, p.TicketID)
+		assert.Equal(t, userID, p.UserID)
+		assert.Equal(t, 2, p.Quantity)
+		assert.Equal(t, purchase.StatusCompleted, p.Status)
+		assert.Equal(t, int64(1250), p.UnitPrice.GetAmount())
+		assert.Equal(t, int64(2500), p.Total.GetAmount())
+		assert.Equal(t, "EUR", p.Total.GetCurrency())
+	})
+
+	t.Run("should keep unit price when the source price changes", func(t *testing.T) {
+		price, _ := valueobject.NewMoney(1000, "EUR")
+		p, err := purchase.NewPurchase(1, userID, 1, price)
+		assert.NoError(t, err)
+
+		_ = price.Scan("2000 EUR")
+		assert.Equal(t, int64(1000), p.UnitPrice.GetAmount())
+	})
+
+	t.Run("should return error when ticket id is missing", func(t *testing.T) {
+		p, err := purchase.NewPurchase(0, userID, 2, unitPrice)
+		assert.ErrorIs(t, err, purchase.ErrTicketIDRequired)
+		assert.Nil(t, p)
+	})
+
+	t.Run("should return error when user id is missing", func(t *testing.T) {
+		p, err := purchase.NewPurchase(1, "", 2, unitPrice)
+		assert.ErrorIs(t, err, purchase.ErrUserIDIsRequired)
+		assert.Nil(t, p)
+	})
+
+	t.Run("should return error when quantity is invalid", func(t *testing.T) {
+		p, err := purchase.NewPurchase(1, userID, 0, unitPrice)
+		assert.ErrorIs(t, err, purchase.ErrInvalidQuantity)
+		assert.Nil(t, p)
+	})
+
+	t.Run("should return error when unit price is missing", func(t *testing.T) {
+		p, err := purchase.NewPurchase(1, userID, 2, nil)
+		assert.ErrorIs(t, err, purchase.ErrUnitPriceRequired)
+		assert.Nil(t, p)
+	})
+}
+
+func TestPurchase_Refund(t *testing.T) {
+	unitPrice, _ := valueobject.NewMoney(1250, "EUR")
+	newPurchase := func() *purchase.Purchase {
+		p, _ := purchase.NewPurchase(1, "406c1d05-bbb2-4e94-b183-7d208c2692e1", 4, unitPrice)
+		p.ID = 7
+		return p
+	}
+
+	t.Run("should refund part of the purchase", func(t *testing.T) {
+		p := newPurchase()
+		refund, err := p.Refund(1)
+		assert.NoError(t, err)
+		assert.Equal(t, 7, refund.PurchaseID)
+		assert.Equal(t, 1, refund.Quantity)
+		assert.Equal(t, int64(1250), refund.Amount.GetAmount())
+		assert.Equal(t, purchase.StatusPartiallyRefunded, p.Status)
+		assert.Equal(t, 3, p.RefundableQuantity())
+	})
+
+	t.Run("should refund the rest of the purchase", func(t *testing.T) {
+		p := newPurchase()
+		_, err := p.Refund(1)
+		assert.NoError(t, err)
+
+		refund, err := p.Refund(3)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3750), refund.Amount.GetAmount())
+		assert.Equal(t, purchase.StatusRefunded, p.Status)
+		assert.Equal(t, 0, p.RefundableQuantity())
+	})
+
+	t.Run("should reject double refunds", func(t *testing.T) {
+		p := newPurchase()
+		_, err := p.Refund(4)
+		assert.NoError(t, err)
+
+		refund, err := p.Refund(1)
+		assert.ErrorIs(t, err, purchase.ErrPurchaseAlreadyRefunded)
+		assert.Nil(t, refund)
+	})
+
+	t.Run("should reject refunds over the purchased quantity", func(t *testing.T) {
+		p := newPurchase()
+		_, err := p.Refund(3)
+		assert.NoError(t, err)
+
+		refund, err := p.Refund(2)
+		assert.ErrorIs(t, err, purchase.ErrRefundExceedsQuantity)
+		assert.Nil(t, refund)
+		assert.Equal(t, 3, p.RefundedQuantity)
+		assert.Equal(t, purchase.StatusPartiallyRefunded, p.Status)
+	})
+
+	t.Run("should reject invalid quantity", func(t *testing.T) {
+		p := newPurchase()
+		refund, err := p.Refund(0)
+		assert.ErrorIs(t, err, purchase.ErrInvalidQuantity)
+		assert.Nil(t, refund)
+		assert.Equal(t, purchase.StatusCompleted, p.Status)
+	})
+}
+
+func TestPurchase_ApplyDiscount(t *testing.T) {
+	userID := "406c1d05-bbb2-4e94-b183-7d208c2692e1"
+	unitPrice, _ := valueobject.NewMoney(1000, "EUR")
+
+	t.Run("should take the discount off the total", func(t *testing.T) {
+		p, _ := purchase.NewPurchase(1, userID, 3, unitPrice)
+		assert.True(t, p.Discount.IsZero())
+
+		discount, _ := valueobject.NewMoney(500, "EUR")
+		assert.NoError(t, p.ApplyDiscount(discount))
+		assert.Equal(t, "500 EUR", p.Discount.String())
+		assert.Equal(t, "2500 EUR", p.Total.String())
+		assert.Equal(t, "1000 EUR", p.UnitPrice.String())
+
+		p.ID = 1
+		completed := p.PullEvents()[0].(purchase.PurchaseCompleted)
+		assert.Equal(t, &valueobject.MoneyDTO{Amount: 2500, Currency: "EUR"}, completed.Total)
+	})
+
+	t.Run("should return error when the discount exceeds the total", func(t *testing.T) {
+		p, _ := purchase.NewPurchase(1, userID, 1, unitPrice)
+		discount, _ := valueobject.NewMoney(1001, "EUR")
+		assert.ErrorIs(t, p.ApplyDiscount(discount), purchase.ErrDiscountExceedsTotal)
+		assert.Equal(t, "1000 EUR", p.Total.String())
+	})
+
+	t.Run("should refund the discounted price of the units", func(t *testing.T) {
+		p, _ := purchase.NewPurchase(1, userID, 3, unitPrice)
+		discount, _ := valueobject.NewMoney(500, "EUR")
+		assert.NoError(t, p.ApplyDiscount(discount))
+
+		var refunded int64
+		for _, want := range []int64{833, 833, 834} {
+			r, err := p.Refund(1)
+			assert.NoError(t, err)
+			assert.Equal(t, want, r.Amount.GetAmount())
+			refunded += r.Amount.GetAmount()
+		}
+		assert.Equal(t, p.Total.GetAmount(), refunded, "a full refund gives back the total")
+	})
+}
+
+func TestPurchase_Events(t *testing.T) {
+	unitPrice, _ := valueobject.NewMoney(1250, "EUR")
+	p, _ := purchase.NewPurchase(1, "406c1d05-bbb2-4e94-b183-7d208c2692e1", 4, unitPrice)
+	p.ID = 7
+
+	events := p.PullEvents()
+	assert.Len(t, events, 1)
+	completed := events[0].(purchase.PurchaseCompleted)
+	assert.Equal(t, 7, completed.PurchaseID)
+	assert.Equal(t, 1, completed.TicketID)
+	assert.Equal(t, 4, completed.Quantity)
+	assert.Equal(t, &valueobject.MoneyDTO{Amount: 5000, Currency: "EUR"}, completed.Total)
+	assert.Empty(t, p.PullEvents())
+
+	_, err := p.Refund(1)
+	assert.NoError(t, err)
+	_, err = p.Refund(0)
+	assert.Error(t, err)
+
+	events = p.PullEvents()
+	assert.Len(t, events, 1)
+	refunded := events[0].(purchase.PurchaseRefunded)
+	assert.Equal(t, 7, refunded.PurchaseID)
+	assert.Equal(t, 1, refunded.Quantity)
+	assert.Equal(t, &valueobject.MoneyDTO{Amount: 1250, Currency: "EUR"}, refunded.Amount)
+	assert.Equal(t, purchase.StatusPartiallyRefunded, refunded.Status)
+}
@@ -0,0 +1,39 @@
+package purchase
+
+import (
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+)
+
+const (
+	EventPurchaseCompleted = "purchase.completed"
+	EventPurchaseRefunded  = "purchase.refunded"
+)
+
+// PurchaseCompleted is recorded by NewPurchase, before the purchase has an
+// ID. The ID is filled in by PullEvents once the purchase was stored.
+type PurchaseCompleted struct {
+	PurchaseID int                   `json:"purchase_id"`
+	TicketID   int                   `json:"ticket_id"`
+	UserID     string                `json:"user_id"`
+	Quantity   int                   `json:"quantity"`
+	Total      *valueobject.MoneyDTO `json:"total"`
+	At         time.Time             `json:"occurred_at"`
+}
+
+func (e PurchaseCompleted) EventName() string     { return EventPurchaseCompleted }
+func (e PurchaseCompleted) OccurredAt() time.Time { return e.At }
+
+// PurchaseRefunded is recorded for every full or partial refund.
+type PurchaseRefunded struct {
+	PurchaseID int                   `json:"purchase_id"`
+	TicketID   int                   `json:"ticket_id"`
+	Quantity   int                   `json:"quantity"`
+	Amount     *valueobject.MoneyDTO `json:"amount"`
+	Status     Status                `json:"status"`
+	At         time.Time             `json:"occurred_at"`
+}
+
+func (e PurchaseRefunded) EventName() string     { return EventPurchaseRefunded }
+func (e PurchaseRefunded) OccurredAt() time.Time { return e.At }
@@ -0,0 +1,19 @@
+package purchase
+
+import (
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+)
+
+type Refund struct {
+	ID         int                `json:"id" gorm:"primaryKey;autoIncrement"`
+	PurchaseID int                `json:"purchase_id" gorm:"not null;index"`
+	Quantity   int                `json:"quantity" gorm:"not null;type:int"`
+	Amount     *valueobject.Money `json:"amount" gorm:"not null;type:varchar(32)"`
+	CreatedAt  time.Time          `json:"created_at" gorm:"not null;default:current_timestamp"`
+}
+
+func (r *Refund) TableName() string {
+	return "refunds"
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/purchase"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+)
+
+// MemoryRepository keeps purchases and refunds in memory, it has to be used
+// together with db.MemoryUnitOfWork.
+type MemoryRepository struct {
+	mu           sync.RWMutex
+	purchases    map[int]purchase.Purchase
+	refunds      map[int]purchase.Refund
+	lastID       int
+	lastRefundID int
+	locks        db.RowLocks
+	now          func() time.Time
+}
+
+func NewMemoryPurchaseRepository() PurchaseRepository {
+	return &MemoryRepository{
+		purchases: make(map[int]purchase.Purchase),
+		refunds:   make(map[int]purchase.Refund),
+		now:       time.Now,
+	}
+}
+
+func (r *MemoryRepository) Create(ctx context.Context, p *purchase.Purchase) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	p.ID = r.lastID
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = r.now()
+	}
+	p.UpdatedAt = p.CreatedAt
+
+	id := p.ID
+	stored := *p
+	stored.PullEvents()
+	r.purchases[id] = stored
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		delete(r.purchases, id)
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (r *MemoryRepository) FindByIDForUpdate(ctx context.Context, id int) (*purchase.Purchase, error) {
+	if err := r.locks.Lock(ctx, id); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.purchases[id]
+	if !ok {
+		return nil, purchase.ErrPurchaseNotFound
+	}
+
+	return &p, nil
+}
+
+func (r *MemoryRepository) Update(ctx context.Context, p *purchase.Purchase) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, ok := r.purchases[p.ID]
+	if !ok {
+		return purchase.ErrPurchaseNotFound
+	}
+
+	p.UpdatedAt = r.now()
+	id := p.ID
+	stored := *p
+	// Recorded events stay with the caller, who publishes them.
+	stored.PullEvents()
+	r.purchases[id] = stored
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		r.purchases[id] = previous
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (r *MemoryRepository) OwnedQuantity(ctx context.Context, ticketID int, userID string) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var owned int
+	for _, p := range r.purchases {
+		if p.TicketID == ticketID && p.UserID == userID {
+			owned += p.RefundableQuantity()
+		}
+	}
+
+	return owned, nil
+}
+
+func (r *MemoryRepository) CreateRefund(ctx context.Context, refund *purchase.Refund) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastRefundID++
+	refund.ID = r.lastRefundID
+	if refund.CreatedAt.IsZero() {
+		refund.CreatedAt = r.now()
+	}
+
+	id := refund.ID
+	r.refunds[id] = *refund
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		delete(r.refunds, id)
+		r.mu.Unlock()
+	})
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/purchase"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -destination=../../../mock/repository/purchase/purchase.go -package=repository github.com/aaydin-tr/ddd-api-example/domain/purchase/repository PurchaseRepository
+type PurchaseRepository interface {
+	Create(ctx context.Context, p *purchase.Purchase) error
+	FindByIDForUpdate(ctx context.Context, id int) (*purchase.Purchase, error)
+	Update(ctx context.Context, p *purchase.Purchase) error
+	CreateRefund(ctx context.Context, r *purchase.Refund) error
+	// OwnedQuantity returns the units of a ticket a user bought and did not
+	// refund.
+	OwnedQuantity(ctx context.Context, ticketID int, userID string) (int, error)
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewPurchaseRepository(db *gorm.DB) PurchaseRepository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(ctx context.Context, p *purchase.Purchase) error {
+	return db.Conn(ctx, r.db).Create(p).Error
+}
+
+func (r *Repository) FindByIDForUpdate(ctx context.Context, id int) (*purchase.Purchase, error) {
+	var p purchase.Purchase
+	err := db.Conn(ctx, r.db).Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).First(&p, id).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, purchase.ErrPurchaseNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func (r *Repository) Update(ctx context.Context, p *purchase.Purchase) error {
+	return db.Conn(ctx, r.db).Save(p).Error
+}
+
+func (r *Repository) OwnedQuantity(ctx context.Context, ticketID int, userID string) (int, error) {
+	var owned int
+	err := db.Conn(ctx, r.db).Model(&purchase.Purchase{}).
+		Select("COALESCE(SUM(quantity - refunded_quantity), 0)").
+		Where("ticket_id = ? AND user_id = ?", ticketID, userID).
+		Scan(&owned).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return owned, nil
+}
+
+func (r *Repository) CreateRefund(ctx context.Context, refund *purchase.Refund) error {
+	return db.Conn(ctx, r.db).Create(refund).Error
+}
@@ -0,0 +1,26 @@
+package reservation
+
+import "time"
+
+type ReservationDTO struct {
+	ID         int       `json:"id"`
+	TicketID   int       `json:"ticket_id"`
+	UserID     string    `json:"user_id"`
+	Quantity   int       `json:"quantity"`
+	Status     string    `json:"status"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	PurchaseID *int      `json:"purchase_id"`
+	SeatIDs    []int     `json:"seat_ids,omitempty"`
+} // @Name ReservationDTO
+
+func NewReservationDTOFromEntity(reservation *Reservation) *ReservationDTO {
+	return &ReservationDTO{
+		ID:         reservation.ID,
+		TicketID:   reservation.TicketID,
+		UserID:     reservation.UserID,
+		Quantity:   reservation.Quantity,
+		Status:     string(reservation.Status),
+		ExpiresAt:  reservation.ExpiresAt,
+		PurchaseID: reservation.PurchaseID,
+	}
+}
@@ -0,0 +1,35 @@
+package reservation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReservationDTOFromEntity(t *testing.T) {
+	expiresAt := time.Date(2024, 1, 1, 12, 10, 0, 0, time.UTC)
+	purchaseID := 3
+	reservation := &Reservation{
+		ID:         1,
+		TicketID:   2,
+		UserID:     "406c1d05-bbb2-4e94-b183-7d208c2692e1",
+		Quantity:   4,
+		Status:     StatusConfirmed,
+		ExpiresAt:  expiresAt,
+		PurchaseID: &purchaseID,
+	}
+
+	expected := &ReservationDTO{
+		ID:         1,
+		TicketID:   2,
+		UserID:     "406c1d05-bbb2-4e94-b183-7d208c2692e1",
+		Quantity:   4,
+		Status:     "confirmed",
+		ExpiresAt:  expiresAt,
+		PurchaseID: &purchaseID,
+	}
+
+	result := NewReservationDTOFromEntity(reservation)
+	assert.Equal(t, expected, result)
+}
@@ -0,0 +1,111 @@
+package reservation
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidQuantity        = errors.New("quantity must be greater than zero")
+	ErrUserIDIsRequired       = errors.New("user id is required")
+	ErrTicketIDRequired       = errors.New("ticket id is required")
+	ErrReservationNotFound    = errors.New("reservation not found")
+	ErrReservationNotActive   = errors.New("reservation is not active")
+	ErrReservationExpired     = errors.New("reservation is expired")
+	ErrReservationNotExpired  = errors.New("reservation is not expired yet")
+	ErrInvalidReservationTime = errors.New("reservation must expire in the future")
+)
+
+type Status string
+
+const (
+	StatusActive    Status = "active"
+	StatusConfirmed Status = "confirmed"
+	StatusCancelled Status = "cancelled"
+	StatusExpired   Status = "expired"
+)
+
+type Reservation struct {
+	ID         int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	TicketID   int       `json:"ticket_id" gorm:"not null;index"`
+	UserID     string    `json:"user_id" gorm:"not null;type:uuid;index"`
+	Quantity   int       `json:"quantity" gorm:"not null;type:int"`
+	Status     Status    `json:"status" gorm:"not null;type:varchar(32);index:idx_reservations_status_expires_at"`
+	ExpiresAt  time.Time `json:"expires_at" gorm:"not null;index:idx_reservations_status_expires_at"`
+	PurchaseID *int      `json:"purchase_id"`
+	CreatedAt  time.Time `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"not null;default:current_timestamp;autoUpdateTime"`
+}
+
+func (r *Reservation) TableName() string {
+	return "reservations"
+}
+
+func (r *Reservation) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+func (r *Reservation) Confirm(now time.Time) error {
+	if r.Status != StatusActive {
+		return ErrReservationNotActive
+	}
+
+	if r.IsExpired(now) {
+		return ErrReservationExpired
+	}
+
+	r.Status = StatusConfirmed
+	return nil
+}
+
+func (r *Reservation) LinkPurchase(purchaseID int) {
+	r.PurchaseID = &purchaseID
+}
+
+func (r *Reservation) Cancel() error {
+	if r.Status != StatusActive {
+		return ErrReservationNotActive
+	}
+
+	r.Status = StatusCancelled
+	return nil
+}
+
+func (r *Reservation) Expire(now time.Time) error {
+	if r.Status != StatusActive {
+		return ErrReservationNotActive
+	}
+
+	if !r.IsExpired(now) {
+		return ErrReservationNotExpired
+	}
+
+	r.Status = StatusExpired
+	return nil
+}
+
+func NewReservation(ticketID int, userID string, quantity int, now time.Time, ttl time.Duration) (*Reservation, error) {
+	if ticketID == 0 {
+		return nil, ErrTicketIDRequired
+	}
+
+	if userID == "" {
+		return nil, ErrUserIDIsRequired
+	}
+
+	if quantity <= 0 {
+		return nil, ErrInvalidQuantity
+	}
+
+	if ttl <= 0 {
+		return nil, ErrInvalidReservationTime
+	}
+
+	return &Reservation{
+		TicketID:  ticketID,
+		UserID:    userID,
+		Quantity:  quantity,
+		Status:    StatusActive,
+		ExpiresAt: now.Add(ttl),
+	}, nil
+}
@@ -0,0 +1,95 @@
+package reservation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/reservation"
+	"github.com/stretchr/testify/assert"
+)
+
+const userID = "406c1d05-bbb2-4e94-b183-7d208c2692e1"
+
+func TestNewReservation(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should create a new reservation successfully", func(t *testing.T) {
+		r, err := reservation.NewReservation(1, userID, 2, now, 10*time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, r.TicketID)
+		assert.Equal(t, userID, r.UserID)
+		assert.Equal(t, 2, r.Quantity)
+		assert.Equal(t, reservation.StatusActive, r.Status)
+		assert.Equal(t, now.Add(10*time.Minute), r.ExpiresAt)
+	})
+
+	tests := []struct {
+		name     string
+		ticketID int
+		userID   string
+		quantity int
+		ttl      time.Duration
+		wantErr  error
+	}{
+		{name: "missing ticket id", ticketID: 0, userID: userID, quantity: 1, ttl: time.Minute, wantErr: reservation.ErrTicketIDRequired},
+		{name: "missing user id", ticketID: 1, userID: "", quantity: 1, ttl: time.Minute, wantErr: reservation.ErrUserIDIsRequired},
+		{name: "invalid quantity", ticketID: 1, userID: userID, quantity: 0, ttl: time.Minute, wantErr: reservation.ErrInvalidQuantity},
+		{name: "invalid ttl", ticketID: 1, userID: userID, quantity: 1, ttl: 0, wantErr: reservation.ErrInvalidReservationTime},
+	}
+
+	for _, tt := range tests {
+		t.Run("should return error when "+tt.name, func(t *testing.T) {
+			r, err := reservation.NewReservation(tt.ticketID, tt.userID, tt.quantity, now, tt.ttl)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, r)
+		})
+	}
+}
+
+func TestReservationTransitions(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	newReservation := func() *reservation.Reservation {
+		r, _ := reservation.NewReservation(1, userID, 2, now, 10*time.Minute)
+		return r
+	}
+
+	t.Run("should confirm active reservation", func(t *testing.T) {
+		r := newReservation()
+		assert.NoError(t, r.Confirm(now.Add(time.Minute)))
+		assert.Equal(t, reservation.StatusConfirmed, r.Status)
+
+		r.LinkPurchase(7)
+		assert.Equal(t, 7, *r.PurchaseID)
+	})
+
+	t.Run("should not confirm expired reservation", func(t *testing.T) {
+		r := newReservation()
+		assert.ErrorIs(t, r.Confirm(now.Add(10*time.Minute)), reservation.ErrReservationExpired)
+		assert.Equal(t, reservation.StatusActive, r.Status)
+	})
+
+	t.Run("should cancel active reservation", func(t *testing.T) {
+		r := newReservation()
+		assert.NoError(t, r.Cancel())
+		assert.Equal(t, reservation.StatusCancelled, r.Status)
+	})
+
+	t.Run("should expire overdue reservation", func(t *testing.T) {
+		r := newReservation()
+		assert.ErrorIs(t, r.Expire(now), reservation.ErrReservationNotExpired)
+		assert.NoError(t, r.Expire(now.Add(time.Hour)))
+		assert.Equal(t, reservation.StatusExpired, r.Status)
+	})
+
+	t.Run("should reject transitions from final states", func(t *testing.T) {
+		for _, status := range []reservation.Status{reservation.StatusConfirmed, reservation.StatusCancelled, reservation.StatusExpired} {
+			r := newReservation()
+			r.Status = status
+
+			assert.ErrorIs(t, r.Confirm(now), reservation.ErrReservationNotActive)
+			assert.ErrorIs(t, r.Cancel(), reservation.ErrReservationNotActive)
+			assert.ErrorIs(t, r.Expire(now.Add(time.Hour)), reservation.ErrReservationNotActive)
+			assert.Equal(t, status, r.Status)
+		}
+	})
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/reservation"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+)
+
+// MemoryRepository keeps reservations in memory, it has to be used together
+// with db.MemoryUnitOfWork.
+type MemoryRepository struct {
+	mu           sync.RWMutex
+	reservations map[int]reservation.Reservation
+	lastID       int
+	locks        db.RowLocks
+	now          func() time.Time
+}
+
+func NewMemoryReservationRepository() ReservationRepository {
+	return &MemoryRepository{reservations: make(map[int]reservation.Reservation), now: time.Now}
+}
+
+func (r *MemoryRepository) Create(ctx context.Context, res *reservation.Reservation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	res.ID = r.lastID
+	if res.CreatedAt.IsZero() {
+		res.CreatedAt = r.now()
+	}
+	res.UpdatedAt = res.CreatedAt
+
+	id := res.ID
+	r.reservations[id] = *res
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		delete(r.reservations, id)
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (r *MemoryRepository) FindByID(ctx context.Context, id int) (*reservation.Reservation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	res, ok := r.reservations[id]
+	if !ok {
+		return nil, reservation.ErrReservationNotFound
+	}
+
+	return &res, nil
+}
+
+func (r *MemoryRepository) FindByIDForUpdate(ctx context.Context, id int) (*reservation.Reservation, error) {
+	if err := r.locks.Lock(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+// FindExpiredForUpdate skips reservations locked by another unit of work,
+// like the SKIP LOCKED query of Repository.
+func (r *MemoryRepository) FindExpiredForUpdate(ctx context.Context, now time.Time, limit int) ([]*reservation.Reservation, error) {
+	r.mu.RLock()
+	var ids []int
+	for id, res := range r.reservations {
+		if res.Status == reservation.StatusActive && !res.ExpiresAt.After(now) {
+			ids = append(ids, id)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Ints(ids)
+
+	var reservations []*reservation.Reservation
+	for _, id := range ids {
+		if len(reservations) == limit {
+			break
+		}
+
+		if !r.locks.TryLock(ctx, id) {
+			continue
+		}
+
+		// The reservation may have changed before it was locked.
+		res, err := r.FindByID(ctx, id)
+		if err != nil || res.Status != reservation.StatusActive || res.ExpiresAt.After(now) {
+			continue
+		}
+
+		reservations = append(reservations, res)
+	}
+
+	return reservations, nil
+}
+
+func (r *MemoryRepository) HeldQuantity(ctx context.Context, ticketID int, userID string, now time.Time) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var held int
+	for _, res := range r.reservations {
+		if res.TicketID == ticketID && res.UserID == userID && res.Status == reservation.StatusActive && res.ExpiresAt.After(now) {
+			held += res.Quantity
+		}
+	}
+
+	return held, nil
+}
+
+func (r *MemoryRepository) Update(ctx context.Context, res *reservation.Reservation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, ok := r.reservations[res.ID]
+	if !ok {
+		return reservation.ErrReservationNotFound
+	}
+
+	res.UpdatedAt = r.now()
+	id := res.ID
+	r.reservations[id] = *res
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		r.reservations[id] = previous
+		r.mu.Unlock()
+	})
+
+	return nil
+}
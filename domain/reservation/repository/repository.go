@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/reservation"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -destination=../../../mock/repository/reservation/reservation.go -package=repository github.com/aaydin-tr/ddd-api-example/domain/reservation/repository ReservationRepository
+type ReservationRepository interface {
+	Create(ctx context.Context, r *reservation.Reservation) error
+	FindByID(ctx context.Context, id int) (*reservation.Reservation, error)
+	FindByIDForUpdate(ctx context.Context, id int) (*reservation.Reservation, error)
+	FindExpiredForUpdate(ctx context.Context, now time.Time, limit int) ([]*reservation.Reservation, error)
+	Update(ctx context.Context, r *reservation.Reservation) error
+	// HeldQuantity returns the units of a ticket held by the active
+	// reservations of a user that have not expired at now. Expired holds
+	// that were not released yet are not counted.
+	HeldQuantity(ctx context.Context, ticketID int, userID string, now time.Time) (int, error)
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewReservationRepository(db *gorm.DB) ReservationRepository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(ctx context.Context, res *reservation.Reservation) error {
+	return db.Conn(ctx, r.db).Create(res).Error
+}
+
+func (r *Repository) FindByID(ctx context.Context, id int) (*reservation.Reservation, error) {
+	var res reservation.Reservation
+	err := db.Conn(ctx, r.db).First(&res, id).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, reservation.ErrReservationNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *Repository) FindByIDForUpdate(ctx context.Context, id int) (*reservation.Reservation, error) {
+	var res reservation.Reservation
+	err := db.Conn(ctx, r.db).Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).First(&res, id).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, reservation.ErrReservationNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// FindExpiredForUpdate locks active reservations whose hold has expired.
+// Rows locked by another sweeper are skipped so that several instances can
+// release holds concurrently.
+func (r *Repository) FindExpiredForUpdate(ctx context.Context, now time.Time, limit int) ([]*reservation.Reservation, error) {
+	var reservations []*reservation.Reservation
+	err := db.Conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
+		Where("status = ? AND expires_at <= ?", reservation.StatusActive, now).
+		Order("id").
+		Limit(limit).
+		Find(&reservations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+func (r *Repository) HeldQuantity(ctx context.Context, ticketID int, userID string, now time.Time) (int, error) {
+	var held int
+	err := db.Conn(ctx, r.db).Model(&reservation.Reservation{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("ticket_id = ? AND user_id = ? AND status = ? AND expires_at > ?", ticketID, userID, reservation.StatusActive, now).
+		Scan(&held).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return held, nil
+}
+
+func (r *Repository) Update(ctx context.Context, res *reservation.Reservation) error {
+	return db.Conn(ctx, r.db).Save(res).Error
+}
@@ -0,0 +1,79 @@
+package seat
+
+type SeatDTO struct {
+	ID      int    `json:"id"`
+	Section string `json:"section"`
+	Row     string `json:"row"`
+	Number  int    `json:"number"`
+	Status  string `json:"status" enums:"available,held,sold"`
+} // @Name SeatDTO
+
+func NewSeatDTOFromEntity(s *Seat) *SeatDTO {
+	return &SeatDTO{
+		ID:      s.ID,
+		Section: s.Section,
+		Row:     s.Row,
+		Number:  s.Number,
+		Status:  string(s.Status),
+	}
+}
+
+type SeatListDTO struct {
+	Items []*SeatDTO `json:"items"`
+} // @Name SeatListDTO
+
+func NewSeatListDTOFromEntities(seats []*Seat) *SeatListDTO {
+	items := make([]*SeatDTO, 0, len(seats))
+	for _, s := range seats {
+		items = append(items, NewSeatDTOFromEntity(s))
+	}
+
+	return &SeatListDTO{Items: items}
+}
+
+type RowDTO struct {
+	Name  string     `json:"name"`
+	Seats []*SeatDTO `json:"seats"`
+} // @Name SeatRowDTO
+
+type SectionDTO struct {
+	Name string    `json:"name"`
+	Rows []*RowDTO `json:"rows"`
+} // @Name SeatSectionDTO
+
+type SeatMapDTO struct {
+	TicketID  int           `json:"ticket_id"`
+	Available int           `json:"available"`
+	Sections  []*SectionDTO `json:"sections"`
+} // @Name SeatMapDTO
+
+// NewSeatMapDTOFromEntities groups seats by section and row, seats have to
+// be ordered by row rank and number.
+func NewSeatMapDTOFromEntities(ticketID int, seats []*Seat) *SeatMapDTO {
+	m := &SeatMapDTO{TicketID: ticketID, Sections: []*SectionDTO{}}
+	var (
+		section *SectionDTO
+		row     *RowDTO
+		rank    = -1
+	)
+	for _, s := range seats {
+		if section == nil || section.Name != s.Section {
+			section = &SectionDTO{Name: s.Section}
+			m.Sections = append(m.Sections, section)
+			row = nil
+		}
+
+		if row == nil || rank != s.RowRank {
+			row = &RowDTO{Name: s.Row}
+			section.Rows = append(section.Rows, row)
+			rank = s.RowRank
+		}
+
+		row.Seats = append(row.Seats, NewSeatDTOFromEntity(s))
+		if s.IsAvailable() {
+			m.Available++
+		}
+	}
+
+	return m
+}
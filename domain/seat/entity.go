@@ -0,0 +1,193 @@
+package seat
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrSeatNotFound      = errors.New("seat not found")
+	ErrSeatUnavailable   = errors.New("seat is not available")
+	ErrSeatNotHeld       = errors.New("seat is not held by the reservation")
+	ErrSeatNotSold       = errors.New("seat is not sold with the purchase")
+	ErrSeatConflict      = errors.New("seat was modified concurrently")
+	ErrNoAdjacentSeats   = errors.New("not enough adjacent seats available")
+	ErrQuantityMismatch  = errors.New("quantity does not match the number of seats")
+	ErrDuplicateSeat     = errors.New("seat is selected more than once")
+	ErrSectionIsRequired = errors.New("section name is required")
+	ErrRowIsRequired     = errors.New("row name is required")
+	ErrDuplicateSection  = errors.New("section names must be unique")
+	ErrDuplicateRow      = errors.New("row names must be unique within a section")
+	ErrInvalidRowSize    = errors.New("a row must have at least one seat")
+	ErrSeatMapTooLarge   = errors.New("seat map has too many seats")
+	ErrSeatMapIsEmpty    = errors.New("seat map must have at least one section")
+	ErrInvalidQuantity   = errors.New("quantity must be greater than zero")
+	ErrSeatIDsRequired   = errors.New("seat ids are required to refund a part of the seats of a purchase")
+)
+
+// MaxSeats is the largest seat map a ticket can have.
+const MaxSeats = 10000
+
+type Status string
+
+const (
+	StatusAvailable Status = "available"
+	StatusHeld      Status = "held"
+	StatusSold      Status = "sold"
+)
+
+// Seat is one place of the seat map of a ticket with assigned seating. Seats
+// are numbered from 1 within their row, seats with consecutive numbers are
+// next to each other. RowRank orders the rows of the whole map, the first
+// row of the first section has rank 0 and is the best one.
+type Seat struct {
+	ID            int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	TicketID      int       `json:"ticket_id" gorm:"not null;index"`
+	Section       string    `json:"section" gorm:"not null;type:varchar(64)"`
+	Row           string    `json:"row" gorm:"not null;type:varchar(16)"`
+	Number        int       `json:"number" gorm:"not null;type:int"`
+	RowRank       int       `json:"row_rank" gorm:"not null;type:int"`
+	Status        Status    `json:"status" gorm:"not null;type:varchar(32);default:'available'"`
+	ReservationID *int      `json:"reservation_id" gorm:"index"`
+	PurchaseID    *int      `json:"purchase_id" gorm:"index"`
+	Version       int       `json:"version" gorm:"not null;type:int;default:1"`
+	CreatedAt     time.Time `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"not null;default:current_timestamp;autoUpdateTime"`
+}
+
+func (s *Seat) TableName() string {
+	return "seats"
+}
+
+func (s *Seat) IsAvailable() bool {
+	return s.Status == StatusAvailable
+}
+
+// Hold keeps the seat for a reservation until it is confirmed or released.
+func (s *Seat) Hold(reservationID int) error {
+	if !s.IsAvailable() {
+		return s.unavailable()
+	}
+
+	s.Status = StatusHeld
+	s.ReservationID = &reservationID
+	return nil
+}
+
+// Sell sells an available seat with a purchase.
+func (s *Seat) Sell(purchaseID int) error {
+	if !s.IsAvailable() {
+		return s.unavailable()
+	}
+
+	s.Status = StatusSold
+	s.PurchaseID = &purchaseID
+	return nil
+}
+
+// ConfirmHold sells a seat held by the reservation with the purchase the
+// reservation was confirmed with.
+func (s *Seat) ConfirmHold(reservationID, purchaseID int) error {
+	if !s.isHeldBy(reservationID) {
+		return fmt.Errorf("%w: seat %d", ErrSeatNotHeld, s.ID)
+	}
+
+	s.Status = StatusSold
+	s.PurchaseID = &purchaseID
+	return nil
+}
+
+// Release makes a seat held by the reservation available again.
+func (s *Seat) Release(reservationID int) error {
+	if !s.isHeldBy(reservationID) {
+		return fmt.Errorf("%w: seat %d", ErrSeatNotHeld, s.ID)
+	}
+
+	s.Status = StatusAvailable
+	s.ReservationID = nil
+	return nil
+}
+
+// Return makes a seat sold with the purchase available again, e.g. when the
+// purchase is refunded.
+func (s *Seat) Return(purchaseID int) error {
+	if s.Status != StatusSold || s.PurchaseID == nil || *s.PurchaseID != purchaseID {
+		return fmt.Errorf("%w: seat %d", ErrSeatNotSold, s.ID)
+	}
+
+	s.Status = StatusAvailable
+	s.ReservationID = nil
+	s.PurchaseID = nil
+	return nil
+}
+
+func (s *Seat) isHeldBy(reservationID int) bool {
+	return s.Status == StatusHeld && s.ReservationID != nil && *s.ReservationID == reservationID
+}
+
+func (s *Seat) unavailable() error {
+	return fmt.Errorf("%w: seat %d is %s", ErrSeatUnavailable, s.ID, s.Status)
+}
+
+// CheckAvailable returns ErrSeatUnavailable for the first of seats that is
+// not available.
+func CheckAvailable(seats []*Seat) error {
+	for _, s := range seats {
+		if !s.IsAvailable() {
+			return s.unavailable()
+		}
+	}
+
+	return nil
+}
+
+// Quantity returns the number of units a request for quantity units or for
+// the seats seatIDs is for. When both are sent they have to agree.
+func Quantity(quantity int, seatIDs []int) (int, error) {
+	if len(seatIDs) == 0 {
+		return quantity, nil
+	}
+
+	if quantity != 0 && quantity != len(seatIDs) {
+		return 0, ErrQuantityMismatch
+	}
+
+	return len(seatIDs), nil
+}
+
+// Pick returns the seats with the given IDs in the order of ids.
+func Pick(seats []*Seat, ids []int) ([]*Seat, error) {
+	byID := make(map[int]*Seat, len(seats))
+	for _, s := range seats {
+		byID[s.ID] = s
+	}
+
+	picked := make([]*Seat, 0, len(ids))
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return nil, fmt.Errorf("%w: seat %d", ErrDuplicateSeat, id)
+		}
+		seen[id] = true
+
+		s, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: seat %d", ErrSeatNotFound, id)
+		}
+
+		picked = append(picked, s)
+	}
+
+	return picked, nil
+}
+
+// IDs returns the IDs of seats in their order.
+func IDs(seats []*Seat) []int {
+	ids := make([]int, 0, len(seats))
+	for _, s := range seats {
+		ids = append(ids, s.ID)
+	}
+
+	return ids
+}
@@ -0,0 +1,73 @@
+package seat_test
+
+import (
+	"testing"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/seat"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeatLifecycle(t *testing.T) {
+	s := &seat.Seat{ID: 1, Status: seat.StatusAvailable}
+
+	assert.NoError(t, s.Hold(10))
+	assert.Equal(t, seat.StatusHeld, s.Status)
+	assert.ErrorIs(t, s.Hold(11), seat.ErrSeatUnavailable)
+	assert.ErrorIs(t, s.Sell(20), seat.ErrSeatUnavailable)
+	assert.ErrorIs(t, s.Release(11), seat.ErrSeatNotHeld)
+	assert.ErrorIs(t, s.ConfirmHold(11, 20), seat.ErrSeatNotHeld)
+
+	assert.NoError(t, s.Release(10))
+	assert.True(t, s.IsAvailable())
+	assert.Nil(t, s.ReservationID)
+
+	assert.NoError(t, s.Hold(12))
+	assert.NoError(t, s.ConfirmHold(12, 20))
+	assert.Equal(t, seat.StatusSold, s.Status)
+	assert.ErrorIs(t, s.Return(21), seat.ErrSeatNotSold)
+
+	assert.NoError(t, s.Return(20))
+	assert.True(t, s.IsAvailable())
+	assert.Nil(t, s.ReservationID)
+	assert.Nil(t, s.PurchaseID)
+
+	assert.NoError(t, s.Sell(22))
+	assert.Equal(t, 22, *s.PurchaseID)
+}
+
+func TestQuantity(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity int
+		ids      []int
+		want     int
+		err      error
+	}{
+		{name: "only quantity", quantity: 3, want: 3},
+		{name: "only seats", ids: []int{1, 2}, want: 2},
+		{name: "both agree", quantity: 2, ids: []int{1, 2}, want: 2},
+		{name: "both disagree", quantity: 3, ids: []int{1, 2}, err: seat.ErrQuantityMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := seat.Quantity(tt.quantity, tt.ids)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPick(t *testing.T) {
+	seats := []*seat.Seat{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	picked, err := seat.Pick(seats, []int{3, 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 1}, seat.IDs(picked))
+
+	_, err = seat.Pick(seats, []int{1, 1})
+	assert.ErrorIs(t, err, seat.ErrDuplicateSeat)
+
+	_, err = seat.Pick(seats, []int{4})
+	assert.ErrorIs(t, err, seat.ErrSeatNotFound)
+}
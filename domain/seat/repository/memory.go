@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/seat"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+)
+
+// MemoryRepository keeps seats in memory, it has to be used together with
+// db.MemoryUnitOfWork so that rollbacks work.
+type MemoryRepository struct {
+	mu     sync.RWMutex
+	seats  map[int]seat.Seat
+	lastID int
+	now    func() time.Time
+}
+
+func NewMemorySeatRepository() SeatRepository {
+	return &MemoryRepository{seats: make(map[int]seat.Seat), now: time.Now}
+}
+
+func (r *MemoryRepository) CreateMany(ctx context.Context, seats []*seat.Seat) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	ids := make([]int, 0, len(seats))
+	for _, s := range seats {
+		r.lastID++
+		s.ID = r.lastID
+		s.CreatedAt = now
+		s.UpdatedAt = now
+		if s.Version == 0 {
+			s.Version = 1
+		}
+
+		r.seats[s.ID] = *s
+		ids = append(ids, s.ID)
+	}
+
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		for _, id := range ids {
+			delete(r.seats, id)
+		}
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (r *MemoryRepository) ListByTicket(ctx context.Context, ticketID int) ([]*seat.Seat, error) {
+	seats := r.list(func(s *seat.Seat) bool { return s.TicketID == ticketID })
+	sort.Slice(seats, func(i, j int) bool {
+		if seats[i].RowRank != seats[j].RowRank {
+			return seats[i].RowRank < seats[j].RowRank
+		}
+
+		return seats[i].Number < seats[j].Number
+	})
+
+	return seats, nil
+}
+
+func (r *MemoryRepository) ListByReservation(ctx context.Context, reservationID int) ([]*seat.Seat, error) {
+	return r.list(func(s *seat.Seat) bool { return s.ReservationID != nil && *s.ReservationID == reservationID }), nil
+}
+
+func (r *MemoryRepository) ListByPurchase(ctx context.Context, purchaseID int) ([]*seat.Seat, error) {
+	return r.list(func(s *seat.Seat) bool { return s.PurchaseID != nil && *s.PurchaseID == purchaseID }), nil
+}
+
+// list returns copies of the matching seats ordered by ID.
+func (r *MemoryRepository) list(match func(s *seat.Seat) bool) []*seat.Seat {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var seats []*seat.Seat
+	for _, stored := range r.seats {
+		s := stored
+		if match(&s) {
+			seats = append(seats, &s)
+		}
+	}
+
+	sort.Slice(seats, func(i, j int) bool { return seats[i].ID < seats[j].ID })
+	return seats
+}
+
+// Update has the same optimistic version check as Repository.Update.
+func (r *MemoryRepository) Update(ctx context.Context, s *seat.Seat) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, ok := r.seats[s.ID]
+	if !ok || previous.Version != s.Version {
+		return seat.ErrSeatConflict
+	}
+
+	s.Version++
+	s.UpdatedAt = r.now()
+	id := s.ID
+	r.seats[id] = *s
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		r.seats[id] = previous
+		r.mu.Unlock()
+	})
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/seat"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"gorm.io/gorm"
+)
+
+// createBatchSize is the number of seats inserted with one statement.
+const createBatchSize = 500
+
+//go:generate mockgen -destination=../../../mock/repository/seat/seat.go -package=repository github.com/aaydin-tr/ddd-api-example/domain/seat/repository SeatRepository
+type SeatRepository interface {
+	CreateMany(ctx context.Context, seats []*seat.Seat) error
+	// ListByTicket returns the seat map of a ticket ordered by row rank and
+	// seat number. Seats are changed together with their ticket, callers
+	// that change seats have to hold the lock of the ticket.
+	ListByTicket(ctx context.Context, ticketID int) ([]*seat.Seat, error)
+	// ListByReservation returns the seats held or sold by a reservation.
+	ListByReservation(ctx context.Context, reservationID int) ([]*seat.Seat, error)
+	// ListByPurchase returns the seats sold with a purchase that were not
+	// refunded.
+	ListByPurchase(ctx context.Context, purchaseID int) ([]*seat.Seat, error)
+	// Update writes the seat only if its version is still the one that was
+	// read, ErrSeatConflict is returned otherwise.
+	Update(ctx context.Context, s *seat.Seat) error
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewSeatRepository(db *gorm.DB) SeatRepository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) CreateMany(ctx context.Context, seats []*seat.Seat) error {
+	return db.Conn(ctx, r.db).CreateInBatches(seats, createBatchSize).Error
+}
+
+func (r *Repository) ListByTicket(ctx context.Context, ticketID int) ([]*seat.Seat, error) {
+	var seats []*seat.Seat
+	err := db.Conn(ctx, r.db).Where("ticket_id = ?", ticketID).Order("row_rank").Order("number").Find(&seats).Error
+	return seats, err
+}
+
+func (r *Repository) ListByReservation(ctx context.Context, reservationID int) ([]*seat.Seat, error) {
+	var seats []*seat.Seat
+	err := db.Conn(ctx, r.db).Where("reservation_id = ?", reservationID).Order("id").Find(&seats).Error
+	return seats, err
+}
+
+func (r *Repository) ListByPurchase(ctx context.Context, purchaseID int) ([]*seat.Seat, error) {
+	var seats []*seat.Seat
+	err := db.Conn(ctx, r.db).Where("purchase_id = ?", purchaseID).Order("id").Find(&seats).Error
+	return seats, err
+}
+
+func (r *Repository) Update(ctx context.Context, s *seat.Seat) error {
+	version := s.Version
+	s.Version++
+
+	result := db.Conn(ctx, r.db).Model(s).Select("*").Where("version = ?", version).Updates(s)
+	if result.Error != nil {
+		s.Version = version
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		s.Version = version
+		return seat.ErrSeatConflict
+	}
+
+	return nil
+}
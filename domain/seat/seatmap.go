@@ -0,0 +1,163 @@
+package seat
+
+import (
+	"sort"
+	"strings"
+)
+
+// Section is a part of the seat map, e.g. the stalls or a balcony. Sections
+// and their rows are listed from best to worst.
+type Section struct {
+	Name string
+	Rows []Row
+}
+
+// Row has Seats seats numbered from 1.
+type Row struct {
+	Name  string
+	Seats int
+}
+
+// NewSeatMap lays out the seats of a ticket, all of them are available.
+func NewSeatMap(ticketID int, sections []Section) ([]*Seat, error) {
+	if len(sections) == 0 {
+		return nil, ErrSeatMapIsEmpty
+	}
+
+	var (
+		seats []*Seat
+		rank  int
+		names = make(map[string]bool)
+	)
+	for _, section := range sections {
+		name := strings.TrimSpace(section.Name)
+		if name == "" {
+			return nil, ErrSectionIsRequired
+		}
+
+		if names[name] {
+			return nil, ErrDuplicateSection
+		}
+		names[name] = true
+
+		rows := make(map[string]bool)
+		for _, row := range section.Rows {
+			rowName := strings.TrimSpace(row.Name)
+			if rowName == "" {
+				return nil, ErrRowIsRequired
+			}
+
+			if rows[rowName] {
+				return nil, ErrDuplicateRow
+			}
+			rows[rowName] = true
+
+			if row.Seats <= 0 {
+				return nil, ErrInvalidRowSize
+			}
+
+			if len(seats)+row.Seats > MaxSeats {
+				return nil, ErrSeatMapTooLarge
+			}
+
+			for number := 1; number <= row.Seats; number++ {
+				seats = append(seats, &Seat{
+					TicketID: ticketID,
+					Section:  name,
+					Row:      rowName,
+					Number:   number,
+					RowRank:  rank,
+					Status:   StatusAvailable,
+					Version:  1,
+				})
+			}
+			rank++
+		}
+	}
+
+	if len(seats) == 0 {
+		return nil, ErrSeatMapIsEmpty
+	}
+
+	return seats, nil
+}
+
+// Select returns the seats ids out of the seat map seats, or the best
+// available quantity seats when no ids are given.
+func Select(seats []*Seat, ids []int, quantity int) ([]*Seat, error) {
+	if len(ids) == 0 {
+		return BestAvailable(seats, quantity)
+	}
+
+	return Pick(seats, ids)
+}
+
+// BestAvailable picks quantity available seats that are next to each other
+// in the same row. The best row is the one with the lowest rank that has
+// enough adjacent seats, within it the seats closest to the middle of the
+// row are picked.
+func BestAvailable(seats []*Seat, quantity int) ([]*Seat, error) {
+	if quantity <= 0 {
+		return nil, ErrInvalidQuantity
+	}
+
+	rows := make(map[int][]*Seat)
+	var ranks []int
+	for _, s := range seats {
+		if _, ok := rows[s.RowRank]; !ok {
+			ranks = append(ranks, s.RowRank)
+		}
+		rows[s.RowRank] = append(rows[s.RowRank], s)
+	}
+	sort.Ints(ranks)
+
+	for _, rank := range ranks {
+		if picked := bestInRow(rows[rank], quantity); picked != nil {
+			return picked, nil
+		}
+	}
+
+	return nil, ErrNoAdjacentSeats
+}
+
+// bestInRow returns the block of quantity adjacent available seats whose
+// middle is closest to the middle of the row, or nil.
+func bestInRow(row []*Seat, quantity int) []*Seat {
+	sorted := make([]*Seat, len(row))
+	copy(sorted, row)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Number < sorted[j].Number })
+
+	// Distances are doubled to stay in integers.
+	middle := sorted[0].Number + sorted[len(sorted)-1].Number
+	var (
+		best     []*Seat
+		bestDist int
+	)
+	start := 0
+	for i, s := range sorted {
+		if !s.IsAvailable() {
+			start = i + 1
+			continue
+		}
+
+		if i > start && s.Number != sorted[i-1].Number+1 {
+			start = i
+		}
+
+		if i-start+1 < quantity {
+			continue
+		}
+
+		block := sorted[i-quantity+1 : i+1]
+		dist := block[0].Number + block[len(block)-1].Number - middle
+		if dist < 0 {
+			dist = -dist
+		}
+
+		if best == nil || dist < bestDist {
+			best, bestDist = block, dist
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,130 @@
+package seat_test
+
+import (
+	"testing"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/seat"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSeatMap(t *testing.T) {
+	seats, err := seat.NewSeatMap(1, []seat.Section{
+		{Name: "Stalls", Rows: []seat.Row{{Name: "A", Seats: 2}, {Name: "B", Seats: 3}}},
+		{Name: " Balcony ", Rows: []seat.Row{{Name: "A", Seats: 1}}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, seats, 6)
+	assert.Equal(t, seat.Seat{TicketID: 1, Section: "Stalls", Row: "B", Number: 3, RowRank: 1, Status: seat.StatusAvailable, Version: 1}, *seats[4])
+	assert.Equal(t, "Balcony", seats[5].Section)
+	assert.Equal(t, 2, seats[5].RowRank)
+
+	tests := []struct {
+		name     string
+		sections []seat.Section
+		err      error
+	}{
+		{name: "no sections", err: seat.ErrSeatMapIsEmpty},
+		{name: "no rows", sections: []seat.Section{{Name: "Stalls"}}, err: seat.ErrSeatMapIsEmpty},
+		{name: "blank section", sections: []seat.Section{{Name: " ", Rows: []seat.Row{{Name: "A", Seats: 1}}}}, err: seat.ErrSectionIsRequired},
+		{name: "blank row", sections: []seat.Section{{Name: "Stalls", Rows: []seat.Row{{Name: "", Seats: 1}}}}, err: seat.ErrRowIsRequired},
+		{name: "empty row", sections: []seat.Section{{Name: "Stalls", Rows: []seat.Row{{Name: "A"}}}}, err: seat.ErrInvalidRowSize},
+		{
+			name: "duplicate section",
+			sections: []seat.Section{
+				{Name: "Stalls", Rows: []seat.Row{{Name: "A", Seats: 1}}},
+				{Name: "Stalls", Rows: []seat.Row{{Name: "B", Seats: 1}}},
+			},
+			err: seat.ErrDuplicateSection,
+		},
+		{name: "duplicate row", sections: []seat.Section{{Name: "Stalls", Rows: []seat.Row{{Name: "A", Seats: 1}, {Name: "A", Seats: 1}}}}, err: seat.ErrDuplicateRow},
+		{name: "too large", sections: []seat.Section{{Name: "Stalls", Rows: []seat.Row{{Name: "A", Seats: seat.MaxSeats}, {Name: "B", Seats: 1}}}}, err: seat.ErrSeatMapTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := seat.NewSeatMap(1, tt.sections)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestBestAvailable(t *testing.T) {
+	layout := func(sold ...int) []*seat.Seat {
+		seats, _ := seat.NewSeatMap(1, []seat.Section{
+			{Name: "Stalls", Rows: []seat.Row{{Name: "A", Seats: 6}, {Name: "B", Seats: 8}}},
+		})
+		for i, s := range seats {
+			s.ID = i + 1
+		}
+		for _, id := range sold {
+			seats[id-1].Status = seat.StatusSold
+		}
+
+		return seats
+	}
+
+	numbers := func(seats []*seat.Seat) []string {
+		var got []string
+		for _, s := range seats {
+			got = append(got, s.Row+string(rune('0'+s.Number)))
+		}
+
+		return got
+	}
+
+	tests := []struct {
+		name     string
+		sold     []int
+		quantity int
+		want     []string
+		err      error
+	}{
+		{name: "middle of the front row", quantity: 2, want: []string{"A3", "A4"}},
+		{name: "whole front row", quantity: 6, want: []string{"A1", "A2", "A3", "A4", "A5", "A6"}},
+		{name: "around a sold seat", sold: []int{3}, quantity: 2, want: []string{"A4", "A5"}},
+		{name: "after a gap", sold: []int{2, 3}, quantity: 3, want: []string{"A4", "A5", "A6"}},
+		{name: "longest block of a split row", sold: []int{3}, quantity: 3, want: []string{"A4", "A5", "A6"}},
+		{name: "next row when the front row is too short", sold: []int{4}, quantity: 4, want: []string{"B3", "B4", "B5", "B6"}},
+		{name: "no block is large enough", quantity: 9, err: seat.ErrNoAdjacentSeats},
+		{name: "invalid quantity", quantity: 0, err: seat.ErrInvalidQuantity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := seat.BestAvailable(layout(tt.sold...), tt.quantity)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, numbers(got))
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	seats, _ := seat.NewSeatMap(1, []seat.Section{{Name: "Stalls", Rows: []seat.Row{{Name: "A", Seats: 3}}}})
+	for i, s := range seats {
+		s.ID = i + 1
+	}
+
+	picked, err := seat.Select(seats, []int{1}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, seat.IDs(picked))
+
+	picked, err = seat.Select(seats, nil, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2}, seat.IDs(picked))
+}
+
+func TestNewSeatMapDTOFromEntities(t *testing.T) {
+	seats, _ := seat.NewSeatMap(1, []seat.Section{
+		{Name: "Stalls", Rows: []seat.Row{{Name: "A", Seats: 2}, {Name: "B", Seats: 1}}},
+		{Name: "Balcony", Rows: []seat.Row{{Name: "A", Seats: 1}}},
+	})
+	seats[0].Status = seat.StatusSold
+
+	m := seat.NewSeatMapDTOFromEntities(1, seats)
+	assert.Equal(t, 3, m.Available)
+	assert.Len(t, m.Sections, 2)
+	assert.Len(t, m.Sections[0].Rows, 2)
+	assert.Len(t, m.Sections[0].Rows[0].Seats, 2)
+	assert.Equal(t, "sold", m.Sections[0].Rows[0].Seats[0].Status)
+	assert.Equal(t, "A", m.Sections[1].Rows[0].Name)
+}
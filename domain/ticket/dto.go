@@ -1,17 +1,123 @@
 package ticket
 
+import (
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+)
+
 type TicketDTO struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Allocation  int    `json:"allocation"`
+	ID           int                   `json:"id"`
+	EventID      *int                  `json:"event_id"`
+	Name         string                `json:"name"`
+	Description  string                `json:"description"`
+	Allocation   int                   `json:"allocation"`
+	Sold         int                   `json:"sold"`
+	Held         int                   `json:"held"`
+	Price        *valueobject.MoneyDTO `json:"price"`
+	MaxPerUser   *int                  `json:"max_per_user"`
+	SalesStartAt *time.Time            `json:"sales_start_at"`
+	SalesEndAt   *time.Time            `json:"sales_end_at"`
+	SaleStatus   string                `json:"sale_status" enums:"upcoming,on_sale,sold_out,closed"`
+	Status       string                `json:"status" enums:"draft,published,paused,archived"`
+	Seating      string                `json:"seating" enums:"general,assigned"`
+	Version      int                   `json:"version"`
 } // @Name TicketDTO
 
-func NewTicketDTOFromEntity(ticket *Ticket) *TicketDTO {
+// NewTicketDTOFromEntity computes the sale status of the ticket at now.
+func NewTicketDTOFromEntity(ticket *Ticket, now time.Time) *TicketDTO {
 	return &TicketDTO{
-		ID:          ticket.ID,
-		Name:        ticket.Name.GetValue(),
-		Description: ticket.Description.GetValue(),
-		Allocation:  ticket.Allocation.GetValue(),
+		ID:           ticket.ID,
+		EventID:      ticket.EventID,
+		Name:         ticket.Name.GetValue(),
+		Description:  ticket.Description.GetValue(),
+		Allocation:   ticket.Allocation.GetValue(),
+		Sold:         ticket.Sold,
+		Held:         ticket.Held,
+		Price:        valueobject.NewMoneyDTO(ticket.Price),
+		MaxPerUser:   ticket.MaxPerUser,
+		SalesStartAt: ticket.SalesStartAt,
+		SalesEndAt:   ticket.SalesEndAt,
+		SaleStatus:   string(ticket.SaleStatus(now)),
+		Status:       string(ticket.Status),
+		Seating:      string(ticket.Seating),
+		Version:      ticket.Version,
+	}
+}
+
+type TicketListDTO struct {
+	Items      []*TicketDTO `json:"items"`
+	Total      int64        `json:"total"`
+	NextCursor string       `json:"next_cursor"`
+} // @Name TicketListDTO
+
+func NewTicketListDTOFromEntities(tickets []*Ticket, total int64, nextCursor string, now time.Time) *TicketListDTO {
+	items := make([]*TicketDTO, 0, len(tickets))
+	for _, t := range tickets {
+		items = append(items, NewTicketDTOFromEntity(t, now))
+	}
+
+	return &TicketListDTO{
+		Items:      items,
+		Total:      total,
+		NextCursor: nextCursor,
+	}
+}
+
+type LedgerEntryDTO struct {
+	ID         int64     `json:"id"`
+	Delta      int       `json:"delta"`
+	Allocation int       `json:"allocation"`
+	Reason     string    `json:"reason"`
+	ActorID    string    `json:"actor_id"`
+	RequestID  string    `json:"request_id"`
+	CreatedAt  time.Time `json:"created_at"`
+} // @Name LedgerEntryDTO
+
+func NewLedgerEntryDTOFromEntity(e *LedgerEntry) *LedgerEntryDTO {
+	return &LedgerEntryDTO{
+		ID:         e.ID,
+		Delta:      e.Delta,
+		Allocation: e.Allocation,
+		Reason:     string(e.Reason),
+		ActorID:    e.ActorID,
+		RequestID:  e.RequestID,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+type LedgerDTO struct {
+	TicketID   int               `json:"ticket_id"`
+	Items      []*LedgerEntryDTO `json:"items"`
+	Total      int64             `json:"total"`
+	NextCursor string            `json:"next_cursor"`
+} // @Name LedgerDTO
+
+func NewLedgerDTOFromEntities(ticketID int, entries []*LedgerEntry, total int64, nextCursor string) *LedgerDTO {
+	items := make([]*LedgerEntryDTO, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, NewLedgerEntryDTOFromEntity(e))
+	}
+
+	return &LedgerDTO{
+		TicketID:   ticketID,
+		Items:      items,
+		Total:      total,
+		NextCursor: nextCursor,
 	}
 }
+
+// LedgerMismatchDTO is a ticket whose ledger does not replay to its current
+// allocation.
+type LedgerMismatchDTO struct {
+	TicketID         int    `json:"ticket_id"`
+	Allocation       int    `json:"allocation"`
+	LedgerAllocation int    `json:"ledger_allocation"`
+	Entries          int    `json:"entries"`
+	Error            string `json:"error"`
+} // @Name LedgerMismatchDTO
+
+type LedgerCheckDTO struct {
+	Checked    int                  `json:"checked"`
+	Mismatches []*LedgerMismatchDTO `json:"mismatches"`
+} // @Name LedgerCheckDTO
@@ -2,6 +2,7 @@ package ticket
 
 import (
 	"testing"
+	"time"
 
 	"github.com/aaydin-tr/ddd-api-example/valueobject"
 	"github.com/stretchr/testify/assert"
@@ -11,11 +12,16 @@ func TestNewTicketDTOFromEntity(t *testing.T) {
 	name, _ := valueobject.NewName("Test Ticket")
 	description, _ := valueobject.NewDescription("This is a test ticket")
 	allocation, _ := valueobject.NewAllocation(100)
+	price, _ := valueobject.NewMoney(1500, "EUR")
 	ticket := &Ticket{
 		ID:          1,
 		Name:        name,
 		Description: description,
 		Allocation:  allocation,
+		Sold:        5,
+		Held:        2,
+		Price:       price,
+		Version:     3,
 	}
 
 	expected := &TicketDTO{
@@ -23,8 +29,34 @@ func TestNewTicketDTOFromEntity(t *testing.T) {
 		Name:        name.GetValue(),
 		Description: description.GetValue(),
 		Allocation:  allocation.GetValue(),
+		Sold:        5,
+		Held:        2,
+		Price:       &valueobject.MoneyDTO{Amount: 1500, Currency: "EUR"},
+		SaleStatus:  "on_sale",
+		Version:     3,
 	}
 
-	result := NewTicketDTOFromEntity(ticket)
+	result := NewTicketDTOFromEntity(ticket, time.Now())
 	assert.Equal(t, expected, result)
 }
+
+func TestNewTicketListDTOFromEntities(t *testing.T) {
+	name, _ := valueobject.NewName("Test Ticket")
+	description, _ := valueobject.NewDescription("This is a test ticket")
+	allocation, _ := valueobject.NewAllocation(100)
+	tickets := []*Ticket{
+		{ID: 1, Name: name, Description: description, Allocation: allocation},
+		{ID: 2, Name: name, Description: description, Allocation: allocation},
+	}
+
+	result := NewTicketListDTOFromEntities(tickets, 10, "cursor", time.Now())
+	assert.Len(t, result.Items, 2)
+	assert.Equal(t, 1, result.Items[0].ID)
+	assert.Equal(t, 2, result.Items[1].ID)
+	assert.Equal(t, int64(10), result.Total)
+	assert.Equal(t, "cursor", result.NextCursor)
+
+	empty := NewTicketListDTOFromEntities(nil, 0, "", time.Now())
+	assert.NotNil(t, empty.Items)
+	assert.Empty(t, empty.Items)
+}
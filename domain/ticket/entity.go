@@ -3,35 +3,137 @@ package ticket
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
+	"github.com/aaydin-tr/ddd-api-example/pkg/eventbus"
 	"github.com/aaydin-tr/ddd-api-example/valueobject"
 	"gorm.io/gorm"
 )
 
 var (
 	ErrInsufficientAllocation = errors.New("insufficient allocation")
+	ErrReturnExceedsSold      = errors.New("cannot return more units than sold")
 	ErrAllocationIsZero       = errors.New("allocation is zero")
 	ErrNameIsRequired         = errors.New("name is required")
 	ErrDescriptionIsRequired  = errors.New("description is required")
 	ErrTicketNotFound         = errors.New("ticket not found")
+	ErrAllocationBelowSold    = errors.New("allocation cannot be lower than sold and held quantity")
+	ErrTicketNotDeleted       = errors.New("ticket is not deleted")
+	ErrInsufficientHeld       = errors.New("insufficient held quantity")
+	ErrPriceIsRequired        = errors.New("price is required")
+	ErrVersionMismatch        = errors.New("ticket version does not match")
+	ErrVersionConflict        = errors.New("ticket was modified concurrently")
+	ErrInvalidMaxPerUser      = errors.New("max per user must be greater than zero")
+	ErrPurchaseLimitExceeded  = errors.New("purchase limit per user exceeded")
+	ErrTicketHasHolds         = errors.New("ticket has held units")
 )
 
+// PurchaseLimitError is returned when a user would own more units of a
+// ticket than its MaxPerUser allows, it matches ErrPurchaseLimitExceeded.
+type PurchaseLimitError struct {
+	Limit     int
+	Remaining int
+}
+
+func (e *PurchaseLimitError) Error() string {
+	return fmt.Sprintf("%s: limit is %d, %d remaining", ErrPurchaseLimitExceeded, e.Limit, e.Remaining)
+}
+
+func (e *PurchaseLimitError) Is(target error) bool {
+	return target == ErrPurchaseLimitExceeded
+}
+
 type Ticket struct {
-	ID          int                      `json:"id" gorm:"primaryKey;autoIncrement"`
-	Name        *valueobject.Name        `json:"name" gorm:"not null;type:varchar(255)"`
-	Description *valueobject.Description `json:"description" gorm:"not null;type:varchar(255)"`
-	Allocation  *valueobject.Allocation  `json:"allocation" gorm:"not null;type:int;default:0"`
-	CreatedAt   time.Time                `json:"created_at" gorm:"not null;default:current_timestamp"`
-	UpdatedAt   time.Time                `json:"updated_at" gorm:"not null;default:current_timestamp;autoUpdateTime"`
-	DeletedAt   gorm.DeletedAt           `json:"deleted_at" gorm:"index"`
+	ID           int                      `json:"id" gorm:"primaryKey;autoIncrement"`
+	EventID      *int                     `json:"event_id" gorm:"index"`
+	Name         *valueobject.Name        `json:"name" gorm:"not null;type:varchar(255)"`
+	Description  *valueobject.Description `json:"description" gorm:"not null;type:varchar(255)"`
+	Allocation   *valueobject.Allocation  `json:"allocation" gorm:"not null;type:int;default:0"`
+	Sold         int                      `json:"sold" gorm:"not null;type:int;default:0"`
+	Held         int                      `json:"held" gorm:"not null;type:int;default:0"`
+	Price        *valueobject.Money       `json:"price" gorm:"not null;type:varchar(32);default:'0 XXX'"`
+	MaxPerUser   *int                     `json:"max_per_user" gorm:"type:int"`
+	SalesStartAt *time.Time               `json:"sales_start_at"`
+	SalesEndAt   *time.Time               `json:"sales_end_at"`
+	Status       Status                   `json:"status" gorm:"not null;type:varchar(32);default:'draft'"`
+	Seating      Seating                  `json:"seating" gorm:"not null;type:varchar(32);default:'general'"`
+	Version      int                      `json:"version" gorm:"not null;type:int;default:1"`
+	CreatedAt    time.Time                `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt    time.Time                `json:"updated_at" gorm:"not null;default:current_timestamp;autoUpdateTime"`
+	DeletedAt    gorm.DeletedAt           `json:"deleted_at" gorm:"index"`
+
+	events []eventbus.Event
+	ledger []*LedgerEntry
 }
 
 func (t *Ticket) TableName() string {
 	return "tickets"
 }
 
-func (t *Ticket) DecrementAllocation(ctx context.Context, amount int) error {
+// DecrementAllocation sells amount units, now has to be within the sales
+// window.
+func (t *Ticket) DecrementAllocation(ctx context.Context, amount int, now time.Time) error {
+	if !t.IsPublished() {
+		return ErrTicketNotPublished
+	}
+
+	if err := t.CheckSalesWindow(now); err != nil {
+		return err
+	}
+
+	if t.Allocation.GetValue() == 0 || t.Allocation.GetValue() < amount {
+		return ErrInsufficientAllocation
+	}
+
+	newAllocation, err := valueobject.NewAllocation(t.Allocation.GetValue() - amount)
+	if err != nil {
+		return err
+	}
+
+	t.Allocation = newAllocation
+	t.Sold += amount
+	t.recordDecrement(amount, now)
+	t.recordLedger(-amount, ReasonPurchase, now)
+	return nil
+}
+
+// ReturnAllocation is the inverse of DecrementAllocation, it puts sold units
+// back to the allocation, e.g. when a purchase is refunded.
+func (t *Ticket) ReturnAllocation(ctx context.Context, amount int, now time.Time) error {
+	if err := t.checkNotArchived(); err != nil {
+		return err
+	}
+
+	if amount <= 0 || t.Sold < amount {
+		return ErrReturnExceedsSold
+	}
+
+	newAllocation, err := valueobject.NewAllocation(t.Allocation.GetValue() + amount)
+	if err != nil {
+		return err
+	}
+
+	t.Allocation = newAllocation
+	t.Sold -= amount
+	t.record(AllocationRestored{TicketID: t.ID, Quantity: amount, Remaining: newAllocation.GetValue(), At: now})
+	t.recordLedger(amount, ReasonRefund, now)
+	return nil
+}
+
+// Hold moves units from the allocation to the held quantity so that they
+// can not be sold to anyone else until the hold is confirmed or released.
+// Holds can only be made within the sales window, a hold made in time can be
+// confirmed after the window closed.
+func (t *Ticket) Hold(ctx context.Context, amount int, now time.Time) error {
+	if !t.IsPublished() {
+		return ErrTicketNotPublished
+	}
+
+	if err := t.CheckSalesWindow(now); err != nil {
+		return err
+	}
+
 	if t.Allocation.GetValue() == 0 || t.Allocation.GetValue() < amount {
 		return ErrInsufficientAllocation
 	}
@@ -42,10 +144,257 @@ func (t *Ticket) DecrementAllocation(ctx context.Context, amount int) error {
 	}
 
 	t.Allocation = newAllocation
+	t.Held += amount
+	t.recordDecrement(amount, now)
+	t.recordLedger(-amount, ReasonHold, now)
 	return nil
 }
 
-func NewTicket(name string, description string, allocation int) (*Ticket, error) {
+func (t *Ticket) ConfirmHold(ctx context.Context, amount int) error {
+	if t.Held < amount {
+		return ErrInsufficientHeld
+	}
+
+	t.Held -= amount
+	t.Sold += amount
+	return nil
+}
+
+func (t *Ticket) ReleaseHold(ctx context.Context, amount int, now time.Time) error {
+	if t.Held < amount {
+		return ErrInsufficientHeld
+	}
+
+	newAllocation, err := valueobject.NewAllocation(t.Allocation.GetValue() + amount)
+	if err != nil {
+		return err
+	}
+
+	t.Allocation = newAllocation
+	t.Held -= amount
+	t.record(AllocationRestored{TicketID: t.ID, Quantity: amount, Remaining: newAllocation.GetValue(), At: now})
+	t.recordLedger(amount, ReasonRelease, now)
+	return nil
+}
+
+func (t *Ticket) Rename(name string) error {
+	if err := t.checkNotArchived(); err != nil {
+		return err
+	}
+
+	ticketName, err := valueobject.NewName(name)
+	if err != nil {
+		return err
+	}
+
+	t.Name = ticketName
+	return nil
+}
+
+func (t *Ticket) ChangeDescription(description string) error {
+	if err := t.checkNotArchived(); err != nil {
+		return err
+	}
+
+	ticketDescription, err := valueobject.NewDescription(description)
+	if err != nil {
+		return err
+	}
+
+	t.Description = ticketDescription
+	return nil
+}
+
+// Total is the number of units of the ticket, whether they are still
+// available, sold or held.
+func (t *Ticket) Total() int {
+	return t.Allocation.GetValue() + t.Sold + t.Held
+}
+
+// ChangeAllocation sets the total number of units for the ticket. Units that
+// are already sold or held are kept, so the remaining allocation becomes
+// total - sold - held. Tickets with assigned seating keep the allocation of
+// their seat map. Raising the allocation records AllocationRestored.
+func (t *Ticket) ChangeAllocation(total int, now time.Time) error {
+	if err := t.checkNotArchived(); err != nil {
+		return err
+	}
+
+	if t.IsSeated() {
+		return ErrAssignedSeating
+	}
+
+	if total < t.Sold+t.Held {
+		return ErrAllocationBelowSold
+	}
+
+	newAllocation, err := valueobject.NewAllocation(total - t.Sold - t.Held)
+	if err != nil {
+		return err
+	}
+
+	delta := newAllocation.GetValue() - t.Allocation.GetValue()
+	t.Allocation = newAllocation
+	if delta > 0 {
+		t.record(AllocationRestored{TicketID: t.ID, Quantity: delta, Remaining: newAllocation.GetValue(), At: now})
+	}
+
+	if delta != 0 {
+		t.recordLedger(delta, ReasonAdjust, now)
+	}
+
+	return nil
+}
+
+// ChangePrice only affects future purchases, purchases keep the unit price
+// they were made with.
+func (t *Ticket) ChangePrice(amount int64, currency string) error {
+	if err := t.checkNotArchived(); err != nil {
+		return err
+	}
+
+	price, err := valueobject.NewMoney(amount, currency)
+	if err != nil {
+		return err
+	}
+
+	t.Price = price
+	return nil
+}
+
+// ChangeMaxPerUser limits the number of units a single user can own, 0
+// removes the limit. Units a user already owns are kept when the limit is
+// lowered.
+func (t *Ticket) ChangeMaxPerUser(max int) error {
+	if err := t.checkNotArchived(); err != nil {
+		return err
+	}
+
+	if max < 0 {
+		return ErrInvalidMaxPerUser
+	}
+
+	if max == 0 {
+		t.MaxPerUser = nil
+		return nil
+	}
+
+	t.MaxPerUser = &max
+	return nil
+}
+
+func (t *Ticket) HasPurchaseLimit() bool {
+	return t.MaxPerUser != nil
+}
+
+// CheckPurchaseLimit checks that a user who already owns owned units of the
+// ticket can get quantity more.
+func (t *Ticket) CheckPurchaseLimit(owned, quantity int) error {
+	if !t.HasPurchaseLimit() {
+		return nil
+	}
+
+	remaining := max(*t.MaxPerUser-owned, 0)
+	if quantity > remaining {
+		return &PurchaseLimitError{Limit: *t.MaxPerUser, Remaining: remaining}
+	}
+
+	return nil
+}
+
+// MatchVersion checks the version a client expects the ticket to have, a nil
+// version matches any version.
+func (t *Ticket) MatchVersion(version *int) error {
+	if version != nil && *version != t.Version {
+		return ErrVersionMismatch
+	}
+
+	return nil
+}
+
+// PullEvents returns the events recorded since the last call and forgets
+// them, so that every event is published once.
+func (t *Ticket) PullEvents() []eventbus.Event {
+	events := make([]eventbus.Event, 0, len(t.events))
+	for _, e := range t.events {
+		switch recorded := e.(type) {
+		case TicketCreated:
+			if recorded.TicketID == 0 {
+				recorded.TicketID = t.ID
+				e = recorded
+			}
+		case TicketStatusChanged:
+			if recorded.TicketID == 0 {
+				recorded.TicketID = t.ID
+				e = recorded
+			}
+		}
+
+		events = append(events, e)
+	}
+
+	t.events = nil
+	return events
+}
+
+// PullLedger returns the ledger entries recorded since the last call and
+// forgets them. The repository writes them together with the ticket.
+func (t *Ticket) PullLedger() []*LedgerEntry {
+	entries := t.ledger
+	for _, e := range entries {
+		e.TicketID = t.ID
+	}
+
+	t.ledger = nil
+	return entries
+}
+
+func (t *Ticket) recordLedger(delta int, reason LedgerReason, now time.Time) {
+	t.ledger = append(t.ledger, &LedgerEntry{
+		TicketID:   t.ID,
+		Delta:      delta,
+		Allocation: t.Allocation.GetValue(),
+		Reason:     reason,
+		CreatedAt:  now,
+	})
+}
+
+func (t *Ticket) record(e eventbus.Event) {
+	t.events = append(t.events, e)
+}
+
+func (t *Ticket) recordDecrement(amount int, now time.Time) {
+	remaining := t.Allocation.GetValue()
+	t.record(AllocationDecremented{TicketID: t.ID, Quantity: amount, Remaining: remaining, At: now})
+	if remaining == 0 {
+		t.record(TicketSoldOut{TicketID: t.ID, At: now})
+	}
+}
+
+func (t *Ticket) IsDeleted() bool {
+	return t.DeletedAt.Valid
+}
+
+// CheckNoHolds fails with ErrTicketHasHolds while units of the ticket are
+// held by reservations, they have to be confirmed, cancelled or expire first.
+func (t *Ticket) CheckNoHolds() error {
+	if t.Held > 0 {
+		return ErrTicketHasHolds
+	}
+
+	return nil
+}
+
+func (t *Ticket) Restore() error {
+	if !t.IsDeleted() {
+		return ErrTicketNotDeleted
+	}
+
+	t.DeletedAt = gorm.DeletedAt{}
+	return nil
+}
+
+func NewTicket(name string, description string, allocation int, price int64, currency string, now time.Time) (*Ticket, error) {
 	ticketName, err := valueobject.NewName(name)
 	if err != nil {
 		return nil, err
@@ -61,9 +410,22 @@ func NewTicket(name string, description string, allocation int) (*Ticket, error)
 		return nil, err
 	}
 
-	return &Ticket{
+	ticketPrice, err := valueobject.NewMoney(price, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Ticket{
 		Name:        ticketName,
 		Description: ticketDescription,
 		Allocation:  ticketAllocation,
-	}, nil
+		Price:       ticketPrice,
+		Status:      StatusDraft,
+		Seating:     SeatingGeneral,
+		Version:     1,
+	}
+	t.record(TicketCreated{Name: ticketName.GetValue(), Allocation: ticketAllocation.GetValue(), Price: valueobject.NewMoneyDTO(ticketPrice), At: now})
+	t.recordLedger(ticketAllocation.GetValue(), ReasonCreate, now)
+
+	return t, nil
 }
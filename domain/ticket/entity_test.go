@@ -3,61 +3,103 @@ package ticket_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
 func TestDecrementAllocation(t *testing.T) {
 	ctx := context.Background()
 	t.Run("should decrement allocation successfully", func(t *testing.T) {
 		firstAllocation := 10
-		tk, err := ticket.NewTicket("Test Ticket", "Test Description", firstAllocation)
+		tk, err := ticket.NewTicket("Test Ticket", "Test Description", firstAllocation, 1500, "EUR", time.Now())
 		assert.NoError(t, err)
+		assert.NoError(t, tk.Publish(time.Now()))
 
 		decrementAmount := 5
-		err = tk.DecrementAllocation(ctx, decrementAmount)
+		err = tk.DecrementAllocation(ctx, decrementAmount, time.Now())
 		assert.NoError(t, err)
 		assert.Equal(t, firstAllocation-decrementAmount, tk.Allocation.GetValue())
+		assert.Equal(t, decrementAmount, tk.Sold)
 	})
 
 	t.Run("should return error when new allocation is invalid", func(t *testing.T) {
 		firstAllocation := 10
-		tk, err := ticket.NewTicket("Test Ticket", "Test Description", firstAllocation)
+		tk, err := ticket.NewTicket("Test Ticket", "Test Description", firstAllocation, 1500, "EUR", time.Now())
 		assert.NoError(t, err)
+		assert.NoError(t, tk.Publish(time.Now()))
 
 		decrementAmount := 15
-		err = tk.DecrementAllocation(ctx, decrementAmount)
+		err = tk.DecrementAllocation(ctx, decrementAmount, time.Now())
 		assert.Error(t, err)
 		assert.Equal(t, firstAllocation, tk.Allocation.GetValue())
+		assert.Equal(t, 0, tk.Sold)
 	})
 
 	t.Run("should return error when allocation hits zero", func(t *testing.T) {
 		firstAllocation := 10
-		tk, err := ticket.NewTicket("Test Ticket", "Test Description", firstAllocation)
+		tk, err := ticket.NewTicket("Test Ticket", "Test Description", firstAllocation, 1500, "EUR", time.Now())
 		assert.NoError(t, err)
+		assert.NoError(t, tk.Publish(time.Now()))
 
 		decrementAmount := 10
-		err = tk.DecrementAllocation(ctx, decrementAmount)
+		err = tk.DecrementAllocation(ctx, decrementAmount, time.Now())
 		assert.NoError(t, err)
 		assert.Equal(t, 0, tk.Allocation.GetValue())
 
-		err = tk.DecrementAllocation(ctx, 1)
+		err = tk.DecrementAllocation(ctx, 1, time.Now())
 		assert.Error(t, err)
 	})
 }
+func TestReturnAllocation(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		amount         int
+		wantAllocation int
+		wantSold       int
+		wantErr        error
+	}{
+		{name: "should return part of the sold units", amount: 2, wantAllocation: 8, wantSold: 2},
+		{name: "should return every sold unit", amount: 4, wantAllocation: 10, wantSold: 0},
+		{name: "should reject more than sold", amount: 5, wantAllocation: 6, wantSold: 4, wantErr: ticket.ErrReturnExceedsSold},
+		{name: "should reject zero amount", amount: 0, wantAllocation: 6, wantSold: 4, wantErr: ticket.ErrReturnExceedsSold},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tk, err := ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+			assert.NoError(t, err)
+			assert.NoError(t, tk.Publish(time.Now()))
+			assert.NoError(t, tk.DecrementAllocation(ctx, 4, time.Now()))
+
+			err = tk.ReturnAllocation(ctx, tt.amount, time.Now())
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantAllocation, tk.Allocation.GetValue())
+			assert.Equal(t, tt.wantSold, tk.Sold)
+		})
+	}
+}
+
 func TestNewTicket(t *testing.T) {
 	t.Run("should create a new ticket successfully", func(t *testing.T) {
 		name := "Test Ticket"
 		description := "Test Description"
 		allocation := 10
 
-		tk, err := ticket.NewTicket(name, description, allocation)
+		tk, err := ticket.NewTicket(name, description, allocation, 1500, "EUR", time.Now())
 		assert.NoError(t, err)
 		assert.NotNil(t, tk)
 		assert.Equal(t, name, tk.Name.GetValue())
 		assert.Equal(t, description, tk.Description.GetValue())
 		assert.Equal(t, allocation, tk.Allocation.GetValue())
+		assert.Equal(t, int64(1500), tk.Price.GetAmount())
+		assert.Equal(t, "EUR", tk.Price.GetCurrency())
+		assert.Equal(t, 1, tk.Version)
 	})
 
 	t.Run("should return error when name is invalid", func(t *testing.T) {
@@ -65,7 +107,7 @@ func TestNewTicket(t *testing.T) {
 		description := "Test Description"
 		allocation := 10
 
-		tk, err := ticket.NewTicket(name, description, allocation)
+		tk, err := ticket.NewTicket(name, description, allocation, 1500, "EUR", time.Now())
 		assert.Error(t, err)
 		assert.Nil(t, tk)
 	})
@@ -75,7 +117,7 @@ func TestNewTicket(t *testing.T) {
 		description := ""
 		allocation := 10
 
-		tk, err := ticket.NewTicket(name, description, allocation)
+		tk, err := ticket.NewTicket(name, description, allocation, 1500, "EUR", time.Now())
 		assert.Error(t, err)
 		assert.Nil(t, tk)
 	})
@@ -85,8 +127,537 @@ func TestNewTicket(t *testing.T) {
 		description := "Test Description"
 		allocation := -1
 
-		tk, err := ticket.NewTicket(name, description, allocation)
+		tk, err := ticket.NewTicket(name, description, allocation, 1500, "EUR", time.Now())
 		assert.Error(t, err)
 		assert.Nil(t, tk)
 	})
+
+	t.Run("should return error when price is invalid", func(t *testing.T) {
+		tk, err := ticket.NewTicket("Test Ticket", "Test Description", 10, -1, "EUR", time.Now())
+		assert.Equal(t, valueobject.ErrInvalidAmount, err)
+		assert.Nil(t, tk)
+
+		tk, err = ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "euro", time.Now())
+		assert.Equal(t, valueobject.ErrInvalidCurrency, err)
+		assert.Nil(t, tk)
+	})
+}
+
+func TestChangePrice(t *testing.T) {
+	tk, err := ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+	assert.NoError(t, err)
+
+	err = tk.ChangePrice(2000, "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2000), tk.Price.GetAmount())
+	assert.Equal(t, "USD", tk.Price.GetCurrency())
+
+	err = tk.ChangePrice(-5, "USD")
+	assert.Equal(t, valueobject.ErrInvalidAmount, err)
+	assert.Equal(t, int64(2000), tk.Price.GetAmount())
+}
+
+func TestRename(t *testing.T) {
+	tk, err := ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+	assert.NoError(t, err)
+
+	t.Run("should rename ticket successfully", func(t *testing.T) {
+		err := tk.Rename("Renamed Ticket")
+		assert.NoError(t, err)
+		assert.Equal(t, "Renamed Ticket", tk.Name.GetValue())
+	})
+
+	t.Run("should return error when name is empty", func(t *testing.T) {
+		err := tk.Rename("")
+		assert.Error(t, err)
+		assert.Equal(t, "Renamed Ticket", tk.Name.GetValue())
+	})
+}
+
+func TestChangeDescription(t *testing.T) {
+	tk, err := ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+	assert.NoError(t, err)
+
+	t.Run("should change description successfully", func(t *testing.T) {
+		err := tk.ChangeDescription("New Description")
+		assert.NoError(t, err)
+		assert.Equal(t, "New Description", tk.Description.GetValue())
+	})
+
+	t.Run("should return error when description is empty", func(t *testing.T) {
+		err := tk.ChangeDescription("")
+		assert.Error(t, err)
+		assert.Equal(t, "New Description", tk.Description.GetValue())
+	})
+}
+
+func TestChangeAllocation(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		total          int
+		wantAllocation int
+		wantErr        error
+	}{
+		{name: "should raise allocation", total: 20, wantAllocation: 16},
+		{name: "should lower allocation", total: 6, wantAllocation: 2},
+		{name: "should lower allocation to sold quantity", total: 4, wantAllocation: 0},
+		{name: "should reject allocation below sold quantity", total: 3, wantAllocation: 6, wantErr: ticket.ErrAllocationBelowSold},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tk, err := ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+			assert.NoError(t, err)
+			assert.NoError(t, tk.Publish(time.Now()))
+			assert.NoError(t, tk.DecrementAllocation(ctx, 4, time.Now()))
+
+			err = tk.ChangeAllocation(tt.total, time.Now())
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantAllocation, tk.Allocation.GetValue())
+			assert.Equal(t, 4, tk.Sold)
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	t.Run("should restore deleted ticket", func(t *testing.T) {
+		tk, err := ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+		assert.NoError(t, err)
+		tk.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+
+		assert.True(t, tk.IsDeleted())
+		assert.NoError(t, tk.Restore())
+		assert.False(t, tk.IsDeleted())
+	})
+
+	t.Run("should return error when ticket is not deleted", func(t *testing.T) {
+		tk, err := ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, tk.Restore(), ticket.ErrTicketNotDeleted)
+	})
+}
+
+func TestHold(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should hold allocation successfully", func(t *testing.T) {
+		tk, err := ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+		assert.NoError(t, err)
+		assert.NoError(t, tk.Publish(time.Now()))
+
+		err = tk.Hold(ctx, 4, time.Now())
+		assert.NoError(t, err)
+		assert.Equal(t, 6, tk.Allocation.GetValue())
+		assert.Equal(t, 4, tk.Held)
+		assert.Equal(t, 0, tk.Sold)
+	})
+
+	t.Run("should return error when allocation is insufficient", func(t *testing.T) {
+		tk, err := ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+		assert.NoError(t, err)
+		assert.NoError(t, tk.Publish(time.Now()))
+
+		err = tk.Hold(ctx, 11, time.Now())
+		assert.ErrorIs(t, err, ticket.ErrInsufficientAllocation)
+		assert.Equal(t, 10, tk.Allocation.GetValue())
+		assert.Equal(t, 0, tk.Held)
+	})
+}
+
+func TestConfirmHold(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should move held units to sold", func(t *testing.T) {
+		tk, err := ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+		assert.NoError(t, err)
+		assert.NoError(t, tk.Publish(time.Now()))
+		assert.NoError(t, tk.Hold(ctx, 4, time.Now()))
+
+		err = tk.ConfirmHold(ctx, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, 6, tk.Allocation.GetValue())
+		assert.Equal(t, 1, tk.Held)
+		assert.Equal(t, 3, tk.Sold)
+	})
+
+	t.Run("should return error when held quantity is insufficient", func(t *testing.T) {
+		tk, err := ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+		assert.NoError(t, err)
+
+		err = tk.ConfirmHold(ctx, 1)
+		assert.ErrorIs(t, err, ticket.ErrInsufficientHeld)
+		assert.Equal(t, 0, tk.Sold)
+	})
+}
+
+func TestReleaseHold(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return held units to allocation", func(t *testing.T) {
+		tk, err := ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+		assert.NoError(t, err)
+		assert.NoError(t, tk.Publish(time.Now()))
+		assert.NoError(t, tk.Hold(ctx, 4, time.Now()))
+
+		err = tk.ReleaseHold(ctx, 4, time.Now())
+		assert.NoError(t, err)
+		assert.Equal(t, 10, tk.Allocation.GetValue())
+		assert.Equal(t, 0, tk.Held)
+	})
+
+	t.Run("should return error when held quantity is insufficient", func(t *testing.T) {
+		tk, err := ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+		assert.NoError(t, err)
+
+		err = tk.ReleaseHold(ctx, 1, time.Now())
+		assert.ErrorIs(t, err, ticket.ErrInsufficientHeld)
+		assert.Equal(t, 10, tk.Allocation.GetValue())
+	})
+}
+
+func TestChangeAllocationWithHeldUnits(t *testing.T) {
+	ctx := context.Background()
+	tk, err := ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+	assert.NoError(t, err)
+	assert.NoError(t, tk.Publish(time.Now()))
+	assert.NoError(t, tk.DecrementAllocation(ctx, 2, time.Now()))
+	assert.NoError(t, tk.Hold(ctx, 3, time.Now()))
+
+	assert.ErrorIs(t, tk.ChangeAllocation(4, time.Now()), ticket.ErrAllocationBelowSold)
+	assert.NoError(t, tk.ChangeAllocation(5, time.Now()))
+	assert.Equal(t, 0, tk.Allocation.GetValue())
+}
+
+func TestMatchVersion(t *testing.T) {
+	tk, err := ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+	assert.NoError(t, err)
+
+	current := 1
+	stale := 2
+
+	assert.NoError(t, tk.MatchVersion(nil))
+	assert.NoError(t, tk.MatchVersion(&current))
+	assert.Equal(t, ticket.ErrVersionMismatch, tk.MatchVersion(&stale))
+}
+
+func TestEvents(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should record ticket created with the stored id", func(t *testing.T) {
+		tk, err := ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+		assert.NoError(t, err)
+
+		tk.ID = 7
+		events := tk.PullEvents()
+		assert.Len(t, events, 1)
+
+		created, ok := events[0].(ticket.TicketCreated)
+		assert.True(t, ok)
+		assert.Equal(t, ticket.EventTicketCreated, created.EventName())
+		assert.Equal(t, 7, created.TicketID)
+		assert.Equal(t, "Test Ticket", created.Name)
+		assert.Equal(t, 10, created.Allocation)
+		assert.Equal(t, int64(1500), created.Price.Amount)
+		assert.False(t, created.OccurredAt().IsZero())
+
+		assert.Empty(t, tk.PullEvents())
+	})
+
+	t.Run("should record decrements and sold out", func(t *testing.T) {
+		tk := &ticket.Ticket{ID: 1, Status: ticket.StatusPublished, Allocation: mustAllocation(t, 5)}
+		assert.NoError(t, tk.DecrementAllocation(ctx, 2, time.Now()))
+		assert.NoError(t, tk.Hold(ctx, 3, time.Now()))
+
+		events := tk.PullEvents()
+		assert.Len(t, events, 3)
+		assert.Equal(t, ticket.AllocationDecremented{TicketID: 1, Quantity: 2, Remaining: 3, At: events[0].OccurredAt()}, events[0])
+		assert.Equal(t, ticket.AllocationDecremented{TicketID: 1, Quantity: 3, Remaining: 0, At: events[1].OccurredAt()}, events[1])
+		assert.Equal(t, ticket.TicketSoldOut{TicketID: 1, At: events[2].OccurredAt()}, events[2])
+	})
+
+	t.Run("should record restored allocation", func(t *testing.T) {
+		tk := &ticket.Ticket{ID: 1, Status: ticket.StatusPublished, Allocation: mustAllocation(t, 0), Sold: 2, Held: 3}
+		assert.NoError(t, tk.ReturnAllocation(ctx, 2, time.Now()))
+		assert.NoError(t, tk.ReleaseHold(ctx, 3, time.Now()))
+
+		events := tk.PullEvents()
+		assert.Len(t, events, 2)
+		assert.Equal(t, ticket.AllocationRestored{TicketID: 1, Quantity: 2, Remaining: 2, At: events[0].OccurredAt()}, events[0])
+		assert.Equal(t, ticket.AllocationRestored{TicketID: 1, Quantity: 3, Remaining: 5, At: events[1].OccurredAt()}, events[1])
+	})
+
+	t.Run("should record raised allocation only", func(t *testing.T) {
+		tk := &ticket.Ticket{ID: 1, Status: ticket.StatusPublished, Allocation: mustAllocation(t, 0), Sold: 2}
+		assert.NoError(t, tk.ChangeAllocation(6, time.Now()))
+		assert.NoError(t, tk.ChangeAllocation(3, time.Now()))
+
+		events := tk.PullEvents()
+		assert.Len(t, events, 1)
+		assert.Equal(t, ticket.AllocationRestored{TicketID: 1, Quantity: 4, Remaining: 4, At: events[0].OccurredAt()}, events[0])
+	})
+
+	t.Run("should not record failed changes", func(t *testing.T) {
+		tk := &ticket.Ticket{ID: 1, Status: ticket.StatusPublished, Allocation: mustAllocation(t, 1)}
+		assert.Error(t, tk.DecrementAllocation(ctx, 2, time.Now()))
+		assert.Error(t, tk.ReturnAllocation(ctx, 1, time.Now()))
+		assert.Error(t, tk.ReleaseHold(ctx, 1, time.Now()))
+		assert.Empty(t, tk.PullEvents())
+	})
+}
+
+func TestTransitions(t *testing.T) {
+	now := time.Date(2026, 11, 1, 19, 0, 0, 0, time.UTC)
+	apply := map[ticket.Transition]func(*ticket.Ticket, time.Time) error{
+		ticket.TransitionPublish: (*ticket.Ticket).Publish,
+		ticket.TransitionPause:   (*ticket.Ticket).Pause,
+		ticket.TransitionResume:  (*ticket.Ticket).Resume,
+		ticket.TransitionArchive: (*ticket.Ticket).Archive,
+	}
+
+	tests := []struct {
+		from       ticket.Status
+		transition ticket.Transition
+		to         ticket.Status
+		wantErr    bool
+	}{
+		{from: ticket.StatusDraft, transition: ticket.TransitionPublish, to: ticket.StatusPublished},
+		{from: ticket.StatusDraft, transition: ticket.TransitionPause, wantErr: true},
+		{from: ticket.StatusDraft, transition: ticket.TransitionResume, wantErr: true},
+		{from: ticket.StatusDraft, transition: ticket.TransitionArchive, to: ticket.StatusArchived},
+		{from: ticket.StatusPublished, transition: ticket.TransitionPublish, wantErr: true},
+		{from: ticket.StatusPublished, transition: ticket.TransitionPause, to: ticket.StatusPaused},
+		{from: ticket.StatusPublished, transition: ticket.TransitionResume, wantErr: true},
+		{from: ticket.StatusPublished, transition: ticket.TransitionArchive, to: ticket.StatusArchived},
+		{from: ticket.StatusPaused, transition: ticket.TransitionPublish, wantErr: true},
+		{from: ticket.StatusPaused, transition: ticket.TransitionPause, wantErr: true},
+		{from: ticket.StatusPaused, transition: ticket.TransitionResume, to: ticket.StatusPublished},
+		{from: ticket.StatusPaused, transition: ticket.TransitionArchive, to: ticket.StatusArchived},
+		{from: ticket.StatusArchived, transition: ticket.TransitionPublish, wantErr: true},
+		{from: ticket.StatusArchived, transition: ticket.TransitionPause, wantErr: true},
+		{from: ticket.StatusArchived, transition: ticket.TransitionResume, wantErr: true},
+		{from: ticket.StatusArchived, transition: ticket.TransitionArchive, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.transition)+" from "+string(tt.from), func(t *testing.T) {
+			tk := &ticket.Ticket{ID: 1, Status: tt.from}
+			err := apply[tt.transition](tk, now)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ticket.ErrInvalidTransition)
+				assert.Equal(t, tt.from, tk.Status)
+				assert.Empty(t, tk.PullEvents())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.to, tk.Status)
+
+			events := tk.PullEvents()
+			assert.Len(t, events, 1)
+			assert.Equal(t, ticket.TicketStatusChanged{TicketID: 1, From: string(tt.from), To: string(tt.to), At: now}, events[0])
+		})
+	}
+
+	t.Run("should not archive a ticket with held units", func(t *testing.T) {
+		tk := &ticket.Ticket{Status: ticket.StatusPublished, Held: 2}
+		assert.ErrorIs(t, tk.Archive(time.Now()), ticket.ErrTicketHasHolds)
+		assert.Equal(t, ticket.StatusPublished, tk.Status)
+		assert.Empty(t, tk.PullEvents())
+	})
+
+	t.Run("should reject unknown transitions", func(t *testing.T) {
+		tk := &ticket.Ticket{Status: ticket.StatusDraft}
+		assert.ErrorIs(t, tk.Apply("delete", now), ticket.ErrUnknownTransition)
+		assert.Equal(t, ticket.StatusDraft, tk.Status)
+	})
+}
+
+func TestNotPublished(t *testing.T) {
+	ctx := context.Background()
+
+	for _, status := range []ticket.Status{ticket.StatusDraft, ticket.StatusPaused, ticket.StatusArchived} {
+		t.Run(string(status), func(t *testing.T) {
+			tk := &ticket.Ticket{ID: 1, Status: status, Allocation: mustAllocation(t, 5)}
+			assert.ErrorIs(t, tk.DecrementAllocation(ctx, 1, time.Now()), ticket.ErrTicketNotPublished)
+			assert.ErrorIs(t, tk.Hold(ctx, 1, time.Now()), ticket.ErrTicketNotPublished)
+			assert.Equal(t, 5, tk.Allocation.GetValue())
+			assert.Empty(t, tk.PullEvents())
+		})
+	}
+
+	t.Run("should stamp the id on a status change recorded before it was stored", func(t *testing.T) {
+		tk, err := ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+		assert.NoError(t, err)
+		assert.Equal(t, ticket.StatusDraft, tk.Status)
+		assert.NoError(t, tk.Publish(time.Now()))
+
+		tk.ID = 7
+		events := tk.PullEvents()
+		assert.Len(t, events, 2)
+		changed, ok := events[1].(ticket.TicketStatusChanged)
+		assert.True(t, ok)
+		assert.Equal(t, 7, changed.TicketID)
+	})
+}
+
+func TestArchivedIsReadOnly(t *testing.T) {
+	ctx := context.Background()
+	name, err := valueobject.NewName("Test Ticket")
+	assert.NoError(t, err)
+
+	tk := &ticket.Ticket{ID: 1, Name: name, Status: ticket.StatusArchived, Allocation: mustAllocation(t, 5), Sold: 2, Seating: ticket.SeatingGeneral}
+	start := time.Now()
+	end := start.Add(time.Hour)
+
+	assert.ErrorIs(t, tk.Rename("Renamed"), ticket.ErrTicketArchived)
+	assert.ErrorIs(t, tk.ChangeDescription("Changed"), ticket.ErrTicketArchived)
+	assert.ErrorIs(t, tk.ChangeAllocation(20, time.Now()), ticket.ErrTicketArchived)
+	assert.ErrorIs(t, tk.ChangePrice(2000, "EUR"), ticket.ErrTicketArchived)
+	assert.ErrorIs(t, tk.ChangeMaxPerUser(2), ticket.ErrTicketArchived)
+	assert.ErrorIs(t, tk.ChangeSalesWindow(&start, &end), ticket.ErrTicketArchived)
+	assert.ErrorIs(t, tk.ReturnAllocation(ctx, 1, time.Now()), ticket.ErrTicketArchived)
+	assert.ErrorIs(t, tk.AssignSeats(10, time.Now()), ticket.ErrTicketArchived)
+
+	assert.Equal(t, "Test Ticket", tk.Name.GetValue())
+	assert.Equal(t, 5, tk.Allocation.GetValue())
+	assert.Equal(t, 2, tk.Sold)
+	assert.Nil(t, tk.MaxPerUser)
+	assert.Nil(t, tk.SalesStartAt)
+	assert.Equal(t, ticket.SeatingGeneral, tk.Seating)
+	assert.Empty(t, tk.PullEvents())
+	assert.Empty(t, tk.PullLedger())
+}
+
+func TestChangeMaxPerUser(t *testing.T) {
+	tk := &ticket.Ticket{}
+	assert.False(t, tk.HasPurchaseLimit())
+
+	assert.NoError(t, tk.ChangeMaxPerUser(4))
+	assert.True(t, tk.HasPurchaseLimit())
+	assert.Equal(t, 4, *tk.MaxPerUser)
+
+	assert.ErrorIs(t, tk.ChangeMaxPerUser(-1), ticket.ErrInvalidMaxPerUser)
+	assert.Equal(t, 4, *tk.MaxPerUser)
+
+	assert.NoError(t, tk.ChangeMaxPerUser(0))
+	assert.False(t, tk.HasPurchaseLimit())
+}
+
+func TestCheckPurchaseLimit(t *testing.T) {
+	limited := &ticket.Ticket{}
+	assert.NoError(t, limited.ChangeMaxPerUser(4))
+
+	tests := []struct {
+		name      string
+		ticket    *ticket.Ticket
+		owned     int
+		quantity  int
+		remaining int
+		wantErr   bool
+	}{
+		{name: "no limit", ticket: &ticket.Ticket{}, owned: 100, quantity: 100},
+		{name: "within limit", ticket: limited, owned: 1, quantity: 3},
+		{name: "above limit", ticket: limited, owned: 1, quantity: 4, remaining: 3, wantErr: true},
+		{name: "limit used up", ticket: limited, owned: 4, quantity: 1, remaining: 0, wantErr: true},
+		{name: "owned more than a lowered limit", ticket: limited, owned: 6, quantity: 1, remaining: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ticket.CheckPurchaseLimit(tt.owned, tt.quantity)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, ticket.ErrPurchaseLimitExceeded)
+			var limitErr *ticket.PurchaseLimitError
+			assert.ErrorAs(t, err, &limitErr)
+			assert.Equal(t, 4, limitErr.Limit)
+			assert.Equal(t, tt.remaining, limitErr.Remaining)
+		})
+	}
+}
+
+func TestLedger(t *testing.T) {
+	ctx := context.Background()
+
+	type entry struct {
+		Delta      int
+		Allocation int
+		Reason     ticket.LedgerReason
+	}
+	entries := func(tk *ticket.Ticket) []entry {
+		var got []entry
+		for _, e := range tk.PullLedger() {
+			assert.Equal(t, tk.ID, e.TicketID)
+			got = append(got, entry{e.Delta, e.Allocation, e.Reason})
+		}
+		return got
+	}
+
+	t.Run("should open the ledger with the created allocation", func(t *testing.T) {
+		tk, err := ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+		assert.NoError(t, err)
+
+		tk.ID = 7
+		assert.Equal(t, []entry{{10, 10, ticket.ReasonCreate}}, entries(tk))
+		assert.Empty(t, tk.PullLedger())
+	})
+
+	t.Run("should record every allocation change with the resulting allocation", func(t *testing.T) {
+		tk := &ticket.Ticket{ID: 1, Status: ticket.StatusPublished, Allocation: mustAllocation(t, 10)}
+		assert.NoError(t, tk.DecrementAllocation(ctx, 2, time.Now()))
+		assert.NoError(t, tk.Hold(ctx, 3, time.Now()))
+		assert.NoError(t, tk.ConfirmHold(ctx, 1))
+		assert.NoError(t, tk.ReleaseHold(ctx, 2, time.Now()))
+		assert.NoError(t, tk.ReturnAllocation(ctx, 1, time.Now()))
+		assert.NoError(t, tk.ChangeAllocation(12, time.Now()))
+		assert.NoError(t, tk.ChangeAllocation(12, time.Now()))
+
+		assert.Equal(t, []entry{
+			{-2, 8, ticket.ReasonPurchase},
+			{-3, 5, ticket.ReasonHold},
+			{2, 7, ticket.ReasonRelease},
+			{1, 8, ticket.ReasonRefund},
+			{2, 10, ticket.ReasonAdjust},
+		}, entries(tk))
+	})
+
+	t.Run("should stamp entries and events with the given time", func(t *testing.T) {
+		now := time.Date(2026, 11, 1, 19, 0, 0, 0, time.UTC)
+		tk := &ticket.Ticket{ID: 1, Status: ticket.StatusPublished, Allocation: mustAllocation(t, 10), Sold: 2, Held: 2}
+		assert.NoError(t, tk.ReleaseHold(ctx, 2, now))
+		assert.NoError(t, tk.ReturnAllocation(ctx, 1, now))
+		assert.NoError(t, tk.ChangeAllocation(20, now))
+		assert.NoError(t, tk.Pause(now))
+
+		for _, e := range tk.PullLedger() {
+			assert.Equal(t, now, e.CreatedAt)
+		}
+
+		events := tk.PullEvents()
+		assert.Len(t, events, 4)
+		for _, e := range events {
+			assert.Equal(t, now, e.OccurredAt())
+		}
+	})
+
+	t.Run("should not record failed changes", func(t *testing.T) {
+		tk := &ticket.Ticket{ID: 1, Status: ticket.StatusPublished, Allocation: mustAllocation(t, 1)}
+		assert.Error(t, tk.DecrementAllocation(ctx, 2, time.Now()))
+		assert.Error(t, tk.Hold(ctx, 2, time.Now()))
+		assert.Error(t, tk.ReturnAllocation(ctx, 1, time.Now()))
+		assert.Empty(t, tk.PullLedger())
+	})
+}
+
+func mustAllocation(t *testing.T, value int) *valueobject.Allocation {
+	allocation, err := valueobject.NewAllocation(value)
+	assert.NoError(t, err)
+
+	return allocation
 }
@@ -0,0 +1,74 @@
+package ticket
+
+import (
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+)
+
+const (
+	EventTicketCreated         = "ticket.created"
+	EventAllocationDecremented = "ticket.allocation_decremented"
+	EventTicketSoldOut         = "ticket.sold_out"
+	EventAllocationRestored    = "ticket.allocation_restored"
+	EventTicketStatusChanged   = "ticket.status_changed"
+)
+
+// TicketCreated is recorded by NewTicket, before the ticket has an ID. The
+// ID is filled in by PullEvents once the ticket was stored.
+type TicketCreated struct {
+	TicketID   int                   `json:"ticket_id"`
+	Name       string                `json:"name"`
+	Allocation int                   `json:"allocation"`
+	Price      *valueobject.MoneyDTO `json:"price"`
+	At         time.Time             `json:"occurred_at"`
+}
+
+func (e TicketCreated) EventName() string     { return EventTicketCreated }
+func (e TicketCreated) OccurredAt() time.Time { return e.At }
+
+// AllocationDecremented is recorded when units are sold or held.
+type AllocationDecremented struct {
+	TicketID  int       `json:"ticket_id"`
+	Quantity  int       `json:"quantity"`
+	Remaining int       `json:"remaining"`
+	At        time.Time `json:"occurred_at"`
+}
+
+func (e AllocationDecremented) EventName() string     { return EventAllocationDecremented }
+func (e AllocationDecremented) OccurredAt() time.Time { return e.At }
+
+// TicketSoldOut is recorded when the last unit of the allocation is sold
+// or held.
+type TicketSoldOut struct {
+	TicketID int       `json:"ticket_id"`
+	At       time.Time `json:"occurred_at"`
+}
+
+func (e TicketSoldOut) EventName() string     { return EventTicketSoldOut }
+func (e TicketSoldOut) OccurredAt() time.Time { return e.At }
+
+// AllocationRestored is recorded when refunded or released units go back
+// to the allocation, or when the allocation is raised.
+type AllocationRestored struct {
+	TicketID  int       `json:"ticket_id"`
+	Quantity  int       `json:"quantity"`
+	Remaining int       `json:"remaining"`
+	At        time.Time `json:"occurred_at"`
+}
+
+func (e AllocationRestored) EventName() string     { return EventAllocationRestored }
+func (e AllocationRestored) OccurredAt() time.Time { return e.At }
+
+// TicketStatusChanged is recorded by every status transition. Tickets
+// published when they are created record it before they have an ID, like
+// TicketCreated.
+type TicketStatusChanged struct {
+	TicketID int       `json:"ticket_id"`
+	From     string    `json:"from"`
+	To       string    `json:"to"`
+	At       time.Time `json:"occurred_at"`
+}
+
+func (e TicketStatusChanged) EventName() string     { return EventTicketStatusChanged }
+func (e TicketStatusChanged) OccurredAt() time.Time { return e.At }
@@ -0,0 +1,25 @@
+package ticket
+
+import "time"
+
+type SortField string
+
+const (
+	SortByID         SortField = "id"
+	SortByAllocation SortField = "allocation"
+	SortByCreatedAt  SortField = "created_at"
+)
+
+type ListFilter struct {
+	EventID       *int
+	Name          string
+	MinAllocation *int
+	MaxAllocation *int
+	SoldOut       *bool
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	SortBy        SortField
+	SortDesc      bool
+	Offset        int
+	Limit         int
+}
@@ -0,0 +1,69 @@
+package ticket
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrLedgerBroken   = errors.New("ledger entry does not follow from the previous entries")
+	ErrLedgerMismatch = errors.New("ledger does not add up to the current allocation")
+)
+
+type LedgerReason string
+
+const (
+	ReasonCreate   LedgerReason = "create"
+	ReasonPurchase LedgerReason = "purchase"
+	ReasonRefund   LedgerReason = "refund"
+	ReasonHold     LedgerReason = "hold"
+	ReasonRelease  LedgerReason = "release"
+	ReasonAdjust   LedgerReason = "adjust"
+	// ReasonBackfill opens the ledger of tickets created before it existed.
+	ReasonBackfill LedgerReason = "backfill"
+)
+
+// LedgerEntry is one change of the allocation of a ticket. Entries are only
+// ever appended, replaying the deltas of a ticket in order gives its
+// current allocation.
+type LedgerEntry struct {
+	ID         int64        `json:"id" gorm:"primaryKey;autoIncrement"`
+	TicketID   int          `json:"ticket_id" gorm:"not null;index"`
+	Delta      int          `json:"delta" gorm:"not null"`
+	Allocation int          `json:"allocation" gorm:"not null"`
+	Reason     LedgerReason `json:"reason" gorm:"not null;type:varchar(32)"`
+	ActorID    string       `json:"actor_id" gorm:"not null;type:varchar(255);default:''"`
+	RequestID  string       `json:"request_id" gorm:"not null;type:varchar(255);default:''"`
+	CreatedAt  time.Time    `json:"created_at" gorm:"not null;default:current_timestamp"`
+}
+
+func (e *LedgerEntry) TableName() string {
+	return "allocation_ledger"
+}
+
+// LedgerReplay adds up the entries of a ticket in the order they were
+// written and checks that every entry follows from the ones before it.
+type LedgerReplay struct {
+	Allocation int
+	Entries    int
+}
+
+func (r *LedgerReplay) Apply(e *LedgerEntry) error {
+	r.Entries++
+	r.Allocation += e.Delta
+	if r.Allocation != e.Allocation {
+		return fmt.Errorf("%w: entry %d leaves %d, the entries up to it add up to %d", ErrLedgerBroken, e.ID, e.Allocation, r.Allocation)
+	}
+
+	return nil
+}
+
+// Check compares the replayed allocation with the current allocation of t.
+func (r *LedgerReplay) Check(t *Ticket) error {
+	if r.Allocation != t.Allocation.GetValue() {
+		return fmt.Errorf("%w: ledger adds up to %d, ticket has %d", ErrLedgerMismatch, r.Allocation, t.Allocation.GetValue())
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package ticket_test
+
+import (
+	"testing"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedgerReplay(t *testing.T) {
+	t.Run("should add up entries that follow from each other", func(t *testing.T) {
+		var replay ticket.LedgerReplay
+		assert.NoError(t, replay.Apply(&ticket.LedgerEntry{ID: 1, Delta: 10, Allocation: 10}))
+		assert.NoError(t, replay.Apply(&ticket.LedgerEntry{ID: 2, Delta: -4, Allocation: 6}))
+		assert.NoError(t, replay.Apply(&ticket.LedgerEntry{ID: 3, Delta: 1, Allocation: 7}))
+
+		assert.Equal(t, ticket.LedgerReplay{Allocation: 7, Entries: 3}, replay)
+		assert.NoError(t, replay.Check(&ticket.Ticket{Allocation: mustAllocation(t, 7)}))
+	})
+
+	t.Run("should reject an entry that does not follow from the ones before", func(t *testing.T) {
+		var replay ticket.LedgerReplay
+		assert.NoError(t, replay.Apply(&ticket.LedgerEntry{ID: 1, Delta: 10, Allocation: 10}))
+		assert.ErrorIs(t, replay.Apply(&ticket.LedgerEntry{ID: 2, Delta: -4, Allocation: 5}), ticket.ErrLedgerBroken)
+	})
+
+	t.Run("should report a ledger that does not add up to the allocation", func(t *testing.T) {
+		var replay ticket.LedgerReplay
+		assert.NoError(t, replay.Apply(&ticket.LedgerEntry{ID: 1, Delta: 10, Allocation: 10}))
+		assert.ErrorIs(t, replay.Check(&ticket.Ticket{Allocation: mustAllocation(t, 8)}), ticket.ErrLedgerMismatch)
+	})
+}
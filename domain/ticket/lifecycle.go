@@ -0,0 +1,105 @@
+package ticket
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var (
+	ErrInvalidTransition  = errors.New("invalid ticket status transition")
+	ErrTicketNotPublished = errors.New("ticket is not published")
+	ErrUnknownTransition  = errors.New("unknown ticket status transition")
+	ErrTicketArchived     = errors.New("archived tickets are read-only")
+)
+
+// Status is the lifecycle state of a ticket. Tickets are created as drafts
+// and can only be bought while they are published.
+type Status string
+
+const (
+	StatusDraft     Status = "draft"
+	StatusPublished Status = "published"
+	StatusPaused    Status = "paused"
+	StatusArchived  Status = "archived"
+)
+
+// Transition is an operation that moves a ticket to another status.
+type Transition string
+
+const (
+	TransitionPublish Transition = "publish"
+	TransitionPause   Transition = "pause"
+	TransitionResume  Transition = "resume"
+	TransitionArchive Transition = "archive"
+)
+
+type transitionRule struct {
+	from []Status
+	to   Status
+}
+
+// transitions lists the statuses every transition is allowed from. An
+// archived ticket can not be changed any more.
+var transitions = map[Transition]transitionRule{
+	TransitionPublish: {from: []Status{StatusDraft}, to: StatusPublished},
+	TransitionPause:   {from: []Status{StatusPublished}, to: StatusPaused},
+	TransitionResume:  {from: []Status{StatusPaused}, to: StatusPublished},
+	TransitionArchive: {from: []Status{StatusDraft, StatusPublished, StatusPaused}, to: StatusArchived},
+}
+
+func (t *Ticket) Publish(now time.Time) error {
+	return t.Apply(TransitionPublish, now)
+}
+
+func (t *Ticket) Pause(now time.Time) error {
+	return t.Apply(TransitionPause, now)
+}
+
+func (t *Ticket) Resume(now time.Time) error {
+	return t.Apply(TransitionResume, now)
+}
+
+func (t *Ticket) Archive(now time.Time) error {
+	return t.Apply(TransitionArchive, now)
+}
+
+// Apply runs transition on the ticket, it fails with ErrInvalidTransition
+// when the transition is not allowed from the current status.
+func (t *Ticket) Apply(transition Transition, now time.Time) error {
+	rule, ok := transitions[transition]
+	if !ok {
+		return fmt.Errorf("%w %q", ErrUnknownTransition, transition)
+	}
+
+	if !slices.Contains(rule.from, t.Status) {
+		return fmt.Errorf("%w: cannot %s a %s ticket", ErrInvalidTransition, transition, t.Status)
+	}
+
+	// Holds would be confirmed or released on a ticket that is read-only.
+	if rule.to == StatusArchived {
+		if err := t.CheckNoHolds(); err != nil {
+			return err
+		}
+	}
+
+	from := t.Status
+	t.Status = rule.to
+	t.record(TicketStatusChanged{TicketID: t.ID, From: string(from), To: string(rule.to), At: now})
+	return nil
+}
+
+func (t *Ticket) IsPublished() bool {
+	return t.Status == StatusPublished
+}
+
+// checkNotArchived guards the mutators of a ticket, an archived ticket keeps
+// the details, allocation and seats it had when it was archived.
+func (t *Ticket) checkNotArchived() error {
+	if t.Status == StatusArchived {
+		return ErrTicketArchived
+	}
+
+	return nil
+}
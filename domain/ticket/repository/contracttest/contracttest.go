@@ -0,0 +1,655 @@
+// Package contracttest checks that a TicketRepository behaves like the
+// Postgres repository the services were written against. Every backend runs
+// the same suite, a new backend is only equivalent once it passes it.
+package contracttest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket/repository"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/pkg/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Backend is a repository together with the unit of work that gives it
+// transactions and row locks.
+type Backend struct {
+	Tickets    repository.TicketRepository
+	UnitOfWork db.UnitOfWork
+}
+
+// Factory returns an empty backend, it is called once for every test.
+type Factory func(t *testing.T) Backend
+
+var errRollback = errors.New("rollback")
+
+// Run runs the whole suite against the backends created by newBackend.
+func Run(t *testing.T, newBackend Factory) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, b Backend)
+	}{
+		{"Create", testCreate},
+		{"FindNotFound", testFindNotFound},
+		{"Update", testUpdate},
+		{"UpdateVersionConflict", testUpdateVersionConflict},
+		{"SoftDelete", testSoftDelete},
+		{"DeleteVersionConflict", testDeleteVersionConflict},
+		{"DeleteRollback", testDeleteRollback},
+		{"Restore", testRestore},
+		{"ListFilters", testListFilters},
+		{"ListSortAndPagination", testListSortAndPagination},
+		{"Rollback", testRollback},
+		{"NestedRollback", testNestedRollback},
+		{"FindByIDForUpdateBlocks", testFindByIDForUpdateBlocks},
+		{"ConcurrentPurchases", testConcurrentPurchases},
+		{"Ledger", testLedger},
+		{"LedgerVersionConflict", testLedgerVersionConflict},
+		{"LedgerRollback", testLedgerRollback},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, newBackend(t))
+		})
+	}
+}
+
+func newTicket(t *testing.T, name string, allocation int) *ticket.Ticket {
+	t.Helper()
+
+	tk, err := ticket.NewTicket(name, "Contract test ticket", allocation, 1500, "EUR", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, tk.Publish(time.Now()))
+
+	return tk
+}
+
+func create(t *testing.T, b Backend, name string, allocation int) *ticket.Ticket {
+	t.Helper()
+
+	tk := newTicket(t, name, allocation)
+	require.NoError(t, b.Tickets.Create(context.Background(), tk))
+
+	return tk
+}
+
+func find(t *testing.T, b Backend, id int) *ticket.Ticket {
+	t.Helper()
+
+	tk, err := b.Tickets.FindByID(context.Background(), id)
+	require.NoError(t, err)
+
+	return tk
+}
+
+func names(tickets []*ticket.Ticket) []string {
+	names := make([]string, 0, len(tickets))
+	for _, tk := range tickets {
+		names = append(names, tk.Name.GetValue())
+	}
+
+	return names
+}
+
+func testCreate(t *testing.T, b Backend) {
+	before := time.Now().Add(-time.Second)
+	first := create(t, b, "First", 10)
+	second := create(t, b, "Second", 20)
+
+	assert.NotZero(t, first.ID)
+	assert.Greater(t, second.ID, first.ID)
+	assert.Equal(t, 1, first.Version)
+
+	found := find(t, b, second.ID)
+	assert.Equal(t, second.ID, found.ID)
+	assert.Equal(t, "Second", found.Name.GetValue())
+	assert.Equal(t, "Contract test ticket", found.Description.GetValue())
+	assert.Equal(t, 20, found.Allocation.GetValue())
+	assert.Equal(t, "1500 EUR", found.Price.String())
+	assert.Zero(t, found.Sold)
+	assert.Zero(t, found.Held)
+	assert.Equal(t, ticket.StatusPublished, found.Status)
+	assert.Equal(t, 1, found.Version)
+	assert.False(t, found.IsDeleted())
+	assert.True(t, found.CreatedAt.After(before))
+	assert.True(t, found.UpdatedAt.After(before))
+}
+
+func testFindNotFound(t *testing.T, b Backend) {
+	ctx := context.Background()
+	tk := create(t, b, "Ticket", 10)
+	missing := tk.ID + 1000
+
+	_, err := b.Tickets.FindByID(ctx, missing)
+	assert.ErrorIs(t, err, ticket.ErrTicketNotFound)
+
+	_, err = b.Tickets.FindByIDUnscoped(ctx, missing)
+	assert.ErrorIs(t, err, ticket.ErrTicketNotFound)
+
+	err = b.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		_, err := b.Tickets.FindByIDForUpdate(ctx, missing)
+		return err
+	})
+	assert.ErrorIs(t, err, ticket.ErrTicketNotFound)
+}
+
+func testUpdate(t *testing.T, b Backend) {
+	ctx := context.Background()
+	tk := create(t, b, "Ticket", 10)
+
+	require.NoError(t, tk.DecrementAllocation(ctx, 3, time.Now()))
+	require.NoError(t, tk.Rename("Renamed"))
+	require.NoError(t, tk.Pause(time.Now()))
+	require.NoError(t, b.Tickets.Update(ctx, tk))
+	assert.Equal(t, 2, tk.Version)
+
+	found := find(t, b, tk.ID)
+	assert.Equal(t, "Renamed", found.Name.GetValue())
+	assert.Equal(t, ticket.StatusPaused, found.Status)
+	assert.Equal(t, 7, found.Allocation.GetValue())
+	assert.Equal(t, 3, found.Sold)
+	assert.Equal(t, 2, found.Version)
+}
+
+func testUpdateVersionConflict(t *testing.T, b Backend) {
+	ctx := context.Background()
+	tk := create(t, b, "Ticket", 10)
+	stale := find(t, b, tk.ID)
+
+	require.NoError(t, tk.DecrementAllocation(ctx, 1, time.Now()))
+	require.NoError(t, b.Tickets.Update(ctx, tk))
+
+	require.NoError(t, stale.DecrementAllocation(ctx, 5, time.Now()))
+	assert.ErrorIs(t, b.Tickets.Update(ctx, stale), ticket.ErrVersionConflict)
+	assert.Equal(t, 1, stale.Version, "a failed update must not change the version")
+
+	found := find(t, b, tk.ID)
+	assert.Equal(t, 9, found.Allocation.GetValue())
+	assert.Equal(t, 2, found.Version)
+}
+
+func testSoftDelete(t *testing.T, b Backend) {
+	ctx := context.Background()
+	tk := create(t, b, "Ticket", 10)
+	require.NoError(t, b.Tickets.Delete(ctx, tk))
+	assert.True(t, tk.IsDeleted())
+	assert.Equal(t, 2, tk.Version)
+
+	_, err := b.Tickets.FindByID(ctx, tk.ID)
+	assert.ErrorIs(t, err, ticket.ErrTicketNotFound)
+
+	err = b.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		_, err := b.Tickets.FindByIDForUpdate(ctx, tk.ID)
+		return err
+	})
+	assert.ErrorIs(t, err, ticket.ErrTicketNotFound)
+
+	tickets, total, err := b.Tickets.List(ctx, ticket.ListFilter{Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, tickets)
+	assert.Zero(t, total)
+
+	deleted, err := b.Tickets.FindByIDUnscoped(ctx, tk.ID)
+	require.NoError(t, err)
+	assert.True(t, deleted.IsDeleted())
+	assert.Equal(t, 2, deleted.Version, "the delete bumps the version")
+	assert.ErrorIs(t, b.Tickets.Update(ctx, deleted), ticket.ErrVersionConflict, "deleted tickets can not be updated")
+	assert.ErrorIs(t, b.Tickets.Delete(ctx, deleted), ticket.ErrVersionConflict, "deleted tickets can not be deleted again")
+}
+
+func testDeleteVersionConflict(t *testing.T, b Backend) {
+	ctx := context.Background()
+	tk := create(t, b, "Ticket", 10)
+	stale := find(t, b, tk.ID)
+
+	require.NoError(t, b.Tickets.Update(ctx, tk))
+	assert.ErrorIs(t, b.Tickets.Delete(ctx, stale), ticket.ErrVersionConflict)
+
+	find(t, b, tk.ID)
+}
+
+func testDeleteRollback(t *testing.T, b Backend) {
+	ctx := context.Background()
+	tk := create(t, b, "Ticket", 10)
+
+	err := b.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		locked, err := b.Tickets.FindByIDForUpdate(ctx, tk.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := b.Tickets.Delete(ctx, locked); err != nil {
+			return err
+		}
+
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+
+	found := find(t, b, tk.ID)
+	assert.False(t, found.IsDeleted())
+	assert.Equal(t, 1, found.Version)
+}
+
+func testRestore(t *testing.T, b Backend) {
+	ctx := context.Background()
+	tk := create(t, b, "Ticket", 10)
+	beforeDelete := *tk
+	require.NoError(t, b.Tickets.Delete(ctx, tk))
+
+	deleted, err := b.Tickets.FindByIDUnscoped(ctx, tk.ID)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, b.Tickets.Restore(ctx, &beforeDelete), ticket.ErrVersionConflict, "the version read before the delete is stale")
+
+	stale := *deleted
+	require.NoError(t, deleted.Restore())
+	require.NoError(t, b.Tickets.Restore(ctx, deleted))
+	assert.Equal(t, 3, deleted.Version)
+
+	restored := find(t, b, tk.ID)
+	assert.False(t, restored.IsDeleted())
+	assert.Equal(t, 3, restored.Version)
+
+	assert.ErrorIs(t, b.Tickets.Restore(ctx, &stale), ticket.ErrVersionConflict)
+}
+
+func testListFilters(t *testing.T, b Backend) {
+	ctx := context.Background()
+	create(t, b, "Rock Concert", 30)
+	create(t, b, "Jazz Night", 10)
+	create(t, b, "Rock Festival", 20)
+	soldOut := create(t, b, "Sold Out Show", 1)
+	require.NoError(t, soldOut.DecrementAllocation(ctx, 1, time.Now()))
+	require.NoError(t, b.Tickets.Update(ctx, soldOut))
+
+	intPtr := func(v int) *int { return &v }
+	boolPtr := func(v bool) *bool { return &v }
+	timePtr := func(v time.Time) *time.Time { return &v }
+
+	tests := []struct {
+		name   string
+		filter ticket.ListFilter
+		want   []string
+	}{
+		{"no filter", ticket.ListFilter{}, []string{"Rock Concert", "Jazz Night", "Rock Festival", "Sold Out Show"}},
+		{"name is case insensitive", ticket.ListFilter{Name: "rOCK"}, []string{"Rock Concert", "Rock Festival"}},
+		{"name is not a pattern", ticket.ListFilter{Name: "%"}, []string{}},
+		{"min allocation", ticket.ListFilter{MinAllocation: intPtr(20)}, []string{"Rock Concert", "Rock Festival"}},
+		{"max allocation", ticket.ListFilter{MaxAllocation: intPtr(10)}, []string{"Jazz Night", "Sold Out Show"}},
+		{"sold out", ticket.ListFilter{SoldOut: boolPtr(true)}, []string{"Sold Out Show"}},
+		{"not sold out", ticket.ListFilter{SoldOut: boolPtr(false), MaxAllocation: intPtr(10)}, []string{"Jazz Night"}},
+		{"created after", ticket.ListFilter{CreatedAfter: timePtr(time.Now().Add(time.Hour))}, []string{}},
+		{"created before", ticket.ListFilter{CreatedBefore: timePtr(time.Now().Add(-time.Hour))}, []string{}},
+		{"created between", ticket.ListFilter{CreatedAfter: timePtr(time.Now().Add(-time.Hour)), CreatedBefore: timePtr(time.Now().Add(time.Hour)), Name: "jazz"}, []string{"Jazz Night"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filter := tc.filter
+			filter.Limit = 10
+
+			tickets, total, err := b.Tickets.List(ctx, filter)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, names(tickets))
+			assert.Equal(t, int64(len(tc.want)), total)
+		})
+	}
+}
+
+func testListSortAndPagination(t *testing.T, b Backend) {
+	ctx := context.Background()
+	create(t, b, "A", 20)
+	create(t, b, "B", 10)
+	create(t, b, "C", 20)
+	create(t, b, "D", 5)
+
+	tests := []struct {
+		name   string
+		filter ticket.ListFilter
+		want   []string
+	}{
+		{"id ascending", ticket.ListFilter{Limit: 10}, []string{"A", "B", "C", "D"}},
+		{"id descending", ticket.ListFilter{SortDesc: true, Limit: 10}, []string{"D", "C", "B", "A"}},
+		{"allocation ties are ordered by id", ticket.ListFilter{SortBy: ticket.SortByAllocation, Limit: 10}, []string{"D", "B", "A", "C"}},
+		{"allocation descending", ticket.ListFilter{SortBy: ticket.SortByAllocation, SortDesc: true, Limit: 10}, []string{"C", "A", "B", "D"}},
+		{"first page", ticket.ListFilter{Limit: 3}, []string{"A", "B", "C"}},
+		{"second page", ticket.ListFilter{Offset: 3, Limit: 3}, []string{"D"}},
+		{"past the end", ticket.ListFilter{Offset: 10, Limit: 3}, []string{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tickets, total, err := b.Tickets.List(ctx, tc.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, names(tickets))
+			assert.Equal(t, int64(4), total)
+		})
+	}
+}
+
+func testRollback(t *testing.T, b Backend) {
+	ctx := context.Background()
+	tk := create(t, b, "Ticket", 10)
+
+	var createdID int
+	err := b.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		locked, err := b.Tickets.FindByIDForUpdate(ctx, tk.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := locked.DecrementAllocation(ctx, 4, time.Now()); err != nil {
+			return err
+		}
+
+		if err := b.Tickets.Update(ctx, locked); err != nil {
+			return err
+		}
+
+		created := newTicket(t, "Created", 5)
+		if err := b.Tickets.Create(ctx, created); err != nil {
+			return err
+		}
+		createdID = created.ID
+
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+
+	found := find(t, b, tk.ID)
+	assert.Equal(t, 10, found.Allocation.GetValue())
+	assert.Zero(t, found.Sold)
+	assert.Equal(t, 1, found.Version)
+
+	_, err = b.Tickets.FindByID(ctx, createdID)
+	assert.ErrorIs(t, err, ticket.ErrTicketNotFound)
+}
+
+func testNestedRollback(t *testing.T, b Backend) {
+	ctx := context.Background()
+	tk := create(t, b, "Ticket", 10)
+
+	err := b.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		outer, err := b.Tickets.FindByIDForUpdate(ctx, tk.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := outer.DecrementAllocation(ctx, 1, time.Now()); err != nil {
+			return err
+		}
+
+		if err := b.Tickets.Update(ctx, outer); err != nil {
+			return err
+		}
+
+		err = b.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+			inner, err := b.Tickets.FindByIDForUpdate(ctx, tk.ID)
+			if err != nil {
+				return err
+			}
+
+			if err := inner.DecrementAllocation(ctx, 5, time.Now()); err != nil {
+				return err
+			}
+
+			if err := b.Tickets.Update(ctx, inner); err != nil {
+				return err
+			}
+
+			return errRollback
+		})
+		if !errors.Is(err, errRollback) {
+			return err
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	found := find(t, b, tk.ID)
+	assert.Equal(t, 9, found.Allocation.GetValue(), "only the write of the outer unit of work is kept")
+	assert.Equal(t, 2, found.Version)
+}
+
+func testFindByIDForUpdateBlocks(t *testing.T, b Backend) {
+	ctx := context.Background()
+	tk := create(t, b, "Ticket", 10)
+
+	locked := make(chan struct{})
+	release := make(chan struct{})
+	first := make(chan error, 1)
+	go func() {
+		first <- b.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+			row, err := lockAndSignal(ctx, b, tk.ID, locked)
+			if err != nil {
+				return err
+			}
+
+			<-release
+			if err := row.DecrementAllocation(ctx, 2, time.Now()); err != nil {
+				return err
+			}
+
+			return b.Tickets.Update(ctx, row)
+		})
+	}()
+
+	select {
+	case <-locked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("first unit of work did not lock the ticket")
+	}
+
+	second := make(chan *ticket.Ticket, 1)
+	go func() {
+		var seen *ticket.Ticket
+		err := b.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+			var err error
+			seen, err = b.Tickets.FindByIDForUpdate(ctx, tk.ID)
+			return err
+		})
+		assert.NoError(t, err)
+		second <- seen
+	}()
+
+	select {
+	case <-second:
+		t.Fatal("FindByIDForUpdate did not wait for the lock")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	require.NoError(t, <-first)
+
+	select {
+	case seen := <-second:
+		require.NotNil(t, seen)
+		assert.Equal(t, 8, seen.Allocation.GetValue(), "the waiting unit of work sees the committed write")
+		assert.Equal(t, 2, seen.Version)
+	case <-time.After(5 * time.Second):
+		t.Fatal("FindByIDForUpdate did not return after the lock was released")
+	}
+}
+
+func lockAndSignal(ctx context.Context, b Backend, id int, locked chan<- struct{}) (*ticket.Ticket, error) {
+	defer close(locked)
+	return b.Tickets.FindByIDForUpdate(ctx, id)
+}
+
+func testConcurrentPurchases(t *testing.T, b Backend) {
+	const (
+		buyers     = 20
+		allocation = 15
+	)
+
+	ctx := context.Background()
+	tk := create(t, b, "Ticket", allocation)
+
+	var (
+		wg           sync.WaitGroup
+		mu           sync.Mutex
+		sold, failed int
+	)
+	for i := 0; i < buyers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := b.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+				locked, err := b.Tickets.FindByIDForUpdate(ctx, tk.ID)
+				if err != nil {
+					return err
+				}
+
+				if err := locked.DecrementAllocation(ctx, 1, time.Now()); err != nil {
+					return err
+				}
+
+				return b.Tickets.Update(ctx, locked)
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				sold++
+			case errors.Is(err, ticket.ErrInsufficientAllocation):
+				failed++
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, allocation, sold)
+	assert.Equal(t, buyers-allocation, failed)
+
+	found := find(t, b, tk.ID)
+	assert.Zero(t, found.Allocation.GetValue())
+	assert.Equal(t, allocation, found.Sold)
+	assert.Equal(t, allocation+1, found.Version)
+}
+
+func ledger(t *testing.T, b Backend, id int) []*ticket.LedgerEntry {
+	t.Helper()
+
+	entries, total, err := b.Tickets.ListLedger(context.Background(), id, 0, 100)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(entries)), total)
+
+	return entries
+}
+
+func testLedger(t *testing.T, b Backend) {
+	ctx := audit.WithRequestID(audit.WithActor(context.Background(), "user-1"), "req-1")
+	tk := newTicket(t, "Ticket", 10)
+	require.NoError(t, b.Tickets.Create(ctx, tk))
+	create(t, b, "Other", 3)
+
+	require.NoError(t, tk.DecrementAllocation(ctx, 4, time.Now()))
+	require.NoError(t, tk.ReturnAllocation(ctx, 1, time.Now()))
+	require.NoError(t, b.Tickets.Update(ctx, tk))
+
+	require.NoError(t, tk.Rename("Renamed"))
+	require.NoError(t, b.Tickets.Update(context.Background(), tk))
+
+	entries := ledger(t, b, tk.ID)
+	require.Len(t, entries, 3, "an update without allocation changes writes no entry")
+	type row struct {
+		Delta      int
+		Allocation int
+		Reason     ticket.LedgerReason
+	}
+	var rows []row
+	for _, e := range entries {
+		rows = append(rows, row{e.Delta, e.Allocation, e.Reason})
+		assert.Equal(t, tk.ID, e.TicketID)
+		assert.Equal(t, "user-1", e.ActorID)
+		assert.Equal(t, "req-1", e.RequestID)
+	}
+	assert.Equal(t, []row{
+		{10, 10, ticket.ReasonCreate},
+		{-4, 6, ticket.ReasonPurchase},
+		{1, 7, ticket.ReasonRefund},
+	}, rows)
+	assert.Less(t, entries[0].ID, entries[1].ID)
+	assert.Less(t, entries[1].ID, entries[2].ID)
+
+	page, total, err := b.Tickets.ListLedger(ctx, tk.ID, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, page, 1)
+	assert.Equal(t, entries[1].ID, page[0].ID)
+
+	var replay ticket.LedgerReplay
+	for _, e := range entries {
+		require.NoError(t, replay.Apply(e))
+	}
+	assert.NoError(t, replay.Check(find(t, b, tk.ID)))
+}
+
+func testLedgerVersionConflict(t *testing.T, b Backend) {
+	ctx := context.Background()
+	tk := create(t, b, "Ticket", 10)
+	stale := find(t, b, tk.ID)
+
+	require.NoError(t, tk.DecrementAllocation(ctx, 1, time.Now()))
+	require.NoError(t, b.Tickets.Update(ctx, tk))
+
+	require.NoError(t, stale.DecrementAllocation(ctx, 5, time.Now()))
+	require.ErrorIs(t, b.Tickets.Update(ctx, stale), ticket.ErrVersionConflict)
+
+	entries := ledger(t, b, tk.ID)
+	require.Len(t, entries, 2, "a failed update must not write ledger entries")
+	assert.Equal(t, 9, entries[1].Allocation)
+}
+
+func testLedgerRollback(t *testing.T, b Backend) {
+	ctx := context.Background()
+	tk := create(t, b, "Ticket", 10)
+
+	var createdID int
+	err := b.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		locked, err := b.Tickets.FindByIDForUpdate(ctx, tk.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := locked.Hold(ctx, 4, time.Now()); err != nil {
+			return err
+		}
+
+		if err := b.Tickets.Update(ctx, locked); err != nil {
+			return err
+		}
+
+		created := newTicket(t, "Created", 5)
+		if err := b.Tickets.Create(ctx, created); err != nil {
+			return err
+		}
+		createdID = created.ID
+
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+
+	assert.Len(t, ledger(t, b, tk.ID), 1)
+	assert.Empty(t, ledger(t, b, createdID))
+
+	require.NoError(t, tk.Hold(ctx, 2, time.Now()))
+	require.NoError(t, b.Tickets.Update(ctx, tk))
+
+	entries := ledger(t, b, tk.ID)
+	require.Len(t, entries, 2)
+	assert.Equal(t, 8, entries[1].Allocation)
+}
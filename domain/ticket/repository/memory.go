@@ -0,0 +1,308 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/pkg/audit"
+	"gorm.io/gorm"
+)
+
+// MemoryRepository keeps tickets in memory, it is meant for tests and for
+// running the API without a database. It has to be used together with
+// db.MemoryUnitOfWork so that row locks and rollbacks work.
+type MemoryRepository struct {
+	mu           sync.RWMutex
+	tickets      map[int]ticket.Ticket
+	ledger       []ticket.LedgerEntry
+	lastID       int
+	lastLedgerID int64
+	locks        db.RowLocks
+	now          func() time.Time
+}
+
+func NewMemoryTicketRepository() TicketRepository {
+	return &MemoryRepository{tickets: make(map[int]ticket.Ticket), now: time.Now}
+}
+
+func (r *MemoryRepository) Create(ctx context.Context, t *ticket.Ticket) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	t.ID = r.lastID
+
+	now := r.now()
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = now
+	}
+
+	if t.UpdatedAt.IsZero() {
+		t.UpdatedAt = now
+	}
+
+	if t.Version == 0 {
+		t.Version = 1
+	}
+
+	r.appendLedger(ctx, t.PullLedger())
+
+	id := t.ID
+	stored := *t
+	stored.PullEvents()
+	r.tickets[id] = stored
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		delete(r.tickets, id)
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (r *MemoryRepository) FindByID(ctx context.Context, id int) (*ticket.Ticket, error) {
+	return r.find(id, false)
+}
+
+func (r *MemoryRepository) FindByIDUnscoped(ctx context.Context, id int) (*ticket.Ticket, error) {
+	return r.find(id, true)
+}
+
+func (r *MemoryRepository) FindByIDForUpdate(ctx context.Context, id int) (*ticket.Ticket, error) {
+	if err := r.locks.Lock(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return r.find(id, false)
+}
+
+func (r *MemoryRepository) List(ctx context.Context, filter ticket.ListFilter) ([]*ticket.Ticket, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	name := strings.ToLower(filter.Name)
+	var tickets []*ticket.Ticket
+	for _, stored := range r.tickets {
+		t := stored
+		allocation := t.Allocation.GetValue()
+		switch {
+		case t.IsDeleted():
+			continue
+		case filter.EventID != nil && (t.EventID == nil || *t.EventID != *filter.EventID):
+			continue
+		case name != "" && !strings.Contains(strings.ToLower(t.Name.GetValue()), name):
+			continue
+		case filter.MinAllocation != nil && allocation < *filter.MinAllocation:
+			continue
+		case filter.MaxAllocation != nil && allocation > *filter.MaxAllocation:
+			continue
+		case filter.SoldOut != nil && *filter.SoldOut != (allocation == 0):
+			continue
+		case filter.CreatedAfter != nil && t.CreatedAt.Before(*filter.CreatedAfter):
+			continue
+		case filter.CreatedBefore != nil && !t.CreatedAt.Before(*filter.CreatedBefore):
+			continue
+		}
+
+		tickets = append(tickets, &t)
+	}
+
+	sort.Slice(tickets, func(i, j int) bool {
+		a, b := tickets[i], tickets[j]
+		if filter.SortDesc {
+			a, b = b, a
+		}
+
+		switch filter.SortBy {
+		case ticket.SortByAllocation:
+			if a.Allocation.GetValue() != b.Allocation.GetValue() {
+				return a.Allocation.GetValue() < b.Allocation.GetValue()
+			}
+		case ticket.SortByCreatedAt:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+		}
+
+		return a.ID < b.ID
+	})
+
+	total := int64(len(tickets))
+	start := min(filter.Offset, len(tickets))
+	end := len(tickets)
+	if filter.Limit > 0 {
+		end = min(start+filter.Limit, len(tickets))
+	}
+
+	return tickets[start:end], total, nil
+}
+
+// Update has the same optimistic version check as Repository.Update.
+func (r *MemoryRepository) Update(ctx context.Context, t *ticket.Ticket) error {
+	return r.write(ctx, t.ID, false, func(stored *ticket.Ticket) error {
+		if stored.IsDeleted() {
+			return ticket.ErrVersionConflict
+		}
+
+		if stored.Version != t.Version {
+			return ticket.ErrVersionConflict
+		}
+
+		t.Version++
+		t.UpdatedAt = r.now()
+		r.appendLedger(ctx, t.PullLedger())
+		*stored = *t
+		return nil
+	})
+}
+
+func (r *MemoryRepository) Delete(ctx context.Context, t *ticket.Ticket) error {
+	return r.write(ctx, t.ID, false, func(stored *ticket.Ticket) error {
+		if stored.IsDeleted() || stored.Version != t.Version {
+			return ticket.ErrVersionConflict
+		}
+
+		stored.DeletedAt = gorm.DeletedAt{Time: r.now(), Valid: true}
+		stored.Version++
+		t.DeletedAt = stored.DeletedAt
+		t.Version = stored.Version
+		return nil
+	})
+}
+
+func (r *MemoryRepository) Restore(ctx context.Context, t *ticket.Ticket) error {
+	return r.write(ctx, t.ID, true, func(stored *ticket.Ticket) error {
+		if stored.Version != t.Version {
+			return ticket.ErrVersionConflict
+		}
+
+		stored.DeletedAt = gorm.DeletedAt{}
+		stored.Version++
+		t.Version = stored.Version
+		return nil
+	})
+}
+
+// write applies change to the stored ticket and registers the previous
+// state to be put back when the unit of work rolls back. A missing ticket
+// is reported as a version conflict, like a conditional update that
+// matches no rows.
+func (r *MemoryRepository) write(ctx context.Context, id int, unscoped bool, change func(stored *ticket.Ticket) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, ok := r.tickets[id]
+	if !ok || (!unscoped && previous.IsDeleted()) {
+		return ticket.ErrVersionConflict
+	}
+
+	stored := previous
+	if err := change(&stored); err != nil {
+		return err
+	}
+
+	// Recorded events stay with the caller, who publishes them.
+	stored.PullEvents()
+
+	r.tickets[id] = stored
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		r.tickets[id] = previous
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (r *MemoryRepository) EventAllocation(ctx context.Context, eventID int) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var allocated int
+	for _, t := range r.tickets {
+		if !t.IsDeleted() && t.EventID != nil && *t.EventID == eventID {
+			allocated += t.Total()
+		}
+	}
+
+	return allocated, nil
+}
+
+func (r *MemoryRepository) CountByEvent(ctx context.Context, eventID int) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, t := range r.tickets {
+		if t.EventID != nil && *t.EventID == eventID {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (r *MemoryRepository) ListLedger(ctx context.Context, ticketID int, offset, limit int) ([]*ticket.LedgerEntry, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var entries []*ticket.LedgerEntry
+	for _, stored := range r.ledger {
+		if stored.TicketID == ticketID {
+			e := stored
+			entries = append(entries, &e)
+		}
+	}
+
+	total := int64(len(entries))
+	start := min(offset, len(entries))
+	end := min(start+limit, len(entries))
+	return entries[start:end], total, nil
+}
+
+// appendLedger has to be called with r.mu locked. Entries are kept in the
+// order they were written, which is the order of their IDs.
+func (r *MemoryRepository) appendLedger(ctx context.Context, entries []*ticket.LedgerEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	first := r.lastLedgerID + 1
+	for _, e := range entries {
+		r.lastLedgerID++
+		e.ID = r.lastLedgerID
+		e.ActorID = audit.Actor(ctx)
+		e.RequestID = audit.RequestID(ctx)
+		r.ledger = append(r.ledger, *e)
+	}
+
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		kept := r.ledger[:0]
+		for _, e := range r.ledger {
+			if e.ID < first || e.ID > first+int64(len(entries))-1 {
+				kept = append(kept, e)
+			}
+		}
+		r.ledger = kept
+	})
+}
+
+func (r *MemoryRepository) find(id int, unscoped bool) (*ticket.Ticket, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	t, ok := r.tickets[id]
+	if !ok || (!unscoped && t.IsDeleted()) {
+		return nil, ticket.ErrTicketNotFound
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,40 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket/repository/contracttest"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRepository_Contract(t *testing.T) {
+	contracttest.Run(t, func(t *testing.T) contracttest.Backend {
+		return contracttest.Backend{
+			Tickets:    repository.NewMemoryTicketRepository(),
+			UnitOfWork: db.NewMemoryUnitOfWork(),
+		}
+	})
+}
+
+func TestMemoryRepository_ReturnsCopies(t *testing.T) {
+	repo := repository.NewMemoryTicketRepository()
+	ctx := context.Background()
+
+	tk, err := ticket.NewTicket("Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+	assert.NoError(t, err)
+	assert.NoError(t, repo.Create(ctx, tk))
+
+	tk.Sold = 3
+	found, err := repo.FindByID(ctx, tk.ID)
+	assert.NoError(t, err)
+	assert.Zero(t, found.Sold, "the created ticket must not be shared with the repository")
+
+	found.Sold = 5
+	again, _ := repo.FindByID(ctx, tk.ID)
+	assert.Zero(t, again.Sold, "callers must not be able to change stored tickets")
+}
@@ -3,19 +3,33 @@ package repository
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/pkg/audit"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 //go:generate mockgen -destination=../../../mock/repository/ticket/ticket.go -package=repository github.com/aaydin-tr/ddd-api-example/domain/ticket/repository TicketRepository
 type TicketRepository interface {
-	GetDB(ctx context.Context) *gorm.DB
 	Create(ctx context.Context, t *ticket.Ticket) error
 	FindByID(ctx context.Context, id int) (*ticket.Ticket, error)
-	FindByIDForUpdate(ctx context.Context, id int, tx *gorm.DB) (*ticket.Ticket, error)
-	Update(ctx context.Context, ticket *ticket.Ticket, tx *gorm.DB) error
+	List(ctx context.Context, filter ticket.ListFilter) ([]*ticket.Ticket, int64, error)
+	FindByIDForUpdate(ctx context.Context, id int) (*ticket.Ticket, error)
+	Update(ctx context.Context, ticket *ticket.Ticket) error
+	FindByIDUnscoped(ctx context.Context, id int) (*ticket.Ticket, error)
+	Delete(ctx context.Context, t *ticket.Ticket) error
+	Restore(ctx context.Context, t *ticket.Ticket) error
+	// ListLedger returns the allocation ledger of a ticket in the order it
+	// was written, with the total number of entries.
+	ListLedger(ctx context.Context, ticketID int, offset, limit int) ([]*ticket.LedgerEntry, int64, error)
+	// EventAllocation adds up the units of the tickets of an event that are
+	// not deleted, see Ticket.Total.
+	EventAllocation(ctx context.Context, eventID int) (int, error)
+	// CountByEvent counts the tickets of an event, deleted ones included.
+	CountByEvent(ctx context.Context, eventID int) (int64, error)
 }
 
 type Repository struct {
@@ -26,17 +40,19 @@ func NewTicketRepository(db *gorm.DB) TicketRepository {
 	return &Repository{db: db}
 }
 
-func (r *Repository) GetDB(ctx context.Context) *gorm.DB {
-	return r.db.WithContext(ctx)
-}
-
+// Create and Update write the ledger entries recorded by the ticket with
+// the row, they have to run in a unit of work to be atomic.
 func (r *Repository) Create(ctx context.Context, t *ticket.Ticket) error {
-	return r.db.WithContext(ctx).Create(t).Error
+	if err := db.Conn(ctx, r.db).Create(t).Error; err != nil {
+		return err
+	}
+
+	return r.appendLedger(ctx, t)
 }
 
 func (r *Repository) FindByID(ctx context.Context, id int) (*ticket.Ticket, error) {
 	var t ticket.Ticket
-	err := r.db.WithContext(ctx).First(&t, id).Error
+	err := db.Conn(ctx, r.db).First(&t, id).Error
 	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, ticket.ErrTicketNotFound
 	}
@@ -48,9 +64,67 @@ func (r *Repository) FindByID(ctx context.Context, id int) (*ticket.Ticket, erro
 	return &t, nil
 }
 
-func (r *Repository) FindByIDForUpdate(ctx context.Context, id int, tx *gorm.DB) (*ticket.Ticket, error) {
+func (r *Repository) List(ctx context.Context, filter ticket.ListFilter) ([]*ticket.Ticket, int64, error) {
+	query := db.Conn(ctx, r.db).Model(&ticket.Ticket{})
+	if filter.EventID != nil {
+		query = query.Where("event_id = ?", *filter.EventID)
+	}
+
+	if filter.Name != "" {
+		query = query.Where("name ILIKE ?", "%"+escapeLike(filter.Name)+"%")
+	}
+
+	if filter.MinAllocation != nil {
+		query = query.Where("allocation >= ?", *filter.MinAllocation)
+	}
+
+	if filter.MaxAllocation != nil {
+		query = query.Where("allocation <= ?", *filter.MaxAllocation)
+	}
+
+	if filter.SoldOut != nil && *filter.SoldOut {
+		query = query.Where("allocation = 0")
+	}
+
+	if filter.SoldOut != nil && !*filter.SoldOut {
+		query = query.Where("allocation > 0")
+	}
+
+	if filter.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedAfter)
+	}
+
+	if filter.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *filter.CreatedBefore)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	sortBy := filter.SortBy
+	if sortBy == "" {
+		sortBy = ticket.SortByID
+	}
+
+	var tickets []*ticket.Ticket
+	err := query.
+		Order(clause.OrderByColumn{Column: clause.Column{Name: string(sortBy)}, Desc: filter.SortDesc}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: string(ticket.SortByID)}, Desc: filter.SortDesc}).
+		Offset(filter.Offset).
+		Limit(filter.Limit).
+		Find(&tickets).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return tickets, total, nil
+}
+
+func (r *Repository) FindByIDForUpdate(ctx context.Context, id int) (*ticket.Ticket, error) {
 	var t ticket.Ticket
-	err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).First(&t, id).Error
+	err := db.Conn(ctx, r.db).Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).First(&t, id).Error
 	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, ticket.ErrTicketNotFound
 	}
@@ -62,11 +136,123 @@ func (r *Repository) FindByIDForUpdate(ctx context.Context, id int, tx *gorm.DB)
 	return &t, nil
 }
 
-func (r *Repository) Update(ctx context.Context, t *ticket.Ticket, tx *gorm.DB) error {
-	err := tx.Save(t).Error
+// Update writes the ticket only if its version is still the one that was
+// read and increments the version, ErrVersionConflict is returned when
+// another write got there first.
+func (r *Repository) Update(ctx context.Context, t *ticket.Ticket) error {
+	version := t.Version
+	t.Version++
+
+	result := db.Conn(ctx, r.db).Model(t).Select("*").Where("version = ?", version).Updates(t)
+	if result.Error != nil {
+		t.Version = version
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		t.Version = version
+		return ticket.ErrVersionConflict
+	}
+
+	return r.appendLedger(ctx, t)
+}
+
+func (r *Repository) EventAllocation(ctx context.Context, eventID int) (int, error) {
+	var allocated int
+	err := db.Conn(ctx, r.db).Model(&ticket.Ticket{}).
+		Where("event_id = ?", eventID).
+		Select("COALESCE(SUM(allocation + sold + held), 0)").
+		Scan(&allocated).Error
+	return allocated, err
+}
+
+func (r *Repository) CountByEvent(ctx context.Context, eventID int) (int64, error) {
+	var count int64
+	err := db.Conn(ctx, r.db).Unscoped().Model(&ticket.Ticket{}).Where("event_id = ?", eventID).Count(&count).Error
+	return count, err
+}
+
+func (r *Repository) ListLedger(ctx context.Context, ticketID int, offset, limit int) ([]*ticket.LedgerEntry, int64, error) {
+	query := db.Conn(ctx, r.db).Model(&ticket.LedgerEntry{}).Where("ticket_id = ?", ticketID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var entries []*ticket.LedgerEntry
+	if err := query.Order("id").Offset(offset).Limit(limit).Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
+func (r *Repository) appendLedger(ctx context.Context, t *ticket.Ticket) error {
+	entries := t.PullLedger()
+	if len(entries) == 0 {
+		return nil
+	}
+
+	for _, e := range entries {
+		e.ActorID = audit.Actor(ctx)
+		e.RequestID = audit.RequestID(ctx)
+	}
+
+	return db.Conn(ctx, r.db).Create(entries).Error
+}
+
+func (r *Repository) FindByIDUnscoped(ctx context.Context, id int) (*ticket.Ticket, error) {
+	var t ticket.Ticket
+	err := db.Conn(ctx, r.db).Unscoped().First(&t, id).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ticket.ErrTicketNotFound
+	}
+
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// Delete soft deletes the ticket and bumps its version in the same update,
+// so that a restore made with the version read before the delete conflicts.
+func (r *Repository) Delete(ctx context.Context, t *ticket.Ticket) error {
+	deletedAt := gorm.DeletedAt{Time: r.db.NowFunc(), Valid: true}
+	result := db.Conn(ctx, r.db).Model(t).
+		Where("version = ?", t.Version).
+		Updates(map[string]interface{}{"deleted_at": deletedAt, "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ticket.ErrVersionConflict
 	}
 
+	t.DeletedAt = deletedAt
+	t.Version++
 	return nil
 }
+
+func (r *Repository) Restore(ctx context.Context, t *ticket.Ticket) error {
+	result := db.Conn(ctx, r.db).Unscoped().Model(t).
+		Where("version = ?", t.Version).
+		Updates(map[string]interface{}{"deleted_at": nil, "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ticket.ErrVersionConflict
+	}
+
+	t.Version++
+	return nil
+}
+
+func escapeLike(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	return replacer.Replace(value)
+}
@@ -0,0 +1,100 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket/repository/contracttest"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db/migration"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db/postgresql"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+	"gorm.io/gorm"
+)
+
+func TestRepository_Contract(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+
+	dbClient := startPostgres(t)
+	contracttest.Run(t, func(t *testing.T) contracttest.Backend {
+		if err := dbClient.Exec("TRUNCATE tickets RESTART IDENTITY CASCADE").Error; err != nil {
+			t.Fatalf("could not truncate tickets: %s", err)
+		}
+
+		return contracttest.Backend{
+			Tickets:    repository.NewTicketRepository(dbClient),
+			UnitOfWork: db.NewUnitOfWork(dbClient, db.NoRetry, sql.LevelDefault),
+		}
+	})
+}
+
+func startPostgres(t *testing.T) *gorm.DB {
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		t.Fatalf("could not connect to docker: %s", err)
+	}
+
+	resource, err := pool.RunWithOptions(
+		&dockertest.RunOptions{
+			Repository: "postgres",
+			Tag:        "17",
+			Env: []string{
+				"POSTGRES_USER=postgres",
+				"POSTGRES_PASSWORD=secret",
+				"POSTGRES_DB=ticket",
+			},
+		},
+		func(hostConfig *docker.HostConfig) {
+			hostConfig.AutoRemove = true
+			hostConfig.RestartPolicy = docker.RestartPolicy{Name: "no"}
+		},
+	)
+	if err != nil {
+		t.Fatalf("could not start postgres: %s", err)
+	}
+
+	t.Cleanup(func() {
+		if err := pool.Purge(resource); err != nil {
+			t.Errorf("could not purge postgres: %s", err)
+		}
+	})
+
+	var dbClient *gorm.DB
+	err = pool.Retry(func() error {
+		port, _ := strconv.Atoi(resource.GetPort("5432/tcp"))
+		client, sqlDB, err := postgresql.NewPostgresDB("localhost", "postgres", "secret", "ticket", port)
+		if err != nil {
+			return err
+		}
+
+		migrator, err := migration.NewMigrator(sqlDB)
+		if err != nil {
+			return err
+		}
+
+		if _, err := migrator.Up(context.Background()); err != nil {
+			return err
+		}
+
+		dbClient = client
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not migrate postgres: %s", err)
+	}
+
+	t.Cleanup(func() {
+		if sqlDB, err := dbClient.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	return dbClient
+}
@@ -0,0 +1,74 @@
+package ticket
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrSalesNotOpen       = errors.New("ticket sales have not started yet")
+	ErrSalesClosed        = errors.New("ticket sales are closed")
+	ErrInvalidSalesWindow = errors.New("sales end must be after sales start")
+)
+
+type SaleStatus string
+
+const (
+	SaleStatusUpcoming SaleStatus = "upcoming"
+	SaleStatusOnSale   SaleStatus = "on_sale"
+	SaleStatusSoldOut  SaleStatus = "sold_out"
+	SaleStatusClosed   SaleStatus = "closed"
+)
+
+// ChangeSalesWindow sets when the ticket goes on and off sale. A nil start
+// puts it on sale right away, a nil end keeps it on sale until it is sold
+// out.
+func (t *Ticket) ChangeSalesWindow(start, end *time.Time) error {
+	if err := t.checkNotArchived(); err != nil {
+		return err
+	}
+
+	if start != nil && end != nil && !end.After(*start) {
+		return ErrInvalidSalesWindow
+	}
+
+	t.SalesStartAt = start
+	t.SalesEndAt = end
+	return nil
+}
+
+// CheckSalesWindow returns ErrSalesNotOpen before the start and
+// ErrSalesClosed from the end of the sales window on.
+func (t *Ticket) CheckSalesWindow(now time.Time) error {
+	if t.SalesStartAt != nil && now.Before(*t.SalesStartAt) {
+		return ErrSalesNotOpen
+	}
+
+	if t.SalesEndAt != nil && !now.Before(*t.SalesEndAt) {
+		return ErrSalesClosed
+	}
+
+	return nil
+}
+
+// SaleStatus tells whether the ticket can be bought at now. Drafts are
+// upcoming, paused and archived tickets are closed.
+func (t *Ticket) SaleStatus(now time.Time) SaleStatus {
+	switch t.Status {
+	case StatusDraft:
+		return SaleStatusUpcoming
+	case StatusPaused, StatusArchived:
+		return SaleStatusClosed
+	}
+
+	switch err := t.CheckSalesWindow(now); {
+	case errors.Is(err, ErrSalesNotOpen):
+		return SaleStatusUpcoming
+	case errors.Is(err, ErrSalesClosed):
+		return SaleStatusClosed
+	case t.Allocation.GetValue() == 0:
+		return SaleStatusSoldOut
+	}
+
+	return SaleStatusOnSale
+}
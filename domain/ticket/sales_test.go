@@ -0,0 +1,85 @@
+package ticket_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeSalesWindow(t *testing.T) {
+	start := time.Date(2026, 11, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	tk := &ticket.Ticket{}
+	assert.NoError(t, tk.ChangeSalesWindow(&start, &end))
+	assert.Equal(t, &start, tk.SalesStartAt)
+	assert.Equal(t, &end, tk.SalesEndAt)
+
+	assert.ErrorIs(t, tk.ChangeSalesWindow(&end, &start), ticket.ErrInvalidSalesWindow)
+	assert.ErrorIs(t, tk.ChangeSalesWindow(&start, &start), ticket.ErrInvalidSalesWindow)
+	assert.Equal(t, &end, tk.SalesEndAt, "a rejected window must not change the ticket")
+
+	assert.NoError(t, tk.ChangeSalesWindow(nil, &end))
+	assert.Nil(t, tk.SalesStartAt)
+}
+
+func TestSalesWindow(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 11, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	tests := []struct {
+		name   string
+		start  *time.Time
+		end    *time.Time
+		now    time.Time
+		err    error
+		status ticket.SaleStatus
+	}{
+		{name: "no window", now: start, status: ticket.SaleStatusOnSale},
+		{name: "before start", start: &start, end: &end, now: start.Add(-time.Second), err: ticket.ErrSalesNotOpen, status: ticket.SaleStatusUpcoming},
+		{name: "at start", start: &start, end: &end, now: start, status: ticket.SaleStatusOnSale},
+		{name: "before end", start: &start, end: &end, now: end.Add(-time.Second), status: ticket.SaleStatusOnSale},
+		{name: "at end", start: &start, end: &end, now: end, err: ticket.ErrSalesClosed, status: ticket.SaleStatusClosed},
+		{name: "only start", start: &start, now: end.Add(time.Hour), status: ticket.SaleStatusOnSale},
+		{name: "only end", end: &end, now: start.Add(-time.Hour), status: ticket.SaleStatusOnSale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tk := &ticket.Ticket{Status: ticket.StatusPublished, Allocation: mustAllocation(t, 10)}
+			assert.NoError(t, tk.ChangeSalesWindow(tt.start, tt.end))
+
+			assert.Equal(t, tt.status, tk.SaleStatus(tt.now))
+			assert.ErrorIs(t, tk.DecrementAllocation(ctx, 1, tt.now), tt.err)
+			assert.ErrorIs(t, tk.Hold(ctx, 1, tt.now), tt.err)
+			if tt.err != nil {
+				assert.Equal(t, 10, tk.Allocation.GetValue())
+				assert.Empty(t, tk.PullEvents())
+			}
+		})
+	}
+
+	t.Run("sold out", func(t *testing.T) {
+		tk := &ticket.Ticket{Status: ticket.StatusPublished, Allocation: mustAllocation(t, 0)}
+		assert.NoError(t, tk.ChangeSalesWindow(&start, &end))
+
+		assert.Equal(t, ticket.SaleStatusSoldOut, tk.SaleStatus(start))
+		assert.Equal(t, ticket.SaleStatusUpcoming, tk.SaleStatus(start.Add(-time.Second)))
+		assert.Equal(t, ticket.SaleStatusClosed, tk.SaleStatus(end))
+	})
+	t.Run("not published", func(t *testing.T) {
+		tk := &ticket.Ticket{Allocation: mustAllocation(t, 10)}
+		assert.NoError(t, tk.ChangeSalesWindow(&start, &end))
+
+		tk.Status = ticket.StatusDraft
+		assert.Equal(t, ticket.SaleStatusUpcoming, tk.SaleStatus(start))
+		tk.Status = ticket.StatusPaused
+		assert.Equal(t, ticket.SaleStatusClosed, tk.SaleStatus(start))
+		tk.Status = ticket.StatusArchived
+		assert.Equal(t, ticket.SaleStatusClosed, tk.SaleStatus(start))
+	})
+}
@@ -0,0 +1,61 @@
+package ticket
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+)
+
+var (
+	ErrNoSeatMap         = errors.New("ticket has no seat map")
+	ErrSeatMapExists     = errors.New("ticket already has a seat map")
+	ErrSeatMapAfterSales = errors.New("a seat map can only be added before units are sold or held")
+	ErrAssignedSeating   = errors.New("the allocation of a ticket with assigned seating follows its seat map")
+)
+
+// Seating tells whether the units of a ticket are interchangeable or every
+// unit is a seat of its seat map.
+type Seating string
+
+const (
+	// SeatingGeneral tickets only count their units.
+	SeatingGeneral Seating = "general"
+	// SeatingAssigned tickets sell the seats of their seat map, the
+	// allocation, sold and held quantities count the seats in each state.
+	SeatingAssigned Seating = "assigned"
+)
+
+func (t *Ticket) IsSeated() bool {
+	return t.Seating == SeatingAssigned
+}
+
+// AssignSeats switches the ticket to assigned seating with a seat map of
+// seats seats, which become its allocation.
+func (t *Ticket) AssignSeats(seats int, now time.Time) error {
+	if err := t.checkNotArchived(); err != nil {
+		return err
+	}
+
+	if t.IsSeated() {
+		return ErrSeatMapExists
+	}
+
+	if t.Sold+t.Held > 0 {
+		return ErrSeatMapAfterSales
+	}
+
+	allocation, err := valueobject.NewAllocation(seats)
+	if err != nil {
+		return err
+	}
+
+	delta := allocation.GetValue() - t.Allocation.GetValue()
+	t.Allocation = allocation
+	t.Seating = SeatingAssigned
+	if delta != 0 {
+		t.recordLedger(delta, ReasonAdjust, now)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package venue
+
+import (
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+)
+
+type VenueDTO struct {
+	ID        int                     `json:"id"`
+	Name      string                  `json:"name"`
+	Address   *valueobject.AddressDTO `json:"address"`
+	Timezone  string                  `json:"timezone"`
+	CreatedAt time.Time               `json:"created_at"`
+	UpdatedAt time.Time               `json:"updated_at"`
+} // @Name VenueDTO
+
+func NewVenueDTOFromEntity(v *Venue) *VenueDTO {
+	return &VenueDTO{
+		ID:        v.ID,
+		Name:      v.Name.GetValue(),
+		Address:   valueobject.NewAddressDTO(v.Address),
+		Timezone:  v.Timezone.GetValue(),
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+}
+
+type VenueListDTO struct {
+	Items      []*VenueDTO `json:"items"`
+	Total      int64       `json:"total"`
+	NextCursor string      `json:"next_cursor"`
+} // @Name VenueListDTO
+
+func NewVenueListDTOFromEntities(venues []*Venue, total int64, nextCursor string) *VenueListDTO {
+	items := make([]*VenueDTO, 0, len(venues))
+	for _, v := range venues {
+		items = append(items, NewVenueDTOFromEntity(v))
+	}
+
+	return &VenueListDTO{
+		Items:      items,
+		Total:      total,
+		NextCursor: nextCursor,
+	}
+}
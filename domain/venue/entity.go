@@ -0,0 +1,85 @@
+package venue
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+)
+
+var (
+	ErrVenueNotFound     = errors.New("venue not found")
+	ErrVenueHasEvents    = errors.New("venue still has events")
+	ErrAddressIsRequired = errors.New("address is required")
+)
+
+// Venue is where events take place, the dates of its events are shown in
+// its timezone.
+type Venue struct {
+	ID        int                   `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name      *valueobject.Name     `json:"name" gorm:"not null;type:varchar(255)"`
+	Address   *valueobject.Address  `json:"address" gorm:"not null;type:jsonb"`
+	Timezone  *valueobject.Timezone `json:"timezone" gorm:"not null;type:varchar(64)"`
+	CreatedAt time.Time             `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt time.Time             `json:"updated_at" gorm:"not null;default:current_timestamp;autoUpdateTime"`
+}
+
+func (v *Venue) TableName() string {
+	return "venues"
+}
+
+func (v *Venue) Rename(name string) error {
+	venueName, err := valueobject.NewName(name)
+	if err != nil {
+		return err
+	}
+
+	v.Name = venueName
+	return nil
+}
+
+func (v *Venue) Move(street, city, postalCode, country string) error {
+	address, err := valueobject.NewAddress(street, city, postalCode, country)
+	if err != nil {
+		return err
+	}
+
+	v.Address = address
+	return nil
+}
+
+func (v *Venue) ChangeTimezone(timezone string) error {
+	tz, err := valueobject.NewTimezone(timezone)
+	if err != nil {
+		return err
+	}
+
+	v.Timezone = tz
+	return nil
+}
+
+// Location returns UTC for a venue that was not loaded with its timezone.
+func (v *Venue) Location() *time.Location {
+	if v == nil || v.Timezone == nil {
+		return time.UTC
+	}
+
+	return v.Timezone.Location()
+}
+
+func NewVenue(name, street, city, postalCode, country, timezone string) (*Venue, error) {
+	v := &Venue{}
+	if err := v.Rename(name); err != nil {
+		return nil, err
+	}
+
+	if err := v.Move(street, city, postalCode, country); err != nil {
+		return nil, err
+	}
+
+	if err := v.ChangeTimezone(timezone); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
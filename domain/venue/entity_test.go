@@ -0,0 +1,65 @@
+package venue_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/venue"
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVenue(t *testing.T) {
+	t.Run("should create venue", func(t *testing.T) {
+		v, err := venue.NewVenue("Uber Arena", "Mercedes-Platz 1", "Berlin", "10243", "DE", "Europe/Berlin")
+		assert.NoError(t, err)
+		assert.Equal(t, "Uber Arena", v.Name.GetValue())
+		assert.Equal(t, "Berlin", v.Address.GetCity())
+		assert.Equal(t, "Europe/Berlin", v.Timezone.GetValue())
+	})
+
+	tests := []struct {
+		name     string
+		venue    string
+		country  string
+		timezone string
+		wantErr  error
+	}{
+		{name: "empty name", venue: "", country: "DE", timezone: "Europe/Berlin", wantErr: valueobject.ErrNameCannotBeEmpty},
+		{name: "invalid country", venue: "Uber Arena", country: "Germany", timezone: "Europe/Berlin", wantErr: valueobject.ErrInvalidCountry},
+		{name: "invalid timezone", venue: "Uber Arena", country: "DE", timezone: "Berlin", wantErr: valueobject.ErrInvalidTimezone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := venue.NewVenue(tt.venue, "Mercedes-Platz 1", "Berlin", "10243", tt.country, tt.timezone)
+			assert.Nil(t, v)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestVenueChanges(t *testing.T) {
+	v, err := venue.NewVenue("Uber Arena", "Mercedes-Platz 1", "Berlin", "10243", "DE", "Europe/Berlin")
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, v.Rename(""), valueobject.ErrNameCannotBeEmpty)
+	assert.ErrorIs(t, v.Move("", "Hamburg", "", "DE"), valueobject.ErrStreetIsRequired)
+	assert.ErrorIs(t, v.ChangeTimezone("CEST"), valueobject.ErrInvalidTimezone)
+	assert.Equal(t, "Uber Arena", v.Name.GetValue())
+	assert.Equal(t, "Berlin", v.Address.GetCity())
+	assert.Equal(t, "Europe/Berlin", v.Timezone.GetValue())
+
+	assert.NoError(t, v.Rename("Barclays Arena"))
+	assert.NoError(t, v.Move("Sylvesterallee 10", "Hamburg", "22525", "DE"))
+	assert.NoError(t, v.ChangeTimezone("Europe/Istanbul"))
+	assert.Equal(t, "Barclays Arena", v.Name.GetValue())
+	assert.Equal(t, "Hamburg", v.Address.GetCity())
+	assert.Equal(t, "Europe/Istanbul", v.Location().String())
+}
+
+func TestLocation(t *testing.T) {
+	var missing *venue.Venue
+	assert.Equal(t, time.UTC, missing.Location())
+	assert.Equal(t, time.UTC, (&venue.Venue{}).Location())
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/venue"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+)
+
+// MemoryRepository keeps venues in memory, it has to be used together with
+// db.MemoryUnitOfWork.
+type MemoryRepository struct {
+	mu     sync.RWMutex
+	venues map[int]venue.Venue
+	lastID int
+	now    func() time.Time
+}
+
+func NewMemoryVenueRepository() VenueRepository {
+	return &MemoryRepository{venues: make(map[int]venue.Venue), now: time.Now}
+}
+
+func (r *MemoryRepository) Create(ctx context.Context, v *venue.Venue) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	v.ID = r.lastID
+
+	now := r.now()
+	if v.CreatedAt.IsZero() {
+		v.CreatedAt = now
+	}
+
+	if v.UpdatedAt.IsZero() {
+		v.UpdatedAt = now
+	}
+
+	id := v.ID
+	r.venues[id] = *v
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		delete(r.venues, id)
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (r *MemoryRepository) FindByID(ctx context.Context, id int) (*venue.Venue, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	v, ok := r.venues[id]
+	if !ok {
+		return nil, venue.ErrVenueNotFound
+	}
+
+	return &v, nil
+}
+
+func (r *MemoryRepository) List(ctx context.Context, offset, limit int) ([]*venue.Venue, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	venues := make([]*venue.Venue, 0, len(r.venues))
+	for _, stored := range r.venues {
+		v := stored
+		venues = append(venues, &v)
+	}
+
+	sort.Slice(venues, func(i, j int) bool {
+		return venues[i].ID < venues[j].ID
+	})
+
+	total := int64(len(venues))
+	start := min(offset, len(venues))
+	end := len(venues)
+	if limit > 0 {
+		end = min(start+limit, len(venues))
+	}
+
+	return venues[start:end], total, nil
+}
+
+func (r *MemoryRepository) Update(ctx context.Context, v *venue.Venue) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, ok := r.venues[v.ID]
+	if !ok {
+		return venue.ErrVenueNotFound
+	}
+
+	v.UpdatedAt = r.now()
+	r.venues[v.ID] = *v
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		r.venues[previous.ID] = previous
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (r *MemoryRepository) Delete(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, ok := r.venues[id]
+	if !ok {
+		return venue.ErrVenueNotFound
+	}
+
+	delete(r.venues, id)
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		r.venues[id] = previous
+		r.mu.Unlock()
+	})
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/venue"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -destination=../../../mock/repository/venue/venue.go -package=repository github.com/aaydin-tr/ddd-api-example/domain/venue/repository VenueRepository
+type VenueRepository interface {
+	Create(ctx context.Context, v *venue.Venue) error
+	FindByID(ctx context.Context, id int) (*venue.Venue, error)
+	// List returns venues ordered by ID with the total number of venues.
+	List(ctx context.Context, offset, limit int) ([]*venue.Venue, int64, error)
+	Update(ctx context.Context, v *venue.Venue) error
+	Delete(ctx context.Context, id int) error
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewVenueRepository(db *gorm.DB) VenueRepository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(ctx context.Context, v *venue.Venue) error {
+	return db.Conn(ctx, r.db).Create(v).Error
+}
+
+func (r *Repository) FindByID(ctx context.Context, id int) (*venue.Venue, error) {
+	var v venue.Venue
+	err := db.Conn(ctx, r.db).First(&v, id).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, venue.ErrVenueNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+func (r *Repository) List(ctx context.Context, offset, limit int) ([]*venue.Venue, int64, error) {
+	query := db.Conn(ctx, r.db).Model(&venue.Venue{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var venues []*venue.Venue
+	if err := query.Order("id").Offset(offset).Limit(limit).Find(&venues).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return venues, total, nil
+}
+
+func (r *Repository) Update(ctx context.Context, v *venue.Venue) error {
+	result := db.Conn(ctx, r.db).Model(v).Select("*").Updates(v)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return venue.ErrVenueNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) Delete(ctx context.Context, id int) error {
+	result := db.Conn(ctx, r.db).Delete(&venue.Venue{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return venue.ErrVenueNotFound
+	}
+
+	return nil
+}
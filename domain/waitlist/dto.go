@@ -0,0 +1,39 @@
+package waitlist
+
+import "time"
+
+type EntryDTO struct {
+	ID             int        `json:"id"`
+	TicketID       int        `json:"ticket_id"`
+	UserID         string     `json:"user_id"`
+	Quantity       int        `json:"quantity"`
+	Status         string     `json:"status"`
+	Position       int        `json:"position,omitempty"`
+	ReservationID  *int       `json:"reservation_id,omitempty"`
+	OfferExpiresAt *time.Time `json:"offer_expires_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+} // @Name WaitlistEntryDTO
+
+// NewEntryDTOFromEntity shows the position of waiting entries, 1 is the next
+// entry to get an offer.
+func NewEntryDTOFromEntity(entry *Entry, position int) *EntryDTO {
+	dto := &EntryDTO{
+		ID:        entry.ID,
+		TicketID:  entry.TicketID,
+		UserID:    entry.UserID,
+		Quantity:  entry.Quantity,
+		Status:    string(entry.Status),
+		CreatedAt: entry.CreatedAt,
+	}
+
+	if entry.Status == StatusWaiting {
+		dto.Position = position
+	}
+
+	if entry.Status == StatusOffered {
+		dto.ReservationID = entry.ReservationID
+		dto.OfferExpiresAt = entry.OfferExpiresAt
+	}
+
+	return dto
+}
@@ -0,0 +1,56 @@
+package waitlist
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEntryDTOFromEntity(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := createdAt.Add(15 * time.Minute)
+	reservationID := 5
+	entry := &Entry{
+		ID:             1,
+		TicketID:       2,
+		UserID:         "406c1d05-bbb2-4e94-b183-7d208c2692e1",
+		Quantity:       3,
+		Status:         StatusWaiting,
+		ReservationID:  &reservationID,
+		OfferExpiresAt: &expiresAt,
+		CreatedAt:      createdAt,
+	}
+
+	t.Run("should show the position of waiting entries", func(t *testing.T) {
+		expected := &EntryDTO{
+			ID:        1,
+			TicketID:  2,
+			UserID:    "406c1d05-bbb2-4e94-b183-7d208c2692e1",
+			Quantity:  3,
+			Status:    "waiting",
+			Position:  4,
+			CreatedAt: createdAt,
+		}
+
+		assert.Equal(t, expected, NewEntryDTOFromEntity(entry, 4))
+	})
+
+	t.Run("should show the offer of offered entries", func(t *testing.T) {
+		offered := *entry
+		offered.Status = StatusOffered
+
+		expected := &EntryDTO{
+			ID:             1,
+			TicketID:       2,
+			UserID:         "406c1d05-bbb2-4e94-b183-7d208c2692e1",
+			Quantity:       3,
+			Status:         "offered",
+			ReservationID:  &reservationID,
+			OfferExpiresAt: &expiresAt,
+			CreatedAt:      createdAt,
+		}
+
+		assert.Equal(t, expected, NewEntryDTOFromEntity(&offered, 4))
+	})
+}
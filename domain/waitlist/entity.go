@@ -0,0 +1,150 @@
+package waitlist
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/reservation"
+	"github.com/aaydin-tr/ddd-api-example/pkg/eventbus"
+)
+
+var (
+	ErrInvalidQuantity  = errors.New("quantity must be greater than zero")
+	ErrUserIDIsRequired = errors.New("user id is required")
+	ErrTicketIDRequired = errors.New("ticket id is required")
+	ErrEntryNotFound    = errors.New("waitlist entry not found")
+	ErrAlreadyWaiting   = errors.New("user is already on the waitlist of this ticket")
+	ErrNotWaiting       = errors.New("waitlist entry is not waiting")
+	ErrOfferPending     = errors.New("waitlist offer is pending, cancel its reservation instead")
+	ErrTicketAvailable  = errors.New("ticket is not sold out, purchase it instead")
+)
+
+type Status string
+
+const (
+	// StatusWaiting entries are in line for an offer.
+	StatusWaiting Status = "waiting"
+	// StatusOffered entries hold units through the reservation of the offer
+	// until it is confirmed or expires.
+	StatusOffered Status = "offered"
+	StatusClaimed Status = "claimed"
+	// StatusLapsed entries let their offer expire or cancelled it.
+	StatusLapsed Status = "lapsed"
+	StatusLeft   Status = "left"
+)
+
+// Entry is the place of a user in the waitlist of a sold out ticket. Entries
+// are served in the order they joined.
+type Entry struct {
+	ID             int        `json:"id" gorm:"primaryKey;autoIncrement"`
+	TicketID       int        `json:"ticket_id" gorm:"not null;index:idx_waitlist_entries_ticket_status"`
+	UserID         string     `json:"user_id" gorm:"not null;type:uuid;index"`
+	Quantity       int        `json:"quantity" gorm:"not null;type:int"`
+	Status         Status     `json:"status" gorm:"not null;type:varchar(32);index:idx_waitlist_entries_ticket_status"`
+	ReservationID  *int       `json:"reservation_id"`
+	OfferExpiresAt *time.Time `json:"offer_expires_at"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"not null;default:current_timestamp;autoUpdateTime"`
+
+	events []eventbus.Event
+}
+
+func (e *Entry) TableName() string {
+	return "waitlist_entries"
+}
+
+// IsActive reports whether the entry is still waiting for or holding an
+// offer. A user has at most one active entry per ticket.
+func (e *Entry) IsActive() bool {
+	return e.Status == StatusWaiting || e.Status == StatusOffered
+}
+
+// Offer hands the units held by r to the entry. The user claims them by
+// confirming the reservation before it expires.
+func (e *Entry) Offer(r *reservation.Reservation) error {
+	if e.Status != StatusWaiting {
+		return ErrNotWaiting
+	}
+
+	expiresAt := r.ExpiresAt
+	e.Status = StatusOffered
+	e.ReservationID = &r.ID
+	e.OfferExpiresAt = &expiresAt
+	e.record(WaitlistOffered{
+		EntryID:       e.ID,
+		TicketID:      e.TicketID,
+		UserID:        e.UserID,
+		Quantity:      e.Quantity,
+		ReservationID: r.ID,
+		ExpiresAt:     expiresAt,
+		At:            time.Now(),
+	})
+	return nil
+}
+
+// Settle follows the reservation of an offer. It reports whether the entry
+// changed, an active reservation leaves the offer pending.
+func (e *Entry) Settle(status reservation.Status) bool {
+	if e.Status != StatusOffered {
+		return false
+	}
+
+	switch status {
+	case reservation.StatusConfirmed:
+		e.Status = StatusClaimed
+	case reservation.StatusCancelled, reservation.StatusExpired:
+		e.Status = StatusLapsed
+	default:
+		return false
+	}
+
+	return true
+}
+
+// Leave takes a waiting entry off the waitlist. Offered units are given
+// back by cancelling the reservation of the offer.
+func (e *Entry) Leave() error {
+	if e.Status == StatusOffered {
+		return ErrOfferPending
+	}
+
+	if e.Status != StatusWaiting {
+		return ErrNotWaiting
+	}
+
+	e.Status = StatusLeft
+	return nil
+}
+
+// PullEvents returns the events recorded since the last call and forgets
+// them, so that every event is published once.
+func (e *Entry) PullEvents() []eventbus.Event {
+	events := e.events
+	e.events = nil
+	return events
+}
+
+func (e *Entry) record(event eventbus.Event) {
+	e.events = append(e.events, event)
+}
+
+func NewEntry(ticketID int, userID string, quantity int) (*Entry, error) {
+	if ticketID == 0 {
+		return nil, ErrTicketIDRequired
+	}
+
+	if userID == "" {
+		return nil, ErrUserIDIsRequired
+	}
+
+	if quantity <= 0 {
+		return nil, ErrInvalidQuantity
+	}
+
+	return &Entry{
+		TicketID: ticketID,
+		UserID:   userID,
+		Quantity: quantity,
+		Status:   StatusWaiting,
+	}, nil
+}
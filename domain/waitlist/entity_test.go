@@ -0,0 +1,128 @@
+package waitlist_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/reservation"
+	"github.com/aaydin-tr/ddd-api-example/domain/waitlist"
+	"github.com/stretchr/testify/assert"
+)
+
+const userID = "406c1d05-bbb2-4e94-b183-7d208c2692e1"
+
+func TestNewEntry(t *testing.T) {
+	t.Run("should create a waiting entry", func(t *testing.T) {
+		e, err := waitlist.NewEntry(1, userID, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, e.TicketID)
+		assert.Equal(t, userID, e.UserID)
+		assert.Equal(t, 2, e.Quantity)
+		assert.Equal(t, waitlist.StatusWaiting, e.Status)
+		assert.True(t, e.IsActive())
+	})
+
+	tests := []struct {
+		name     string
+		ticketID int
+		userID   string
+		quantity int
+		wantErr  error
+	}{
+		{name: "missing ticket id", ticketID: 0, userID: userID, quantity: 1, wantErr: waitlist.ErrTicketIDRequired},
+		{name: "missing user id", ticketID: 1, userID: "", quantity: 1, wantErr: waitlist.ErrUserIDIsRequired},
+		{name: "invalid quantity", ticketID: 1, userID: userID, quantity: 0, wantErr: waitlist.ErrInvalidQuantity},
+	}
+
+	for _, tt := range tests {
+		t.Run("should return error when "+tt.name, func(t *testing.T) {
+			e, err := waitlist.NewEntry(tt.ticketID, tt.userID, tt.quantity)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, e)
+		})
+	}
+}
+
+func TestEntryOffer(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	r, err := reservation.NewReservation(1, userID, 2, now, 15*time.Minute)
+	assert.NoError(t, err)
+	r.ID = 9
+
+	e, err := waitlist.NewEntry(1, userID, 2)
+	assert.NoError(t, err)
+	e.ID = 3
+
+	assert.NoError(t, e.Offer(r))
+	assert.Equal(t, waitlist.StatusOffered, e.Status)
+	assert.Equal(t, 9, *e.ReservationID)
+	assert.Equal(t, now.Add(15*time.Minute), *e.OfferExpiresAt)
+	assert.True(t, e.IsActive())
+
+	events := e.PullEvents()
+	assert.Len(t, events, 1)
+	assert.Equal(t, waitlist.WaitlistOffered{
+		EntryID:       3,
+		TicketID:      1,
+		UserID:        userID,
+		Quantity:      2,
+		ReservationID: 9,
+		ExpiresAt:     now.Add(15 * time.Minute),
+		At:            events[0].OccurredAt(),
+	}, events[0])
+	assert.Empty(t, e.PullEvents())
+
+	assert.ErrorIs(t, e.Offer(r), waitlist.ErrNotWaiting)
+}
+
+func TestEntrySettle(t *testing.T) {
+	tests := []struct {
+		status      reservation.Status
+		wantChanged bool
+		want        waitlist.Status
+	}{
+		{status: reservation.StatusActive, wantChanged: false, want: waitlist.StatusOffered},
+		{status: reservation.StatusConfirmed, wantChanged: true, want: waitlist.StatusClaimed},
+		{status: reservation.StatusCancelled, wantChanged: true, want: waitlist.StatusLapsed},
+		{status: reservation.StatusExpired, wantChanged: true, want: waitlist.StatusLapsed},
+	}
+
+	for _, tt := range tests {
+		t.Run("should follow a "+string(tt.status)+" reservation", func(t *testing.T) {
+			e := &waitlist.Entry{ID: 1, TicketID: 1, UserID: userID, Quantity: 1, Status: waitlist.StatusOffered}
+			assert.Equal(t, tt.wantChanged, e.Settle(tt.status))
+			assert.Equal(t, tt.want, e.Status)
+		})
+	}
+
+	t.Run("should ignore entries without an offer", func(t *testing.T) {
+		e := &waitlist.Entry{ID: 1, TicketID: 1, UserID: userID, Quantity: 1, Status: waitlist.StatusWaiting}
+		assert.False(t, e.Settle(reservation.StatusConfirmed))
+		assert.Equal(t, waitlist.StatusWaiting, e.Status)
+	})
+}
+
+func TestEntryLeave(t *testing.T) {
+	tests := []struct {
+		status  waitlist.Status
+		wantErr error
+	}{
+		{status: waitlist.StatusWaiting},
+		{status: waitlist.StatusOffered, wantErr: waitlist.ErrOfferPending},
+		{status: waitlist.StatusClaimed, wantErr: waitlist.ErrNotWaiting},
+		{status: waitlist.StatusLapsed, wantErr: waitlist.ErrNotWaiting},
+		{status: waitlist.StatusLeft, wantErr: waitlist.ErrNotWaiting},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			e := &waitlist.Entry{ID: 1, TicketID: 1, UserID: userID, Quantity: 1, Status: tt.status}
+			err := e.Leave()
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, waitlist.StatusLeft, e.Status)
+				assert.False(t, e.IsActive())
+			}
+		})
+	}
+}
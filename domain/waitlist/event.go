@@ -0,0 +1,20 @@
+package waitlist
+
+import "time"
+
+const EventWaitlistOffered = "waitlist.offered"
+
+// WaitlistOffered is recorded when units are held for a waitlist entry. The
+// user claims them by confirming the reservation before ExpiresAt.
+type WaitlistOffered struct {
+	EntryID       int       `json:"entry_id"`
+	TicketID      int       `json:"ticket_id"`
+	UserID        string    `json:"user_id"`
+	Quantity      int       `json:"quantity"`
+	ReservationID int       `json:"reservation_id"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	At            time.Time `json:"occurred_at"`
+}
+
+func (e WaitlistOffered) EventName() string     { return EventWaitlistOffered }
+func (e WaitlistOffered) OccurredAt() time.Time { return e.At }
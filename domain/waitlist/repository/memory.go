@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/waitlist"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+)
+
+// MemoryRepository keeps waitlist entries in memory, it has to be used
+// together with db.MemoryUnitOfWork.
+type MemoryRepository struct {
+	mu      sync.RWMutex
+	entries map[int]waitlist.Entry
+	lastID  int
+	now     func() time.Time
+}
+
+func NewMemoryWaitlistRepository() WaitlistRepository {
+	return &MemoryRepository{entries: make(map[int]waitlist.Entry), now: time.Now}
+}
+
+func (r *MemoryRepository) Create(ctx context.Context, e *waitlist.Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.entries {
+		if existing.TicketID == e.TicketID && existing.UserID == e.UserID && existing.IsActive() {
+			return waitlist.ErrAlreadyWaiting
+		}
+	}
+
+	r.lastID++
+	e.ID = r.lastID
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = r.now()
+	}
+	e.UpdatedAt = e.CreatedAt
+
+	id := e.ID
+	r.entries[id] = *e
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		delete(r.entries, id)
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (r *MemoryRepository) Update(ctx context.Context, e *waitlist.Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, ok := r.entries[e.ID]
+	if !ok {
+		return waitlist.ErrEntryNotFound
+	}
+
+	e.UpdatedAt = r.now()
+	id := e.ID
+	r.entries[id] = *e
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		r.entries[id] = previous
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (r *MemoryRepository) ListActive(ctx context.Context, ticketID int) ([]*waitlist.Entry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var entries []*waitlist.Entry
+	for _, e := range r.entries {
+		if e.TicketID == ticketID && e.IsActive() {
+			entries = append(entries, &e)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	return entries, nil
+}
+
+func (r *MemoryRepository) FindLatestByUser(ctx context.Context, ticketID int, userID string) (*waitlist.Entry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest *waitlist.Entry
+	for _, e := range r.entries {
+		if e.TicketID == ticketID && e.UserID == userID && (latest == nil || e.ID > latest.ID) {
+			latest = &e
+		}
+	}
+
+	if latest == nil {
+		return nil, waitlist.ErrEntryNotFound
+	}
+
+	return latest, nil
+}
+
+func (r *MemoryRepository) Position(ctx context.Context, e *waitlist.Entry) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	position := 1
+	for _, other := range r.entries {
+		if other.TicketID == e.TicketID && other.Status == waitlist.StatusWaiting && other.ID < e.ID {
+			position++
+		}
+	}
+
+	return position, nil
+}
+
+func (r *MemoryRepository) TicketsWithWaiting(ctx context.Context) ([]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[int]bool)
+	var ids []int
+	for _, e := range r.entries {
+		if e.Status == waitlist.StatusWaiting && !seen[e.TicketID] {
+			seen[e.TicketID] = true
+			ids = append(ids, e.TicketID)
+		}
+	}
+
+	sort.Ints(ids)
+	return ids, nil
+}
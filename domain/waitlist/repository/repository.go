@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/waitlist"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -destination=../../../mock/repository/waitlist/waitlist.go -package=repository github.com/aaydin-tr/ddd-api-example/domain/waitlist/repository WaitlistRepository
+type WaitlistRepository interface {
+	Create(ctx context.Context, e *waitlist.Entry) error
+	Update(ctx context.Context, e *waitlist.Entry) error
+	// ListActive returns the waiting and offered entries of a ticket in the
+	// order they joined.
+	ListActive(ctx context.Context, ticketID int) ([]*waitlist.Entry, error)
+	// FindLatestByUser returns the entry a user joined the waitlist of a
+	// ticket with most recently.
+	FindLatestByUser(ctx context.Context, ticketID int, userID string) (*waitlist.Entry, error)
+	// Position returns the number of waiting entries of the ticket that
+	// joined before e, plus one.
+	Position(ctx context.Context, e *waitlist.Entry) (int, error)
+	// TicketsWithWaiting returns the IDs of tickets with waiting entries.
+	TicketsWithWaiting(ctx context.Context) ([]int, error)
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewWaitlistRepository(db *gorm.DB) WaitlistRepository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(ctx context.Context, e *waitlist.Entry) error {
+	err := db.Conn(ctx, r.db).Create(e).Error
+	if db.IsUniqueViolation(err) {
+		return waitlist.ErrAlreadyWaiting
+	}
+
+	return err
+}
+
+func (r *Repository) Update(ctx context.Context, e *waitlist.Entry) error {
+	return db.Conn(ctx, r.db).Save(e).Error
+}
+
+func (r *Repository) ListActive(ctx context.Context, ticketID int) ([]*waitlist.Entry, error) {
+	var entries []*waitlist.Entry
+	err := db.Conn(ctx, r.db).
+		Where("ticket_id = ? AND status IN ?", ticketID, []waitlist.Status{waitlist.StatusWaiting, waitlist.StatusOffered}).
+		Order("id").
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (r *Repository) FindLatestByUser(ctx context.Context, ticketID int, userID string) (*waitlist.Entry, error) {
+	var e waitlist.Entry
+	err := db.Conn(ctx, r.db).
+		Where("ticket_id = ? AND user_id = ?", ticketID, userID).
+		Order("id DESC").
+		First(&e).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, waitlist.ErrEntryNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+func (r *Repository) Position(ctx context.Context, e *waitlist.Entry) (int, error) {
+	var ahead int64
+	err := db.Conn(ctx, r.db).Model(&waitlist.Entry{}).
+		Where("ticket_id = ? AND status = ? AND id < ?", e.TicketID, waitlist.StatusWaiting, e.ID).
+		Count(&ahead).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return int(ahead) + 1, nil
+}
+
+func (r *Repository) TicketsWithWaiting(ctx context.Context) ([]int, error) {
+	var ids []int
+	err := db.Conn(ctx, r.db).Model(&waitlist.Entry{}).
+		Distinct("ticket_id").
+		Where("status = ?", waitlist.StatusWaiting).
+		Order("ticket_id").
+		Pluck("ticket_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrDeliveryPending  = errors.New("webhook delivery is still pending")
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// Delivery is one event sent to one subscription, it is kept after it
+// succeeded or failed as the delivery log of the subscription. MessageID is
+// the outbox message of the event, receivers can use it to drop
+// duplicates.
+type Delivery struct {
+	ID             int            `json:"id" gorm:"primaryKey;autoIncrement"`
+	SubscriptionID int            `json:"subscription_id" gorm:"not null;index"`
+	MessageID      int64          `json:"message_id" gorm:"not null"`
+	Event          string         `json:"event" gorm:"not null;type:varchar(255)"`
+	Payload        string         `json:"payload" gorm:"not null;type:jsonb"`
+	Status         DeliveryStatus `json:"status" gorm:"not null;type:varchar(32)"`
+	Attempts       int            `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  time.Time      `json:"next_attempt_at" gorm:"not null;default:current_timestamp"`
+	ResponseStatus int            `json:"response_status" gorm:"not null;default:0"`
+	LastError      string         `json:"last_error" gorm:"not null;type:text;default:''"`
+	DeliveredAt    *time.Time     `json:"delivered_at"`
+	ReplayOf       *int           `json:"replay_of"`
+	CreatedAt      time.Time      `json:"created_at" gorm:"not null;default:current_timestamp"`
+	UpdatedAt      time.Time      `json:"updated_at" gorm:"not null;default:current_timestamp;autoUpdateTime"`
+}
+
+func (d *Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+func NewDelivery(subscriptionID int, messageID int64, event, payload string, now time.Time) *Delivery {
+	return &Delivery{
+		SubscriptionID: subscriptionID,
+		MessageID:      messageID,
+		Event:          event,
+		Payload:        payload,
+		Status:         DeliveryPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+func (d *Delivery) IsDue(now time.Time) bool {
+	return d.Status == DeliveryPending && !now.Before(d.NextAttemptAt)
+}
+
+// MarkSucceeded records an attempt the receiver answered with a 2xx
+// status.
+func (d *Delivery) MarkSucceeded(status int, now time.Time) {
+	d.Attempts++
+	d.Status = DeliverySucceeded
+	d.ResponseStatus = status
+	d.LastError = ""
+	d.DeliveredAt = &now
+}
+
+// ScheduleRetry records a failed attempt that is tried again at next.
+// status is 0 when the receiver did not answer.
+func (d *Delivery) ScheduleRetry(status int, err error, next time.Time) {
+	d.Attempts++
+	d.ResponseStatus = status
+	d.LastError = err.Error()
+	d.NextAttemptAt = next
+}
+
+// MarkFailed records the last failed attempt, the delivery is not tried
+// again unless it is replayed.
+func (d *Delivery) MarkFailed(status int, err error) {
+	d.Attempts++
+	d.Status = DeliveryFailed
+	d.ResponseStatus = status
+	d.LastError = err.Error()
+}
+
+// Replay returns a new delivery of the same payload to the same
+// subscription. The original stays in the log as it is.
+func (d *Delivery) Replay(now time.Time) (*Delivery, error) {
+	if d.Status == DeliveryPending {
+		return nil, ErrDeliveryPending
+	}
+
+	replay := NewDelivery(d.SubscriptionID, d.MessageID, d.Event, d.Payload, now)
+	id := d.ID
+	replay.ReplayOf = &id
+	return replay, nil
+}
@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type SubscriptionDTO struct {
+	ID     int      `json:"id"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	// Secret is only returned when the subscription is created.
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+} // @Name WebhookSubscriptionDTO
+
+func NewSubscriptionDTOFromEntity(s *Subscription) *SubscriptionDTO {
+	return &SubscriptionDTO{
+		ID:        s.ID,
+		URL:       s.URL,
+		Events:    s.Events,
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+type SubscriptionListDTO struct {
+	Items []*SubscriptionDTO `json:"items"`
+} // @Name WebhookSubscriptionListDTO
+
+func NewSubscriptionListDTOFromEntities(subscriptions []*Subscription) *SubscriptionListDTO {
+	items := make([]*SubscriptionDTO, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		items = append(items, NewSubscriptionDTOFromEntity(s))
+	}
+
+	return &SubscriptionListDTO{Items: items}
+}
+
+type DeliveryDTO struct {
+	ID             int             `json:"id"`
+	SubscriptionID int             `json:"subscription_id"`
+	MessageID      int64           `json:"message_id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status"`
+	LastError      string          `json:"last_error"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	ReplayOf       *int            `json:"replay_of"`
+	CreatedAt      time.Time       `json:"created_at"`
+} // @Name WebhookDeliveryDTO
+
+func NewDeliveryDTOFromEntity(d *Delivery) *DeliveryDTO {
+	return &DeliveryDTO{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		MessageID:      d.MessageID,
+		Event:          d.Event,
+		Payload:        json.RawMessage(d.Payload),
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		LastError:      d.LastError,
+		NextAttemptAt:  d.NextAttemptAt,
+		DeliveredAt:    d.DeliveredAt,
+		ReplayOf:       d.ReplayOf,
+		CreatedAt:      d.CreatedAt,
+	}
+}
+
+type DeliveryListDTO struct {
+	Items []*DeliveryDTO `json:"items"`
+} // @Name WebhookDeliveryListDTO
+
+func NewDeliveryListDTOFromEntities(deliveries []*Delivery) *DeliveryListDTO {
+	items := make([]*DeliveryDTO, 0, len(deliveries))
+	for _, d := range deliveries {
+		items = append(items, NewDeliveryDTOFromEntity(d))
+	}
+
+	return &DeliveryListDTO{Items: items}
+}
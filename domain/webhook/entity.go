@@ -0,0 +1,126 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/purchase"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	"github.com/aaydin-tr/ddd-api-example/domain/waitlist"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidURL           = errors.New("url must be an absolute http or https url")
+	ErrEventsRequired       = errors.New("at least one event is required")
+	ErrUnknownEvent         = errors.New("unknown event")
+	ErrInvalidEvents        = errors.New("invalid events value")
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+)
+
+// Events are the event names a subscription can filter on.
+var Events = []string{
+	ticket.EventTicketCreated,
+	ticket.EventAllocationDecremented,
+	ticket.EventTicketSoldOut,
+	ticket.EventAllocationRestored,
+	ticket.EventTicketStatusChanged,
+	purchase.EventPurchaseCompleted,
+	purchase.EventPurchaseRefunded,
+	waitlist.EventWaitlistOffered,
+}
+
+// Subscription sends the events it filters on to URL. Every delivery is
+// signed with Secret, which is only shown once when the subscription is
+// created.
+type Subscription struct {
+	ID        int            `json:"id" gorm:"primaryKey;autoIncrement"`
+	URL       string         `json:"url" gorm:"not null;type:text"`
+	Events    EventNames     `json:"events" gorm:"not null;type:jsonb"`
+	Secret    string         `json:"-" gorm:"not null;type:varchar(255)"`
+	CreatedAt time.Time      `json:"created_at" gorm:"not null;default:current_timestamp"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
+
+func (s *Subscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+func (s *Subscription) Matches(event string) bool {
+	return slices.Contains(s.Events, event)
+}
+
+func NewSubscription(rawURL string, events []string) (*Subscription, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, ErrInvalidURL
+	}
+
+	if len(events) == 0 {
+		return nil, ErrEventsRequired
+	}
+
+	var names EventNames
+	for _, event := range events {
+		if !slices.Contains(Events, event) {
+			return nil, fmt.Errorf("%w %q", ErrUnknownEvent, event)
+		}
+
+		if !slices.Contains(names, event) {
+			names = append(names, event)
+		}
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Subscription{URL: u.String(), Events: names, Secret: secret}, nil
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// EventNames is stored in a single jsonb column as an array of strings.
+type EventNames []string
+
+func (e *EventNames) Scan(value interface{}) error {
+	var raw []byte
+	switch v := value.(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return ErrInvalidEvents
+	}
+
+	return json.Unmarshal(raw, e)
+}
+
+func (e EventNames) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+
+	raw, err := json.Marshal([]string(e))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(raw), nil
+}
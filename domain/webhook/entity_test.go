@@ -0,0 +1,116 @@
+package webhook_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSubscription(t *testing.T) {
+	t.Run("should create a subscription with a secret", func(t *testing.T) {
+		s, err := webhook.NewSubscription("https://partner.example.com/hooks", []string{"ticket.sold_out", "purchase.completed", "ticket.sold_out"})
+		assert.NoError(t, err)
+		assert.Equal(t, "https://partner.example.com/hooks", s.URL)
+		assert.Equal(t, webhook.EventNames{"ticket.sold_out", "purchase.completed"}, s.Events)
+		assert.True(t, strings.HasPrefix(s.Secret, "whsec_"))
+		assert.Len(t, s.Secret, len("whsec_")+64)
+
+		other, _ := webhook.NewSubscription("https://partner.example.com/hooks", []string{"ticket.sold_out"})
+		assert.NotEqual(t, s.Secret, other.Secret)
+	})
+
+	t.Run("should reject invalid urls", func(t *testing.T) {
+		for _, url := range []string{"", "partner.example.com/hooks", "ftp://partner.example.com", "https://", "http://[::1"} {
+			_, err := webhook.NewSubscription(url, []string{"ticket.sold_out"})
+			assert.ErrorIs(t, err, webhook.ErrInvalidURL, url)
+		}
+	})
+
+	t.Run("should reject missing or unknown events", func(t *testing.T) {
+		_, err := webhook.NewSubscription("http://localhost:9000", nil)
+		assert.ErrorIs(t, err, webhook.ErrEventsRequired)
+
+		_, err = webhook.NewSubscription("http://localhost:9000", []string{"ticket.sold_out", "ticket.renamed"})
+		assert.ErrorIs(t, err, webhook.ErrUnknownEvent)
+		assert.EqualError(t, err, `unknown event "ticket.renamed"`)
+	})
+}
+
+func TestSubscription_Matches(t *testing.T) {
+	s := &webhook.Subscription{Events: webhook.EventNames{"ticket.sold_out"}}
+	assert.True(t, s.Matches("ticket.sold_out"))
+	assert.False(t, s.Matches("ticket.created"))
+}
+
+func TestEventNames(t *testing.T) {
+	value, err := webhook.EventNames{"ticket.sold_out", "purchase.completed"}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `["ticket.sold_out","purchase.completed"]`, value)
+
+	value, err = webhook.EventNames(nil).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `[]`, value)
+
+	var names webhook.EventNames
+	assert.NoError(t, names.Scan([]byte(`["ticket.sold_out"]`)))
+	assert.Equal(t, webhook.EventNames{"ticket.sold_out"}, names)
+	assert.NoError(t, names.Scan(`["purchase.refunded"]`))
+	assert.Equal(t, webhook.EventNames{"purchase.refunded"}, names)
+	assert.ErrorIs(t, names.Scan(42), webhook.ErrInvalidEvents)
+}
+
+func TestDelivery(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should be due until it succeeded", func(t *testing.T) {
+		d := webhook.NewDelivery(1, 42, "ticket.sold_out", `{}`, now)
+		assert.Equal(t, webhook.DeliveryPending, d.Status)
+		assert.True(t, d.IsDue(now))
+
+		d.ScheduleRetry(503, assert.AnError, now.Add(time.Minute))
+		assert.Equal(t, 1, d.Attempts)
+		assert.Equal(t, 503, d.ResponseStatus)
+		assert.Equal(t, assert.AnError.Error(), d.LastError)
+		assert.False(t, d.IsDue(now))
+		assert.True(t, d.IsDue(now.Add(time.Minute)))
+
+		d.MarkSucceeded(204, now.Add(time.Minute))
+		assert.Equal(t, 2, d.Attempts)
+		assert.Equal(t, webhook.DeliverySucceeded, d.Status)
+		assert.Equal(t, 204, d.ResponseStatus)
+		assert.Empty(t, d.LastError)
+		assert.Equal(t, now.Add(time.Minute), *d.DeliveredAt)
+		assert.False(t, d.IsDue(now.Add(time.Hour)))
+	})
+
+	t.Run("should not be due once it failed", func(t *testing.T) {
+		d := webhook.NewDelivery(1, 42, "ticket.sold_out", `{}`, now)
+		d.MarkFailed(0, assert.AnError)
+		assert.Equal(t, webhook.DeliveryFailed, d.Status)
+		assert.Equal(t, 1, d.Attempts)
+		assert.False(t, d.IsDue(now.Add(time.Hour)))
+	})
+
+	t.Run("should replay finished deliveries only", func(t *testing.T) {
+		d := webhook.NewDelivery(1, 42, "ticket.sold_out", `{"id":42}`, now)
+		d.ID = 3
+
+		_, err := d.Replay(now)
+		assert.ErrorIs(t, err, webhook.ErrDeliveryPending)
+
+		d.MarkFailed(500, assert.AnError)
+		replay, err := d.Replay(now.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Zero(t, replay.ID)
+		assert.Equal(t, 3, *replay.ReplayOf)
+		assert.Equal(t, 1, replay.SubscriptionID)
+		assert.Equal(t, int64(42), replay.MessageID)
+		assert.Equal(t, `{"id":42}`, replay.Payload)
+		assert.Equal(t, webhook.DeliveryPending, replay.Status)
+		assert.Zero(t, replay.Attempts)
+		assert.True(t, replay.IsDue(now.Add(time.Hour)))
+	})
+}
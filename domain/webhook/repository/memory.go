@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/webhook"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+)
+
+// MemoryRepository keeps subscriptions and deliveries in memory, it has to
+// be used together with db.MemoryUnitOfWork.
+type MemoryRepository struct {
+	mu                 sync.RWMutex
+	subscriptions      map[int]webhook.Subscription
+	deliveries         map[int]webhook.Delivery
+	lastSubscriptionID int
+	lastDeliveryID     int
+	locks              db.RowLocks
+	now                func() time.Time
+}
+
+func NewMemoryWebhookRepository() WebhookRepository {
+	return &MemoryRepository{
+		subscriptions: make(map[int]webhook.Subscription),
+		deliveries:    make(map[int]webhook.Delivery),
+		now:           time.Now,
+	}
+}
+
+func (r *MemoryRepository) CreateSubscription(ctx context.Context, s *webhook.Subscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastSubscriptionID++
+	s.ID = r.lastSubscriptionID
+	if s.CreatedAt.IsZero() {
+		s.CreatedAt = r.now()
+	}
+
+	id := s.ID
+	r.subscriptions[id] = *s
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		delete(r.subscriptions, id)
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (r *MemoryRepository) FindSubscription(ctx context.Context, id int) (*webhook.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.subscriptions[id]
+	if !ok || s.DeletedAt.Valid {
+		return nil, webhook.ErrSubscriptionNotFound
+	}
+
+	return &s, nil
+}
+
+func (r *MemoryRepository) ListSubscriptions(ctx context.Context) ([]*webhook.Subscription, error) {
+	return r.subscriptionsWhere(func(s *webhook.Subscription) bool { return true }), nil
+}
+
+func (r *MemoryRepository) SubscriptionsFor(ctx context.Context, event string) ([]*webhook.Subscription, error) {
+	return r.subscriptionsWhere(func(s *webhook.Subscription) bool { return s.Matches(event) }), nil
+}
+
+func (r *MemoryRepository) subscriptionsWhere(keep func(s *webhook.Subscription) bool) []*webhook.Subscription {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var subscriptions []*webhook.Subscription
+	for _, stored := range r.subscriptions {
+		s := stored
+		if !s.DeletedAt.Valid && keep(&s) {
+			subscriptions = append(subscriptions, &s)
+		}
+	}
+
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].ID < subscriptions[j].ID
+	})
+
+	return subscriptions
+}
+
+func (r *MemoryRepository) DeleteSubscription(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, ok := r.subscriptions[id]
+	if !ok || previous.DeletedAt.Valid {
+		return webhook.ErrSubscriptionNotFound
+	}
+
+	deleted := previous
+	deleted.DeletedAt.Time = r.now()
+	deleted.DeletedAt.Valid = true
+	r.subscriptions[id] = deleted
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		r.subscriptions[id] = previous
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (r *MemoryRepository) CreateDelivery(ctx context.Context, d *webhook.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if d.ReplayOf == nil {
+		for _, stored := range r.deliveries {
+			if stored.ReplayOf == nil && stored.MessageID == d.MessageID && stored.SubscriptionID == d.SubscriptionID {
+				return nil
+			}
+		}
+	}
+
+	r.lastDeliveryID++
+	d.ID = r.lastDeliveryID
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = r.now()
+	}
+	d.UpdatedAt = d.CreatedAt
+
+	id := d.ID
+	r.deliveries[id] = *d
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		delete(r.deliveries, id)
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (r *MemoryRepository) FindDelivery(ctx context.Context, id int) (*webhook.Delivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	d, ok := r.deliveries[id]
+	if !ok {
+		return nil, webhook.ErrDeliveryNotFound
+	}
+
+	return &d, nil
+}
+
+func (r *MemoryRepository) ListDeliveries(ctx context.Context, subscriptionID int, limit int) ([]*webhook.Delivery, error) {
+	deliveries := r.deliveriesWhere(func(d *webhook.Delivery) bool { return d.SubscriptionID == subscriptionID })
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].ID > deliveries[j].ID
+	})
+
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	return deliveries, nil
+}
+
+// ClaimDueDeliveries skips deliveries locked by another unit of work, like
+// the SKIP LOCKED query of Repository.
+func (r *MemoryRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*webhook.Delivery, error) {
+	due := r.deliveriesWhere(func(d *webhook.Delivery) bool { return d.IsDue(now) })
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].ID < due[j].ID
+	})
+
+	var claimed []*webhook.Delivery
+	for _, d := range due {
+		if len(claimed) == limit {
+			break
+		}
+
+		if !r.locks.TryLock(ctx, d.ID) {
+			continue
+		}
+
+		// The delivery may have been sent before it was locked.
+		current, err := r.FindDelivery(ctx, d.ID)
+		if err != nil || !current.IsDue(now) {
+			continue
+		}
+
+		claimed = append(claimed, current)
+	}
+
+	return claimed, nil
+}
+
+func (r *MemoryRepository) UpdateDelivery(ctx context.Context, d *webhook.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, ok := r.deliveries[d.ID]
+	if !ok {
+		return webhook.ErrDeliveryNotFound
+	}
+
+	d.UpdatedAt = r.now()
+	id := d.ID
+	r.deliveries[id] = *d
+	db.OnRollback(ctx, func() {
+		r.mu.Lock()
+		r.deliveries[id] = previous
+		r.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (r *MemoryRepository) deliveriesWhere(keep func(d *webhook.Delivery) bool) []*webhook.Delivery {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var deliveries []*webhook.Delivery
+	for _, stored := range r.deliveries {
+		d := stored
+		if keep(&d) {
+			deliveries = append(deliveries, &d)
+		}
+	}
+
+	return deliveries
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/webhook"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -destination=../../../mock/repository/webhook/webhook.go -package=repository github.com/aaydin-tr/ddd-api-example/domain/webhook/repository WebhookRepository
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, s *webhook.Subscription) error
+	FindSubscription(ctx context.Context, id int) (*webhook.Subscription, error)
+	ListSubscriptions(ctx context.Context) ([]*webhook.Subscription, error)
+	// SubscriptionsFor returns the subscriptions filtering on event.
+	SubscriptionsFor(ctx context.Context, event string) ([]*webhook.Subscription, error)
+	DeleteSubscription(ctx context.Context, id int) error
+	// CreateDelivery skips a delivery that is not a replay when the message
+	// was already delivered to the subscription, d.ID stays 0 then. This
+	// keeps a message that is relayed again from being sent twice.
+	CreateDelivery(ctx context.Context, d *webhook.Delivery) error
+	FindDelivery(ctx context.Context, id int) (*webhook.Delivery, error)
+	// ListDeliveries returns the latest deliveries of a subscription, newest
+	// first.
+	ListDeliveries(ctx context.Context, subscriptionID int, limit int) ([]*webhook.Delivery, error)
+	// ClaimDueDeliveries locks up to limit pending deliveries that are due at
+	// now, oldest first. Deliveries locked by another sender are skipped.
+	ClaimDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*webhook.Delivery, error)
+	UpdateDelivery(ctx context.Context, d *webhook.Delivery) error
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) CreateSubscription(ctx context.Context, s *webhook.Subscription) error {
+	return db.Conn(ctx, r.db).Create(s).Error
+}
+
+func (r *Repository) FindSubscription(ctx context.Context, id int) (*webhook.Subscription, error) {
+	var s webhook.Subscription
+	err := db.Conn(ctx, r.db).First(&s, id).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, webhook.ErrSubscriptionNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+func (r *Repository) ListSubscriptions(ctx context.Context) ([]*webhook.Subscription, error) {
+	var subscriptions []*webhook.Subscription
+	if err := db.Conn(ctx, r.db).Order("id").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (r *Repository) SubscriptionsFor(ctx context.Context, event string) ([]*webhook.Subscription, error) {
+	filter, err := json.Marshal([]string{event})
+	if err != nil {
+		return nil, err
+	}
+
+	var subscriptions []*webhook.Subscription
+	err = db.Conn(ctx, r.db).Where("events @> ?::jsonb", string(filter)).Order("id").Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (r *Repository) DeleteSubscription(ctx context.Context, id int) error {
+	result := db.Conn(ctx, r.db).Delete(&webhook.Subscription{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return webhook.ErrSubscriptionNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) CreateDelivery(ctx context.Context, d *webhook.Delivery) error {
+	return db.Conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "message_id"}, {Name: "subscription_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "replay_of IS NULL"}}},
+		DoNothing:   true,
+	}).Create(d).Error
+}
+
+func (r *Repository) FindDelivery(ctx context.Context, id int) (*webhook.Delivery, error) {
+	var d webhook.Delivery
+	err := db.Conn(ctx, r.db).First(&d, id).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, webhook.ErrDeliveryNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+func (r *Repository) ListDeliveries(ctx context.Context, subscriptionID int, limit int) ([]*webhook.Delivery, error) {
+	var deliveries []*webhook.Delivery
+	err := db.Conn(ctx, r.db).
+		Where("subscription_id = ?", subscriptionID).
+		Order("id DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r *Repository) ClaimDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*webhook.Delivery, error) {
+	var deliveries []*webhook.Delivery
+	err := db.Conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
+		Where("status = ? AND next_attempt_at <= ?", webhook.DeliveryPending, now).
+		Order("id").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r *Repository) UpdateDelivery(ctx context.Context, d *webhook.Delivery) error {
+	return db.Conn(ctx, r.db).Save(d).Error
+}
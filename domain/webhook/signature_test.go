@@ -0,0 +1,57 @@
+package webhook_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	sentAt := time.Unix(1704110400, 0)
+	body := []byte(`{"id":1}`)
+
+	// echo -n '1704110400.{"id":1}' | openssl dgst -sha256 -hmac whsec_test
+	assert.Equal(t, "sha256=0b9c47a323e75f30f3b2b58aad1195045bd9290e1ce719e27976aa910f6c8520", webhook.Sign("whsec_test", sentAt, body))
+	assert.NotEqual(t, webhook.Sign("whsec_test", sentAt, body), webhook.Sign("whsec_other", sentAt, body))
+	assert.NotEqual(t, webhook.Sign("whsec_test", sentAt, body), webhook.Sign("whsec_test", sentAt.Add(time.Second), body))
+}
+
+func TestVerify(t *testing.T) {
+	sentAt := time.Unix(1704110400, 0)
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+	body := []byte(`{"id":1}`)
+	signature := webhook.Sign("whsec_test", sentAt, body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		timestamp string
+		body      []byte
+		now       time.Time
+		err       error
+	}{
+		{name: "valid", secret: "whsec_test", signature: signature, timestamp: timestamp, body: body, now: sentAt.Add(time.Minute)},
+		{name: "wrong secret", secret: "whsec_other", signature: signature, timestamp: timestamp, body: body, now: sentAt, err: webhook.ErrInvalidSignature},
+		{name: "tampered body", secret: "whsec_test", signature: signature, timestamp: timestamp, body: []byte(`{"id":2}`), now: sentAt, err: webhook.ErrInvalidSignature},
+		{name: "tampered timestamp", secret: "whsec_test", signature: signature, timestamp: "1704110401", body: body, now: sentAt, err: webhook.ErrInvalidSignature},
+		{name: "malformed timestamp", secret: "whsec_test", signature: signature, timestamp: "yesterday", body: body, now: sentAt, err: webhook.ErrInvalidSignature},
+		{name: "missing prefix", secret: "whsec_test", signature: signature[len("sha256="):], timestamp: timestamp, body: body, now: sentAt, err: webhook.ErrInvalidSignature},
+		{name: "too old", secret: "whsec_test", signature: signature, timestamp: timestamp, body: body, now: sentAt.Add(6 * time.Minute), err: webhook.ErrSignatureExpired},
+		{name: "too far in the future", secret: "whsec_test", signature: signature, timestamp: timestamp, body: body, now: sentAt.Add(-6 * time.Minute), err: webhook.ErrSignatureExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := webhook.Verify(tt.secret, tt.signature, tt.timestamp, tt.body, tt.now, 5*time.Minute)
+			if tt.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package db
+
+import (
+	"context"
+
+	"github.com/aaydin-tr/ddd-api-example/pkg/eventbus"
+)
+
+type afterCommitPublisher struct {
+	next eventbus.Publisher
+}
+
+// PublishAfterCommit delays publishing to next until the unit of work in
+// the context has committed, events of rolled back work are dropped.
+func PublishAfterCommit(next eventbus.Publisher) eventbus.Publisher {
+	return &afterCommitPublisher{next: next}
+}
+
+func (p *afterCommitPublisher) Publish(ctx context.Context, events ...eventbus.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	AfterCommit(ctx, func(ctx context.Context) {
+		_ = p.next.Publish(ctx, events...)
+	})
+
+	return nil
+}
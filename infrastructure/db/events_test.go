@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/pkg/eventbus"
+	"github.com/stretchr/testify/assert"
+)
+
+type named string
+
+func (e named) EventName() string     { return string(e) }
+func (e named) OccurredAt() time.Time { return time.Time{} }
+
+func TestPublishAfterCommit(t *testing.T) {
+	uow := NewMemoryUnitOfWork()
+
+	var got []eventbus.Event
+	bus := eventbus.New()
+	bus.SubscribeAll(func(ctx context.Context, e eventbus.Event) error {
+		got = append(got, e)
+		return nil
+	})
+	publisher := PublishAfterCommit(bus)
+
+	t.Run("should publish once the unit of work committed", func(t *testing.T) {
+		got = nil
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			assert.NoError(t, publisher.Publish(ctx, named("first"), named("second")))
+			assert.Empty(t, got)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []eventbus.Event{named("first"), named("second")}, got)
+	})
+
+	t.Run("should drop events of rolled back work", func(t *testing.T) {
+		got = nil
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			assert.NoError(t, publisher.Publish(ctx, named("first")))
+			return errors.New("fn error")
+		})
+
+		assert.EqualError(t, err, "fn error")
+		assert.Empty(t, got)
+	})
+
+	t.Run("should publish right away outside a unit of work", func(t *testing.T) {
+		got = nil
+		assert.NoError(t, publisher.Publish(context.Background(), named("first")))
+		assert.Equal(t, []eventbus.Event{named("first")}, got)
+	})
+}
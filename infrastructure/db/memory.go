@@ -0,0 +1,149 @@
+package db
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryUnitOfWork gives in-memory repositories the transaction semantics
+// of GormUnitOfWork. Repositories register how to undo their writes with
+// OnRollback and take row locks with RowLocks, both are tied to the unit of
+// work running in the context. Writes are visible to other callers before
+// the unit of work ends, which is fine for tests and local development.
+type MemoryUnitOfWork struct{}
+
+func NewMemoryUnitOfWork() UnitOfWork {
+	return &MemoryUnitOfWork{}
+}
+
+type memoryTxKey struct{}
+
+type memoryTx struct {
+	root        *memoryTx
+	undo        []func()
+	release     []func()
+	afterCommit []func(ctx context.Context)
+}
+
+func (u *MemoryUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error, opts ...Option) error {
+	tx := &memoryTx{}
+	parent, nested := ctx.Value(memoryTxKey{}).(*memoryTx)
+	if nested {
+		tx.root = parent.root
+	} else {
+		tx.root = tx
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			for i := len(tx.undo) - 1; i >= 0; i-- {
+				tx.undo[i]()
+			}
+		}
+
+		if nested {
+			// Like a savepoint, the writes of a nested unit of work are only
+			// final once the outermost one commits.
+			if committed {
+				parent.undo = append(parent.undo, tx.undo...)
+				parent.afterCommit = append(parent.afterCommit, tx.afterCommit...)
+			}
+			return
+		}
+
+		for i := len(tx.release) - 1; i >= 0; i-- {
+			tx.release[i]()
+		}
+
+		if committed {
+			runAfterCommit(ctx, tx.afterCommit)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, memoryTxKey{}, tx)); err != nil {
+		return err
+	}
+
+	committed = true
+	return nil
+}
+
+// OnRollback registers undo to run when the in-memory unit of work running
+// in ctx does not commit. Outside of a unit of work writes are final and
+// undo is dropped.
+func OnRollback(ctx context.Context, undo func()) {
+	if tx, ok := ctx.Value(memoryTxKey{}).(*memoryTx); ok {
+		tx.undo = append(tx.undo, undo)
+	}
+}
+
+// RowLocks emulates SELECT ... FOR UPDATE for in-memory repositories. A
+// lock is held by the outermost unit of work in the context until it ends,
+// locking the same row again from that unit of work does not block.
+type RowLocks struct {
+	mu   sync.Mutex
+	rows map[int]*rowLock
+}
+
+type rowLock struct {
+	owner *memoryTx
+	free  chan struct{}
+}
+
+// Lock waits until the row is free and locks it for the unit of work in
+// ctx. Outside of a unit of work it only waits for the row to be free.
+func (l *RowLocks) Lock(ctx context.Context, id int) error {
+	for {
+		free, acquired := l.acquire(ctx, id)
+		if acquired {
+			return nil
+		}
+
+		select {
+		case <-free:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// TryLock locks the row when it is free and reports whether it did, like
+// FOR UPDATE SKIP LOCKED.
+func (l *RowLocks) TryLock(ctx context.Context, id int) bool {
+	_, acquired := l.acquire(ctx, id)
+	return acquired
+}
+
+func (l *RowLocks) acquire(ctx context.Context, id int) (<-chan struct{}, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var owner *memoryTx
+	if tx, ok := ctx.Value(memoryTxKey{}).(*memoryTx); ok {
+		owner = tx.root
+	}
+
+	if row, ok := l.rows[id]; ok {
+		return row.free, owner != nil && row.owner == owner
+	}
+
+	if owner == nil {
+		return nil, true
+	}
+
+	if l.rows == nil {
+		l.rows = make(map[int]*rowLock)
+	}
+
+	row := &rowLock{owner: owner, free: make(chan struct{})}
+	l.rows[id] = row
+	owner.release = append(owner.release, func() {
+		l.mu.Lock()
+		delete(l.rows, id)
+		l.mu.Unlock()
+		close(row.free)
+	})
+
+	return nil, true
+}
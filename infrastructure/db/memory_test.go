@@ -0,0 +1,239 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryUnitOfWork(t *testing.T) {
+	t.Run("commit keeps the writes", func(t *testing.T) {
+		uow := NewMemoryUnitOfWork()
+		undone := false
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			OnRollback(ctx, func() { undone = true })
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, undone)
+	})
+
+	t.Run("error undoes the writes in reverse order", func(t *testing.T) {
+		uow := NewMemoryUnitOfWork()
+		var undone []int
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			OnRollback(ctx, func() { undone = append(undone, 1) })
+			OnRollback(ctx, func() { undone = append(undone, 2) })
+			return errors.New("fn error")
+		})
+
+		assert.EqualError(t, err, "fn error")
+		assert.Equal(t, []int{2, 1}, undone)
+	})
+
+	t.Run("panic undoes the writes", func(t *testing.T) {
+		uow := NewMemoryUnitOfWork()
+		undone := false
+		assert.PanicsWithValue(t, "boom", func() {
+			_ = uow.Do(context.Background(), func(ctx context.Context) error {
+				OnRollback(ctx, func() { undone = true })
+				panic("boom")
+			})
+		})
+		assert.True(t, undone)
+	})
+
+	t.Run("nested failure only undoes its own writes", func(t *testing.T) {
+		uow := NewMemoryUnitOfWork()
+		var undone []string
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			OnRollback(ctx, func() { undone = append(undone, "outer") })
+			_ = uow.Do(ctx, func(ctx context.Context) error {
+				OnRollback(ctx, func() { undone = append(undone, "nested") })
+				return errors.New("nested error")
+			})
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"nested"}, undone)
+	})
+
+	t.Run("committed nested writes are undone by the outer rollback", func(t *testing.T) {
+		uow := NewMemoryUnitOfWork()
+		var undone []string
+		_ = uow.Do(context.Background(), func(ctx context.Context) error {
+			_ = uow.Do(ctx, func(ctx context.Context) error {
+				OnRollback(ctx, func() { undone = append(undone, "nested") })
+				return nil
+			})
+			return errors.New("outer error")
+		})
+
+		assert.Equal(t, []string{"nested"}, undone)
+	})
+
+	t.Run("writes outside a unit of work are final", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			OnRollback(context.Background(), func() { t.Fatal("must not be called") })
+		})
+	})
+}
+
+func TestMemoryUnitOfWork_AfterCommit(t *testing.T) {
+	t.Run("runs after the outer unit of work released its locks", func(t *testing.T) {
+		uow := NewMemoryUnitOfWork()
+		var locks RowLocks
+		ran := false
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			if err := locks.Lock(ctx, 1); err != nil {
+				return err
+			}
+
+			return uow.Do(ctx, func(ctx context.Context) error {
+				AfterCommit(ctx, func(ctx context.Context) {
+					ran = true
+					assert.True(t, locks.TryLock(ctx, 1), "the row must be unlocked")
+				})
+				return nil
+			})
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, ran)
+	})
+
+	t.Run("is dropped on rollback", func(t *testing.T) {
+		uow := NewMemoryUnitOfWork()
+		var ran []string
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			AfterCommit(ctx, func(ctx context.Context) { ran = append(ran, "outer") })
+			_ = uow.Do(ctx, func(ctx context.Context) error {
+				AfterCommit(ctx, func(ctx context.Context) { ran = append(ran, "nested") })
+				return errors.New("nested error")
+			})
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"outer"}, ran)
+
+		err = uow.Do(context.Background(), func(ctx context.Context) error {
+			AfterCommit(ctx, func(ctx context.Context) { t.Fatal("must not be called") })
+			return errors.New("fn error")
+		})
+		assert.EqualError(t, err, "fn error")
+	})
+}
+
+func TestRowLocks(t *testing.T) {
+	t.Run("serializes units of work", func(t *testing.T) {
+		var locks RowLocks
+		uow := NewMemoryUnitOfWork()
+		locked := make(chan struct{})
+		release := make(chan struct{})
+
+		go func() {
+			_ = uow.Do(context.Background(), func(ctx context.Context) error {
+				assert.NoError(t, locks.Lock(ctx, 1))
+				close(locked)
+				<-release
+				return nil
+			})
+		}()
+		<-locked
+
+		acquired := make(chan struct{})
+		go func() {
+			_ = uow.Do(context.Background(), func(ctx context.Context) error {
+				assert.NoError(t, locks.Lock(ctx, 1))
+				close(acquired)
+				return nil
+			})
+		}()
+
+		select {
+		case <-acquired:
+			t.Fatal("lock acquired while held by another unit of work")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		close(release)
+		select {
+		case <-acquired:
+		case <-time.After(time.Second):
+			t.Fatal("lock was not released when the unit of work ended")
+		}
+	})
+
+	t.Run("is reentrant within a unit of work", func(t *testing.T) {
+		var locks RowLocks
+		uow := NewMemoryUnitOfWork()
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			assert.NoError(t, locks.Lock(ctx, 1))
+			return uow.Do(ctx, func(ctx context.Context) error {
+				return locks.Lock(ctx, 1)
+			})
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("try lock skips locked rows", func(t *testing.T) {
+		var locks RowLocks
+		uow := NewMemoryUnitOfWork()
+		_ = uow.Do(context.Background(), func(ctx context.Context) error {
+			assert.True(t, locks.TryLock(ctx, 1))
+
+			return uow.Do(context.Background(), func(other context.Context) error {
+				assert.False(t, locks.TryLock(other, 1))
+				assert.True(t, locks.TryLock(other, 2))
+				return nil
+			})
+		})
+	})
+
+	t.Run("waiting stops when the context is done", func(t *testing.T) {
+		var locks RowLocks
+		uow := NewMemoryUnitOfWork()
+		_ = uow.Do(context.Background(), func(ctx context.Context) error {
+			assert.NoError(t, locks.Lock(ctx, 1))
+
+			waitCtx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			assert.ErrorIs(t, locks.Lock(waitCtx, 1), context.DeadlineExceeded)
+			return nil
+		})
+	})
+
+	t.Run("concurrent increments are not lost", func(t *testing.T) {
+		var locks RowLocks
+		uow := NewMemoryUnitOfWork()
+		counter := 0
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = uow.Do(context.Background(), func(ctx context.Context) error {
+					if err := locks.Lock(ctx, 1); err != nil {
+						return err
+					}
+
+					current := counter
+					time.Sleep(time.Microsecond)
+					counter = current + 1
+					return nil
+				})
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 50, counter)
+	})
+}
@@ -0,0 +1,156 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const Usage = `usage: migrate <command>
+
+commands:
+  status        list migrations and whether they are applied
+  up            apply all pending migrations
+  down [N]      revert the last N applied migrations (default 1)
+  create NAME   create an empty migration in ` + Dir
+
+var (
+	ErrUnknownCommand = errors.New("unknown migrate command")
+	ErrNameRequired   = errors.New("migration name is required")
+)
+
+var nonWord = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Run executes a migrate subcommand. open is only called by commands that
+// need the database, so create works without one.
+func Run(ctx context.Context, args []string, out io.Writer, open func() (*Migrator, error)) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w\n%s", ErrUnknownCommand, Usage)
+	}
+
+	if args[0] == "create" {
+		if len(args) < 2 {
+			return ErrNameRequired
+		}
+
+		up, down, err := Create(Dir, strings.Join(args[1:], " "), time.Now())
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "created %s\ncreated %s\n", up, down)
+		return nil
+	}
+
+	var run func(m *Migrator) error
+	switch args[0] {
+	case "status":
+		run = func(m *Migrator) error {
+			return printStatus(ctx, m, out)
+		}
+	case "up":
+		run = func(m *Migrator) error {
+			applied, err := m.Up(ctx)
+			for _, migration := range applied {
+				fmt.Fprintf(out, "applied %s\n", migration)
+			}
+
+			if err == nil && len(applied) == 0 {
+				fmt.Fprintln(out, "schema is up to date")
+			}
+
+			return err
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return ErrInvalidSteps
+			}
+
+			steps = n
+		}
+
+		run = func(m *Migrator) error {
+			reverted, err := m.Down(ctx, steps)
+			for _, migration := range reverted {
+				fmt.Fprintf(out, "reverted %s\n", migration)
+			}
+
+			return err
+		}
+	default:
+		return fmt.Errorf("%w %q\n%s", ErrUnknownCommand, args[0], Usage)
+	}
+
+	m, err := open()
+	if err != nil {
+		return err
+	}
+
+	return run(m)
+}
+
+// Create writes an empty up and down migration to dir and returns their
+// paths. The version is the creation time, which keeps migrations written
+// on different branches in order.
+func Create(dir, name string, now time.Time) (string, string, error) {
+	name = strings.Trim(nonWord.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return "", "", ErrNameRequired
+	}
+
+	base := filepath.Join(dir, fmt.Sprintf("%s_%s", now.UTC().Format("20060102150405"), name))
+	up, down := base+".up.sql", base+".down.sql"
+	templates := map[string]string{
+		up:   "-- Write the statements that apply " + name + " here.\n",
+		down: "-- Write the statements that revert " + name + " here.\n",
+	}
+
+	for _, file := range []string{up, down} {
+		f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return "", "", err
+		}
+
+		_, err = f.WriteString(templates[file])
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	return up, down, nil
+}
+
+func printStatus(ctx context.Context, m *Migrator, out io.Writer) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, s := range statuses {
+		appliedAt := "pending"
+		if s.Applied() {
+			appliedAt = s.AppliedAt.Format(time.RFC3339)
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,89 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreate(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "migrations")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatalf("failed to create migrations dir: %v", err)
+	}
+
+	now := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+
+	up, down, err := Create(dir, "Add Venue to Tickets!", now)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "20250314150926_add_venue_to_tickets.up.sql"), up)
+	assert.Equal(t, filepath.Join(dir, "20250314150926_add_venue_to_tickets.down.sql"), down)
+
+	migrations, err := load(os.DirFS(root))
+	assert.NoError(t, err)
+	assert.Len(t, migrations, 1)
+	assert.Equal(t, "add_venue_to_tickets", migrations[0].Name)
+
+	_, _, err = Create(dir, "add venue to tickets", now)
+	assert.ErrorIs(t, err, os.ErrExist)
+
+	_, _, err = Create(dir, "!!", now)
+	assert.ErrorIs(t, err, ErrNameRequired)
+}
+
+func TestRun(t *testing.T) {
+	noDB := func() (*Migrator, error) {
+		return nil, errors.New("database should not be opened")
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{name: "no command", args: nil, wantErr: ErrUnknownCommand},
+		{name: "unknown command", args: []string{"sideways"}, wantErr: ErrUnknownCommand},
+		{name: "create without name", args: []string{"create"}, wantErr: ErrNameRequired},
+		{name: "down with invalid steps", args: []string{"down", "zero"}, wantErr: ErrInvalidSteps},
+		{name: "down with negative steps", args: []string{"down", "-1"}, wantErr: ErrInvalidSteps},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Run(context.Background(), tt.args, &bytes.Buffer{}, noDB)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("status", func(t *testing.T) {
+		m, mock := newTestMigrator(t)
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+		expectApplied(mock, 1)
+
+		var out bytes.Buffer
+		err := Run(context.Background(), []string{"status"}, &out, func() (*Migrator, error) { return m, nil })
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "VERSION")
+		assert.Contains(t, out.String(), "add_venue")
+		assert.Contains(t, out.String(), "pending")
+	})
+
+	t.Run("up when up to date", func(t *testing.T) {
+		m, mock := newTestMigrator(t)
+		expectLocked(mock, 1, 2, 3)
+		expectUnlock(mock)
+
+		var out bytes.Buffer
+		err := Run(context.Background(), []string{"up"}, &out, func() (*Migrator, error) { return m, nil })
+		assert.NoError(t, err)
+		assert.Equal(t, "schema is up to date\n", out.String())
+	})
+}
@@ -0,0 +1,137 @@
+package migration_test
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket/repository"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db/migration"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db/postgresql"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// autoMigratedTicket is the ticket the tickets table was created from by
+// AutoMigrate, before any migration existed.
+type autoMigratedTicket struct {
+	ID          int            `gorm:"primaryKey;autoIncrement"`
+	Name        string         `gorm:"not null;type:varchar(255)"`
+	Description string         `gorm:"not null;type:varchar(255)"`
+	Allocation  int            `gorm:"not null;type:int;default:0"`
+	CreatedAt   time.Time      `gorm:"not null;default:current_timestamp"`
+	UpdatedAt   time.Time      `gorm:"not null;default:current_timestamp;autoUpdateTime"`
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+}
+
+func (autoMigratedTicket) TableName() string {
+	return "tickets"
+}
+
+func TestMigrator_AdoptsAutoMigratedSchema(t *testing.T) {
+	if os.Getenv("INTEGRATION") == "" {
+		t.Skip("skipping integration tests: set INTEGRATION environment variable")
+	}
+
+	ctx := context.Background()
+	dbClient := startPostgres(t)
+	require.NoError(t, dbClient.AutoMigrate(&autoMigratedTicket{}))
+	require.NoError(t, dbClient.Create(&autoMigratedTicket{Name: "adopted", Description: "created by AutoMigrate", Allocation: 10}).Error)
+
+	sqlDB, err := dbClient.DB()
+	require.NoError(t, err)
+
+	migrator, err := migration.NewMigrator(sqlDB)
+	require.NoError(t, err)
+
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	pending, err := migrator.Pending(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	repo := repository.NewTicketRepository(dbClient)
+	tk, err := repo.FindByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "adopted", tk.Name.GetValue())
+	assert.Equal(t, 10, tk.Allocation.GetValue())
+	assert.Zero(t, tk.Sold)
+	assert.Zero(t, tk.Held)
+	assert.Equal(t, 1, tk.Version)
+	assert.Equal(t, ticket.StatusPublished, tk.Status)
+
+	require.NoError(t, tk.DecrementAllocation(ctx, 2, time.Now()))
+	require.NoError(t, repo.Update(ctx, tk))
+
+	updated, err := repo.FindByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 8, updated.Allocation.GetValue())
+	assert.Equal(t, 2, updated.Sold)
+	assert.Equal(t, 2, updated.Version)
+}
+
+func startPostgres(t *testing.T) *gorm.DB {
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		t.Fatalf("could not connect to docker: %s", err)
+	}
+
+	resource, err := pool.RunWithOptions(
+		&dockertest.RunOptions{
+			Repository: "postgres",
+			Tag:        "17",
+			Env: []string{
+				"POSTGRES_USER=postgres",
+				"POSTGRES_PASSWORD=secret",
+				"POSTGRES_DB=ticket",
+			},
+		},
+		func(hostConfig *docker.HostConfig) {
+			hostConfig.AutoRemove = true
+			hostConfig.RestartPolicy = docker.RestartPolicy{Name: "no"}
+		},
+	)
+	if err != nil {
+		t.Fatalf("could not start postgres: %s", err)
+	}
+
+	t.Cleanup(func() {
+		if err := pool.Purge(resource); err != nil {
+			t.Errorf("could not purge postgres: %s", err)
+		}
+	})
+
+	var dbClient *gorm.DB
+	err = pool.Retry(func() error {
+		port, _ := strconv.Atoi(resource.GetPort("5432/tcp"))
+		client, sqlDB, err := postgresql.NewPostgresDB("localhost", "postgres", "secret", "ticket", port)
+		if err != nil {
+			return err
+		}
+
+		if err := sqlDB.Ping(); err != nil {
+			return err
+		}
+
+		dbClient = client
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not connect to postgres: %s", err)
+	}
+
+	t.Cleanup(func() {
+		if sqlDB, err := dbClient.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	return dbClient
+}
@@ -0,0 +1,262 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"migrations/2_add_venue.up.sql":        {Data: []byte("ALTER TABLE tickets ADD COLUMN venue text")},
+		"migrations/2_add_venue.down.sql":      {Data: []byte("ALTER TABLE tickets DROP COLUMN venue")},
+		"migrations/1_create_tickets.up.sql":   {Data: []byte("CREATE TABLE tickets (id bigserial)")},
+		"migrations/1_create_tickets.down.sql": {Data: []byte("DROP TABLE tickets")},
+		"migrations/3_backfill.up.sql":         {Data: []byte("UPDATE tickets SET venue = 'unknown'")},
+		"migrations/3_backfill.down.sql":       {Data: []byte("-- nothing to revert")},
+	}
+}
+
+func newTestMigrator(t *testing.T) (*Migrator, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+
+	m, err := newMigrator(db, testFS())
+	if err != nil {
+		t.Fatalf("failed to create migrator: %v", err)
+	}
+
+	return m, mock
+}
+
+func expectLocked(mock sqlmock.Sqlmock, applied ...int64) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+	expectApplied(mock, applied...)
+}
+
+func expectApplied(mock sqlmock.Sqlmock, applied ...int64) {
+	rows := sqlmock.NewRows([]string{"version", "applied_at"})
+	for _, version := range applied {
+		rows.AddRow(version, time.Now())
+	}
+	mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").WillReturnRows(rows)
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_unlock($1)")).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("embedded migrations", func(t *testing.T) {
+		migrations, err := load(files)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, migrations)
+		for i := 1; i < len(migrations); i++ {
+			assert.Less(t, migrations[i-1].Version, migrations[i].Version)
+		}
+	})
+
+	t.Run("sorted by version", func(t *testing.T) {
+		migrations, err := load(testFS())
+		assert.NoError(t, err)
+		assert.Len(t, migrations, 3)
+		assert.Equal(t, "1_create_tickets", migrations[0].String())
+		assert.Equal(t, "2_add_venue", migrations[1].String())
+		assert.Equal(t, "3_backfill", migrations[2].String())
+		assert.Equal(t, "DROP TABLE tickets", migrations[0].Down)
+	})
+
+	tests := []struct {
+		name    string
+		fs      fstest.MapFS
+		wantErr error
+	}{
+		{
+			name:    "invalid file name",
+			fs:      fstest.MapFS{"migrations/create_tickets.up.sql": {Data: []byte("SELECT 1")}},
+			wantErr: ErrInvalidFileName,
+		},
+		{
+			name:    "missing down",
+			fs:      fstest.MapFS{"migrations/1_create_tickets.up.sql": {Data: []byte("SELECT 1")}},
+			wantErr: ErrMissingDown,
+		},
+		{
+			name:    "missing up",
+			fs:      fstest.MapFS{"migrations/1_create_tickets.down.sql": {Data: []byte("SELECT 1")}},
+			wantErr: ErrMissingUp,
+		},
+		{
+			name: "empty up",
+			fs: fstest.MapFS{
+				"migrations/1_create_tickets.up.sql":   {Data: []byte("  \n")},
+				"migrations/1_create_tickets.down.sql": {Data: []byte("SELECT 1")},
+			},
+			wantErr: ErrMissingUp,
+		},
+		{
+			name: "duplicate version",
+			fs: fstest.MapFS{
+				"migrations/1_create_tickets.up.sql": {Data: []byte("SELECT 1")},
+				"migrations/1_create_venues.up.sql":  {Data: []byte("SELECT 1")},
+			},
+			wantErr: ErrDuplicateMigration,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := load(tt.fs)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestMigrator_Status(t *testing.T) {
+	m, mock := newTestMigrator(t)
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+	expectApplied(mock, 1)
+
+	statuses, err := m.Status(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 3)
+	assert.True(t, statuses[0].Applied())
+	assert.False(t, statuses[1].Applied())
+	assert.False(t, statuses[2].Applied())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Pending(t *testing.T) {
+	t.Run("behind", func(t *testing.T) {
+		m, mock := newTestMigrator(t)
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+		expectApplied(mock, 1)
+
+		pending, err := m.Pending(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, pending, 2)
+		assert.Equal(t, int64(2), pending[0].Version)
+	})
+
+	t.Run("up to date", func(t *testing.T) {
+		m, mock := newTestMigrator(t)
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+		expectApplied(mock, 1, 2, 3)
+
+		pending, err := m.Pending(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+
+	t.Run("unknown applied version", func(t *testing.T) {
+		m, mock := newTestMigrator(t)
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+		expectApplied(mock, 1, 4)
+
+		_, err := m.Pending(context.Background())
+		assert.ErrorIs(t, err, ErrUnknownMigration)
+	})
+}
+
+func TestMigrator_Up(t *testing.T) {
+	t.Run("applies pending migrations in order", func(t *testing.T) {
+		m, mock := newTestMigrator(t)
+		expectLocked(mock, 1)
+		mock.ExpectBegin()
+		mock.ExpectExec("ALTER TABLE tickets ADD COLUMN venue text").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO schema_migrations").WithArgs(int64(2), "add_venue").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE tickets SET venue").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO schema_migrations").WithArgs(int64(3), "backfill").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		expectUnlock(mock)
+
+		applied, err := m.Up(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, applied, 2)
+		assert.Equal(t, int64(2), applied[0].Version)
+		assert.Equal(t, int64(3), applied[1].Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("stops at a failing migration", func(t *testing.T) {
+		m, mock := newTestMigrator(t)
+		expectLocked(mock, 1)
+		mock.ExpectBegin()
+		mock.ExpectExec("ALTER TABLE tickets ADD COLUMN venue text").WillReturnError(errors.New("syntax error"))
+		mock.ExpectRollback()
+		expectUnlock(mock)
+
+		applied, err := m.Up(context.Background())
+		assert.EqualError(t, err, "apply 2_add_venue: syntax error")
+		assert.Empty(t, applied)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("releases the lock when the table can not be read", func(t *testing.T) {
+		m, mock := newTestMigrator(t)
+		mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").WillReturnError(sql.ErrConnDone)
+		expectUnlock(mock)
+
+		_, err := m.Up(context.Background())
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMigrator_Down(t *testing.T) {
+	t.Run("reverts the newest migrations first", func(t *testing.T) {
+		m, mock := newTestMigrator(t)
+		expectLocked(mock, 1, 2, 3)
+		mock.ExpectBegin()
+		mock.ExpectExec("-- nothing to revert").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM schema_migrations WHERE version = $1")).WithArgs(int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectExec("ALTER TABLE tickets DROP COLUMN venue").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM schema_migrations WHERE version = $1")).WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		expectUnlock(mock)
+
+		reverted, err := m.Down(context.Background(), 2)
+		assert.NoError(t, err)
+		assert.Len(t, reverted, 2)
+		assert.Equal(t, int64(3), reverted[0].Version)
+		assert.Equal(t, int64(2), reverted[1].Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("skips migrations that are not applied", func(t *testing.T) {
+		m, mock := newTestMigrator(t)
+		expectLocked(mock, 1)
+		mock.ExpectBegin()
+		mock.ExpectExec("DROP TABLE tickets").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM schema_migrations WHERE version = $1")).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		expectUnlock(mock)
+
+		reverted, err := m.Down(context.Background(), 5)
+		assert.NoError(t, err)
+		assert.Len(t, reverted, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("invalid steps", func(t *testing.T) {
+		m, _ := newTestMigrator(t)
+		_, err := m.Down(context.Background(), 0)
+		assert.ErrorIs(t, err, ErrInvalidSteps)
+	})
+}
@@ -0,0 +1,13 @@
+-- Matches the tickets table AutoMigrate created before any request added to
+-- it, the next migration brings such databases up to date.
+CREATE TABLE IF NOT EXISTS tickets (
+    id          bigserial PRIMARY KEY,
+    name        varchar(255) NOT NULL,
+    description varchar(255) NOT NULL,
+    allocation  int NOT NULL DEFAULT 0,
+    created_at  timestamptz NOT NULL DEFAULT current_timestamp,
+    updated_at  timestamptz NOT NULL DEFAULT current_timestamp,
+    deleted_at  timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_tickets_deleted_at ON tickets (deleted_at);
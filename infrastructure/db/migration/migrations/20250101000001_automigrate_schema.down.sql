@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS idempotency_keys;
+DROP TABLE IF EXISTS reservations;
+DROP TABLE IF EXISTS refunds;
+DROP TABLE IF EXISTS purchases;
+
+ALTER TABLE tickets DROP COLUMN IF EXISTS version;
+ALTER TABLE tickets DROP COLUMN IF EXISTS price;
+ALTER TABLE tickets DROP COLUMN IF EXISTS held;
+ALTER TABLE tickets DROP COLUMN IF EXISTS sold;
@@ -0,0 +1,63 @@
+-- Adds what AutoMigrate created for purchases, refunds, reservations,
+-- idempotency keys and ticket versioning. Every statement is guarded, so a
+-- database that AutoMigrate left at any of those steps ends up the same.
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS sold int NOT NULL DEFAULT 0;
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS held int NOT NULL DEFAULT 0;
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS price varchar(32) NOT NULL DEFAULT '0 XXX';
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS version int NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS purchases (
+    id         bigserial PRIMARY KEY,
+    ticket_id  bigint NOT NULL,
+    user_id    uuid NOT NULL,
+    quantity   int NOT NULL,
+    status     varchar(32) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT current_timestamp,
+    updated_at timestamptz NOT NULL DEFAULT current_timestamp
+);
+
+ALTER TABLE purchases ADD COLUMN IF NOT EXISTS unit_price varchar(32) NOT NULL DEFAULT '0 XXX';
+ALTER TABLE purchases ADD COLUMN IF NOT EXISTS total varchar(32) NOT NULL DEFAULT '0 XXX';
+ALTER TABLE purchases ADD COLUMN IF NOT EXISTS refunded_quantity int NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_purchases_ticket_id ON purchases (ticket_id);
+CREATE INDEX IF NOT EXISTS idx_purchases_user_id ON purchases (user_id);
+
+CREATE TABLE IF NOT EXISTS refunds (
+    id          bigserial PRIMARY KEY,
+    purchase_id bigint NOT NULL,
+    quantity    int NOT NULL,
+    amount      varchar(32) NOT NULL,
+    created_at  timestamptz NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_purchase_id ON refunds (purchase_id);
+
+CREATE TABLE IF NOT EXISTS reservations (
+    id          bigserial PRIMARY KEY,
+    ticket_id   bigint NOT NULL,
+    user_id     uuid NOT NULL,
+    quantity    int NOT NULL,
+    status      varchar(32) NOT NULL,
+    expires_at  timestamptz NOT NULL,
+    purchase_id bigint,
+    created_at  timestamptz NOT NULL DEFAULT current_timestamp,
+    updated_at  timestamptz NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_reservations_ticket_id ON reservations (ticket_id);
+CREATE INDEX IF NOT EXISTS idx_reservations_user_id ON reservations (user_id);
+CREATE INDEX IF NOT EXISTS idx_reservations_status_expires_at ON reservations (status, expires_at);
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key           varchar(255) PRIMARY KEY,
+    fingerprint   varchar(64) NOT NULL,
+    completed     boolean NOT NULL DEFAULT false,
+    status_code   bigint NOT NULL DEFAULT 0,
+    content_type  varchar(255) NOT NULL DEFAULT '',
+    response_body bytea,
+    created_at    timestamptz NOT NULL DEFAULT current_timestamp,
+    expires_at    timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
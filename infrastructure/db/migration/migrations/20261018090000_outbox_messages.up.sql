@@ -0,0 +1,14 @@
+CREATE TABLE outbox_messages (
+    id              bigserial PRIMARY KEY,
+    event_name      varchar(255) NOT NULL,
+    payload         jsonb NOT NULL,
+    occurred_at     timestamptz NOT NULL,
+    created_at      timestamptz NOT NULL DEFAULT current_timestamp,
+    attempts        int NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL DEFAULT current_timestamp,
+    last_error      text NOT NULL DEFAULT '',
+    published_at    timestamptz
+);
+
+-- The relay only ever looks at unpublished messages.
+CREATE INDEX idx_outbox_messages_unpublished ON outbox_messages (next_attempt_at, id) WHERE published_at IS NULL;
@@ -0,0 +1,36 @@
+CREATE TABLE webhook_subscriptions (
+    id         bigserial PRIMARY KEY,
+    url        text NOT NULL,
+    events     jsonb NOT NULL,
+    secret     varchar(255) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT current_timestamp,
+    deleted_at timestamptz
+);
+
+CREATE INDEX idx_webhook_subscriptions_deleted_at ON webhook_subscriptions (deleted_at);
+
+CREATE TABLE webhook_deliveries (
+    id              bigserial PRIMARY KEY,
+    subscription_id bigint NOT NULL REFERENCES webhook_subscriptions (id),
+    message_id      bigint NOT NULL,
+    event           varchar(255) NOT NULL,
+    payload         jsonb NOT NULL,
+    status          varchar(32) NOT NULL,
+    attempts        int NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL DEFAULT current_timestamp,
+    response_status int NOT NULL DEFAULT 0,
+    last_error      text NOT NULL DEFAULT '',
+    delivered_at    timestamptz,
+    replay_of       bigint REFERENCES webhook_deliveries (id),
+    created_at      timestamptz NOT NULL DEFAULT current_timestamp,
+    updated_at      timestamptz NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX idx_webhook_deliveries_subscription_id ON webhook_deliveries (subscription_id, id);
+
+-- A message relayed again must not be queued twice for a subscription,
+-- replays are separate deliveries of the same message.
+CREATE UNIQUE INDEX idx_webhook_deliveries_message ON webhook_deliveries (message_id, subscription_id) WHERE replay_of IS NULL;
+
+-- The sender only ever looks at pending deliveries.
+CREATE INDEX idx_webhook_deliveries_pending ON webhook_deliveries (next_attempt_at, id) WHERE status = 'pending';
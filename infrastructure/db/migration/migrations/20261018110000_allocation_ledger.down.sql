@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS allocation_ledger;
+DROP FUNCTION IF EXISTS allocation_ledger_append_only();
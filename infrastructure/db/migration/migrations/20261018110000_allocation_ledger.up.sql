@@ -0,0 +1,27 @@
+CREATE TABLE allocation_ledger (
+    id         bigserial PRIMARY KEY,
+    ticket_id  bigint NOT NULL REFERENCES tickets (id),
+    delta      int NOT NULL,
+    allocation int NOT NULL,
+    reason     varchar(32) NOT NULL,
+    actor_id   varchar(255) NOT NULL DEFAULT '',
+    request_id varchar(255) NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX idx_allocation_ledger_ticket_id ON allocation_ledger (ticket_id, id);
+
+-- The ledger is append-only, a wrong entry is corrected by a new one.
+CREATE FUNCTION allocation_ledger_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'allocation_ledger is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER allocation_ledger_append_only
+    BEFORE UPDATE OR DELETE ON allocation_ledger
+    FOR EACH ROW EXECUTE FUNCTION allocation_ledger_append_only();
+
+-- Existing tickets start their ledger at the allocation they have now.
+INSERT INTO allocation_ledger (ticket_id, delta, allocation, reason)
+SELECT id, allocation, allocation, 'backfill' FROM tickets ORDER BY id;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_reservations_ticket_id_user_id;
+DROP INDEX IF EXISTS idx_purchases_ticket_id_user_id;
+ALTER TABLE tickets DROP COLUMN IF EXISTS max_per_user;
@@ -0,0 +1,6 @@
+ALTER TABLE tickets ADD COLUMN max_per_user int CHECK (max_per_user > 0);
+
+-- Purchases and holds of a user are added up on every purchase of a ticket
+-- with a limit.
+CREATE INDEX idx_purchases_ticket_id_user_id ON purchases (ticket_id, user_id);
+CREATE INDEX idx_reservations_ticket_id_user_id ON reservations (ticket_id, user_id) WHERE status = 'active';
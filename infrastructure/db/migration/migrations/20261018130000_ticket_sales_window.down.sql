@@ -0,0 +1,3 @@
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS chk_tickets_sales_window;
+ALTER TABLE tickets DROP COLUMN IF EXISTS sales_end_at;
+ALTER TABLE tickets DROP COLUMN IF EXISTS sales_start_at;
@@ -0,0 +1,3 @@
+ALTER TABLE tickets ADD COLUMN sales_start_at timestamptz;
+ALTER TABLE tickets ADD COLUMN sales_end_at timestamptz;
+ALTER TABLE tickets ADD CONSTRAINT chk_tickets_sales_window CHECK (sales_end_at > sales_start_at);
@@ -0,0 +1,2 @@
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS chk_tickets_status;
+ALTER TABLE tickets DROP COLUMN IF EXISTS status;
@@ -0,0 +1,4 @@
+-- Tickets that exist already are on sale, new ones start as drafts.
+ALTER TABLE tickets ADD COLUMN status varchar(32) NOT NULL DEFAULT 'published';
+ALTER TABLE tickets ALTER COLUMN status SET DEFAULT 'draft';
+ALTER TABLE tickets ADD CONSTRAINT chk_tickets_status CHECK (status IN ('draft', 'published', 'paused', 'archived'));
@@ -0,0 +1,3 @@
+ALTER TABLE tickets DROP COLUMN IF EXISTS event_id;
+DROP TABLE IF EXISTS events;
+DROP TABLE IF EXISTS venues;
@@ -0,0 +1,29 @@
+CREATE TABLE venues (
+    id         bigserial PRIMARY KEY,
+    name       varchar(255) NOT NULL,
+    address    jsonb NOT NULL,
+    timezone   varchar(64) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT current_timestamp,
+    updated_at timestamptz NOT NULL DEFAULT current_timestamp
+);
+
+CREATE TABLE events (
+    id          bigserial PRIMARY KEY,
+    venue_id    bigint NOT NULL REFERENCES venues (id),
+    name        varchar(255) NOT NULL,
+    description varchar(255) NOT NULL,
+    dates       tstzrange NOT NULL,
+    capacity    int NOT NULL,
+    created_at  timestamptz NOT NULL DEFAULT current_timestamp,
+    updated_at  timestamptz NOT NULL DEFAULT current_timestamp,
+    CONSTRAINT chk_events_dates CHECK (NOT isempty(dates) AND NOT lower_inf(dates) AND NOT upper_inf(dates)),
+    CONSTRAINT chk_events_capacity CHECK (capacity > 0)
+);
+
+CREATE INDEX idx_events_venue_id ON events (venue_id);
+CREATE INDEX idx_events_dates ON events USING gist (dates);
+
+-- Tickets that exist already do not belong to an event and are not counted
+-- against any capacity.
+ALTER TABLE tickets ADD COLUMN event_id bigint REFERENCES events (id);
+CREATE INDEX idx_tickets_event_id ON tickets (event_id);
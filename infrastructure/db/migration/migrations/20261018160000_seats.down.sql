@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS seats;
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS chk_tickets_seating;
+ALTER TABLE tickets DROP COLUMN IF EXISTS seating;
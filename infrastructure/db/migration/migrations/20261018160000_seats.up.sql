@@ -0,0 +1,24 @@
+-- Tickets that exist already have general seating.
+ALTER TABLE tickets ADD COLUMN seating varchar(32) NOT NULL DEFAULT 'general';
+ALTER TABLE tickets ADD CONSTRAINT chk_tickets_seating CHECK (seating IN ('general', 'assigned'));
+
+CREATE TABLE seats (
+    id             bigserial PRIMARY KEY,
+    ticket_id      bigint NOT NULL REFERENCES tickets (id),
+    section        varchar(64) NOT NULL,
+    "row"          varchar(16) NOT NULL,
+    number         int NOT NULL,
+    row_rank       int NOT NULL,
+    status         varchar(32) NOT NULL DEFAULT 'available',
+    reservation_id bigint,
+    purchase_id    bigint,
+    version        int NOT NULL DEFAULT 1,
+    created_at     timestamptz NOT NULL DEFAULT current_timestamp,
+    updated_at     timestamptz NOT NULL DEFAULT current_timestamp,
+    CONSTRAINT uq_seats_place UNIQUE (ticket_id, section, "row", number),
+    CONSTRAINT chk_seats_number CHECK (number > 0),
+    CONSTRAINT chk_seats_status CHECK (status IN ('available', 'held', 'sold'))
+);
+
+CREATE INDEX idx_seats_reservation_id ON seats (reservation_id);
+CREATE INDEX idx_seats_purchase_id ON seats (purchase_id);
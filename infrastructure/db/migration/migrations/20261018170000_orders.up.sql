@@ -0,0 +1,27 @@
+CREATE TABLE orders (
+    id         bigserial PRIMARY KEY,
+    user_id    uuid NOT NULL,
+    total      varchar(32) NOT NULL DEFAULT '0 XXX',
+    created_at timestamptz NOT NULL DEFAULT current_timestamp,
+    updated_at timestamptz NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX idx_orders_user_id ON orders (user_id);
+
+CREATE TABLE order_lines (
+    id          bigserial PRIMARY KEY,
+    order_id    bigint NOT NULL REFERENCES orders (id),
+    position    int NOT NULL,
+    ticket_id   bigint NOT NULL REFERENCES tickets (id),
+    purchase_id bigint NOT NULL REFERENCES purchases (id),
+    quantity    int NOT NULL,
+    unit_price  varchar(32) NOT NULL DEFAULT '0 XXX',
+    total       varchar(32) NOT NULL DEFAULT '0 XXX',
+    created_at  timestamptz NOT NULL DEFAULT current_timestamp,
+    CONSTRAINT uq_order_lines_position UNIQUE (order_id, position),
+    CONSTRAINT uq_order_lines_ticket UNIQUE (order_id, ticket_id),
+    CONSTRAINT chk_order_lines_quantity CHECK (quantity > 0)
+);
+
+CREATE INDEX idx_order_lines_ticket_id ON order_lines (ticket_id);
+CREATE INDEX idx_order_lines_purchase_id ON order_lines (purchase_id);
@@ -0,0 +1,5 @@
+ALTER TABLE order_lines DROP COLUMN IF EXISTS discount;
+ALTER TABLE orders DROP COLUMN IF EXISTS discount;
+ALTER TABLE purchases DROP COLUMN IF EXISTS discount;
+DROP TABLE IF EXISTS promotion_redemptions;
+DROP TABLE IF EXISTS promotions;
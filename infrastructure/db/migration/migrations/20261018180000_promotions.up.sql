@@ -0,0 +1,47 @@
+CREATE TABLE promotions (
+    id              bigserial PRIMARY KEY,
+    code            varchar(64) NOT NULL,
+    kind            varchar(32) NOT NULL,
+    value           bigint NOT NULL,
+    currency        varchar(3) NOT NULL DEFAULT '',
+    ticket_id       bigint REFERENCES tickets (id),
+    max_redemptions int,
+    max_per_user    int,
+    redemptions     int NOT NULL DEFAULT 0,
+    starts_at       timestamptz,
+    ends_at         timestamptz,
+    created_at      timestamptz NOT NULL DEFAULT current_timestamp,
+    updated_at      timestamptz NOT NULL DEFAULT current_timestamp,
+    CONSTRAINT uq_promotions_code UNIQUE (code),
+    CONSTRAINT chk_promotions_kind CHECK (kind IN ('percentage', 'fixed')),
+    CONSTRAINT chk_promotions_value CHECK (value > 0 AND (kind <> 'percentage' OR value <= 100)),
+    CONSTRAINT chk_promotions_redemptions CHECK (redemptions >= 0 AND (max_redemptions IS NULL OR redemptions <= max_redemptions)),
+    CONSTRAINT chk_promotions_validity CHECK (starts_at IS NULL OR ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX idx_promotions_ticket_id ON promotions (ticket_id);
+
+CREATE TABLE promotion_redemptions (
+    id           bigserial PRIMARY KEY,
+    promotion_id bigint NOT NULL REFERENCES promotions (id),
+    user_id      uuid NOT NULL,
+    purchase_id  bigint REFERENCES purchases (id),
+    order_id     bigint REFERENCES orders (id),
+    discount     varchar(32) NOT NULL,
+    created_at   timestamptz NOT NULL DEFAULT current_timestamp,
+    CONSTRAINT chk_promotion_redemptions_target CHECK ((purchase_id IS NULL) <> (order_id IS NULL))
+);
+
+CREATE INDEX idx_promotion_redemptions_promotion_user ON promotion_redemptions (promotion_id, user_id);
+CREATE INDEX idx_promotion_redemptions_purchase_id ON promotion_redemptions (purchase_id);
+CREATE INDEX idx_promotion_redemptions_order_id ON promotion_redemptions (order_id);
+
+-- Existing purchases and orders were not discounted.
+ALTER TABLE purchases ADD COLUMN discount varchar(32) NOT NULL DEFAULT '0 XXX';
+UPDATE purchases SET discount = '0 ' || split_part(unit_price, ' ', 2);
+
+ALTER TABLE orders ADD COLUMN discount varchar(32) NOT NULL DEFAULT '0 XXX';
+UPDATE orders SET discount = '0 ' || split_part(total, ' ', 2);
+
+ALTER TABLE order_lines ADD COLUMN discount varchar(32) NOT NULL DEFAULT '0 XXX';
+UPDATE order_lines SET discount = '0 ' || split_part(unit_price, ' ', 2);
@@ -0,0 +1,22 @@
+CREATE TABLE waitlist_entries (
+    id               bigserial PRIMARY KEY,
+    ticket_id        bigint NOT NULL REFERENCES tickets (id),
+    user_id          uuid NOT NULL,
+    quantity         int NOT NULL,
+    status           varchar(32) NOT NULL,
+    reservation_id   bigint REFERENCES reservations (id),
+    offer_expires_at timestamptz,
+    created_at       timestamptz NOT NULL DEFAULT current_timestamp,
+    updated_at       timestamptz NOT NULL DEFAULT current_timestamp,
+    CONSTRAINT chk_waitlist_entries_quantity CHECK (quantity > 0),
+    CONSTRAINT chk_waitlist_entries_status CHECK (status IN ('waiting', 'offered', 'claimed', 'lapsed', 'left')),
+    CONSTRAINT chk_waitlist_entries_offer CHECK ((status = 'waiting' OR status = 'left') = (reservation_id IS NULL))
+);
+
+CREATE INDEX idx_waitlist_entries_ticket_status ON waitlist_entries (ticket_id, status);
+CREATE INDEX idx_waitlist_entries_user_id ON waitlist_entries (user_id);
+
+-- A user waits at most once per ticket, entries that were served or left
+-- do not count.
+CREATE UNIQUE INDEX idx_waitlist_entries_active_user ON waitlist_entries (ticket_id, user_id)
+    WHERE status IN ('waiting', 'offered');
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+)
+
+// ErrTransactionConflict is returned when a unit of work still fails with a
+// retryable error after all attempts of its retry policy were used.
+var ErrTransactionConflict = errors.New("transaction conflicted with a concurrent transaction")
+
+const (
+	sqlStateSerializationFailure = "40001"
+	sqlStateDeadlockDetected     = "40P01"
+	sqlStateUniqueViolation      = "23505"
+)
+
+// RetryPolicy decides how often a unit of work is retried when the database
+// aborts it with a serialization failure or a deadlock. Attempts wait an
+// exponentially growing, jittered delay between BaseDelay and MaxDelay.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// NoRetry runs every unit of work exactly once.
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+func (p RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+
+	return p.MaxAttempts
+}
+
+// Backoff returns the delay before the given retry, retries are counted
+// from 1. Half of the delay is fixed and the other half is random so that
+// transactions that failed together do not collide again.
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	delay := p.MaxDelay
+	if retry < 32 {
+		if exp := p.BaseDelay << (retry - 1); exp > 0 && exp < p.MaxDelay {
+			delay = exp
+		}
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// IsRetryable reports whether err is a serialization failure or a deadlock
+// that may succeed when the whole transaction is run again.
+func IsRetryable(err error) bool {
+	var state interface{ SQLState() string }
+	if !errors.As(err, &state) {
+		return false
+	}
+
+	switch state.SQLState() {
+	case sqlStateSerializationFailure, sqlStateDeadlockDetected:
+		return true
+	}
+
+	return false
+}
+
+// IsUniqueViolation reports whether err was caused by a unique constraint.
+func IsUniqueViolation(err error) bool {
+	var state interface{ SQLState() string }
+	return errors.As(err, &state) && state.SQLState() == sqlStateUniqueViolation
+}
+
+func ParseIsolationLevel(level string) (sql.IsolationLevel, error) {
+	switch strings.ToLower(level) {
+	case "", "default":
+		return sql.LevelDefault, nil
+	case "read committed":
+		return sql.LevelReadCommitted, nil
+	case "repeatable read":
+		return sql.LevelRepeatableRead, nil
+	case "serializable":
+		return sql.LevelSerializable, nil
+	}
+
+	return sql.LevelDefault, fmt.Errorf("unknown isolation level %q", level)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
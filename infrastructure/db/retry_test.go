@@ -0,0 +1,92 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "serialization failure", err: &sqlStateError{code: "40001"}, want: true},
+		{name: "deadlock", err: &sqlStateError{code: "40P01"}, want: true},
+		{name: "wrapped", err: fmt.Errorf("purchase: %w", &sqlStateError{code: "40001"}), want: true},
+		{name: "unique violation", err: &sqlStateError{code: "23505"}, want: false},
+		{name: "plain error", err: errors.New("boom"), want: false},
+		{name: "nil", err: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsRetryable(tt.err))
+		})
+	}
+}
+
+func TestIsUniqueViolation(t *testing.T) {
+	assert.True(t, IsUniqueViolation(fmt.Errorf("create: %w", &sqlStateError{code: "23505"})))
+	assert.False(t, IsUniqueViolation(&sqlStateError{code: "40001"}))
+	assert.False(t, IsUniqueViolation(errors.New("boom")))
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+
+	tests := []struct {
+		retry int
+		max   time.Duration
+	}{
+		{retry: 1, max: 10 * time.Millisecond},
+		{retry: 2, max: 20 * time.Millisecond},
+		{retry: 3, max: 40 * time.Millisecond},
+		{retry: 4, max: 50 * time.Millisecond},
+		{retry: 64, max: 50 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("retry %d", tt.retry), func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				delay := policy.Backoff(tt.retry)
+				assert.GreaterOrEqual(t, delay, tt.max/2)
+				assert.LessOrEqual(t, delay, tt.max)
+			}
+		})
+	}
+
+	assert.Zero(t, NoRetry.Backoff(1))
+}
+
+func TestParseIsolationLevel(t *testing.T) {
+	tests := []struct {
+		level   string
+		want    sql.IsolationLevel
+		wantErr bool
+	}{
+		{level: "", want: sql.LevelDefault},
+		{level: "read committed", want: sql.LevelReadCommitted},
+		{level: "Repeatable Read", want: sql.LevelRepeatableRead},
+		{level: "serializable", want: sql.LevelSerializable},
+		{level: "chaos", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			got, err := ParseIsolationLevel(tt.level)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -destination=../../mock/db/unit_of_work.go -package=db github.com/aaydin-tr/ddd-api-example/infrastructure/db UnitOfWork
+type UnitOfWork interface {
+	// Do runs fn in a transaction that is committed when fn returns nil and
+	// rolled back when it returns an error or panics. The transaction is
+	// stored in the context passed to fn, repositories pick it up with
+	// Conn. Calling Do again with that context runs fn in a savepoint.
+	//
+	// A transaction aborted by a serialization failure or a deadlock is run
+	// again according to the retry policy, so fn must not have side effects
+	// outside of the transaction.
+	Do(ctx context.Context, fn func(ctx context.Context) error, opts ...Option) error
+}
+
+// Option changes how a single unit of work is run.
+type Option func(*options)
+
+type options struct {
+	isolation sql.IsolationLevel
+}
+
+// WithIsolation runs the transaction with the given isolation level instead
+// of the default of the unit of work. It has no effect on nested calls.
+func WithIsolation(level sql.IsolationLevel) Option {
+	return func(o *options) {
+		o.isolation = level
+	}
+}
+
+type txKey struct{}
+
+type ambientTx struct {
+	tx          *gorm.DB
+	depth       int
+	afterCommit []func(ctx context.Context)
+}
+
+type GormUnitOfWork struct {
+	db        *gorm.DB
+	retry     RetryPolicy
+	isolation sql.IsolationLevel
+	retries   atomic.Int64
+}
+
+func NewUnitOfWork(db *gorm.DB, retry RetryPolicy, isolation sql.IsolationLevel) UnitOfWork {
+	return &GormUnitOfWork{db: db, retry: retry, isolation: isolation}
+}
+
+// Retries returns how many times a transaction was run again since the unit
+// of work was created.
+func (u *GormUnitOfWork) Retries() int64 {
+	return u.retries.Load()
+}
+
+func (u *GormUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error, opts ...Option) error {
+	if current, ok := ctx.Value(txKey{}).(*ambientTx); ok {
+		return u.savepoint(ctx, current, fn)
+	}
+
+	o := options{isolation: u.isolation}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	attempts := u.retry.attempts()
+	for attempt := 1; ; attempt++ {
+		err := u.run(ctx, o, fn)
+		if err == nil || !IsRetryable(err) {
+			return err
+		}
+
+		if attempt == attempts {
+			return fmt.Errorf("%w after %d attempts: %w", ErrTransactionConflict, attempts, err)
+		}
+
+		delay := u.retry.Backoff(attempt)
+		u.retries.Add(1)
+		log.Printf("retrying transaction in %s (attempt %d of %d): %s", delay, attempt+1, attempts, err)
+
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+func (u *GormUnitOfWork) run(ctx context.Context, o options, fn func(ctx context.Context) error) error {
+	var txOptions []*sql.TxOptions
+	if o.isolation != sql.LevelDefault {
+		txOptions = append(txOptions, &sql.TxOptions{Isolation: o.isolation})
+	}
+
+	tx := u.db.WithContext(ctx).Begin(txOptions...)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+	}()
+
+	current := &ambientTx{tx: tx}
+	if err := fn(context.WithValue(ctx, txKey{}, current)); err != nil {
+		if rbErr := tx.Rollback().Error; rbErr != nil {
+			return errors.Join(err, rbErr)
+		}
+
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	runAfterCommit(ctx, current.afterCommit)
+	return nil
+}
+
+// savepoint runs a nested unit of work, only the work done inside fn is
+// undone when it fails and the outer transaction can carry on.
+func (u *GormUnitOfWork) savepoint(ctx context.Context, current *ambientTx, fn func(ctx context.Context) error) error {
+	nested := &ambientTx{tx: current.tx, depth: current.depth + 1}
+	name := fmt.Sprintf("sp_%d", nested.depth)
+
+	if err := current.tx.SavePoint(name).Error; err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			current.tx.RollbackTo(name)
+			panic(r)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, nested)); err != nil {
+		if rbErr := current.tx.RollbackTo(name).Error; rbErr != nil {
+			return errors.Join(err, rbErr)
+		}
+
+		return err
+	}
+
+	current.afterCommit = append(current.afterCommit, nested.afterCommit...)
+	return nil
+}
+
+// Conn returns the transaction of the unit of work running in ctx, or db
+// when there is none.
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if current, ok := ctx.Value(txKey{}).(*ambientTx); ok {
+		return current.tx.WithContext(ctx)
+	}
+
+	return db.WithContext(ctx)
+}
+
+// AfterCommit registers fn to run once the outermost unit of work in ctx has
+// committed, it is dropped when the work is rolled back or retried. Outside
+// of a unit of work there is nothing to wait for and fn runs right away. fn
+// gets a context without the transaction.
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	if current, ok := ctx.Value(txKey{}).(*ambientTx); ok {
+		current.afterCommit = append(current.afterCommit, fn)
+		return
+	}
+
+	if current, ok := ctx.Value(memoryTxKey{}).(*memoryTx); ok {
+		current.afterCommit = append(current.afterCommit, fn)
+		return
+	}
+
+	fn(ctx)
+}
+
+func runAfterCommit(ctx context.Context, hooks []func(ctx context.Context)) {
+	for _, hook := range hooks {
+		hook(ctx)
+	}
+}
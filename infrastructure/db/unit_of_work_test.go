@@ -0,0 +1,399 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	mockDb, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+
+	dialector := postgres.New(postgres.Config{
+		Conn:       mockDb,
+		DriverName: "postgres",
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+
+	return db, mock
+}
+
+func TestUnitOfWork_Commit(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	uow := NewUnitOfWork(db, NoRetry, sql.LevelDefault)
+	err := uow.Do(context.Background(), func(ctx context.Context) error {
+		assert.NotSame(t, db.Statement.ConnPool, Conn(ctx, db).Statement.ConnPool)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnitOfWork_WithIsolation(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	uow := NewUnitOfWork(db, NoRetry, sql.LevelReadCommitted)
+	err := uow.Do(context.Background(), func(ctx context.Context) error {
+		return nil
+	}, WithIsolation(sql.LevelSerializable))
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnitOfWork_RollbackOnError(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	uow := NewUnitOfWork(db, NoRetry, sql.LevelDefault)
+	want := errors.New("fn error")
+	err := uow.Do(context.Background(), func(ctx context.Context) error {
+		return want
+	})
+
+	assert.ErrorIs(t, err, want)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnitOfWork_RollbackOnPanic(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	uow := NewUnitOfWork(db, NoRetry, sql.LevelDefault)
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = uow.Do(context.Background(), func(ctx context.Context) error {
+			panic("boom")
+		})
+	})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnitOfWork_BeginError(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectBegin().WillReturnError(errors.New("begin error"))
+
+	uow := NewUnitOfWork(db, NoRetry, sql.LevelDefault)
+	called := false
+	err := uow.Do(context.Background(), func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+
+	assert.EqualError(t, err, "begin error")
+	assert.False(t, called)
+}
+
+func TestUnitOfWork_NestedSavepoint(t *testing.T) {
+	t.Run("nested success is committed with the outer transaction", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		uow := NewUnitOfWork(db, NoRetry, sql.LevelDefault)
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			outer := Conn(ctx, db)
+			return uow.Do(ctx, func(ctx context.Context) error {
+				assert.Same(t, outer.Statement.ConnPool, Conn(ctx, db).Statement.ConnPool)
+				return nil
+			})
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nested failure only rolls back to the savepoint", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		uow := NewUnitOfWork(db, NoRetry, sql.LevelDefault)
+		nestedErr := errors.New("nested error")
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			err := uow.Do(ctx, func(ctx context.Context) error {
+				return nestedErr
+			})
+			assert.ErrorIs(t, err, nestedErr)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("deeper savepoints get their own names", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("SAVEPOINT sp_2").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_2").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		uow := NewUnitOfWork(db, NoRetry, sql.LevelDefault)
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			return uow.Do(ctx, func(ctx context.Context) error {
+				return uow.Do(ctx, func(ctx context.Context) error {
+					return errors.New("deep error")
+				})
+			})
+		})
+
+		assert.EqualError(t, err, "deep error")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestConn(t *testing.T) {
+	db, _ := newMockDB(t)
+	assert.Same(t, db.Statement.ConnPool, Conn(context.Background(), db).Statement.ConnPool)
+}
+
+type sqlStateError struct {
+	code string
+}
+
+func (e *sqlStateError) Error() string {
+	return "sqlstate " + e.code
+}
+
+func (e *sqlStateError) SQLState() string {
+	return e.code
+}
+
+func TestUnitOfWork_Retry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+
+	t.Run("retries a serialization failure", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		uow := NewUnitOfWork(db, policy, sql.LevelDefault)
+		calls := 0
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			calls++
+			if calls < 3 {
+				return fmt.Errorf("update ticket: %w", &sqlStateError{code: "40001"})
+			}
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, int64(2), uow.(*GormUnitOfWork).Retries())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		for i := 0; i < policy.MaxAttempts; i++ {
+			mock.ExpectBegin()
+			mock.ExpectRollback()
+		}
+
+		uow := NewUnitOfWork(db, policy, sql.LevelDefault)
+		deadlock := &sqlStateError{code: "40P01"}
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			return deadlock
+		})
+
+		assert.ErrorIs(t, err, ErrTransactionConflict)
+		assert.ErrorIs(t, err, deadlock)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		uow := NewUnitOfWork(db, policy, sql.LevelDefault)
+		calls := 0
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			calls++
+			return &sqlStateError{code: "23505"}
+		})
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrTransactionConflict)
+		assert.Equal(t, 1, calls)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("retries a failed commit", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectCommit().WillReturnError(&sqlStateError{code: "40001"})
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		uow := NewUnitOfWork(db, policy, sql.LevelSerializable)
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nested units of work are not retried on their own", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		uow := NewUnitOfWork(db, RetryPolicy{MaxAttempts: 1}, sql.LevelDefault)
+		nestedCalls := 0
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			return uow.Do(ctx, func(ctx context.Context) error {
+				nestedCalls++
+				return &sqlStateError{code: "40001"}
+			})
+		})
+
+		assert.ErrorIs(t, err, ErrTransactionConflict)
+		assert.Equal(t, 1, nestedCalls)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("stops waiting when the context is done", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		uow := NewUnitOfWork(db, RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}, sql.LevelDefault)
+		ctx, cancel := context.WithCancel(context.Background())
+		err := uow.Do(ctx, func(ctx context.Context) error {
+			cancel()
+			return &sqlStateError{code: "40001"}
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUnitOfWork_AfterCommit(t *testing.T) {
+	t.Run("runs after the commit without the transaction", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		uow := NewUnitOfWork(db, NoRetry, sql.LevelDefault)
+		ran := false
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			AfterCommit(ctx, func(ctx context.Context) {
+				ran = true
+				assert.NoError(t, mock.ExpectationsWereMet())
+				assert.Nil(t, ctx.Value(txKey{}))
+			})
+			assert.False(t, ran)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, ran)
+	})
+
+	t.Run("is dropped on rollback", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		uow := NewUnitOfWork(db, NoRetry, sql.LevelDefault)
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			AfterCommit(ctx, func(ctx context.Context) { t.Fatal("must not be called") })
+			return errors.New("fn error")
+		})
+
+		assert.EqualError(t, err, "fn error")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("is dropped with a failed savepoint", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		uow := NewUnitOfWork(db, NoRetry, sql.LevelDefault)
+		var ran []string
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			_ = uow.Do(ctx, func(ctx context.Context) error {
+				AfterCommit(ctx, func(ctx context.Context) { ran = append(ran, "failed") })
+				return errors.New("nested error")
+			})
+			return uow.Do(ctx, func(ctx context.Context) error {
+				AfterCommit(ctx, func(ctx context.Context) { ran = append(ran, "committed") })
+				return nil
+			})
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"committed"}, ran)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("only runs for the attempt that committed", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		uow := NewUnitOfWork(db, RetryPolicy{MaxAttempts: 2}, sql.LevelDefault)
+		var ran []int
+		attempt := 0
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			attempt++
+			current := attempt
+			AfterCommit(ctx, func(ctx context.Context) { ran = append(ran, current) })
+			if attempt == 1 {
+				return &sqlStateError{code: "40001"}
+			}
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{2}, ran)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("runs right away outside a unit of work", func(t *testing.T) {
+		ran := false
+		AfterCommit(context.Background(), func(ctx context.Context) { ran = true })
+		assert.True(t, ran)
+	})
+}
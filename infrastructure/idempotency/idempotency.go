@@ -0,0 +1,78 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	StorePostgres = "postgres"
+	StoreMemory   = "memory"
+)
+
+var (
+	ErrRecordNotFound = errors.New("idempotency key not found")
+	ErrKeyExists      = errors.New("idempotency key already exists")
+)
+
+type Record struct {
+	Key          string    `gorm:"primaryKey;type:varchar(255)"`
+	Fingerprint  string    `gorm:"not null;type:varchar(64)"`
+	Completed    bool      `gorm:"not null;default:false"`
+	StatusCode   int       `gorm:"not null;default:0"`
+	ContentType  string    `gorm:"not null;type:varchar(255);default:''"`
+	ResponseBody []byte    `gorm:"type:bytea"`
+	CreatedAt    time.Time `gorm:"not null;default:current_timestamp"`
+	ExpiresAt    time.Time `gorm:"not null;index"`
+}
+
+func (r *Record) TableName() string {
+	return "idempotency_keys"
+}
+
+func (r *Record) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// Store keeps idempotency records. Reserve must be atomic so that only one of
+// several concurrent requests with the same key is allowed to proceed.
+type Store interface {
+	Get(ctx context.Context, key string) (*Record, error)
+	Reserve(ctx context.Context, record *Record) error
+	Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error
+	Release(ctx context.Context, key string) error
+	DeleteExpired(ctx context.Context) error
+}
+
+func NewStore(kind string, db *gorm.DB) (Store, error) {
+	switch kind {
+	case StorePostgres:
+		return NewPostgresStore(db), nil
+	case StoreMemory:
+		return NewMemoryStore(), nil
+	}
+
+	return nil, fmt.Errorf("unknown idempotency store %q", kind)
+}
+
+// RunJanitor periodically removes expired records until ctx is cancelled.
+func RunJanitor(ctx context.Context, store Store, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := store.DeleteExpired(ctx); err != nil {
+				log.Printf("failed to delete expired idempotency keys: %s", err)
+			}
+		}
+	}
+}
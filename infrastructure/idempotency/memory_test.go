@@ -0,0 +1,82 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := &MemoryStore{records: make(map[string]Record), now: func() time.Time { return now }}
+
+	t.Run("should return not found for unknown key", func(t *testing.T) {
+		_, err := store.Get(ctx, "unknown")
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+
+	t.Run("should reserve and complete a key", func(t *testing.T) {
+		err := store.Reserve(ctx, &Record{Key: "key-1", Fingerprint: "fp", ExpiresAt: now.Add(time.Hour)})
+		assert.NoError(t, err)
+
+		record, err := store.Get(ctx, "key-1")
+		assert.NoError(t, err)
+		assert.False(t, record.Completed)
+
+		err = store.Complete(ctx, "key-1", 201, "application/json", []byte(`{"id":1}`))
+		assert.NoError(t, err)
+
+		record, err = store.Get(ctx, "key-1")
+		assert.NoError(t, err)
+		assert.True(t, record.Completed)
+		assert.Equal(t, 201, record.StatusCode)
+		assert.Equal(t, "application/json", record.ContentType)
+		assert.Equal(t, []byte(`{"id":1}`), record.ResponseBody)
+	})
+
+	t.Run("should reject reserving an active key", func(t *testing.T) {
+		err := store.Reserve(ctx, &Record{Key: "key-1", Fingerprint: "other", ExpiresAt: now.Add(time.Hour)})
+		assert.ErrorIs(t, err, ErrKeyExists)
+	})
+
+	t.Run("should release only pending keys", func(t *testing.T) {
+		assert.NoError(t, store.Reserve(ctx, &Record{Key: "key-2", Fingerprint: "fp", ExpiresAt: now.Add(time.Hour)}))
+		assert.NoError(t, store.Release(ctx, "key-2"))
+		_, err := store.Get(ctx, "key-2")
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+
+		assert.NoError(t, store.Release(ctx, "key-1"))
+		_, err = store.Get(ctx, "key-1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("should treat expired keys as missing and allow reuse", func(t *testing.T) {
+		assert.NoError(t, store.Reserve(ctx, &Record{Key: "key-3", Fingerprint: "fp", ExpiresAt: now.Add(-time.Second)}))
+		_, err := store.Get(ctx, "key-3")
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+
+		assert.NoError(t, store.Reserve(ctx, &Record{Key: "key-3", Fingerprint: "new", ExpiresAt: now.Add(time.Hour)}))
+		record, err := store.Get(ctx, "key-3")
+		assert.NoError(t, err)
+		assert.Equal(t, "new", record.Fingerprint)
+	})
+
+	t.Run("should delete expired keys", func(t *testing.T) {
+		assert.NoError(t, store.Reserve(ctx, &Record{Key: "key-4", Fingerprint: "fp", ExpiresAt: now.Add(-time.Second)}))
+		assert.NoError(t, store.DeleteExpired(ctx))
+		assert.NotContains(t, store.records, "key-4")
+		assert.Contains(t, store.records, "key-1")
+	})
+}
+
+func TestNewStore(t *testing.T) {
+	store, err := NewStore(StoreMemory, nil)
+	assert.NoError(t, err)
+	assert.IsType(t, &MemoryStore{}, store)
+
+	_, err = NewStore("redis", nil)
+	assert.Error(t, err)
+}
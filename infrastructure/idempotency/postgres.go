@@ -0,0 +1,70 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresStore struct {
+	db *gorm.DB
+}
+
+func NewPostgresStore(db *gorm.DB) Store {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) Get(ctx context.Context, key string) (*Record, error) {
+	var record Record
+	err := s.db.WithContext(ctx).Where("key = ? AND expires_at > ?", key, time.Now()).First(&record).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRecordNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func (s *PostgresStore) Reserve(ctx context.Context, record *Record) error {
+	result := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"fingerprint", "completed", "status_code", "content_type", "response_body", "created_at", "expires_at",
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Lte{Column: clause.Column{Table: "idempotency_keys", Name: "expires_at"}, Value: time.Now()},
+		}},
+	}).Create(record)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrKeyExists
+	}
+
+	return nil
+}
+
+func (s *PostgresStore) Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	return s.db.WithContext(ctx).Model(&Record{}).Where("key = ?", key).Updates(map[string]interface{}{
+		"completed":     true,
+		"status_code":   statusCode,
+		"content_type":  contentType,
+		"response_body": body,
+	}).Error
+}
+
+func (s *PostgresStore) Release(ctx context.Context, key string) error {
+	return s.db.WithContext(ctx).Where("key = ? AND completed = ?", key, false).Delete(&Record{}).Error
+}
+
+func (s *PostgresStore) DeleteExpired(ctx context.Context) error {
+	return s.db.WithContext(ctx).Where("expires_at <= ?", time.Now()).Delete(&Record{}).Error
+}
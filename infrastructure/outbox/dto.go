@@ -0,0 +1,39 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type MessageDTO struct {
+	ID            int64           `json:"id"`
+	Event         string          `json:"event"`
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	LastError     string          `json:"last_error"`
+} // @Name OutboxMessageDTO
+
+type MessageListDTO struct {
+	Items []*MessageDTO `json:"items"`
+} // @Name OutboxMessageListDTO
+
+func NewMessageListDTOFromEntities(messages []*Message) *MessageListDTO {
+	items := make([]*MessageDTO, 0, len(messages))
+	for _, m := range messages {
+		items = append(items, &MessageDTO{
+			ID:            m.ID,
+			Event:         m.EventName,
+			Payload:       json.RawMessage(m.Payload),
+			OccurredAt:    m.OccurredAt,
+			CreatedAt:     m.CreatedAt,
+			Attempts:      m.Attempts,
+			NextAttemptAt: m.NextAttemptAt,
+			LastError:     m.LastError,
+		})
+	}
+
+	return &MessageListDTO{Items: items}
+}
@@ -0,0 +1,115 @@
+package outbox
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+)
+
+// MemoryStore keeps the outbox in memory for STORAGE=memory, it has to be
+// used with db.MemoryUnitOfWork. Messages are lost on restart.
+type MemoryStore struct {
+	mu       sync.Mutex
+	messages map[int64]Message
+	lastID   int64
+	locks    db.RowLocks
+}
+
+func NewMemoryStore() Store {
+	return &MemoryStore{messages: make(map[int64]Message)}
+}
+
+func (s *MemoryStore) Add(ctx context.Context, messages ...*Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, m := range messages {
+		s.lastID++
+		m.ID = s.lastID
+		s.messages[m.ID] = *m
+
+		id := m.ID
+		db.OnRollback(ctx, func() {
+			s.mu.Lock()
+			delete(s.messages, id)
+			s.mu.Unlock()
+		})
+	}
+
+	return nil
+}
+
+func (s *MemoryStore) ClaimDue(ctx context.Context, now time.Time, limit int) ([]*Message, error) {
+	var claimed []*Message
+	for _, m := range s.sorted(func(m *Message) bool { return m.IsDue(now) }) {
+		if len(claimed) == limit {
+			break
+		}
+
+		if !s.locks.TryLock(ctx, int(m.ID)) {
+			continue
+		}
+
+		// Another relay may have delivered it before releasing the lock.
+		s.mu.Lock()
+		current, ok := s.messages[m.ID]
+		s.mu.Unlock()
+		if !ok || !current.IsDue(now) {
+			continue
+		}
+
+		claimed = append(claimed, &current)
+	}
+
+	return claimed, nil
+}
+
+func (s *MemoryStore) Update(ctx context.Context, m *Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, ok := s.messages[m.ID]
+	if !ok {
+		return nil
+	}
+
+	s.messages[m.ID] = *m
+	db.OnRollback(ctx, func() {
+		s.mu.Lock()
+		s.messages[previous.ID] = previous
+		s.mu.Unlock()
+	})
+
+	return nil
+}
+
+func (s *MemoryStore) Stuck(ctx context.Context, limit int) ([]*Message, error) {
+	stuck := s.sorted((*Message).IsStuck)
+	if len(stuck) > limit {
+		stuck = stuck[:limit]
+	}
+
+	return stuck, nil
+}
+
+func (s *MemoryStore) sorted(keep func(m *Message) bool) []*Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var messages []*Message
+	for _, stored := range s.messages {
+		m := stored
+		if keep(&m) {
+			messages = append(messages, &m)
+		}
+	}
+
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].ID < messages[j].ID
+	})
+
+	return messages
+}
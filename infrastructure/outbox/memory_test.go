@@ -0,0 +1,97 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	uow := db.NewMemoryUnitOfWork()
+	store := NewMemoryStore()
+
+	first, _ := NewMessage(sold{TicketID: 1, At: now}, now)
+	second, _ := NewMessage(sold{TicketID: 2, At: now}, now)
+	third, _ := NewMessage(sold{TicketID: 3, At: now}, now)
+	assert.NoError(t, store.Add(ctx, first, second, third))
+	assert.Equal(t, []int64{1, 2, 3}, []int64{first.ID, second.ID, third.ID})
+
+	t.Run("should skip messages claimed by another unit of work", func(t *testing.T) {
+		claimed := make(chan struct{})
+		release := make(chan struct{})
+		done := make(chan error)
+		go func() {
+			done <- uow.Do(ctx, func(ctx context.Context) error {
+				messages, err := store.ClaimDue(ctx, now, 1)
+				assert.Equal(t, []int64{1}, ids(messages))
+				close(claimed)
+				<-release
+				return err
+			})
+		}()
+
+		<-claimed
+		err := uow.Do(ctx, func(ctx context.Context) error {
+			messages, err := store.ClaimDue(ctx, now, 10)
+			assert.Equal(t, []int64{2, 3}, ids(messages))
+			return err
+		})
+		assert.NoError(t, err)
+
+		close(release)
+		assert.NoError(t, <-done)
+	})
+
+	t.Run("should not claim messages that are not due", func(t *testing.T) {
+		err := uow.Do(ctx, func(ctx context.Context) error {
+			second.MarkFailed(errors.New("timeout"), now.Add(time.Minute))
+			return store.Update(ctx, second)
+		})
+		assert.NoError(t, err)
+
+		messages, err := store.ClaimDue(ctx, now, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{1, 3}, ids(messages))
+	})
+
+	t.Run("should restore updated messages on rollback", func(t *testing.T) {
+		err := uow.Do(ctx, func(ctx context.Context) error {
+			updated := *first
+			updated.MarkPublished(now)
+			if err := store.Update(ctx, &updated); err != nil {
+				return err
+			}
+			return errors.New("fn error")
+		})
+		assert.EqualError(t, err, "fn error")
+
+		messages, err := store.ClaimDue(ctx, now, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{1, 3}, ids(messages))
+	})
+
+	t.Run("should list stuck messages", func(t *testing.T) {
+		stuck, err := store.Stuck(ctx, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{2}, ids(stuck))
+		assert.Equal(t, "timeout", stuck[0].LastError)
+
+		stuck, err = store.Stuck(ctx, 0)
+		assert.NoError(t, err)
+		assert.Empty(t, stuck)
+	})
+}
+
+func ids(messages []*Message) []int64 {
+	var ids []int64
+	for _, m := range messages {
+		ids = append(ids, m.ID)
+	}
+
+	return ids
+}
@@ -0,0 +1,132 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/pkg/eventbus"
+)
+
+// Message is an event waiting in the outbox to be delivered by the relay.
+// It is written in the same transaction as the change that raised the
+// event, so either both are stored or neither.
+type Message struct {
+	ID            int64      `gorm:"primaryKey;autoIncrement"`
+	EventName     string     `gorm:"not null;type:varchar(255)"`
+	Payload       string     `gorm:"not null;type:jsonb"`
+	OccurredAt    time.Time  `gorm:"not null"`
+	CreatedAt     time.Time  `gorm:"not null;default:current_timestamp"`
+	Attempts      int        `gorm:"not null;default:0"`
+	NextAttemptAt time.Time  `gorm:"not null;default:current_timestamp"`
+	LastError     string     `gorm:"not null;type:text;default:''"`
+	PublishedAt   *time.Time `gorm:""`
+}
+
+func (m *Message) TableName() string {
+	return "outbox_messages"
+}
+
+func NewMessage(e eventbus.Event, now time.Time) (*Message, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s: %w", e.EventName(), err)
+	}
+
+	return &Message{
+		EventName:     e.EventName(),
+		Payload:       string(payload),
+		OccurredAt:    e.OccurredAt(),
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}, nil
+}
+
+func (m *Message) IsPublished() bool {
+	return m.PublishedAt != nil
+}
+
+// IsStuck reports whether delivering the message failed at least once and
+// it is still waiting for another attempt.
+func (m *Message) IsStuck() bool {
+	return !m.IsPublished() && m.Attempts > 0
+}
+
+func (m *Message) IsDue(now time.Time) bool {
+	return !m.IsPublished() && !now.Before(m.NextAttemptAt)
+}
+
+func (m *Message) MarkPublished(now time.Time) {
+	m.Attempts++
+	m.LastError = ""
+	m.PublishedAt = &now
+}
+
+func (m *Message) MarkFailed(err error, next time.Time) {
+	m.Attempts++
+	m.LastError = err.Error()
+	m.NextAttemptAt = next
+}
+
+// Envelope is what publishers deliver. Consumers see every message at least
+// once and can use the ID to drop duplicates.
+type Envelope struct {
+	ID         int64           `json:"id"`
+	Event      string          `json:"event"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+func (m *Message) Envelope() Envelope {
+	return Envelope{
+		ID:         m.ID,
+		Event:      m.EventName,
+		OccurredAt: m.OccurredAt,
+		Payload:    json.RawMessage(m.Payload),
+	}
+}
+
+// Store keeps outbox messages. Add and the claim and update of the relay
+// use the unit of work running in the context.
+type Store interface {
+	Add(ctx context.Context, messages ...*Message) error
+	// ClaimDue locks up to limit unpublished messages that are due at now,
+	// oldest first. Messages locked by another relay are skipped.
+	ClaimDue(ctx context.Context, now time.Time, limit int) ([]*Message, error)
+	Update(ctx context.Context, m *Message) error
+	// Stuck returns unpublished messages that failed at least once, oldest
+	// first.
+	Stuck(ctx context.Context, limit int) ([]*Message, error)
+}
+
+type recorder struct {
+	store Store
+	now   func() time.Time
+}
+
+// NewRecorder returns a publisher that adds events to the outbox. Services
+// publish inside their unit of work, so the messages are committed together
+// with the change.
+func NewRecorder(store Store) eventbus.Publisher {
+	return &recorder{store: store, now: time.Now}
+}
+
+func (r *recorder) Publish(ctx context.Context, events ...eventbus.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := r.now()
+	messages := make([]*Message, 0, len(events))
+	for _, e := range events {
+		m, err := NewMessage(e, now)
+		if err != nil {
+			return err
+		}
+
+		messages = append(messages, m)
+	}
+
+	return r.store.Add(ctx, messages...)
+}
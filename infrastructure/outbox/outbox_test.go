@@ -0,0 +1,78 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/stretchr/testify/assert"
+)
+
+type sold struct {
+	TicketID int       `json:"ticket_id"`
+	At       time.Time `json:"at"`
+}
+
+func (e sold) EventName() string     { return "ticket.sold_out" }
+func (e sold) OccurredAt() time.Time { return e.At }
+
+var now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestMessage(t *testing.T) {
+	m, err := NewMessage(sold{TicketID: 1, At: now.Add(-time.Second)}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "ticket.sold_out", m.EventName)
+	assert.JSONEq(t, `{"ticket_id":1,"at":"2024-01-01T11:59:59Z"}`, m.Payload)
+	assert.Equal(t, now.Add(-time.Second), m.OccurredAt)
+	assert.True(t, m.IsDue(now))
+	assert.False(t, m.IsStuck())
+
+	m.MarkFailed(errors.New("connection refused"), now.Add(time.Minute))
+	assert.Equal(t, 1, m.Attempts)
+	assert.Equal(t, "connection refused", m.LastError)
+	assert.True(t, m.IsStuck())
+	assert.False(t, m.IsDue(now))
+	assert.True(t, m.IsDue(now.Add(time.Minute)))
+
+	m.MarkPublished(now.Add(time.Minute))
+	assert.Equal(t, 2, m.Attempts)
+	assert.Empty(t, m.LastError)
+	assert.True(t, m.IsPublished())
+	assert.False(t, m.IsStuck())
+	assert.False(t, m.IsDue(now.Add(time.Hour)))
+}
+
+func TestRecorder(t *testing.T) {
+	uow := db.NewMemoryUnitOfWork()
+	store := NewMemoryStore()
+	recorder := &recorder{store: store, now: func() time.Time { return now }}
+
+	t.Run("should add events committed with the unit of work", func(t *testing.T) {
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			return recorder.Publish(ctx, sold{TicketID: 1, At: now})
+		})
+		assert.NoError(t, err)
+
+		messages, err := store.ClaimDue(context.Background(), now, 10)
+		assert.NoError(t, err)
+		assert.Len(t, messages, 1)
+		assert.Equal(t, "ticket.sold_out", messages[0].EventName)
+	})
+
+	t.Run("should drop events of rolled back work", func(t *testing.T) {
+		err := uow.Do(context.Background(), func(ctx context.Context) error {
+			if err := recorder.Publish(ctx, sold{TicketID: 2, At: now}); err != nil {
+				return err
+			}
+			return errors.New("fn error")
+		})
+		assert.EqualError(t, err, "fn error")
+
+		messages, err := store.ClaimDue(context.Background(), now, 10)
+		assert.NoError(t, err)
+		assert.Len(t, messages, 1)
+		assert.JSONEq(t, `{"ticket_id":1,"at":"2024-01-01T12:00:00Z"}`, messages[0].Payload)
+	})
+}
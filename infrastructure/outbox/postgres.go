@@ -0,0 +1,61 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresStore struct {
+	db *gorm.DB
+}
+
+func NewPostgresStore(db *gorm.DB) Store {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) Add(ctx context.Context, messages ...*Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	return db.Conn(ctx, s.db).Create(messages).Error
+}
+
+func (s *PostgresStore) ClaimDue(ctx context.Context, now time.Time, limit int) ([]*Message, error) {
+	var messages []*Message
+	err := db.Conn(ctx, s.db).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
+		Where("published_at IS NULL AND next_attempt_at <= ?", now).
+		Order("id").
+		Limit(limit).
+		Find(&messages).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (s *PostgresStore) Update(ctx context.Context, m *Message) error {
+	return db.Conn(ctx, s.db).Model(m).
+		Select("attempts", "next_attempt_at", "last_error", "published_at").
+		Updates(m).Error
+}
+
+func (s *PostgresStore) Stuck(ctx context.Context, limit int) ([]*Message, error) {
+	var messages []*Message
+	err := db.Conn(ctx, s.db).
+		Where("published_at IS NULL AND attempts > 0").
+		Order("id").
+		Limit(limit).
+		Find(&messages).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,30 @@
+package outbox
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestPostgresStore_ClaimDue(t *testing.T) {
+	mockDb, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: mockDb, DriverName: "postgres"}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		`SELECT * FROM "outbox_messages" WHERE published_at IS NULL AND next_attempt_at <= $1 ORDER BY id LIMIT $2 FOR UPDATE SKIP LOCKED`,
+	)).WithArgs(now, 10).WillReturnRows(sqlmock.NewRows([]string{"id", "event_name", "payload"}).AddRow(1, "ticket.sold_out", `{}`))
+
+	messages, err := NewPostgresStore(gormDB).ClaimDue(context.Background(), now, 10)
+	assert.NoError(t, err)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "ticket.sold_out", messages[0].EventName)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
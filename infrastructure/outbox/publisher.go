@@ -0,0 +1,176 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	PublisherLog     = "log"
+	PublisherWebhook = "webhook"
+	PublisherFile    = "file"
+)
+
+const webhookTimeout = 10 * time.Second
+
+var (
+	ErrWebhookURLRequired = errors.New("webhook outbox publisher needs a url")
+	ErrFilePathRequired   = errors.New("file outbox publisher needs a path")
+)
+
+// Publisher delivers an outbox message to the outside world. The relay
+// retries messages whose Publish fails, so Publish may see a message more
+// than once.
+type Publisher interface {
+	Publish(ctx context.Context, m *Message) error
+}
+
+type chain []Publisher
+
+// Chain publishes to every publisher in order and stops at the first
+// error. A message that failed is relayed to all of them again, so the
+// publishers after the first have to tolerate duplicates.
+func Chain(publishers ...Publisher) Publisher {
+	return chain(publishers)
+}
+
+func (c chain) Publish(ctx context.Context, m *Message) error {
+	for _, p := range c {
+		if err := p.Publish(ctx, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func NewPublisher(kind, webhookURL, filePath string) (Publisher, error) {
+	switch kind {
+	case PublisherLog:
+		return NewLogPublisher(os.Stdout), nil
+	case PublisherWebhook:
+		if webhookURL == "" {
+			return nil, ErrWebhookURLRequired
+		}
+
+		return NewWebhookPublisher(webhookURL, &http.Client{Timeout: webhookTimeout}), nil
+	case PublisherFile:
+		if filePath == "" {
+			return nil, ErrFilePathRequired
+		}
+
+		return NewFilePublisher(filePath), nil
+	}
+
+	return nil, fmt.Errorf("unknown outbox publisher %q", kind)
+}
+
+// LogPublisher writes every message as a line of JSON to w.
+type LogPublisher struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewLogPublisher(w io.Writer) Publisher {
+	return &LogPublisher{w: w}
+}
+
+func (p *LogPublisher) Publish(ctx context.Context, m *Message) error {
+	line, err := json.Marshal(m.Envelope())
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, err = p.w.Write(append(line, '\n'))
+	return err
+}
+
+// WebhookPublisher posts every message as JSON to url. Any status other
+// than 2xx is a failed delivery.
+type WebhookPublisher struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookPublisher(url string, client *http.Client) Publisher {
+	return &WebhookPublisher{url: url, client: client}
+}
+
+func (p *WebhookPublisher) Publish(ctx context.Context, m *Message) error {
+	body, err := json.Marshal(m.Envelope())
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Name", m.EventName)
+	req.Header.Set("X-Message-ID", strconv.FormatInt(m.ID, 10))
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	_, _ = io.Copy(io.Discard, res.Body)
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with %s", res.Status)
+	}
+
+	return nil
+}
+
+// FilePublisher appends every message as a line of JSON to the file at
+// path and syncs it before reporting the delivery.
+type FilePublisher struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFilePublisher(path string) Publisher {
+	return &FilePublisher{path: path}
+}
+
+func (p *FilePublisher) Publish(ctx context.Context, m *Message) error {
+	line, err := json.Marshal(m.Envelope())
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f, err := os.OpenFile(p.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,104 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMessage(t *testing.T) *Message {
+	m, err := NewMessage(sold{TicketID: 1, At: now}, now)
+	assert.NoError(t, err)
+	m.ID = 7
+	return m
+}
+
+const envelope = `{"id":7,"event":"ticket.sold_out","occurred_at":"2024-01-01T12:00:00Z","payload":{"ticket_id":1,"at":"2024-01-01T12:00:00Z"}}`
+
+func TestNewPublisher(t *testing.T) {
+	_, err := NewPublisher(PublisherLog, "", "")
+	assert.NoError(t, err)
+
+	_, err = NewPublisher(PublisherWebhook, "", "")
+	assert.ErrorIs(t, err, ErrWebhookURLRequired)
+
+	_, err = NewPublisher(PublisherFile, "", "")
+	assert.ErrorIs(t, err, ErrFilePathRequired)
+
+	_, err = NewPublisher("kafka", "", "")
+	assert.EqualError(t, err, `unknown outbox publisher "kafka"`)
+}
+
+func TestLogPublisher(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, NewLogPublisher(&buf).Publish(context.Background(), newTestMessage(t)))
+	assert.JSONEq(t, envelope, buf.String())
+	assert.Equal(t, byte('\n'), buf.Bytes()[buf.Len()-1])
+}
+
+func TestWebhookPublisher(t *testing.T) {
+	t.Run("should post the envelope", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.Equal(t, "ticket.sold_out", r.Header.Get("X-Event-Name"))
+			assert.Equal(t, "7", r.Header.Get("X-Message-ID"))
+
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, envelope, string(body))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		err := NewWebhookPublisher(server.URL, server.Client()).Publish(context.Background(), newTestMessage(t))
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail on a non 2xx status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		err := NewWebhookPublisher(server.URL, server.Client()).Publish(context.Background(), newTestMessage(t))
+		assert.EqualError(t, err, "webhook responded with 503 Service Unavailable")
+	})
+}
+
+func TestFilePublisher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	publisher := NewFilePublisher(path)
+
+	assert.NoError(t, publisher.Publish(context.Background(), newTestMessage(t)))
+	assert.NoError(t, publisher.Publish(context.Background(), newTestMessage(t)))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	lines := bytes.Split(bytes.TrimSpace(content), []byte("\n"))
+	assert.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.JSONEq(t, envelope, string(line))
+	}
+}
+
+func TestChain(t *testing.T) {
+	boom := errors.New("boom")
+	first, second := &fakePublisher{}, &fakePublisher{}
+
+	assert.NoError(t, Chain(first, second).Publish(context.Background(), newTestMessage(t)))
+	assert.Equal(t, []int64{7}, first.published)
+	assert.Equal(t, []int64{7}, second.published)
+
+	first.fail = map[int64]error{7: boom}
+	assert.ErrorIs(t, Chain(first, second).Publish(context.Background(), newTestMessage(t)), boom)
+	assert.Equal(t, []int64{7}, second.published)
+}
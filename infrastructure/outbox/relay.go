@@ -0,0 +1,120 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"math/rand/v2"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+)
+
+// Relay delivers outbox messages to a publisher. Every batch is claimed,
+// delivered and marked in one unit of work, a relay that dies in between
+// leaves the messages unpublished and they are delivered again, so every
+// message is delivered at least once. Several relays can run next to each
+// other, each claims messages the others have not locked.
+type Relay struct {
+	uow       db.UnitOfWork
+	store     Store
+	publisher Publisher
+	batchSize int
+	baseDelay time.Duration
+	maxDelay  time.Duration
+	now       func() time.Time
+}
+
+func NewRelay(uow db.UnitOfWork, store Store, publisher Publisher, batchSize int, baseDelay, maxDelay time.Duration) *Relay {
+	return &Relay{
+		uow:       uow,
+		store:     store,
+		publisher: publisher,
+		batchSize: batchSize,
+		baseDelay: baseDelay,
+		maxDelay:  maxDelay,
+		now:       time.Now,
+	}
+}
+
+// Run relays all due messages every interval until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.drain(ctx)
+		}
+	}
+}
+
+func (r *Relay) drain(ctx context.Context) {
+	for {
+		claimed, err := r.RelayBatch(ctx)
+		if err != nil {
+			log.Printf("failed to relay outbox messages: %s", err)
+			return
+		}
+
+		if claimed < r.batchSize {
+			return
+		}
+	}
+}
+
+// RelayBatch delivers one batch of due messages and returns how many were
+// claimed. A message that can not be delivered is tried again after a
+// delay that doubles with every attempt.
+func (r *Relay) RelayBatch(ctx context.Context) (int, error) {
+	var claimed int
+	err := r.uow.Do(ctx, func(ctx context.Context) error {
+		now := r.now()
+		messages, err := r.store.ClaimDue(ctx, now, r.batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range messages {
+			if err := r.publisher.Publish(ctx, m); err != nil {
+				m.MarkFailed(err, now.Add(r.backoff(m.Attempts+1)))
+				log.Printf("failed to deliver outbox message %d (attempt %d): %s", m.ID, m.Attempts, err)
+			} else {
+				m.MarkPublished(r.now())
+			}
+
+			if err := r.store.Update(ctx, m); err != nil {
+				return err
+			}
+		}
+
+		claimed = len(messages)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return claimed, nil
+}
+
+// backoff returns the delay before the next attempt, attempts are counted
+// from 1. Half of the delay is random so that messages that failed together
+// are not retried together.
+func (r *Relay) backoff(attempt int) time.Duration {
+	delay := r.maxDelay
+	if attempt < 32 {
+		if exp := r.baseDelay << (attempt - 1); exp > 0 && exp < r.maxDelay {
+			delay = exp
+		}
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
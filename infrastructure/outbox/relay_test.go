@@ -0,0 +1,99 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePublisher struct {
+	published []int64
+	fail      map[int64]error
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, m *Message) error {
+	if err := p.fail[m.ID]; err != nil {
+		return err
+	}
+
+	p.published = append(p.published, m.ID)
+	return nil
+}
+
+func TestRelay_RelayBatch(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	publisher := &fakePublisher{fail: map[int64]error{2: errors.New("503 Service Unavailable")}}
+	relay := NewRelay(db.NewMemoryUnitOfWork(), store, publisher, 2, time.Second, time.Minute)
+	clock := now
+	relay.now = func() time.Time { return clock }
+
+	for i := 1; i <= 3; i++ {
+		m, _ := NewMessage(sold{TicketID: i, At: now}, now)
+		assert.NoError(t, store.Add(ctx, m))
+	}
+
+	t.Run("should deliver a batch of due messages oldest first", func(t *testing.T) {
+		claimed, err := relay.RelayBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, claimed)
+		assert.Equal(t, []int64{1}, publisher.published)
+
+		claimed, err = relay.RelayBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, claimed)
+		assert.Equal(t, []int64{1, 3}, publisher.published)
+	})
+
+	t.Run("should retry a failed message after a backoff", func(t *testing.T) {
+		stuck, err := store.Stuck(ctx, 10)
+		assert.NoError(t, err)
+		assert.Len(t, stuck, 1)
+		assert.Equal(t, 1, stuck[0].Attempts)
+		assert.Equal(t, "503 Service Unavailable", stuck[0].LastError)
+		assert.WithinRange(t, stuck[0].NextAttemptAt, now.Add(500*time.Millisecond), now.Add(time.Second))
+
+		claimed, err := relay.RelayBatch(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, claimed)
+
+		clock = stuck[0].NextAttemptAt
+		delete(publisher.fail, 2)
+		claimed, err = relay.RelayBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, claimed)
+		assert.Equal(t, []int64{1, 3, 2}, publisher.published)
+
+		stuck, err = store.Stuck(ctx, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, stuck)
+	})
+}
+
+func TestRelay_Backoff(t *testing.T) {
+	relay := NewRelay(nil, nil, nil, 1, time.Second, time.Minute)
+
+	tests := []struct {
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		{attempt: 1, min: 500 * time.Millisecond, max: time.Second},
+		{attempt: 2, min: time.Second, max: 2 * time.Second},
+		{attempt: 4, min: 4 * time.Second, max: 8 * time.Second},
+		{attempt: 7, min: 30 * time.Second, max: time.Minute},
+		{attempt: 100, min: 30 * time.Second, max: time.Minute},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			delay := relay.backoff(tt.attempt)
+			assert.GreaterOrEqual(t, delay, tt.min, "attempt %d", tt.attempt)
+			assert.LessOrEqual(t, delay, tt.max, "attempt %d", tt.attempt)
+		}
+	}
+}
@@ -4,8 +4,20 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"time"
 
+	"github.com/aaydin-tr/ddd-api-example/controller/event"
+	"github.com/aaydin-tr/ddd-api-example/controller/order"
+	"github.com/aaydin-tr/ddd-api-example/controller/outbox"
+	"github.com/aaydin-tr/ddd-api-example/controller/promotion"
+	"github.com/aaydin-tr/ddd-api-example/controller/purchase"
+	"github.com/aaydin-tr/ddd-api-example/controller/reservation"
 	"github.com/aaydin-tr/ddd-api-example/controller/ticket"
+	"github.com/aaydin-tr/ddd-api-example/controller/venue"
+	"github.com/aaydin-tr/ddd-api-example/controller/waitlist"
+	"github.com/aaydin-tr/ddd-api-example/controller/webhook"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/idempotency"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/middleware"
 	"github.com/aaydin-tr/ddd-api-example/pkg/validator"
 
 	_ "github.com/aaydin-tr/ddd-api-example/docs"
@@ -13,23 +25,41 @@ import (
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
 
+type Controllers struct {
+	Ticket      *ticket.TicketController
+	Purchase    *purchase.PurchaseController
+	Reservation *reservation.ReservationController
+	Order       *order.OrderController
+	Outbox      *outbox.OutboxController
+	Webhook     *webhook.WebhookController
+	Venue       *venue.VenueController
+	Event       *event.EventController
+	Promotion   *promotion.PromotionController
+	Waitlist    *waitlist.WaitlistController
+}
+
 type EchoServer struct {
-	controller *ticket.TicketController
-	host       string
-	port       string
+	controllers      Controllers
+	idempotencyStore idempotency.Store
+	idempotencyTTL   time.Duration
+	host             string
+	port             string
 
 	e *echo.Echo
 }
 
-func NewEchoServer(tickectController *ticket.TicketController, host, port string) *EchoServer {
+func NewEchoServer(controllers Controllers, idempotencyStore idempotency.Store, idempotencyTTL time.Duration, host, port string) *EchoServer {
 	svc := &EchoServer{
-		controller: tickectController,
-		host:       host,
-		port:       port,
+		controllers:      controllers,
+		idempotencyStore: idempotencyStore,
+		idempotencyTTL:   idempotencyTTL,
+		host:             host,
+		port:             port,
 	}
 
 	e := echo.New()
 	e.Validator = validator.New()
+	e.Use(middleware.Audit())
 
 	svc.e = e
 
@@ -37,9 +67,63 @@ func NewEchoServer(tickectController *ticket.TicketController, host, port string
 }
 
 func (s *EchoServer) Start() {
-	s.e.POST("/ticketsuser", s.controller.Create)
-	s.e.GET("/tickets/:id", s.controller.FindByID)
-	s.e.POST("/tickets/:id/purchases", s.controller.Purchases)
+	idempotent := middleware.Idempotency(s.idempotencyStore, s.idempotencyTTL)
+
+	s.e.POST("/ticketsuser", s.controllers.Ticket.Create)
+	s.e.GET("/tickets", s.controllers.Ticket.List)
+	s.e.GET("/tickets/:id", s.controllers.Ticket.FindByID)
+	s.e.PATCH("/tickets/:id", s.controllers.Ticket.Update)
+	s.e.DELETE("/tickets/:id", s.controllers.Ticket.Delete)
+	s.e.POST("/tickets/:id/restore", s.controllers.Ticket.Restore)
+	s.e.POST("/tickets/:id/publish", s.controllers.Ticket.Publish)
+	s.e.POST("/tickets/:id/pause", s.controllers.Ticket.Pause)
+	s.e.POST("/tickets/:id/resume", s.controllers.Ticket.Resume)
+	s.e.POST("/tickets/:id/archive", s.controllers.Ticket.Archive)
+	s.e.POST("/tickets/:id/purchases", s.controllers.Ticket.Purchases, idempotent)
+	s.e.GET("/tickets/:id/ledger", s.controllers.Ticket.Ledger)
+	s.e.POST("/tickets/:id/seat-map", s.controllers.Ticket.CreateSeatMap)
+	s.e.GET("/tickets/:id/seat-map", s.controllers.Ticket.SeatMap)
+	s.e.GET("/tickets/:id/seat-map/best-available", s.controllers.Ticket.BestAvailableSeats)
+
+	s.e.POST("/venues", s.controllers.Venue.Create)
+	s.e.GET("/venues", s.controllers.Venue.List)
+	s.e.GET("/venues/:id", s.controllers.Venue.FindByID)
+	s.e.PATCH("/venues/:id", s.controllers.Venue.Update)
+	s.e.DELETE("/venues/:id", s.controllers.Venue.Delete)
+
+	s.e.POST("/events", s.controllers.Event.Create)
+	s.e.GET("/events", s.controllers.Event.List)
+	s.e.GET("/events/:id", s.controllers.Event.FindByID)
+	s.e.PATCH("/events/:id", s.controllers.Event.Update)
+	s.e.DELETE("/events/:id", s.controllers.Event.Delete)
+
+	s.e.POST("/purchases/:id/refunds", s.controllers.Purchase.Refund, idempotent)
+
+	s.e.POST("/tickets/:id/reservations", s.controllers.Reservation.Create, idempotent)
+	s.e.GET("/reservations/:id", s.controllers.Reservation.FindByID)
+	s.e.POST("/reservations/:id/confirm", s.controllers.Reservation.Confirm, idempotent)
+	s.e.POST("/reservations/:id/cancel", s.controllers.Reservation.Cancel)
+
+	s.e.POST("/tickets/:id/waitlist", s.controllers.Waitlist.Join)
+	s.e.GET("/tickets/:id/waitlist", s.controllers.Waitlist.FindByUser)
+	s.e.DELETE("/tickets/:id/waitlist", s.controllers.Waitlist.Leave)
+
+	s.e.POST("/orders", s.controllers.Order.Create, idempotent)
+	s.e.GET("/orders/:id", s.controllers.Order.FindByID)
+
+	s.e.POST("/webhooks", s.controllers.Webhook.Create)
+	s.e.GET("/webhooks", s.controllers.Webhook.List)
+	s.e.DELETE("/webhooks/:id", s.controllers.Webhook.Delete)
+	s.e.GET("/webhooks/:id/deliveries", s.controllers.Webhook.ListDeliveries)
+
+	s.e.GET("/admin/outbox/stuck", s.controllers.Outbox.ListStuck)
+	s.e.GET("/admin/ledger/check", s.controllers.Ticket.CheckLedger)
+	s.e.POST("/admin/webhooks/deliveries/:id/replay", s.controllers.Webhook.Replay)
+	s.e.POST("/admin/promotions", s.controllers.Promotion.Create)
+	s.e.GET("/admin/promotions", s.controllers.Promotion.List)
+	s.e.GET("/admin/promotions/:id", s.controllers.Promotion.FindByID)
+	s.e.PATCH("/admin/promotions/:id", s.controllers.Promotion.Update)
+	s.e.DELETE("/admin/promotions/:id", s.controllers.Promotion.Delete)
 	s.e.GET("/swagger/*", echoSwagger.WrapHandler)
 
 	if err := s.e.Start(s.host + ":" + s.port); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/aaydin-tr/ddd-api-example/pkg/audit"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderUserID       = "X-User-ID"
+	maxAuditHeaderSize = 255
+)
+
+// Audit puts the request ID and the acting user into the request context
+// for the audit records written while handling the request. The request
+// ID is taken from X-Request-ID or generated and echoed back, the user from
+// X-User-ID. Values longer than 255 bytes are ignored.
+func Audit() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			requestID := req.Header.Get(echo.HeaderXRequestID)
+			if requestID == "" || len(requestID) > maxAuditHeaderSize {
+				requestID = newRequestID()
+			}
+			c.Response().Header().Set(echo.HeaderXRequestID, requestID)
+
+			ctx := audit.WithRequestID(req.Context(), requestID)
+			if userID := req.Header.Get(HeaderUserID); userID != "" && len(userID) <= maxAuditHeaderSize {
+				ctx = audit.WithActor(ctx, userID)
+			}
+
+			c.SetRequest(req.WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aaydin-tr/ddd-api-example/pkg/audit"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAudit(t *testing.T) {
+	var actor, requestID string
+
+	e := echo.New()
+	e.GET("/", func(c echo.Context) error {
+		actor = audit.Actor(c.Request().Context())
+		requestID = audit.RequestID(c.Request().Context())
+		return c.NoContent(http.StatusOK)
+	}, Audit())
+
+	do := func(headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("should take the actor and request id from the headers", func(t *testing.T) {
+		rec := do(map[string]string{HeaderUserID: "user-1", echo.HeaderXRequestID: "req-1"})
+
+		assert.Equal(t, "user-1", actor)
+		assert.Equal(t, "req-1", requestID)
+		assert.Equal(t, "req-1", rec.Header().Get(echo.HeaderXRequestID))
+	})
+
+	t.Run("should generate a request id when none is sent", func(t *testing.T) {
+		rec := do(nil)
+
+		assert.Empty(t, actor)
+		assert.Len(t, requestID, 32)
+		assert.Equal(t, requestID, rec.Header().Get(echo.HeaderXRequestID))
+	})
+
+	t.Run("should ignore values that are too long", func(t *testing.T) {
+		long := strings.Repeat("a", 256)
+		do(map[string]string{HeaderUserID: long, echo.HeaderXRequestID: long})
+
+		assert.Empty(t, actor)
+		assert.Len(t, requestID, 32)
+	})
+}
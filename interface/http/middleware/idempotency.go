@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/idempotency"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/response"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderIdempotencyKey      = "Idempotency-Key"
+	HeaderIdempotencyReplayed = "Idempotency-Replayed"
+	maxIdempotencyKeyLength   = 255
+)
+
+var (
+	ErrIdempotencyKeyTooLong       = errors.New("idempotency key is too long")
+	ErrIdempotencyKeyReused        = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyInProgress    = errors.New("a request with this idempotency key is already in progress")
+	ErrIdempotencyStoreUnavailable = errors.New("idempotency store is unavailable")
+)
+
+type bodyRecorder struct {
+	http.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// Idempotency replays the stored response when a request is retried with the
+// same Idempotency-Key header. Requests without the header are not affected.
+func Idempotency(store idempotency.Store, ttl time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(HeaderIdempotencyKey)
+			if key == "" {
+				return next(c)
+			}
+
+			if len(key) > maxIdempotencyKeyLength {
+				return response.NewErrorRespone(c, ErrIdempotencyKeyTooLong, http.StatusBadRequest)
+			}
+
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return response.NewErrorRespone(c, err, http.StatusBadRequest)
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := c.Request().Context()
+			fingerprint := fingerprint(c.Request().Method, c.Request().URL.Path, body)
+
+			record, err := store.Get(ctx, key)
+			if err == nil {
+				return replay(c, record, fingerprint)
+			}
+
+			if !errors.Is(err, idempotency.ErrRecordNotFound) {
+				return response.NewErrorRespone(c, ErrIdempotencyStoreUnavailable, http.StatusInternalServerError)
+			}
+
+			err = store.Reserve(ctx, &idempotency.Record{
+				Key:         key,
+				Fingerprint: fingerprint,
+				ExpiresAt:   time.Now().Add(ttl),
+			})
+			if errors.Is(err, idempotency.ErrKeyExists) {
+				return response.NewErrorRespone(c, ErrIdempotencyKeyInProgress, http.StatusConflict)
+			}
+
+			if err != nil {
+				return response.NewErrorRespone(c, ErrIdempotencyStoreUnavailable, http.StatusInternalServerError)
+			}
+
+			// The key is settled even when the client went away while the
+			// handler ran, otherwise it would stay in progress until it expires.
+			settleCtx := context.WithoutCancel(ctx)
+			defer func() {
+				if r := recover(); r != nil {
+					if err := store.Release(settleCtx, key); err != nil {
+						c.Logger().Error(err)
+					}
+					panic(r)
+				}
+			}()
+
+			recorder := &bodyRecorder{ResponseWriter: c.Response().Writer, body: new(bytes.Buffer)}
+			c.Response().Writer = recorder
+
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			// Server errors are not stored so that the client can safely retry.
+			if c.Response().Status >= http.StatusInternalServerError {
+				if err := store.Release(settleCtx, key); err != nil {
+					c.Logger().Error(err)
+				}
+				return nil
+			}
+
+			contentType := c.Response().Header().Get(echo.HeaderContentType)
+			if err := store.Complete(settleCtx, key, c.Response().Status, contentType, recorder.body.Bytes()); err != nil {
+				c.Logger().Error(err)
+			}
+
+			return nil
+		}
+	}
+}
+
+func replay(c echo.Context, record *idempotency.Record, fingerprint string) error {
+	if record.Fingerprint != fingerprint {
+		return response.NewErrorRespone(c, ErrIdempotencyKeyReused, http.StatusUnprocessableEntity)
+	}
+
+	if !record.Completed {
+		return response.NewErrorRespone(c, ErrIdempotencyKeyInProgress, http.StatusConflict)
+	}
+
+	c.Response().Header().Set(HeaderIdempotencyReplayed, "true")
+	if len(record.ResponseBody) == 0 {
+		return c.NoContent(record.StatusCode)
+	}
+
+	return c.Blob(record.StatusCode, record.ContentType, record.ResponseBody)
+}
+
+func fingerprint(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
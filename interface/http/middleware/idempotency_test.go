@@ -0,0 +1,176 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/idempotency"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotency(t *testing.T) {
+	store := idempotency.NewMemoryStore()
+	calls := 0
+	status := http.StatusCreated
+
+	e := echo.New()
+	e.POST("/tickets/:id/purchases", func(c echo.Context) error {
+		calls++
+		if status >= http.StatusInternalServerError {
+			return echo.NewHTTPError(status, errors.New("boom"))
+		}
+		return c.JSON(status, map[string]int{"id": calls})
+	}, Idempotency(store, time.Hour))
+
+	do := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/tickets/1/purchases", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if key != "" {
+			req.Header.Set(HeaderIdempotencyKey, key)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("should call handler every time without key", func(t *testing.T) {
+		calls = 0
+		do("", `{"quantity": 1}`)
+		do("", `{"quantity": 1}`)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("should replay stored response for same key and body", func(t *testing.T) {
+		calls = 0
+		first := do("key-1", `{"quantity": 1}`)
+		second := do("key-1", `{"quantity": 1}`)
+
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, http.StatusCreated, first.Code)
+		assert.Equal(t, http.StatusCreated, second.Code)
+		assert.JSONEq(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, "true", second.Header().Get(HeaderIdempotencyReplayed))
+	})
+
+	t.Run("should reject key reused with a different body", func(t *testing.T) {
+		calls = 0
+		rec := do("key-1", `{"quantity": 2}`)
+
+		assert.Equal(t, 0, calls)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("should reject key while first request is in progress", func(t *testing.T) {
+		err := store.Reserve(context.Background(), &idempotency.Record{
+			Key:         "key-2",
+			Fingerprint: fingerprint(http.MethodPost, "/tickets/1/purchases", []byte(`{"quantity": 1}`)),
+			ExpiresAt:   time.Now().Add(time.Hour),
+		})
+		assert.NoError(t, err)
+
+		rec := do("key-2", `{"quantity": 1}`)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("should not store server errors", func(t *testing.T) {
+		calls = 0
+		status = http.StatusInternalServerError
+		first := do("key-3", `{"quantity": 1}`)
+		status = http.StatusCreated
+		second := do("key-3", `{"quantity": 1}`)
+
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, http.StatusInternalServerError, first.Code)
+		assert.Equal(t, http.StatusCreated, second.Code)
+	})
+
+	t.Run("should reject too long key", func(t *testing.T) {
+		rec := do(strings.Repeat("k", maxIdempotencyKeyLength+1), `{"quantity": 1}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+// contextStore fails like a database connection would once the context of
+// the request is cancelled.
+type contextStore struct {
+	idempotency.Store
+}
+
+func (s *contextStore) Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.Store.Complete(ctx, key, statusCode, contentType, body)
+}
+
+func (s *contextStore) Release(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.Store.Release(ctx, key)
+}
+
+func TestIdempotencySettlesKey(t *testing.T) {
+	store := &contextStore{Store: idempotency.NewMemoryStore()}
+
+	do := func(e *echo.Echo, ctx context.Context, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/tickets/1/purchases", strings.NewReader(`{"quantity": 1}`)).WithContext(ctx)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(HeaderIdempotencyKey, key)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("should complete key when request context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		e := echo.New()
+		e.POST("/tickets/:id/purchases", func(c echo.Context) error {
+			cancel()
+			return c.JSON(http.StatusCreated, map[string]int{"id": 1})
+		}, Idempotency(store, time.Hour))
+
+		do(e, ctx, "cancelled")
+
+		record, err := store.Get(context.Background(), "cancelled")
+		assert.NoError(t, err)
+		assert.True(t, record.Completed)
+		assert.Equal(t, http.StatusCreated, record.StatusCode)
+	})
+
+	t.Run("should release key when request context is cancelled and handler fails", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		e := echo.New()
+		e.POST("/tickets/:id/purchases", func(c echo.Context) error {
+			cancel()
+			return echo.NewHTTPError(http.StatusInternalServerError, errors.New("boom"))
+		}, Idempotency(store, time.Hour))
+
+		do(e, ctx, "cancelled-failed")
+
+		_, err := store.Get(context.Background(), "cancelled-failed")
+		assert.ErrorIs(t, err, idempotency.ErrRecordNotFound)
+	})
+
+	t.Run("should release key when handler panics", func(t *testing.T) {
+		e := echo.New()
+		e.POST("/tickets/:id/purchases", func(c echo.Context) error {
+			panic("boom")
+		}, Idempotency(store, time.Hour))
+
+		assert.PanicsWithValue(t, "boom", func() { do(e, context.Background(), "panicked") })
+
+		_, err := store.Get(context.Background(), "panicked")
+		assert.ErrorIs(t, err, idempotency.ErrRecordNotFound)
+	})
+}
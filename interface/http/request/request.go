@@ -1,12 +1,235 @@
 package request
 
+import "time"
+
+// PurchaseTicketRequest buys the seats seat_ids of a ticket with assigned
+// seating, without them the best available adjacent seats are bought.
+// Quantity can be omitted when seat_ids are sent.
 type PurchaseTicketRequest struct {
-	Quantity int    `json:"quantity" validate:"required,gte=1"`
-	UserID   string `json:"user_id" validate:"required,uuid4"`
+	Quantity  int    `json:"quantity" validate:"required_without=SeatIDs,omitempty,gte=1"`
+	SeatIDs   []int  `json:"seat_ids" validate:"omitempty,max=100,unique,dive,gte=1"`
+	UserID    string `json:"user_id" validate:"required,uuid4"`
+	PromoCode string `json:"promo_code" validate:"omitempty,max=64"`
 } // @Name PurchaseTicketRequest
 
+// MoneyRequest is an amount in the minor units of an ISO-4217 currency,
+// e.g. {"amount": 1250, "currency": "EUR"} is 12.50 euro.
+type MoneyRequest struct {
+	Amount   int64  `json:"amount" validate:"gte=0"`
+	Currency string `json:"currency" validate:"required,iso4217"`
+} // @Name MoneyRequest
+
+// CreateTicketRequest publishes the ticket right away unless status is
+// "draft".
 type CreateTicketRequest struct {
-	Name        string `json:"name" validate:"required"`
-	Description string `json:"description" validate:"required"`
-	Allocation  int    `json:"allocation" validate:"required,gte=1"`
+	EventID      *int          `json:"event_id" validate:"omitempty,gte=1"`
+	Name         string        `json:"name" validate:"required"`
+	Description  string        `json:"description" validate:"required"`
+	Allocation   int           `json:"allocation" validate:"required,gte=1"`
+	Price        *MoneyRequest `json:"price" validate:"required"`
+	MaxPerUser   int           `json:"max_per_user" validate:"omitempty,gte=1"`
+	SalesStartAt *time.Time    `json:"sales_start_at"`
+	SalesEndAt   *time.Time    `json:"sales_end_at"`
+	Status       string        `json:"status" validate:"omitempty,oneof=draft published" enums:"draft,published"`
 } // @Name CreateTicketRequest
+
+// UpdateTicketRequest only changes the fields that are sent, a max_per_user
+// of 0 removes the purchase limit. A sent sales_start_at or sales_end_at
+// replaces that end of the sales window.
+type UpdateTicketRequest struct {
+	Name        *string `json:"name" validate:"omitempty,min=1"`
+	Description *string `json:"description" validate:"omitempty,min=1"`
+	// TotalAllocation counts sold and held units too, unlike the allocation
+	// of a ticket, which is the number of units that are still available.
+	TotalAllocation *int          `json:"total_allocation" validate:"omitempty,gte=0"`
+	Price           *MoneyRequest `json:"price" validate:"omitempty"`
+	MaxPerUser      *int          `json:"max_per_user" validate:"omitempty,gte=0"`
+	SalesStartAt    *time.Time    `json:"sales_start_at"`
+	SalesEndAt      *time.Time    `json:"sales_end_at"`
+} // @Name UpdateTicketRequest
+
+type ListTicketsRequest struct {
+	EventID       *int       `query:"event_id" validate:"omitempty,gte=1"`
+	Name          string     `query:"name"`
+	MinAllocation *int       `query:"min_allocation" validate:"omitempty,gte=0"`
+	MaxAllocation *int       `query:"max_allocation" validate:"omitempty,gte=0"`
+	SoldOut       *bool      `query:"sold_out"`
+	CreatedAfter  *time.Time `query:"created_after"`
+	CreatedBefore *time.Time `query:"created_before"`
+	SortBy        string     `query:"sort_by" validate:"omitempty,oneof=allocation created_at"`
+	Order         string     `query:"order" validate:"omitempty,oneof=asc desc"`
+	Limit         int        `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	Cursor        string     `query:"cursor"`
+}
+
+type ListLedgerRequest struct {
+	Limit  int    `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	Cursor string `query:"cursor"`
+}
+
+// CreateReservationRequest holds seats like PurchaseTicketRequest buys them.
+type CreateReservationRequest struct {
+	Quantity int    `json:"quantity" validate:"required_without=SeatIDs,omitempty,gte=1"`
+	SeatIDs  []int  `json:"seat_ids" validate:"omitempty,max=100,unique,dive,gte=1"`
+	UserID   string `json:"user_id" validate:"required,uuid4"`
+} // @Name CreateReservationRequest
+
+// JoinWaitlistRequest asks for quantity units of a sold out ticket once they
+// become available again.
+type JoinWaitlistRequest struct {
+	Quantity int    `json:"quantity" validate:"required,gte=1"`
+	UserID   string `json:"user_id" validate:"required,uuid4"`
+} // @Name JoinWaitlistRequest
+
+// WaitlistEntryRequest names the user whose waitlist entry is shown or
+// removed.
+type WaitlistEntryRequest struct {
+	UserID string `query:"user_id" validate:"required,uuid4"`
+} // @Name WaitlistEntryRequest
+
+// RefundPurchaseRequest refunds the whole remaining quantity of the purchase
+// when quantity is omitted. Partial refunds of seats have to name the
+// seat_ids that are refunded.
+type RefundPurchaseRequest struct {
+	Quantity *int  `json:"quantity" validate:"omitempty,gte=1"`
+	SeatIDs  []int `json:"seat_ids" validate:"omitempty,max=100,unique,dive,gte=1"`
+} // @Name RefundPurchaseRequest
+
+// CreateSeatMapRequest lists the sections of the seat map and their rows
+// from best to worst, the seats of a row are numbered from 1.
+type CreateSeatMapRequest struct {
+	Sections []SeatSectionRequest `json:"sections" validate:"required,min=1,dive"`
+} // @Name CreateSeatMapRequest
+
+type SeatSectionRequest struct {
+	Name string           `json:"name" validate:"required,max=64"`
+	Rows []SeatRowRequest `json:"rows" validate:"required,min=1,dive"`
+} // @Name SeatSectionRequest
+
+type SeatRowRequest struct {
+	Name  string `json:"name" validate:"required,max=16"`
+	Seats int    `json:"seats" validate:"required,gte=1,lte=500"`
+} // @Name SeatRowRequest
+
+type BestAvailableSeatsRequest struct {
+	Quantity int `query:"quantity" validate:"required,gte=1,lte=100"`
+}
+
+// CreateOrderRequest buys every line or none of them, a ticket can only be
+// in one line, and can redeem one promotion code for the whole order.
+type CreateOrderRequest struct {
+	UserID    string             `json:"user_id" validate:"required,uuid4"`
+	Lines     []OrderLineRequest `json:"lines" validate:"required,min=1,max=20,dive"`
+	PromoCode string             `json:"promo_code" validate:"omitempty,max=64"`
+} // @Name CreateOrderRequest
+
+type OrderLineRequest struct {
+	TicketID int   `json:"ticket_id" validate:"required,gte=1"`
+	Quantity int   `json:"quantity" validate:"required_without=SeatIDs,omitempty,gte=1"`
+	SeatIDs  []int `json:"seat_ids" validate:"omitempty,max=100,unique,dive,gte=1"`
+} // @Name OrderLineRequest
+
+type ListOutboxMessagesRequest struct {
+	Limit int `query:"limit" validate:"omitempty,gte=1,lte=100"`
+}
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" validate:"required,url"`
+	Events []string `json:"events" validate:"required,min=1,dive,required"`
+} // @Name CreateWebhookRequest
+
+type ListWebhookDeliveriesRequest struct {
+	Limit int `query:"limit" validate:"omitempty,gte=1,lte=100"`
+}
+
+type AddressRequest struct {
+	Street     string `json:"street" validate:"required"`
+	City       string `json:"city" validate:"required"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country" validate:"required,iso3166_1_alpha2"`
+} // @Name AddressRequest
+
+// CreateVenueRequest takes an IANA timezone like "Europe/Berlin", the dates
+// of the venue's events are shown in it.
+type CreateVenueRequest struct {
+	Name     string          `json:"name" validate:"required"`
+	Address  *AddressRequest `json:"address" validate:"required"`
+	Timezone string          `json:"timezone" validate:"required"`
+} // @Name CreateVenueRequest
+
+// UpdateVenueRequest only changes the fields that are sent, a sent address
+// replaces the whole address.
+type UpdateVenueRequest struct {
+	Name     *string         `json:"name" validate:"omitempty,min=1"`
+	Address  *AddressRequest `json:"address" validate:"omitempty"`
+	Timezone *string         `json:"timezone" validate:"omitempty,min=1"`
+} // @Name UpdateVenueRequest
+
+type ListVenuesRequest struct {
+	Limit  int    `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	Cursor string `query:"cursor"`
+}
+
+type CreateEventRequest struct {
+	VenueID     int       `json:"venue_id" validate:"required,gte=1"`
+	Name        string    `json:"name" validate:"required"`
+	Description string    `json:"description" validate:"required"`
+	StartsAt    time.Time `json:"starts_at" validate:"required"`
+	EndsAt      time.Time `json:"ends_at" validate:"required"`
+	Capacity    int       `json:"capacity" validate:"required,gte=1"`
+} // @Name CreateEventRequest
+
+// UpdateEventRequest only changes the fields that are sent. The capacity
+// cannot be lowered below the units the event's tickets allocate.
+type UpdateEventRequest struct {
+	Name        *string    `json:"name" validate:"omitempty,min=1"`
+	Description *string    `json:"description" validate:"omitempty,min=1"`
+	StartsAt    *time.Time `json:"starts_at"`
+	EndsAt      *time.Time `json:"ends_at"`
+	Capacity    *int       `json:"capacity" validate:"omitempty,gte=1"`
+} // @Name UpdateEventRequest
+
+// ListEventsRequest returns the events that take place at least partly
+// between from and to.
+type ListEventsRequest struct {
+	VenueID *int       `query:"venue_id" validate:"omitempty,gte=1"`
+	From    *time.Time `query:"from"`
+	To      *time.Time `query:"to"`
+	Limit   int        `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	Cursor  string     `query:"cursor"`
+}
+
+// CreatePromotionRequest takes a percentage from 1 to 100 or, for a fixed
+// discount, an amount in the minor units of currency. A promotion without
+// ticket_id discounts every ticket.
+type CreatePromotionRequest struct {
+	Code           string     `json:"code" validate:"required,max=64"`
+	Kind           string     `json:"kind" validate:"required,oneof=percentage fixed" enums:"percentage,fixed"`
+	Value          int64      `json:"value" validate:"required,gte=1"`
+	Currency       string     `json:"currency" validate:"required_if=Kind fixed,omitempty,iso4217"`
+	TicketID       *int       `json:"ticket_id" validate:"omitempty,gte=1"`
+	MaxRedemptions int        `json:"max_redemptions" validate:"omitempty,gte=1"`
+	MaxPerUser     int        `json:"max_per_user" validate:"omitempty,gte=1"`
+	StartsAt       *time.Time `json:"starts_at"`
+	EndsAt         *time.Time `json:"ends_at"`
+} // @Name CreatePromotionRequest
+
+// UpdatePromotionRequest only changes the fields that are sent. A ticket_id,
+// max_redemptions or max_per_user of 0 removes the restriction, a sent
+// starts_at or ends_at replaces that end of the validity.
+type UpdatePromotionRequest struct {
+	Code           *string    `json:"code" validate:"omitempty,min=1,max=64"`
+	Kind           *string    `json:"kind" validate:"omitempty,oneof=percentage fixed" enums:"percentage,fixed"`
+	Value          *int64     `json:"value" validate:"omitempty,gte=1"`
+	Currency       *string    `json:"currency" validate:"omitempty,iso4217"`
+	TicketID       *int       `json:"ticket_id" validate:"omitempty,gte=0"`
+	MaxRedemptions *int       `json:"max_redemptions" validate:"omitempty,gte=0"`
+	MaxPerUser     *int       `json:"max_per_user" validate:"omitempty,gte=0"`
+	StartsAt       *time.Time `json:"starts_at"`
+	EndsAt         *time.Time `json:"ends_at"`
+} // @Name UpdatePromotionRequest
+
+type ListPromotionsRequest struct {
+	Limit  int    `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	Cursor string `query:"cursor"`
+}
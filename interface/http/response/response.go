@@ -1,6 +1,10 @@
 package response
 
-import "github.com/labstack/echo/v4"
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
 
 type EmptyBody struct{} // @Name EmptyBody
 
@@ -30,3 +34,48 @@ func NewErrorRespone(c echo.Context, err error, status int) error {
 		Message: err.Error(),
 	})
 }
+
+// PurchaseLimitErrorResponse tells a user how many more units of a ticket
+// they can buy.
+type PurchaseLimitErrorResponse struct {
+	Message   string `json:"message"`
+	Status    int    `json:"status"`
+	Limit     int    `json:"limit"`
+	Remaining int    `json:"remaining"`
+} // @Name PurchaseLimitErrorResponse
+
+func NewPurchaseLimitErrorResponse(c echo.Context, err error, limit, remaining int) error {
+	return c.JSON(http.StatusUnprocessableEntity, &PurchaseLimitErrorResponse{
+		Message:   err.Error(),
+		Status:    http.StatusUnprocessableEntity,
+		Limit:     limit,
+		Remaining: remaining,
+	})
+}
+
+// OrderLineError is why a line of an order failed, Line is the position of
+// the line in the request. Limit and Remaining are set when the line is
+// above the per user limit of its ticket.
+type OrderLineError struct {
+	Line      int    `json:"line"`
+	TicketID  int    `json:"ticket_id"`
+	Message   string `json:"message"`
+	Status    int    `json:"status"`
+	Limit     *int   `json:"limit,omitempty"`
+	Remaining *int   `json:"remaining,omitempty"`
+} // @Name OrderLineError
+
+// OrderErrorResponse lists every line of an order that failed.
+type OrderErrorResponse struct {
+	Message string            `json:"message"`
+	Status  int               `json:"status"`
+	Lines   []*OrderLineError `json:"lines"`
+} // @Name OrderErrorResponse
+
+func NewOrderErrorResponse(c echo.Context, err error, status int, lines []*OrderLineError) error {
+	return c.JSON(status, &OrderErrorResponse{
+		Message: err.Error(),
+		Status:  status,
+		Lines:   lines,
+	})
+}
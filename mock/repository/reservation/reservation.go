@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aaydin-tr/ddd-api-example/domain/reservation/repository (interfaces: ReservationRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../../mock/repository/reservation/reservation.go -package=repository github.com/aaydin-tr/ddd-api-example/domain/reservation/repository ReservationRepository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	reservation "github.com/aaydin-tr/ddd-api-example/domain/reservation"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReservationRepository is a mock of ReservationRepository interface.
+type MockReservationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReservationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReservationRepositoryMockRecorder is the mock recorder for MockReservationRepository.
+type MockReservationRepositoryMockRecorder struct {
+	mock *MockReservationRepository
+}
+
+// NewMockReservationRepository creates a new mock instance.
+func NewMockReservationRepository(ctrl *gomock.Controller) *MockReservationRepository {
+	mock := &MockReservationRepository{ctrl: ctrl}
+	mock.recorder = &MockReservationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReservationRepository) EXPECT() *MockReservationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReservationRepository) Create(ctx context.Context, r *reservation.Reservation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReservationRepositoryMockRecorder) Create(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReservationRepository)(nil).Create), ctx, r)
+}
+
+// FindByID mocks base method.
+func (m *MockReservationRepository) FindByID(ctx context.Context, id int) (*reservation.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*reservation.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockReservationRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockReservationRepository)(nil).FindByID), ctx, id)
+}
+
+// FindByIDForUpdate mocks base method.
+func (m *MockReservationRepository) FindByIDForUpdate(ctx context.Context, id int) (*reservation.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*reservation.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDForUpdate indicates an expected call of FindByIDForUpdate.
+func (mr *MockReservationRepositoryMockRecorder) FindByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDForUpdate", reflect.TypeOf((*MockReservationRepository)(nil).FindByIDForUpdate), ctx, id)
+}
+
+// FindExpiredForUpdate mocks base method.
+func (m *MockReservationRepository) FindExpiredForUpdate(ctx context.Context, now time.Time, limit int) ([]*reservation.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExpiredForUpdate", ctx, now, limit)
+	ret0, _ := ret[0].([]*reservation.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExpiredForUpdate indicates an expected call of FindExpiredForUpdate.
+func (mr *MockReservationRepositoryMockRecorder) FindExpiredForUpdate(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExpiredForUpdate", reflect.TypeOf((*MockReservationRepository)(nil).FindExpiredForUpdate), ctx, now, limit)
+}
+
+// HeldQuantity mocks base method.
+func (m *MockReservationRepository) HeldQuantity(ctx context.Context, ticketID int, userID string, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeldQuantity", ctx, ticketID, userID, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HeldQuantity indicates an expected call of HeldQuantity.
+func (mr *MockReservationRepositoryMockRecorder) HeldQuantity(ctx, ticketID, userID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeldQuantity", reflect.TypeOf((*MockReservationRepository)(nil).HeldQuantity), ctx, ticketID, userID, now)
+}
+
+// Update mocks base method.
+func (m *MockReservationRepository) Update(ctx context.Context, r *reservation.Reservation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockReservationRepositoryMockRecorder) Update(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockReservationRepository)(nil).Update), ctx, r)
+}
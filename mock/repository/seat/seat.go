@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aaydin-tr/ddd-api-example/domain/seat/repository (interfaces: SeatRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../../mock/repository/seat/seat.go -package=repository github.com/aaydin-tr/ddd-api-example/domain/seat/repository SeatRepository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	seat "github.com/aaydin-tr/ddd-api-example/domain/seat"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSeatRepository is a mock of SeatRepository interface.
+type MockSeatRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeatRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSeatRepositoryMockRecorder is the mock recorder for MockSeatRepository.
+type MockSeatRepositoryMockRecorder struct {
+	mock *MockSeatRepository
+}
+
+// NewMockSeatRepository creates a new mock instance.
+func NewMockSeatRepository(ctrl *gomock.Controller) *MockSeatRepository {
+	mock := &MockSeatRepository{ctrl: ctrl}
+	mock.recorder = &MockSeatRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeatRepository) EXPECT() *MockSeatRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateMany mocks base method.
+func (m *MockSeatRepository) CreateMany(ctx context.Context, seats []*seat.Seat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMany", ctx, seats)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMany indicates an expected call of CreateMany.
+func (mr *MockSeatRepositoryMockRecorder) CreateMany(ctx, seats any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMany", reflect.TypeOf((*MockSeatRepository)(nil).CreateMany), ctx, seats)
+}
+
+// ListByPurchase mocks base method.
+func (m *MockSeatRepository) ListByPurchase(ctx context.Context, purchaseID int) ([]*seat.Seat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByPurchase", ctx, purchaseID)
+	ret0, _ := ret[0].([]*seat.Seat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByPurchase indicates an expected call of ListByPurchase.
+func (mr *MockSeatRepositoryMockRecorder) ListByPurchase(ctx, purchaseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByPurchase", reflect.TypeOf((*MockSeatRepository)(nil).ListByPurchase), ctx, purchaseID)
+}
+
+// ListByReservation mocks base method.
+func (m *MockSeatRepository) ListByReservation(ctx context.Context, reservationID int) ([]*seat.Seat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByReservation", ctx, reservationID)
+	ret0, _ := ret[0].([]*seat.Seat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByReservation indicates an expected call of ListByReservation.
+func (mr *MockSeatRepositoryMockRecorder) ListByReservation(ctx, reservationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByReservation", reflect.TypeOf((*MockSeatRepository)(nil).ListByReservation), ctx, reservationID)
+}
+
+// ListByTicket mocks base method.
+func (m *MockSeatRepository) ListByTicket(ctx context.Context, ticketID int) ([]*seat.Seat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTicket", ctx, ticketID)
+	ret0, _ := ret[0].([]*seat.Seat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTicket indicates an expected call of ListByTicket.
+func (mr *MockSeatRepositoryMockRecorder) ListByTicket(ctx, ticketID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTicket", reflect.TypeOf((*MockSeatRepository)(nil).ListByTicket), ctx, ticketID)
+}
+
+// Update mocks base method.
+func (m *MockSeatRepository) Update(ctx context.Context, s *seat.Seat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSeatRepositoryMockRecorder) Update(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSeatRepository)(nil).Update), ctx, s)
+}
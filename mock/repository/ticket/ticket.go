@@ -15,7 +15,6 @@ import (
 
 	ticket "github.com/aaydin-tr/ddd-api-example/domain/ticket"
 	gomock "go.uber.org/mock/gomock"
-	gorm "gorm.io/gorm"
 )
 
 // MockTicketRepository is a mock of TicketRepository interface.
@@ -42,6 +41,21 @@ func (m *MockTicketRepository) EXPECT() *MockTicketRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountByEvent mocks base method.
+func (m *MockTicketRepository) CountByEvent(ctx context.Context, eventID int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByEvent", ctx, eventID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByEvent indicates an expected call of CountByEvent.
+func (mr *MockTicketRepositoryMockRecorder) CountByEvent(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByEvent", reflect.TypeOf((*MockTicketRepository)(nil).CountByEvent), ctx, eventID)
+}
+
 // Create mocks base method.
 func (m *MockTicketRepository) Create(ctx context.Context, t *ticket.Ticket) error {
 	m.ctrl.T.Helper()
@@ -56,6 +70,35 @@ func (mr *MockTicketRepositoryMockRecorder) Create(ctx, t any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTicketRepository)(nil).Create), ctx, t)
 }
 
+// Delete mocks base method.
+func (m *MockTicketRepository) Delete(ctx context.Context, t *ticket.Ticket) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTicketRepositoryMockRecorder) Delete(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTicketRepository)(nil).Delete), ctx, t)
+}
+
+// EventAllocation mocks base method.
+func (m *MockTicketRepository) EventAllocation(ctx context.Context, eventID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventAllocation", ctx, eventID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EventAllocation indicates an expected call of EventAllocation.
+func (mr *MockTicketRepositoryMockRecorder) EventAllocation(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventAllocation", reflect.TypeOf((*MockTicketRepository)(nil).EventAllocation), ctx, eventID)
+}
+
 // FindByID mocks base method.
 func (m *MockTicketRepository) FindByID(ctx context.Context, id int) (*ticket.Ticket, error) {
 	m.ctrl.T.Helper()
@@ -72,44 +115,91 @@ func (mr *MockTicketRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
 }
 
 // FindByIDForUpdate mocks base method.
-func (m *MockTicketRepository) FindByIDForUpdate(ctx context.Context, id int, tx *gorm.DB) (*ticket.Ticket, error) {
+func (m *MockTicketRepository) FindByIDForUpdate(ctx context.Context, id int) (*ticket.Ticket, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByIDForUpdate", ctx, id, tx)
+	ret := m.ctrl.Call(m, "FindByIDForUpdate", ctx, id)
 	ret0, _ := ret[0].(*ticket.Ticket)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByIDForUpdate indicates an expected call of FindByIDForUpdate.
-func (mr *MockTicketRepositoryMockRecorder) FindByIDForUpdate(ctx, id, tx any) *gomock.Call {
+func (mr *MockTicketRepositoryMockRecorder) FindByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDForUpdate", reflect.TypeOf((*MockTicketRepository)(nil).FindByIDForUpdate), ctx, id)
+}
+
+// FindByIDUnscoped mocks base method.
+func (m *MockTicketRepository) FindByIDUnscoped(ctx context.Context, id int) (*ticket.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDUnscoped", ctx, id)
+	ret0, _ := ret[0].(*ticket.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDUnscoped indicates an expected call of FindByIDUnscoped.
+func (mr *MockTicketRepositoryMockRecorder) FindByIDUnscoped(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDForUpdate", reflect.TypeOf((*MockTicketRepository)(nil).FindByIDForUpdate), ctx, id, tx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDUnscoped", reflect.TypeOf((*MockTicketRepository)(nil).FindByIDUnscoped), ctx, id)
 }
 
-// GetDB mocks base method.
-func (m *MockTicketRepository) GetDB(ctx context.Context) *gorm.DB {
+// List mocks base method.
+func (m *MockTicketRepository) List(ctx context.Context, filter ticket.ListFilter) ([]*ticket.Ticket, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDB", ctx)
-	ret0, _ := ret[0].(*gorm.DB)
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]*ticket.Ticket)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockTicketRepositoryMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTicketRepository)(nil).List), ctx, filter)
+}
+
+// ListLedger mocks base method.
+func (m *MockTicketRepository) ListLedger(ctx context.Context, ticketID, offset, limit int) ([]*ticket.LedgerEntry, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLedger", ctx, ticketID, offset, limit)
+	ret0, _ := ret[0].([]*ticket.LedgerEntry)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListLedger indicates an expected call of ListLedger.
+func (mr *MockTicketRepositoryMockRecorder) ListLedger(ctx, ticketID, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLedger", reflect.TypeOf((*MockTicketRepository)(nil).ListLedger), ctx, ticketID, offset, limit)
+}
+
+// Restore mocks base method.
+func (m *MockTicketRepository) Restore(ctx context.Context, t *ticket.Ticket) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, t)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// GetDB indicates an expected call of GetDB.
-func (mr *MockTicketRepositoryMockRecorder) GetDB(ctx any) *gomock.Call {
+// Restore indicates an expected call of Restore.
+func (mr *MockTicketRepositoryMockRecorder) Restore(ctx, t any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDB", reflect.TypeOf((*MockTicketRepository)(nil).GetDB), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTicketRepository)(nil).Restore), ctx, t)
 }
 
 // Update mocks base method.
-func (m *MockTicketRepository) Update(ctx context.Context, ticket *ticket.Ticket, tx *gorm.DB) error {
+func (m *MockTicketRepository) Update(ctx context.Context, ticket *ticket.Ticket) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, ticket, tx)
+	ret := m.ctrl.Call(m, "Update", ctx, ticket)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockTicketRepositoryMockRecorder) Update(ctx, ticket, tx any) *gomock.Call {
+func (mr *MockTicketRepositoryMockRecorder) Update(ctx, ticket any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTicketRepository)(nil).Update), ctx, ticket, tx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTicketRepository)(nil).Update), ctx, ticket)
 }
@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aaydin-tr/ddd-api-example/domain/waitlist/repository (interfaces: WaitlistRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../../mock/repository/waitlist/waitlist.go -package=repository github.com/aaydin-tr/ddd-api-example/domain/waitlist/repository WaitlistRepository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	waitlist "github.com/aaydin-tr/ddd-api-example/domain/waitlist"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWaitlistRepository is a mock of WaitlistRepository interface.
+type MockWaitlistRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWaitlistRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWaitlistRepositoryMockRecorder is the mock recorder for MockWaitlistRepository.
+type MockWaitlistRepositoryMockRecorder struct {
+	mock *MockWaitlistRepository
+}
+
+// NewMockWaitlistRepository creates a new mock instance.
+func NewMockWaitlistRepository(ctrl *gomock.Controller) *MockWaitlistRepository {
+	mock := &MockWaitlistRepository{ctrl: ctrl}
+	mock.recorder = &MockWaitlistRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWaitlistRepository) EXPECT() *MockWaitlistRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWaitlistRepository) Create(ctx context.Context, e *waitlist.Entry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWaitlistRepositoryMockRecorder) Create(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWaitlistRepository)(nil).Create), ctx, e)
+}
+
+// FindLatestByUser mocks base method.
+func (m *MockWaitlistRepository) FindLatestByUser(ctx context.Context, ticketID int, userID string) (*waitlist.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatestByUser", ctx, ticketID, userID)
+	ret0, _ := ret[0].(*waitlist.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatestByUser indicates an expected call of FindLatestByUser.
+func (mr *MockWaitlistRepositoryMockRecorder) FindLatestByUser(ctx, ticketID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestByUser", reflect.TypeOf((*MockWaitlistRepository)(nil).FindLatestByUser), ctx, ticketID, userID)
+}
+
+// ListActive mocks base method.
+func (m *MockWaitlistRepository) ListActive(ctx context.Context, ticketID int) ([]*waitlist.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActive", ctx, ticketID)
+	ret0, _ := ret[0].([]*waitlist.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActive indicates an expected call of ListActive.
+func (mr *MockWaitlistRepositoryMockRecorder) ListActive(ctx, ticketID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActive", reflect.TypeOf((*MockWaitlistRepository)(nil).ListActive), ctx, ticketID)
+}
+
+// Position mocks base method.
+func (m *MockWaitlistRepository) Position(ctx context.Context, e *waitlist.Entry) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Position", ctx, e)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Position indicates an expected call of Position.
+func (mr *MockWaitlistRepositoryMockRecorder) Position(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Position", reflect.TypeOf((*MockWaitlistRepository)(nil).Position), ctx, e)
+}
+
+// TicketsWithWaiting mocks base method.
+func (m *MockWaitlistRepository) TicketsWithWaiting(ctx context.Context) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TicketsWithWaiting", ctx)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TicketsWithWaiting indicates an expected call of TicketsWithWaiting.
+func (mr *MockWaitlistRepositoryMockRecorder) TicketsWithWaiting(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TicketsWithWaiting", reflect.TypeOf((*MockWaitlistRepository)(nil).TicketsWithWaiting), ctx)
+}
+
+// Update mocks base method.
+func (m *MockWaitlistRepository) Update(ctx context.Context, e *waitlist.Entry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWaitlistRepositoryMockRecorder) Update(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWaitlistRepository)(nil).Update), ctx, e)
+}
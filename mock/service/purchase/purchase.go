@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aaydin-tr/ddd-api-example/service/purchase (interfaces: PurchaseService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mock/service/purchase/purchase.go -package=service github.com/aaydin-tr/ddd-api-example/service/purchase PurchaseService
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	purchase "github.com/aaydin-tr/ddd-api-example/domain/purchase"
+	request "github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPurchaseService is a mock of PurchaseService interface.
+type MockPurchaseService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurchaseServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPurchaseServiceMockRecorder is the mock recorder for MockPurchaseService.
+type MockPurchaseServiceMockRecorder struct {
+	mock *MockPurchaseService
+}
+
+// NewMockPurchaseService creates a new mock instance.
+func NewMockPurchaseService(ctrl *gomock.Controller) *MockPurchaseService {
+	mock := &MockPurchaseService{ctrl: ctrl}
+	mock.recorder = &MockPurchaseServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurchaseService) EXPECT() *MockPurchaseServiceMockRecorder {
+	return m.recorder
+}
+
+// Refund mocks base method.
+func (m *MockPurchaseService) Refund(ctx context.Context, id int, req request.RefundPurchaseRequest) (*purchase.RefundDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", ctx, id, req)
+	ret0, _ := ret[0].(*purchase.RefundDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockPurchaseServiceMockRecorder) Refund(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockPurchaseService)(nil).Refund), ctx, id, req)
+}
@@ -13,6 +13,8 @@ import (
 	context "context"
 	reflect "reflect"
 
+	purchase "github.com/aaydin-tr/ddd-api-example/domain/purchase"
+	seat "github.com/aaydin-tr/ddd-api-example/domain/seat"
 	ticket "github.com/aaydin-tr/ddd-api-example/domain/ticket"
 	request "github.com/aaydin-tr/ddd-api-example/interface/http/request"
 	gomock "go.uber.org/mock/gomock"
@@ -42,6 +44,36 @@ func (m *MockTicketService) EXPECT() *MockTicketServiceMockRecorder {
 	return m.recorder
 }
 
+// BestAvailableSeats mocks base method.
+func (m *MockTicketService) BestAvailableSeats(ctx context.Context, id int, req request.BestAvailableSeatsRequest) (*seat.SeatListDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BestAvailableSeats", ctx, id, req)
+	ret0, _ := ret[0].(*seat.SeatListDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BestAvailableSeats indicates an expected call of BestAvailableSeats.
+func (mr *MockTicketServiceMockRecorder) BestAvailableSeats(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BestAvailableSeats", reflect.TypeOf((*MockTicketService)(nil).BestAvailableSeats), ctx, id, req)
+}
+
+// CheckLedger mocks base method.
+func (m *MockTicketService) CheckLedger(ctx context.Context) (*ticket.LedgerCheckDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckLedger", ctx)
+	ret0, _ := ret[0].(*ticket.LedgerCheckDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckLedger indicates an expected call of CheckLedger.
+func (mr *MockTicketServiceMockRecorder) CheckLedger(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLedger", reflect.TypeOf((*MockTicketService)(nil).CheckLedger), ctx)
+}
+
 // Create mocks base method.
 func (m *MockTicketService) Create(ctx context.Context, req request.CreateTicketRequest) (*ticket.TicketDTO, error) {
 	m.ctrl.T.Helper()
@@ -57,18 +89,33 @@ func (mr *MockTicketServiceMockRecorder) Create(ctx, req any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTicketService)(nil).Create), ctx, req)
 }
 
-// DecrementAllocation mocks base method.
-func (m *MockTicketService) DecrementAllocation(ctx context.Context, ticketID, amount int) error {
+// CreateSeatMap mocks base method.
+func (m *MockTicketService) CreateSeatMap(ctx context.Context, id int, req request.CreateSeatMapRequest) (*seat.SeatMapDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSeatMap", ctx, id, req)
+	ret0, _ := ret[0].(*seat.SeatMapDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSeatMap indicates an expected call of CreateSeatMap.
+func (mr *MockTicketServiceMockRecorder) CreateSeatMap(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeatMap", reflect.TypeOf((*MockTicketService)(nil).CreateSeatMap), ctx, id, req)
+}
+
+// Delete mocks base method.
+func (m *MockTicketService) Delete(ctx context.Context, id int, version *int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DecrementAllocation", ctx, ticketID, amount)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DecrementAllocation indicates an expected call of DecrementAllocation.
-func (mr *MockTicketServiceMockRecorder) DecrementAllocation(ctx, ticketID, amount any) *gomock.Call {
+// Delete indicates an expected call of Delete.
+func (mr *MockTicketServiceMockRecorder) Delete(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementAllocation", reflect.TypeOf((*MockTicketService)(nil).DecrementAllocation), ctx, ticketID, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTicketService)(nil).Delete), ctx, id, version)
 }
 
 // FindByID mocks base method.
@@ -85,3 +132,108 @@ func (mr *MockTicketServiceMockRecorder) FindByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockTicketService)(nil).FindByID), ctx, id)
 }
+
+// Ledger mocks base method.
+func (m *MockTicketService) Ledger(ctx context.Context, id int, req request.ListLedgerRequest) (*ticket.LedgerDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ledger", ctx, id, req)
+	ret0, _ := ret[0].(*ticket.LedgerDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Ledger indicates an expected call of Ledger.
+func (mr *MockTicketServiceMockRecorder) Ledger(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ledger", reflect.TypeOf((*MockTicketService)(nil).Ledger), ctx, id, req)
+}
+
+// List mocks base method.
+func (m *MockTicketService) List(ctx context.Context, req request.ListTicketsRequest) (*ticket.TicketListDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, req)
+	ret0, _ := ret[0].(*ticket.TicketListDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockTicketServiceMockRecorder) List(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTicketService)(nil).List), ctx, req)
+}
+
+// Purchase mocks base method.
+func (m *MockTicketService) Purchase(ctx context.Context, ticketID int, req request.PurchaseTicketRequest) (*purchase.PurchaseDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purchase", ctx, ticketID, req)
+	ret0, _ := ret[0].(*purchase.PurchaseDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purchase indicates an expected call of Purchase.
+func (mr *MockTicketServiceMockRecorder) Purchase(ctx, ticketID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purchase", reflect.TypeOf((*MockTicketService)(nil).Purchase), ctx, ticketID, req)
+}
+
+// Restore mocks base method.
+func (m *MockTicketService) Restore(ctx context.Context, id int, version *int) (*ticket.TicketDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, version)
+	ret0, _ := ret[0].(*ticket.TicketDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTicketServiceMockRecorder) Restore(ctx, id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTicketService)(nil).Restore), ctx, id, version)
+}
+
+// SeatMap mocks base method.
+func (m *MockTicketService) SeatMap(ctx context.Context, id int) (*seat.SeatMapDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SeatMap", ctx, id)
+	ret0, _ := ret[0].(*seat.SeatMapDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SeatMap indicates an expected call of SeatMap.
+func (mr *MockTicketServiceMockRecorder) SeatMap(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeatMap", reflect.TypeOf((*MockTicketService)(nil).SeatMap), ctx, id)
+}
+
+// Transition mocks base method.
+func (m *MockTicketService) Transition(ctx context.Context, id int, transition ticket.Transition, version *int) (*ticket.TicketDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transition", ctx, id, transition, version)
+	ret0, _ := ret[0].(*ticket.TicketDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transition indicates an expected call of Transition.
+func (mr *MockTicketServiceMockRecorder) Transition(ctx, id, transition, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transition", reflect.TypeOf((*MockTicketService)(nil).Transition), ctx, id, transition, version)
+}
+
+// Update mocks base method.
+func (m *MockTicketService) Update(ctx context.Context, id int, req request.UpdateTicketRequest, version *int) (*ticket.TicketDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, req, version)
+	ret0, _ := ret[0].(*ticket.TicketDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTicketServiceMockRecorder) Update(ctx, id, req, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTicketService)(nil).Update), ctx, id, req, version)
+}
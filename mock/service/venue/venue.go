@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aaydin-tr/ddd-api-example/service/venue (interfaces: VenueService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mock/service/venue/venue.go -package=service github.com/aaydin-tr/ddd-api-example/service/venue VenueService
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	venue "github.com/aaydin-tr/ddd-api-example/domain/venue"
+	request "github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVenueService is a mock of VenueService interface.
+type MockVenueService struct {
+	ctrl     *gomock.Controller
+	recorder *MockVenueServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockVenueServiceMockRecorder is the mock recorder for MockVenueService.
+type MockVenueServiceMockRecorder struct {
+	mock *MockVenueService
+}
+
+// NewMockVenueService creates a new mock instance.
+func NewMockVenueService(ctrl *gomock.Controller) *MockVenueService {
+	mock := &MockVenueService{ctrl: ctrl}
+	mock.recorder = &MockVenueServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVenueService) EXPECT() *MockVenueServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockVenueService) Create(ctx context.Context, req request.CreateVenueRequest) (*venue.VenueDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req)
+	ret0, _ := ret[0].(*venue.VenueDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockVenueServiceMockRecorder) Create(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVenueService)(nil).Create), ctx, req)
+}
+
+// Delete mocks base method.
+func (m *MockVenueService) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockVenueServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVenueService)(nil).Delete), ctx, id)
+}
+
+// FindByID mocks base method.
+func (m *MockVenueService) FindByID(ctx context.Context, id int) (*venue.VenueDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*venue.VenueDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockVenueServiceMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockVenueService)(nil).FindByID), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockVenueService) List(ctx context.Context, req request.ListVenuesRequest) (*venue.VenueListDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, req)
+	ret0, _ := ret[0].(*venue.VenueListDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockVenueServiceMockRecorder) List(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockVenueService)(nil).List), ctx, req)
+}
+
+// Update mocks base method.
+func (m *MockVenueService) Update(ctx context.Context, id int, req request.UpdateVenueRequest) (*venue.VenueDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, req)
+	ret0, _ := ret[0].(*venue.VenueDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockVenueServiceMockRecorder) Update(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVenueService)(nil).Update), ctx, id, req)
+}
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aaydin-tr/ddd-api-example/service/waitlist (interfaces: WaitlistService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mock/service/waitlist/waitlist.go -package=service github.com/aaydin-tr/ddd-api-example/service/waitlist WaitlistService
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	waitlist "github.com/aaydin-tr/ddd-api-example/domain/waitlist"
+	request "github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWaitlistService is a mock of WaitlistService interface.
+type MockWaitlistService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWaitlistServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockWaitlistServiceMockRecorder is the mock recorder for MockWaitlistService.
+type MockWaitlistServiceMockRecorder struct {
+	mock *MockWaitlistService
+}
+
+// NewMockWaitlistService creates a new mock instance.
+func NewMockWaitlistService(ctrl *gomock.Controller) *MockWaitlistService {
+	mock := &MockWaitlistService{ctrl: ctrl}
+	mock.recorder = &MockWaitlistServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWaitlistService) EXPECT() *MockWaitlistServiceMockRecorder {
+	return m.recorder
+}
+
+// FindByUser mocks base method.
+func (m *MockWaitlistService) FindByUser(ctx context.Context, ticketID int, userID string) (*waitlist.EntryDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUser", ctx, ticketID, userID)
+	ret0, _ := ret[0].(*waitlist.EntryDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUser indicates an expected call of FindByUser.
+func (mr *MockWaitlistServiceMockRecorder) FindByUser(ctx, ticketID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUser", reflect.TypeOf((*MockWaitlistService)(nil).FindByUser), ctx, ticketID, userID)
+}
+
+// Join mocks base method.
+func (m *MockWaitlistService) Join(ctx context.Context, ticketID int, req request.JoinWaitlistRequest) (*waitlist.EntryDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Join", ctx, ticketID, req)
+	ret0, _ := ret[0].(*waitlist.EntryDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Join indicates an expected call of Join.
+func (mr *MockWaitlistServiceMockRecorder) Join(ctx, ticketID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Join", reflect.TypeOf((*MockWaitlistService)(nil).Join), ctx, ticketID, req)
+}
+
+// Leave mocks base method.
+func (m *MockWaitlistService) Leave(ctx context.Context, ticketID int, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Leave", ctx, ticketID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Leave indicates an expected call of Leave.
+func (mr *MockWaitlistServiceMockRecorder) Leave(ctx, ticketID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Leave", reflect.TypeOf((*MockWaitlistService)(nil).Leave), ctx, ticketID, userID)
+}
+
+// Offer mocks base method.
+func (m *MockWaitlistService) Offer(ctx context.Context, ticketID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Offer", ctx, ticketID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Offer indicates an expected call of Offer.
+func (mr *MockWaitlistServiceMockRecorder) Offer(ctx, ticketID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Offer", reflect.TypeOf((*MockWaitlistService)(nil).Offer), ctx, ticketID)
+}
+
+// OfferAll mocks base method.
+func (m *MockWaitlistService) OfferAll(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OfferAll", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OfferAll indicates an expected call of OfferAll.
+func (mr *MockWaitlistServiceMockRecorder) OfferAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OfferAll", reflect.TypeOf((*MockWaitlistService)(nil).OfferAll), ctx)
+}
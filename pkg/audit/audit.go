@@ -0,0 +1,31 @@
+// Package audit carries who made a change and in which request through the
+// context, so that audit records can be written far from the handler.
+package audit
+
+import "context"
+
+type actorKey struct{}
+
+type requestIDKey struct{}
+
+func WithActor(ctx context.Context, actorID string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actorID)
+}
+
+// Actor returns the ID of the user the change is made for, or "" when it is
+// not known.
+func Actor(ctx context.Context) string {
+	actorID, _ := ctx.Value(actorKey{}).(string)
+	return actorID
+}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns the ID of the request the change is made in, or "" for
+// background work.
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
@@ -3,6 +3,7 @@ package env
 import (
 	"log"
 	"sync"
+	"time"
 
 	"github.com/caarlos0/env"
 	"github.com/joho/godotenv"
@@ -16,6 +17,39 @@ type ENV struct {
 	PostgresPassword string `env:"POSTGRES_PASSWORD,required"`
 	Host             string `env:"HOST,required"`
 	Port             string `env:"PORT,required"`
+
+	Storage string `env:"STORAGE" envDefault:"postgres"`
+
+	IdempotencyStore  string        `env:"IDEMPOTENCY_STORE" envDefault:"postgres"`
+	IdempotencyKeyTTL time.Duration `env:"IDEMPOTENCY_KEY_TTL" envDefault:"24h"`
+
+	ReservationTTL           time.Duration `env:"RESERVATION_TTL" envDefault:"10m"`
+	ReservationSweepInterval time.Duration `env:"RESERVATION_SWEEP_INTERVAL" envDefault:"30s"`
+
+	WaitlistOfferTTL      time.Duration `env:"WAITLIST_OFFER_TTL" envDefault:"15m"`
+	WaitlistSweepInterval time.Duration `env:"WAITLIST_SWEEP_INTERVAL" envDefault:"30s"`
+
+	PurchaseLocking string `env:"PURCHASE_LOCKING" envDefault:"pessimistic"`
+
+	TxIsolationLevel string        `env:"TX_ISOLATION_LEVEL" envDefault:"read committed"`
+	TxMaxAttempts    int           `env:"TX_MAX_ATTEMPTS" envDefault:"3"`
+	TxRetryBaseDelay time.Duration `env:"TX_RETRY_BASE_DELAY" envDefault:"20ms"`
+	TxRetryMaxDelay  time.Duration `env:"TX_RETRY_MAX_DELAY" envDefault:"500ms"`
+
+	OutboxPublisher      string        `env:"OUTBOX_PUBLISHER" envDefault:"log"`
+	OutboxWebhookURL     string        `env:"OUTBOX_WEBHOOK_URL"`
+	OutboxFilePath       string        `env:"OUTBOX_FILE_PATH" envDefault:"outbox.jsonl"`
+	OutboxPollInterval   time.Duration `env:"OUTBOX_POLL_INTERVAL" envDefault:"1s"`
+	OutboxBatchSize      int           `env:"OUTBOX_BATCH_SIZE" envDefault:"100"`
+	OutboxRetryBaseDelay time.Duration `env:"OUTBOX_RETRY_BASE_DELAY" envDefault:"1s"`
+	OutboxRetryMaxDelay  time.Duration `env:"OUTBOX_RETRY_MAX_DELAY" envDefault:"10m"`
+
+	WebhookTimeout        time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"10s"`
+	WebhookPollInterval   time.Duration `env:"WEBHOOK_POLL_INTERVAL" envDefault:"1s"`
+	WebhookBatchSize      int           `env:"WEBHOOK_BATCH_SIZE" envDefault:"50"`
+	WebhookMaxAttempts    int           `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"8"`
+	WebhookRetryBaseDelay time.Duration `env:"WEBHOOK_RETRY_BASE_DELAY" envDefault:"10s"`
+	WebhookRetryMaxDelay  time.Duration `env:"WEBHOOK_RETRY_MAX_DELAY" envDefault:"1h"`
 }
 
 var doOnce sync.Once
@@ -0,0 +1,107 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Event is something that happened in the domain. Events are plain values,
+// subscribers receive them by their concrete type.
+type Event interface {
+	EventName() string
+	OccurredAt() time.Time
+}
+
+type Handler func(ctx context.Context, e Event) error
+
+// Publisher is what the services publish their events to. Services publish
+// inside their unit of work, an error rolls the work back.
+type Publisher interface {
+	Publish(ctx context.Context, events ...Event) error
+}
+
+type chain []Publisher
+
+// Chain publishes to every publisher in order and stops at the first error.
+func Chain(publishers ...Publisher) Publisher {
+	return chain(publishers)
+}
+
+func (c chain) Publish(ctx context.Context, events ...Event) error {
+	for _, p := range c {
+		if err := p.Publish(ctx, events...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Bus dispatches events to the subscribers of their type in the calling
+// goroutine, in the order the events and subscribers were added. Events are
+// published after the change that raised them was committed, so a failing
+// subscriber is logged and does not stop the others.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[reflect.Type][]Handler
+	all      []Handler
+}
+
+func New() *Bus {
+	return &Bus{handlers: make(map[reflect.Type][]Handler)}
+}
+
+// Subscribe registers handler for every published event of type E.
+func Subscribe[E Event](b *Bus, handler func(ctx context.Context, e E) error) {
+	eventType := reflect.TypeFor[E]()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[eventType] = append(b.handlers[eventType], func(ctx context.Context, e Event) error {
+		return handler(ctx, e.(E))
+	})
+}
+
+// SubscribeAll registers handler for every published event, whatever its
+// type.
+func (b *Bus) SubscribeAll(handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.all = append(b.all, handler)
+}
+
+// Publish dispatches events right away and never fails, subscriber errors
+// are only logged. Wrap the bus with db.PublishAfterCommit so that
+// subscribers only see committed changes.
+func (b *Bus) Publish(ctx context.Context, events ...Event) error {
+	for _, e := range events {
+		b.mu.RLock()
+		handlers := append(b.handlers[reflect.TypeOf(e)][:0:0], b.handlers[reflect.TypeOf(e)]...)
+		handlers = append(handlers, b.all...)
+		b.mu.RUnlock()
+
+		for _, handler := range handlers {
+			if err := dispatch(ctx, handler, e); err != nil {
+				log.Printf("event %s: %s", e.EventName(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func dispatch(ctx context.Context, handler Handler, e Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("subscriber panicked: %v", r)
+		}
+	}()
+
+	return handler(ctx, e)
+}
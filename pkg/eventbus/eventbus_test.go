@@ -0,0 +1,121 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type created struct {
+	ID int
+}
+
+func (e created) EventName() string     { return "created" }
+func (e created) OccurredAt() time.Time { return time.Time{} }
+
+type deleted struct {
+	ID int
+}
+
+func (e deleted) EventName() string     { return "deleted" }
+func (e deleted) OccurredAt() time.Time { return time.Time{} }
+
+func TestBus_Publish(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should dispatch events to subscribers of their type in order", func(t *testing.T) {
+		bus := New()
+
+		var got []string
+		Subscribe(bus, func(ctx context.Context, e created) error {
+			got = append(got, "first created")
+			return nil
+		})
+		Subscribe(bus, func(ctx context.Context, e created) error {
+			got = append(got, "second created")
+			return nil
+		})
+		Subscribe(bus, func(ctx context.Context, e deleted) error {
+			got = append(got, "deleted")
+			return nil
+		})
+
+		bus.Publish(ctx, created{ID: 1}, deleted{ID: 1})
+		assert.Equal(t, []string{"first created", "second created", "deleted"}, got)
+	})
+
+	t.Run("should dispatch every event to subscribers of all events", func(t *testing.T) {
+		bus := New()
+
+		var got []string
+		bus.SubscribeAll(func(ctx context.Context, e Event) error {
+			got = append(got, e.EventName())
+			return nil
+		})
+
+		bus.Publish(ctx, created{ID: 1}, deleted{ID: 1})
+		assert.Equal(t, []string{"created", "deleted"}, got)
+	})
+
+	t.Run("should keep dispatching when a subscriber fails or panics", func(t *testing.T) {
+		bus := New()
+
+		var got []int
+		Subscribe(bus, func(ctx context.Context, e created) error {
+			return errors.New("failed")
+		})
+		Subscribe(bus, func(ctx context.Context, e created) error {
+			panic("boom")
+		})
+		Subscribe(bus, func(ctx context.Context, e created) error {
+			got = append(got, e.ID)
+			return nil
+		})
+
+		bus.Publish(ctx, created{ID: 1}, created{ID: 2})
+		assert.Equal(t, []int{1, 2}, got)
+	})
+
+	t.Run("should ignore events without subscribers", func(t *testing.T) {
+		bus := New()
+		assert.NotPanics(t, func() {
+			bus.Publish(ctx, created{ID: 1})
+		})
+	})
+}
+
+type recording struct {
+	events []Event
+	err    error
+}
+
+func (r *recording) Publish(ctx context.Context, events ...Event) error {
+	r.events = append(r.events, events...)
+	return r.err
+}
+
+func TestChain(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should publish to every publisher in order", func(t *testing.T) {
+		first, second := &recording{}, &recording{}
+
+		err := Chain(first, second).Publish(ctx, created{ID: 1}, deleted{ID: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, []Event{created{ID: 1}, deleted{ID: 1}}, first.events)
+		assert.Equal(t, []Event{created{ID: 1}, deleted{ID: 1}}, second.events)
+	})
+
+	t.Run("should stop at the first error", func(t *testing.T) {
+		boom := errors.New("boom")
+		first, second := &recording{err: boom}, &recording{}
+
+		err := Chain(first, second).Publish(ctx, created{ID: 1})
+		assert.ErrorIs(t, err, boom)
+		assert.Len(t, first.events, 1)
+		assert.Empty(t, second.events)
+	})
+}
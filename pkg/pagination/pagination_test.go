@@ -0,0 +1,51 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	t.Run("should round trip offset", func(t *testing.T) {
+		offset, err := DecodeCursor(EncodeCursor(40))
+		assert.NoError(t, err)
+		assert.Equal(t, 40, offset)
+	})
+
+	t.Run("should return zero offset for empty cursor", func(t *testing.T) {
+		offset, err := DecodeCursor("")
+		assert.NoError(t, err)
+		assert.Equal(t, 0, offset)
+	})
+
+	t.Run("should return error for malformed cursor", func(t *testing.T) {
+		_, err := DecodeCursor("not-a-cursor!")
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("should return error for negative offset", func(t *testing.T) {
+		_, err := DecodeCursor(EncodeCursor(-1))
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
+
+func TestNextCursor(t *testing.T) {
+	tests := []struct {
+		name   string
+		offset int
+		limit  int
+		total  int64
+		want   string
+	}{
+		{name: "more pages", offset: 0, limit: 10, total: 25, want: EncodeCursor(10)},
+		{name: "last page", offset: 20, limit: 10, total: 25, want: ""},
+		{name: "exact end", offset: 10, limit: 10, total: 20, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NextCursor(tt.offset, tt.limit, tt.total))
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/event"
+	"github.com/aaydin-tr/ddd-api-example/domain/event/repository"
+	ticketRepository "github.com/aaydin-tr/ddd-api-example/domain/ticket/repository"
+	venueRepository "github.com/aaydin-tr/ddd-api-example/domain/venue/repository"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	"github.com/aaydin-tr/ddd-api-example/pkg/pagination"
+)
+
+//go:generate mockgen -destination=../../mock/service/event/event.go -package=service github.com/aaydin-tr/ddd-api-example/service/event EventService
+type EventService interface {
+	Create(ctx context.Context, req request.CreateEventRequest) (*event.EventDTO, error)
+	FindByID(ctx context.Context, id int) (*event.EventDTO, error)
+	List(ctx context.Context, req request.ListEventsRequest) (*event.EventListDTO, error)
+	Update(ctx context.Context, id int, req request.UpdateEventRequest) (*event.EventDTO, error)
+	Delete(ctx context.Context, id int) error
+}
+
+type Service struct {
+	uow        db.UnitOfWork
+	repo       repository.EventRepository
+	venueRepo  venueRepository.VenueRepository
+	ticketRepo ticketRepository.TicketRepository
+}
+
+func NewEventService(uow db.UnitOfWork, repo repository.EventRepository, venueRepo venueRepository.VenueRepository, ticketRepo ticketRepository.TicketRepository) EventService {
+	return &Service{uow: uow, repo: repo, venueRepo: venueRepo, ticketRepo: ticketRepo}
+}
+
+func (s *Service) Create(ctx context.Context, req request.CreateEventRequest) (*event.EventDTO, error) {
+	e, err := event.NewEvent(req.VenueID, req.Name, req.Description, req.StartsAt, req.EndsAt, req.Capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := s.venueRepo.FindByID(ctx, req.VenueID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, e); err != nil {
+		return nil, err
+	}
+
+	return event.NewEventDTOFromEntity(e, v.Location()), nil
+}
+
+func (s *Service) FindByID(ctx context.Context, id int) (*event.EventDTO, error) {
+	e, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.dto(ctx, e)
+}
+
+func (s *Service) List(ctx context.Context, req request.ListEventsRequest) (*event.EventListDTO, error) {
+	offset, err := pagination.DecodeCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = pagination.DefaultLimit
+	}
+
+	events, total, err := s.repo.List(ctx, event.ListFilter{
+		VenueID: req.VenueID,
+		From:    req.From,
+		To:      req.To,
+		Offset:  offset,
+		Limit:   limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make(map[int]*time.Location)
+	for _, e := range events {
+		if _, ok := locations[e.VenueID]; ok {
+			continue
+		}
+
+		v, err := s.venueRepo.FindByID(ctx, e.VenueID)
+		if err != nil {
+			return nil, err
+		}
+
+		locations[e.VenueID] = v.Location()
+	}
+
+	return event.NewEventListDTOFromEntities(events, locations, total, pagination.NextCursor(offset, limit, total)), nil
+}
+
+// Update locks the event, so that tickets cannot allocate more units while
+// a lower capacity is checked.
+func (s *Service) Update(ctx context.Context, id int, req request.UpdateEventRequest) (*event.EventDTO, error) {
+	var e *event.Event
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		e, err = s.repo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if req.Name != nil {
+			if err := e.Rename(*req.Name); err != nil {
+				return err
+			}
+		}
+
+		if req.Description != nil {
+			if err := e.ChangeDescription(*req.Description); err != nil {
+				return err
+			}
+		}
+
+		if req.StartsAt != nil || req.EndsAt != nil {
+			start, end := e.Dates.GetStart(), e.Dates.GetEnd()
+			if req.StartsAt != nil {
+				start = *req.StartsAt
+			}
+
+			if req.EndsAt != nil {
+				end = *req.EndsAt
+			}
+
+			if err := e.Reschedule(start, end); err != nil {
+				return err
+			}
+		}
+
+		if req.Capacity != nil {
+			allocated, err := s.ticketRepo.EventAllocation(ctx, e.ID)
+			if err != nil {
+				return err
+			}
+
+			if err := e.ChangeCapacity(*req.Capacity, allocated); err != nil {
+				return err
+			}
+		}
+
+		return s.repo.Update(ctx, e)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.dto(ctx, e)
+}
+
+// Delete only deletes events that never had tickets, deleted tickets still
+// belong to their event.
+func (s *Service) Delete(ctx context.Context, id int) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.repo.FindByIDForUpdate(ctx, id); err != nil {
+			return err
+		}
+
+		tickets, err := s.ticketRepo.CountByEvent(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if tickets > 0 {
+			return event.ErrEventHasTickets
+		}
+
+		return s.repo.Delete(ctx, id)
+	})
+}
+
+func (s *Service) dto(ctx context.Context, e *event.Event) (*event.EventDTO, error) {
+	v, err := s.venueRepo.FindByID(ctx, e.VenueID)
+	if err != nil {
+		return nil, err
+	}
+
+	return event.NewEventDTOFromEntity(e, v.Location()), nil
+}
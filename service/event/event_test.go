@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/event"
+	"github.com/aaydin-tr/ddd-api-example/domain/event/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	ticketRepository "github.com/aaydin-tr/ddd-api-example/domain/ticket/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/venue"
+	venueRepository "github.com/aaydin-tr/ddd-api-example/domain/venue/repository"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+	"github.com/stretchr/testify/assert"
+)
+
+var start = time.Date(2026, 11, 1, 19, 0, 0, 0, time.UTC)
+
+// newService returns an event service with one venue in Europe/Istanbul.
+func newService(t *testing.T) (EventService, ticketRepository.TicketRepository, *venue.Venue) {
+	t.Helper()
+
+	venues := venueRepository.NewMemoryVenueRepository()
+	v, err := venue.NewVenue("Arena", "Istiklal Cd. 1", "Istanbul", "34433", "TR", "Europe/Istanbul")
+	assert.NoError(t, err)
+	assert.NoError(t, venues.Create(context.Background(), v))
+
+	tickets := ticketRepository.NewMemoryTicketRepository()
+	return NewEventService(db.NewMemoryUnitOfWork(), repository.NewMemoryEventRepository(), venues, tickets), tickets, v
+}
+
+func newEventRequest(venueID int, name string, startsAt time.Time) request.CreateEventRequest {
+	return request.CreateEventRequest{
+		VenueID:     venueID,
+		Name:        name,
+		Description: "Test Description",
+		StartsAt:    startsAt,
+		EndsAt:      startsAt.Add(3 * time.Hour),
+		Capacity:    100,
+	}
+}
+
+func TestService_Create(t *testing.T) {
+	service, _, v := newService(t)
+
+	t.Run("success", func(t *testing.T) {
+		got, err := service.Create(context.Background(), newEventRequest(v.ID, "Concert", start))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, got.ID)
+		assert.Equal(t, "Europe/Istanbul", got.Timezone)
+		assert.Equal(t, 22, got.StartsAt.Hour())
+		assert.True(t, start.Equal(got.StartsAt))
+		assert.Equal(t, 100, got.Capacity)
+	})
+
+	t.Run("unknown venue error", func(t *testing.T) {
+		_, err := service.Create(context.Background(), newEventRequest(999, "Concert", start))
+		assert.ErrorIs(t, err, venue.ErrVenueNotFound)
+	})
+
+	t.Run("invalid dates error", func(t *testing.T) {
+		req := newEventRequest(v.ID, "Concert", start)
+		req.EndsAt = start
+		_, err := service.Create(context.Background(), req)
+		assert.ErrorIs(t, err, valueobject.ErrInvalidDateRange)
+	})
+}
+
+func TestService_List(t *testing.T) {
+	ctx := context.Background()
+	service, _, v := newService(t)
+	for i, name := range []string{"Opening", "Concert", "Closing"} {
+		_, err := service.Create(ctx, newEventRequest(v.ID, name, start.AddDate(0, 0, 7*i)))
+		assert.NoError(t, err)
+	}
+
+	t.Run("overlapping the range", func(t *testing.T) {
+		from, to := start.Add(time.Hour), start.AddDate(0, 0, 7)
+		got, err := service.List(ctx, request.ListEventsRequest{From: &from, To: &to})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), got.Total)
+		assert.Equal(t, "Opening", got.Items[0].Name)
+		assert.Equal(t, "Europe/Istanbul", got.Items[0].Timezone)
+	})
+
+	t.Run("by venue", func(t *testing.T) {
+		other := v.ID + 1
+		got, err := service.List(ctx, request.ListEventsRequest{VenueID: &other})
+		assert.NoError(t, err)
+		assert.Empty(t, got.Items)
+
+		got, err = service.List(ctx, request.ListEventsRequest{VenueID: &v.ID, Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), got.Total)
+		assert.Equal(t, []string{"Opening", "Concert"}, []string{got.Items[0].Name, got.Items[1].Name})
+		assert.NotEmpty(t, got.NextCursor)
+	})
+}
+
+func TestService_Update(t *testing.T) {
+	ctx := context.Background()
+	service, tickets, v := newService(t)
+	created, err := service.Create(ctx, newEventRequest(v.ID, "Concert", start))
+	assert.NoError(t, err)
+
+	tk, _ := ticket.NewTicket("Test Ticket", "Test Description", 60, 1500, "EUR", time.Now())
+	tk.EventID = &created.ID
+	assert.NoError(t, tickets.Create(ctx, tk))
+
+	t.Run("success", func(t *testing.T) {
+		name, capacity, endsAt := "Late Concert", 60, start.Add(5*time.Hour)
+		got, err := service.Update(ctx, created.ID, request.UpdateEventRequest{Name: &name, Capacity: &capacity, EndsAt: &endsAt})
+		assert.NoError(t, err)
+		assert.Equal(t, "Late Concert", got.Name)
+		assert.Equal(t, 60, got.Capacity)
+		assert.True(t, start.Equal(got.StartsAt))
+		assert.True(t, endsAt.Equal(got.EndsAt))
+	})
+
+	t.Run("capacity below allocated error", func(t *testing.T) {
+		capacity := 59
+		_, err := service.Update(ctx, created.ID, request.UpdateEventRequest{Capacity: &capacity})
+		assert.ErrorIs(t, err, event.ErrCapacityBelowAllocated)
+	})
+
+	t.Run("invalid dates error", func(t *testing.T) {
+		startsAt := start.Add(6 * time.Hour)
+		_, err := service.Update(ctx, created.ID, request.UpdateEventRequest{StartsAt: &startsAt})
+		assert.ErrorIs(t, err, valueobject.ErrInvalidDateRange)
+	})
+
+	t.Run("not found error", func(t *testing.T) {
+		name := "Concert"
+		_, err := service.Update(ctx, 999, request.UpdateEventRequest{Name: &name})
+		assert.ErrorIs(t, err, event.ErrEventNotFound)
+	})
+}
+
+func TestService_Delete(t *testing.T) {
+	ctx := context.Background()
+	service, tickets, v := newService(t)
+	withTicket, err := service.Create(ctx, newEventRequest(v.ID, "Concert", start))
+	assert.NoError(t, err)
+	empty, err := service.Create(ctx, newEventRequest(v.ID, "Rehearsal", start))
+	assert.NoError(t, err)
+
+	tk, _ := ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+	tk.EventID = &withTicket.ID
+	assert.NoError(t, tickets.Create(ctx, tk))
+	assert.NoError(t, tickets.Delete(ctx, tk))
+
+	assert.ErrorIs(t, service.Delete(ctx, withTicket.ID), event.ErrEventHasTickets, "deleted tickets still belong to the event")
+	assert.NoError(t, service.Delete(ctx, empty.ID))
+
+	_, err = service.FindByID(ctx, empty.ID)
+	assert.ErrorIs(t, err, event.ErrEventNotFound)
+}
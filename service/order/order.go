@@ -0,0 +1,333 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/order"
+	"github.com/aaydin-tr/ddd-api-example/domain/order/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/promotion"
+	promotionRepository "github.com/aaydin-tr/ddd-api-example/domain/promotion/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/purchase"
+	purchaseRepository "github.com/aaydin-tr/ddd-api-example/domain/purchase/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/seat"
+	seatRepository "github.com/aaydin-tr/ddd-api-example/domain/seat/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	ticketRepository "github.com/aaydin-tr/ddd-api-example/domain/ticket/repository"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	"github.com/aaydin-tr/ddd-api-example/pkg/audit"
+	"github.com/aaydin-tr/ddd-api-example/pkg/eventbus"
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+)
+
+//go:generate mockgen -destination=../../mock/service/order/order.go -package=service github.com/aaydin-tr/ddd-api-example/service/order OrderService
+type OrderService interface {
+	Create(ctx context.Context, req request.CreateOrderRequest) (*order.OrderDTO, error)
+	FindByID(ctx context.Context, id int) (*order.OrderDTO, error)
+}
+
+type Service struct {
+	uow           db.UnitOfWork
+	repo          repository.OrderRepository
+	ticketRepo    ticketRepository.TicketRepository
+	purchaseRepo  purchaseRepository.PurchaseRepository
+	seatRepo      seatRepository.SeatRepository
+	promotionRepo promotionRepository.PromotionRepository
+	events        eventbus.Publisher
+	now           func() time.Time
+}
+
+func NewOrderService(uow db.UnitOfWork, repo repository.OrderRepository, ticketRepo ticketRepository.TicketRepository, purchaseRepo purchaseRepository.PurchaseRepository, seatRepo seatRepository.SeatRepository, promotionRepo promotionRepository.PromotionRepository, events eventbus.Publisher) OrderService {
+	return &Service{uow: uow, repo: repo, ticketRepo: ticketRepo, purchaseRepo: purchaseRepo, seatRepo: seatRepo, promotionRepo: promotionRepo, events: events, now: time.Now}
+}
+
+// item is a line of an order that passed every check and is bought once
+// all lines did.
+type item struct {
+	ticket   *ticket.Ticket
+	purchase *purchase.Purchase
+	seats    []*seat.Seat
+}
+
+// Create buys all lines of the order in one unit of work or none of them.
+// The tickets are locked in the order of their IDs, so that orders sharing
+// tickets cannot deadlock. Every line is checked before anything is written
+// and all lines that fail are reported together in an order.CheckoutError.
+// A promotion code is redeemed once for the whole order, after the tickets
+// are locked.
+func (s *Service) Create(ctx context.Context, req request.CreateOrderRequest) (*order.OrderDTO, error) {
+	ctx = audit.WithActor(ctx, req.UserID)
+
+	var failed []*order.LineError
+	fail := func(i int, err error) {
+		failed = append(failed, &order.LineError{Line: i, TicketID: req.Lines[i].TicketID, Err: err})
+	}
+
+	positions := make([]int, 0, len(req.Lines))
+	seen := make(map[int]bool, len(req.Lines))
+	for i, line := range req.Lines {
+		if seen[line.TicketID] {
+			fail(i, order.ErrDuplicateTicket)
+			continue
+		}
+		seen[line.TicketID] = true
+		positions = append(positions, i)
+	}
+
+	if len(failed) > 0 {
+		return nil, &order.CheckoutError{Lines: failed}
+	}
+
+	sort.Slice(positions, func(a, b int) bool {
+		return req.Lines[positions[a]].TicketID < req.Lines[positions[b]].TicketID
+	})
+
+	var (
+		o     *order.Order
+		items []*item
+	)
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		failed = nil
+		items = make([]*item, len(req.Lines))
+		now := s.now()
+		for _, i := range positions {
+			line := req.Lines[i]
+			t, err := s.ticketRepo.FindByIDForUpdate(ctx, line.TicketID)
+			if errors.Is(err, ticket.ErrTicketNotFound) {
+				fail(i, err)
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			var available []*seat.Seat
+			if t.IsSeated() {
+				available, err = s.seatRepo.ListByTicket(ctx, t.ID)
+				if err != nil {
+					return err
+				}
+			}
+
+			var owned int
+			if t.HasPurchaseLimit() {
+				owned, err = s.purchaseRepo.OwnedQuantity(ctx, t.ID, req.UserID)
+				if err != nil {
+					return err
+				}
+			}
+
+			it, err := checkLine(ctx, t, line, req.UserID, available, owned, now)
+			if err != nil {
+				fail(i, err)
+				continue
+			}
+
+			items[i] = it
+		}
+
+		if len(failed) > 0 {
+			sort.Slice(failed, func(a, b int) bool { return failed[a].Line < failed[b].Line })
+			return &order.CheckoutError{Lines: failed}
+		}
+
+		var (
+			redemption *promotion.Redemption
+			err        error
+		)
+		if req.PromoCode != "" {
+			redemption, err = s.redeem(ctx, req.PromoCode, req.UserID, items, now)
+			if err != nil {
+				return err
+			}
+		}
+
+		lines := make([]*order.Line, 0, len(items))
+		for _, it := range items {
+			lines = append(lines, &order.Line{
+				TicketID:  it.ticket.ID,
+				Quantity:  it.purchase.Quantity,
+				UnitPrice: it.purchase.UnitPrice,
+				Discount:  it.purchase.Discount,
+				Total:     it.purchase.Total,
+			})
+		}
+
+		o, err = order.NewOrder(req.UserID, lines)
+		if err != nil {
+			return err
+		}
+
+		for _, i := range positions {
+			if err := s.buy(ctx, items[i]); err != nil {
+				return err
+			}
+
+			o.Lines[i].PurchaseID = items[i].purchase.ID
+		}
+
+		if err := s.repo.Create(ctx, o); err != nil {
+			return err
+		}
+
+		if redemption == nil {
+			return nil
+		}
+
+		redemption.OrderID = &o.ID
+		return s.promotionRepo.CreateRedemption(ctx, redemption)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dto := order.NewOrderDTOFromEntity(o)
+	for i, line := range dto.Lines {
+		line.SeatIDs = seat.IDs(items[i].seats)
+	}
+
+	return dto, nil
+}
+
+// redeem spreads the discount of the promotion code over the purchases of
+// the items it applies to.
+func (s *Service) redeem(ctx context.Context, code, userID string, items []*item, now time.Time) (*promotion.Redemption, error) {
+	promo, err := s.promotionRepo.FindByCodeForUpdate(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	redeemed, err := s.promotionRepo.RedemptionCount(ctx, promo.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ticketIDs := make([]int, 0, len(items))
+	totals := make([]*valueobject.Money, 0, len(items))
+	for _, it := range items {
+		ticketIDs = append(ticketIDs, it.ticket.ID)
+		totals = append(totals, it.purchase.Total)
+	}
+
+	discounts, err := promo.Allocate(ticketIDs, totals)
+	if err != nil {
+		return nil, err
+	}
+
+	var discount *valueobject.Money
+	for i, it := range items {
+		if err := it.purchase.ApplyDiscount(discounts[i]); err != nil {
+			return nil, err
+		}
+
+		if discount == nil {
+			discount = discounts[i]
+			continue
+		}
+
+		discount, err = discount.Add(discounts[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	redemption, err := promo.Redeem(userID, redeemed, discount, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.promotionRepo.Update(ctx, promo); err != nil {
+		return nil, err
+	}
+
+	return redemption, nil
+}
+
+// checkLine makes every change a line needs on its ticket, available are
+// the seats of a ticket with assigned seating and owned the units the user
+// bought of it so far.
+func checkLine(ctx context.Context, t *ticket.Ticket, line request.OrderLineRequest, userID string, available []*seat.Seat, owned int, now time.Time) (*item, error) {
+	quantity, err := seat.Quantity(line.Quantity, line.SeatIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var seats []*seat.Seat
+	if t.IsSeated() {
+		seats, err = seat.Select(available, line.SeatIDs, quantity)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := seat.CheckAvailable(seats); err != nil {
+			return nil, err
+		}
+	} else if len(line.SeatIDs) > 0 {
+		return nil, ticket.ErrNoSeatMap
+	}
+
+	if err := t.CheckPurchaseLimit(owned, quantity); err != nil {
+		return nil, err
+	}
+
+	if err := t.DecrementAllocation(ctx, quantity, now); err != nil {
+		return nil, err
+	}
+
+	p, err := purchase.NewPurchase(t.ID, userID, quantity, t.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item{ticket: t, purchase: p, seats: seats}, nil
+}
+
+// buy writes a checked line like Service.Purchase of the ticket service.
+func (s *Service) buy(ctx context.Context, it *item) error {
+	if err := s.ticketRepo.Update(ctx, it.ticket); err != nil {
+		return err
+	}
+
+	if err := s.events.Publish(ctx, it.ticket.PullEvents()...); err != nil {
+		return err
+	}
+
+	if err := s.purchaseRepo.Create(ctx, it.purchase); err != nil {
+		return err
+	}
+
+	for _, st := range it.seats {
+		if err := st.Sell(it.purchase.ID); err != nil {
+			return err
+		}
+
+		if err := s.seatRepo.Update(ctx, st); err != nil {
+			return err
+		}
+	}
+
+	return s.events.Publish(ctx, it.purchase.PullEvents()...)
+}
+
+func (s *Service) FindByID(ctx context.Context, id int) (*order.OrderDTO, error) {
+	o, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := order.NewOrderDTOFromEntity(o)
+	for i, line := range o.Lines {
+		seats, err := s.seatRepo.ListByPurchase(ctx, line.PurchaseID)
+		if err != nil {
+			return nil, err
+		}
+
+		dto.Lines[i].SeatIDs = seat.IDs(seats)
+	}
+
+	return dto, nil
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/order"
+	orderRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/order/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/promotion"
+	promotionRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/promotion/repository"
+	purchaseRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/purchase/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/seat"
+	seatRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/seat/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	ticketRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/ticket/repository"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	mockdb "github.com/aaydin-tr/ddd-api-example/mock/db"
+	orderRepository "github.com/aaydin-tr/ddd-api-example/mock/repository/order"
+	promotionRepository "github.com/aaydin-tr/ddd-api-example/mock/repository/promotion"
+	purchaseRepository "github.com/aaydin-tr/ddd-api-example/mock/repository/purchase"
+	seatRepository "github.com/aaydin-tr/ddd-api-example/mock/repository/seat"
+	ticketRepository "github.com/aaydin-tr/ddd-api-example/mock/repository/ticket"
+	"github.com/aaydin-tr/ddd-api-example/pkg/eventbus"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+const userID = "1250052d-c061-4a1f-81f0-d88af3dcb3d5"
+
+// runInTransaction stands in for the unit of work and runs fn directly.
+func runInTransaction(ctx context.Context, fn func(ctx context.Context) error, _ ...db.Option) error {
+	return fn(ctx)
+}
+
+func newTicket(allocation int) *ticket.Ticket {
+	tk, _ := ticket.NewTicket("Test Ticket", "Test Description", allocation, 1500, "EUR", time.Now())
+	tk.Status = ticket.StatusPublished
+	return tk
+}
+
+type fixture struct {
+	service    OrderService
+	tickets    ticketRepositoryImpl.TicketRepository
+	purchases  purchaseRepositoryImpl.PurchaseRepository
+	seats      seatRepositoryImpl.SeatRepository
+	promotions promotionRepositoryImpl.PromotionRepository
+}
+
+func newFixture(t *testing.T, allocations ...int) (*fixture, []*ticket.Ticket) {
+	f := &fixture{
+		tickets:    ticketRepositoryImpl.NewMemoryTicketRepository(),
+		purchases:  purchaseRepositoryImpl.NewMemoryPurchaseRepository(),
+		seats:      seatRepositoryImpl.NewMemorySeatRepository(),
+		promotions: promotionRepositoryImpl.NewMemoryPromotionRepository(),
+	}
+	f.service = NewOrderService(db.NewMemoryUnitOfWork(), orderRepositoryImpl.NewMemoryOrderRepository(), f.tickets, f.purchases, f.seats, f.promotions, eventbus.New())
+
+	var created []*ticket.Ticket
+	for _, allocation := range allocations {
+		tk := newTicket(allocation)
+		assert.NoError(t, f.tickets.Create(context.Background(), tk))
+		created = append(created, tk)
+	}
+
+	return f, created
+}
+
+func TestService_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should buy every line", func(t *testing.T) {
+		f, tickets := newFixture(t, 10, 5)
+		created, err := f.service.Create(ctx, request.CreateOrderRequest{UserID: userID, Lines: []request.OrderLineRequest{
+			{TicketID: tickets[1].ID, Quantity: 2},
+			{TicketID: tickets[0].ID, Quantity: 1},
+		}})
+		assert.NoError(t, err)
+		assert.Len(t, created.Lines, 2)
+		assert.Equal(t, tickets[1].ID, created.Lines[0].TicketID)
+		assert.Equal(t, 2, created.Lines[0].Quantity)
+		assert.Equal(t, int64(4500), created.Total.Amount)
+
+		adult, _ := f.tickets.FindByID(ctx, tickets[0].ID)
+		child, _ := f.tickets.FindByID(ctx, tickets[1].ID)
+		assert.Equal(t, 9, adult.Allocation.GetValue())
+		assert.Equal(t, 3, child.Allocation.GetValue())
+
+		owned, _ := f.purchases.OwnedQuantity(ctx, tickets[1].ID, userID)
+		assert.Equal(t, 2, owned)
+
+		found, err := f.service.FindByID(ctx, created.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, created.Lines[0].PurchaseID, found.Lines[0].PurchaseID)
+		assert.NotZero(t, found.Lines[1].PurchaseID)
+	})
+
+	t.Run("should buy nothing when a line fails and report every failed line", func(t *testing.T) {
+		f, tickets := newFixture(t, 10, 1)
+		limited := newTicket(10)
+		assert.NoError(t, limited.ChangeMaxPerUser(2))
+		assert.NoError(t, f.tickets.Create(ctx, limited))
+
+		_, err := f.service.Create(ctx, request.CreateOrderRequest{UserID: userID, Lines: []request.OrderLineRequest{
+			{TicketID: tickets[0].ID, Quantity: 2},
+			{TicketID: 999, Quantity: 1},
+			{TicketID: tickets[1].ID, Quantity: 2},
+			{TicketID: limited.ID, Quantity: 3},
+		}})
+
+		var checkoutErr *order.CheckoutError
+		assert.ErrorAs(t, err, &checkoutErr)
+		assert.Len(t, checkoutErr.Lines, 3)
+		assert.Equal(t, 1, checkoutErr.Lines[0].Line)
+		assert.ErrorIs(t, checkoutErr.Lines[0], ticket.ErrTicketNotFound)
+		assert.Equal(t, 2, checkoutErr.Lines[1].Line)
+		assert.ErrorIs(t, checkoutErr.Lines[1], ticket.ErrInsufficientAllocation)
+		assert.Equal(t, limited.ID, checkoutErr.Lines[2].TicketID)
+		var limitErr *ticket.PurchaseLimitError
+		assert.ErrorAs(t, checkoutErr.Lines[2], &limitErr)
+
+		found, _ := f.tickets.FindByID(ctx, tickets[0].ID)
+		assert.Equal(t, 10, found.Allocation.GetValue())
+		assert.Equal(t, 0, found.Sold)
+		owned, _ := f.purchases.OwnedQuantity(ctx, tickets[0].ID, userID)
+		assert.Zero(t, owned)
+	})
+
+	t.Run("should reject a ticket in two lines", func(t *testing.T) {
+		f, tickets := newFixture(t, 10)
+		_, err := f.service.Create(ctx, request.CreateOrderRequest{UserID: userID, Lines: []request.OrderLineRequest{
+			{TicketID: tickets[0].ID, Quantity: 1},
+			{TicketID: tickets[0].ID, Quantity: 1},
+		}})
+
+		var checkoutErr *order.CheckoutError
+		assert.ErrorAs(t, err, &checkoutErr)
+		assert.Len(t, checkoutErr.Lines, 1)
+		assert.Equal(t, 1, checkoutErr.Lines[0].Line)
+		assert.ErrorIs(t, err, order.ErrDuplicateTicket)
+	})
+
+	t.Run("should sell the seats of seated lines", func(t *testing.T) {
+		f, tickets := newFixture(t, 10)
+		seated := newTicket(1)
+		assert.NoError(t, seated.AssignSeats(4, time.Now()))
+		assert.NoError(t, f.tickets.Create(ctx, seated))
+		layout, _ := seat.NewSeatMap(seated.ID, []seat.Section{{Name: "Stalls", Rows: []seat.Row{{Name: "A", Seats: 4}}}})
+		assert.NoError(t, f.seats.CreateMany(ctx, layout))
+
+		created, err := f.service.Create(ctx, request.CreateOrderRequest{UserID: userID, Lines: []request.OrderLineRequest{
+			{TicketID: seated.ID, SeatIDs: []int{layout[0].ID}},
+			{TicketID: tickets[0].ID, Quantity: 1},
+		}})
+		assert.NoError(t, err)
+		assert.Equal(t, []int{layout[0].ID}, created.Lines[0].SeatIDs)
+		assert.Empty(t, created.Lines[1].SeatIDs)
+
+		_, err = f.service.Create(ctx, request.CreateOrderRequest{UserID: userID, Lines: []request.OrderLineRequest{
+			{TicketID: tickets[0].ID, Quantity: 1},
+			{TicketID: seated.ID, SeatIDs: []int{layout[0].ID, layout[1].ID}},
+		}})
+		assert.ErrorIs(t, err, seat.ErrSeatUnavailable)
+
+		found, _ := f.tickets.FindByID(ctx, tickets[0].ID)
+		assert.Equal(t, 9, found.Allocation.GetValue())
+		sold, _ := f.seats.ListByTicket(ctx, seated.ID)
+		assert.True(t, sold[1].IsAvailable())
+	})
+
+	t.Run("should spread an order-wide promotion over the lines", func(t *testing.T) {
+		f, tickets := newFixture(t, 10, 10)
+		promo, _ := promotion.NewPromotion("WELCOME", promotion.KindFixed, 1000, "EUR")
+		assert.NoError(t, f.promotions.Create(ctx, promo))
+
+		created, err := f.service.Create(ctx, request.CreateOrderRequest{UserID: userID, PromoCode: "welcome", Lines: []request.OrderLineRequest{
+			{TicketID: tickets[1].ID, Quantity: 2},
+			{TicketID: tickets[0].ID, Quantity: 1},
+		}})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1000), created.Discount.Amount)
+		assert.Equal(t, int64(3500), created.Total.Amount)
+		assert.Equal(t, int64(666), created.Lines[0].Discount.Amount)
+		assert.Equal(t, int64(2334), created.Lines[0].Total.Amount)
+		assert.Equal(t, int64(334), created.Lines[1].Discount.Amount)
+		assert.Equal(t, int64(1166), created.Lines[1].Total.Amount)
+
+		found, _ := f.promotions.FindByID(ctx, promo.ID)
+		assert.Equal(t, 1, found.Redemptions, "an order redeems a code once")
+	})
+
+	t.Run("should only discount the ticket of a ticket promotion", func(t *testing.T) {
+		f, tickets := newFixture(t, 10, 10)
+		promo, _ := promotion.NewPromotion("CHILD50", promotion.KindPercentage, 50, "")
+		promo.TicketID = &tickets[1].ID
+		assert.NoError(t, f.promotions.Create(ctx, promo))
+
+		created, err := f.service.Create(ctx, request.CreateOrderRequest{UserID: userID, PromoCode: "CHILD50", Lines: []request.OrderLineRequest{
+			{TicketID: tickets[0].ID, Quantity: 1},
+			{TicketID: tickets[1].ID, Quantity: 2},
+		}})
+		assert.NoError(t, err)
+		assert.Nil(t, created.Lines[0].Discount)
+		assert.Equal(t, int64(1500), created.Lines[1].Discount.Amount)
+		assert.Equal(t, int64(3000), created.Total.Amount)
+	})
+
+	t.Run("should buy nothing when the promotion cannot be redeemed", func(t *testing.T) {
+		f, tickets := newFixture(t, 10)
+		promo, _ := promotion.NewPromotion("OTHER", promotion.KindPercentage, 10, "")
+		other := 999
+		promo.TicketID = &other
+		assert.NoError(t, f.promotions.Create(ctx, promo))
+
+		_, err := f.service.Create(ctx, request.CreateOrderRequest{UserID: userID, PromoCode: "OTHER", Lines: []request.OrderLineRequest{
+			{TicketID: tickets[0].ID, Quantity: 1},
+		}})
+		assert.ErrorIs(t, err, promotion.ErrNotApplicable)
+
+		found, _ := f.tickets.FindByID(ctx, tickets[0].ID)
+		assert.Equal(t, 10, found.Allocation.GetValue())
+		owned, _ := f.purchases.OwnedQuantity(ctx, tickets[0].ID, userID)
+		assert.Zero(t, owned)
+	})
+}
+
+func TestService_CreateLocksInTicketOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUow := mockdb.NewMockUnitOfWork(ctrl)
+	mockTicketRepo := ticketRepository.NewMockTicketRepository(ctrl)
+	service := NewOrderService(mockUow, orderRepository.NewMockOrderRepository(ctrl), mockTicketRepo, purchaseRepository.NewMockPurchaseRepository(ctrl), seatRepository.NewMockSeatRepository(ctrl), promotionRepository.NewMockPromotionRepository(ctrl), eventbus.New())
+
+	mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+	gomock.InOrder(
+		mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 2).Return(nil, ticket.ErrTicketNotFound),
+		mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 5).Return(nil, ticket.ErrTicketNotFound),
+		mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 9).Return(nil, errors.New("connection lost")),
+	)
+
+	_, err := service.Create(context.Background(), request.CreateOrderRequest{UserID: userID, Lines: []request.OrderLineRequest{
+		{TicketID: 9, Quantity: 1},
+		{TicketID: 2, Quantity: 1},
+		{TicketID: 5, Quantity: 1},
+	}})
+	assert.EqualError(t, err, "connection lost")
+}
+
+func TestService_FindByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := orderRepository.NewMockOrderRepository(ctrl)
+	service := NewOrderService(mockdb.NewMockUnitOfWork(ctrl), mockRepo, ticketRepository.NewMockTicketRepository(ctrl), purchaseRepository.NewMockPurchaseRepository(ctrl), seatRepository.NewMockSeatRepository(ctrl), promotionRepository.NewMockPromotionRepository(ctrl), eventbus.New())
+
+	mockRepo.EXPECT().FindByID(gomock.Any(), 1).Return(nil, order.ErrOrderNotFound)
+	_, err := service.FindByID(context.Background(), 1)
+	assert.ErrorIs(t, err, order.ErrOrderNotFound)
+}
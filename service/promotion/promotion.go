@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/promotion"
+	"github.com/aaydin-tr/ddd-api-example/domain/promotion/repository"
+	ticketRepository "github.com/aaydin-tr/ddd-api-example/domain/ticket/repository"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	"github.com/aaydin-tr/ddd-api-example/pkg/pagination"
+)
+
+//go:generate mockgen -destination=../../mock/service/promotion/promotion.go -package=service github.com/aaydin-tr/ddd-api-example/service/promotion PromotionService
+type PromotionService interface {
+	Create(ctx context.Context, req request.CreatePromotionRequest) (*promotion.PromotionDTO, error)
+	FindByID(ctx context.Context, id int) (*promotion.PromotionDTO, error)
+	List(ctx context.Context, req request.ListPromotionsRequest) (*promotion.PromotionListDTO, error)
+	Update(ctx context.Context, id int, req request.UpdatePromotionRequest) (*promotion.PromotionDTO, error)
+	Delete(ctx context.Context, id int) error
+}
+
+type Service struct {
+	uow        db.UnitOfWork
+	repo       repository.PromotionRepository
+	ticketRepo ticketRepository.TicketRepository
+}
+
+func NewPromotionService(uow db.UnitOfWork, repo repository.PromotionRepository, ticketRepo ticketRepository.TicketRepository) PromotionService {
+	return &Service{uow: uow, repo: repo, ticketRepo: ticketRepo}
+}
+
+func (s *Service) Create(ctx context.Context, req request.CreatePromotionRequest) (*promotion.PromotionDTO, error) {
+	p, err := promotion.NewPromotion(req.Code, promotion.Kind(req.Kind), req.Value, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.ChangeMaxRedemptions(limit(req.MaxRedemptions)); err != nil {
+		return nil, err
+	}
+
+	if err := p.ChangeMaxPerUser(limit(req.MaxPerUser)); err != nil {
+		return nil, err
+	}
+
+	if err := p.ChangeValidity(req.StartsAt, req.EndsAt); err != nil {
+		return nil, err
+	}
+
+	if req.TicketID != nil {
+		if _, err := s.ticketRepo.FindByID(ctx, *req.TicketID); err != nil {
+			return nil, err
+		}
+
+		p.TicketID = req.TicketID
+	}
+
+	if err := s.repo.Create(ctx, p); err != nil {
+		return nil, err
+	}
+
+	return promotion.NewPromotionDTOFromEntity(p), nil
+}
+
+func (s *Service) FindByID(ctx context.Context, id int) (*promotion.PromotionDTO, error) {
+	p, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return promotion.NewPromotionDTOFromEntity(p), nil
+}
+
+func (s *Service) List(ctx context.Context, req request.ListPromotionsRequest) (*promotion.PromotionListDTO, error) {
+	offset, err := pagination.DecodeCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = pagination.DefaultLimit
+	}
+
+	promotions, total, err := s.repo.List(ctx, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return promotion.NewPromotionListDTOFromEntities(promotions, total, pagination.NextCursor(offset, limit, total)), nil
+}
+
+// Update locks the promotion, so that redemptions made at the same time are
+// not overwritten.
+func (s *Service) Update(ctx context.Context, id int, req request.UpdatePromotionRequest) (*promotion.PromotionDTO, error) {
+	var p *promotion.Promotion
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		p, err = s.repo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if req.Code != nil {
+			if err := p.ChangeCode(*req.Code); err != nil {
+				return err
+			}
+		}
+
+		if req.Kind != nil || req.Value != nil || req.Currency != nil {
+			kind, value, currency := p.Kind, p.Value, p.Currency
+			if req.Kind != nil {
+				kind = promotion.Kind(*req.Kind)
+			}
+
+			if req.Value != nil {
+				value = *req.Value
+			}
+
+			if req.Currency != nil {
+				currency = *req.Currency
+			}
+
+			if err := p.ChangeDiscount(kind, value, currency); err != nil {
+				return err
+			}
+		}
+
+		if req.TicketID != nil {
+			ticketID := limit(*req.TicketID)
+			if ticketID != nil {
+				if _, err := s.ticketRepo.FindByID(ctx, *ticketID); err != nil {
+					return err
+				}
+			}
+
+			p.TicketID = ticketID
+		}
+
+		if req.MaxRedemptions != nil {
+			if err := p.ChangeMaxRedemptions(limit(*req.MaxRedemptions)); err != nil {
+				return err
+			}
+		}
+
+		if req.MaxPerUser != nil {
+			if err := p.ChangeMaxPerUser(limit(*req.MaxPerUser)); err != nil {
+				return err
+			}
+		}
+
+		if req.StartsAt != nil || req.EndsAt != nil {
+			start, end := p.StartsAt, p.EndsAt
+			if req.StartsAt != nil {
+				start = req.StartsAt
+			}
+
+			if req.EndsAt != nil {
+				end = req.EndsAt
+			}
+
+			if err := p.ChangeValidity(start, end); err != nil {
+				return err
+			}
+		}
+
+		return s.repo.Update(ctx, p)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return promotion.NewPromotionDTOFromEntity(p), nil
+}
+
+// Delete only deletes promotions that were never redeemed.
+func (s *Service) Delete(ctx context.Context, id int) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		p, err := s.repo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := p.CheckDeletable(); err != nil {
+			return err
+		}
+
+		return s.repo.Delete(ctx, id)
+	})
+}
+
+// limit turns the 0 of a request into no limit.
+func limit(n int) *int {
+	if n == 0 {
+		return nil
+	}
+
+	return &n
+}
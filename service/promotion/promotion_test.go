@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/promotion"
+	"github.com/aaydin-tr/ddd-api-example/domain/promotion/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	ticketRepository "github.com/aaydin-tr/ddd-api-example/domain/ticket/repository"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	"github.com/aaydin-tr/ddd-api-example/pkg/pagination"
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+	"github.com/stretchr/testify/assert"
+)
+
+func newService(t *testing.T) (PromotionService, repository.PromotionRepository, *ticket.Ticket) {
+	promotions := repository.NewMemoryPromotionRepository()
+	tickets := ticketRepository.NewMemoryTicketRepository()
+	tk, _ := ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+	assert.NoError(t, tickets.Create(context.Background(), tk))
+
+	return NewPromotionService(db.NewMemoryUnitOfWork(), promotions, tickets), promotions, tk
+}
+
+func TestService_Create(t *testing.T) {
+	ctx := context.Background()
+	service, _, tk := newService(t)
+
+	t.Run("success", func(t *testing.T) {
+		end := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+		got, err := service.Create(ctx, request.CreatePromotionRequest{
+			Code:           "winter",
+			Kind:           "fixed",
+			Value:          500,
+			Currency:       "EUR",
+			TicketID:       &tk.ID,
+			MaxRedemptions: 100,
+			EndsAt:         &end,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "WINTER", got.Code)
+		assert.Equal(t, &tk.ID, got.TicketID)
+		assert.Equal(t, 100, *got.MaxRedemptions)
+		assert.Nil(t, got.MaxPerUser)
+	})
+
+	t.Run("code exists error", func(t *testing.T) {
+		_, err := service.Create(ctx, request.CreatePromotionRequest{Code: "Winter", Kind: "percentage", Value: 10})
+		assert.ErrorIs(t, err, promotion.ErrCodeExists)
+	})
+
+	t.Run("ticket not found error", func(t *testing.T) {
+		other := 999
+		_, err := service.Create(ctx, request.CreatePromotionRequest{Code: "OTHER", Kind: "percentage", Value: 10, TicketID: &other})
+		assert.ErrorIs(t, err, ticket.ErrTicketNotFound)
+	})
+
+	t.Run("invalid percentage error", func(t *testing.T) {
+		_, err := service.Create(ctx, request.CreatePromotionRequest{Code: "HALF", Kind: "percentage", Value: 150})
+		assert.ErrorIs(t, err, promotion.ErrInvalidPercentage)
+	})
+}
+
+func TestService_List(t *testing.T) {
+	ctx := context.Background()
+	service, _, _ := newService(t)
+	for _, code := range []string{"A", "B", "C"} {
+		_, err := service.Create(ctx, request.CreatePromotionRequest{Code: code, Kind: "percentage", Value: 10})
+		assert.NoError(t, err)
+	}
+
+	first, err := service.List(ctx, request.ListPromotionsRequest{Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), first.Total)
+	assert.Len(t, first.Items, 2)
+	assert.Equal(t, pagination.EncodeCursor(2), first.NextCursor)
+
+	second, err := service.List(ctx, request.ListPromotionsRequest{Limit: 2, Cursor: first.NextCursor})
+	assert.NoError(t, err)
+	assert.Len(t, second.Items, 1)
+	assert.Equal(t, "C", second.Items[0].Code)
+}
+
+func TestService_Update(t *testing.T) {
+	ctx := context.Background()
+	service, promotions, tk := newService(t)
+	created, err := service.Create(ctx, request.CreatePromotionRequest{Code: "SPRING", Kind: "percentage", Value: 10, TicketID: &tk.ID, MaxPerUser: 1})
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		kind, value, currency, zero := "fixed", int64(300), "EUR", 0
+		got, err := service.Update(ctx, created.ID, request.UpdatePromotionRequest{Kind: &kind, Value: &value, Currency: &currency, TicketID: &zero, MaxPerUser: &zero})
+		assert.NoError(t, err)
+		assert.Equal(t, promotion.KindFixed, got.Kind)
+		assert.Equal(t, int64(300), got.Value)
+		assert.Equal(t, "EUR", got.Currency)
+		assert.Nil(t, got.TicketID)
+		assert.Nil(t, got.MaxPerUser)
+		assert.Equal(t, "SPRING", got.Code)
+	})
+
+	t.Run("fixed discount without currency error", func(t *testing.T) {
+		percentage, fixed, value := "percentage", "fixed", int64(20)
+		got, err := service.Update(ctx, created.ID, request.UpdatePromotionRequest{Kind: &percentage, Value: &value})
+		assert.NoError(t, err)
+		assert.Empty(t, got.Currency)
+
+		_, err = service.Update(ctx, created.ID, request.UpdatePromotionRequest{Kind: &fixed})
+		assert.ErrorIs(t, err, valueobject.ErrInvalidCurrency)
+	})
+
+	t.Run("max below redemptions error", func(t *testing.T) {
+		p, _ := promotions.FindByID(ctx, created.ID)
+		p.Redemptions = 5
+		assert.NoError(t, promotions.Update(ctx, p))
+
+		limit := 4
+		_, err := service.Update(ctx, created.ID, request.UpdatePromotionRequest{MaxRedemptions: &limit})
+		assert.ErrorIs(t, err, promotion.ErrMaxBelowRedemptions)
+	})
+
+	t.Run("not found error", func(t *testing.T) {
+		_, err := service.Update(ctx, 999, request.UpdatePromotionRequest{})
+		assert.ErrorIs(t, err, promotion.ErrPromotionNotFound)
+	})
+}
+
+func TestService_Delete(t *testing.T) {
+	ctx := context.Background()
+	service, promotions, _ := newService(t)
+	unused, _ := service.Create(ctx, request.CreatePromotionRequest{Code: "UNUSED", Kind: "percentage", Value: 10})
+	redeemed, _ := service.Create(ctx, request.CreatePromotionRequest{Code: "REDEEMED", Kind: "percentage", Value: 10})
+
+	p, _ := promotions.FindByID(ctx, redeemed.ID)
+	p.Redemptions = 1
+	assert.NoError(t, promotions.Update(ctx, p))
+
+	assert.NoError(t, service.Delete(ctx, unused.ID))
+	_, err := service.FindByID(ctx, unused.ID)
+	assert.ErrorIs(t, err, promotion.ErrPromotionNotFound)
+
+	assert.ErrorIs(t, service.Delete(ctx, redeemed.ID), promotion.ErrPromotionRedeemed)
+}
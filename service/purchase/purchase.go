@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/purchase"
+	"github.com/aaydin-tr/ddd-api-example/domain/purchase/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/seat"
+	seatRepository "github.com/aaydin-tr/ddd-api-example/domain/seat/repository"
+	ticketRepository "github.com/aaydin-tr/ddd-api-example/domain/ticket/repository"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	"github.com/aaydin-tr/ddd-api-example/pkg/eventbus"
+)
+
+//go:generate mockgen -destination=../../mock/service/purchase/purchase.go -package=service github.com/aaydin-tr/ddd-api-example/service/purchase PurchaseService
+type PurchaseService interface {
+	Refund(ctx context.Context, id int, req request.RefundPurchaseRequest) (*purchase.RefundDTO, error)
+}
+
+type Service struct {
+	uow        db.UnitOfWork
+	repo       repository.PurchaseRepository
+	ticketRepo ticketRepository.TicketRepository
+	seatRepo   seatRepository.SeatRepository
+	events     eventbus.Publisher
+	now        func() time.Time
+}
+
+func NewPurchaseService(uow db.UnitOfWork, repo repository.PurchaseRepository, ticketRepo ticketRepository.TicketRepository, seatRepo seatRepository.SeatRepository, events eventbus.Publisher) PurchaseService {
+	return &Service{uow: uow, repo: repo, ticketRepo: ticketRepo, seatRepo: seatRepo, events: events, now: time.Now}
+}
+
+// Refund returns the refunded units to the ticket allocation. The purchase
+// and the ticket are locked in the same transaction so that concurrent
+// refunds of the same purchase can not exceed the purchased quantity.
+// Refunded seats become available again. A deleted ticket is not written
+// to, its purchases fail with ErrTicketNotFound until it is restored.
+func (s *Service) Refund(ctx context.Context, id int, req request.RefundPurchaseRequest) (*purchase.RefundDTO, error) {
+	var (
+		p      *purchase.Purchase
+		refund *purchase.Refund
+		seats  []*seat.Seat
+	)
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		p, err = s.repo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		sold, err := s.seatRepo.ListByPurchase(ctx, p.ID)
+		if err != nil {
+			return err
+		}
+
+		quantity := p.RefundableQuantity()
+		if req.Quantity != nil {
+			quantity = *req.Quantity
+		}
+
+		if len(sold) > 0 || len(req.SeatIDs) > 0 {
+			seats, quantity, err = refundedSeats(sold, req)
+			if err != nil {
+				return err
+			}
+		}
+
+		refund, err = p.Refund(quantity)
+		if err != nil {
+			return err
+		}
+
+		t, err := s.ticketRepo.FindByIDForUpdate(ctx, p.TicketID)
+		if err != nil {
+			return err
+		}
+
+		if err := t.ReturnAllocation(ctx, quantity, s.now()); err != nil {
+			return err
+		}
+
+		for _, st := range seats {
+			if err := st.Return(p.ID); err != nil {
+				return err
+			}
+
+			if err := s.seatRepo.Update(ctx, st); err != nil {
+				return err
+			}
+		}
+
+		if err := s.ticketRepo.Update(ctx, t); err != nil {
+			return err
+		}
+
+		if err := s.events.Publish(ctx, t.PullEvents()...); err != nil {
+			return err
+		}
+
+		if err := s.repo.Update(ctx, p); err != nil {
+			return err
+		}
+
+		if err := s.events.Publish(ctx, p.PullEvents()...); err != nil {
+			return err
+		}
+
+		return s.repo.CreateRefund(ctx, refund)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dto := purchase.NewRefundDTOFromEntity(refund, p)
+	dto.SeatIDs = seat.IDs(seats)
+	return dto, nil
+}
+
+// refundedSeats picks the seats of a purchase the refund is for out of the
+// seats sold with it. Without seat IDs all of them are refunded.
+func refundedSeats(sold []*seat.Seat, req request.RefundPurchaseRequest) ([]*seat.Seat, int, error) {
+	if len(req.SeatIDs) == 0 {
+		if req.Quantity != nil && *req.Quantity != len(sold) {
+			return nil, 0, seat.ErrSeatIDsRequired
+		}
+
+		return sold, len(sold), nil
+	}
+
+	var quantity int
+	if req.Quantity != nil {
+		quantity = *req.Quantity
+	}
+
+	quantity, err := seat.Quantity(quantity, req.SeatIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	seats, err := seat.Pick(sold, req.SeatIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return seats, quantity, nil
+}
@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/purchase"
+	purchaseRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/purchase/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/seat"
+	seatRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/seat/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	ticketRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/ticket/repository"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	mockdb "github.com/aaydin-tr/ddd-api-example/mock/db"
+	repository "github.com/aaydin-tr/ddd-api-example/mock/repository/purchase"
+	seatRepository "github.com/aaydin-tr/ddd-api-example/mock/repository/seat"
+	ticketRepository "github.com/aaydin-tr/ddd-api-example/mock/repository/ticket"
+	"github.com/aaydin-tr/ddd-api-example/pkg/eventbus"
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// runInTransaction stands in for the unit of work and runs fn directly.
+func runInTransaction(ctx context.Context, fn func(ctx context.Context) error, _ ...db.Option) error {
+	return fn(ctx)
+}
+
+func TestNewPurchaseService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewPurchaseService(mockdb.NewMockUnitOfWork(ctrl), repository.NewMockPurchaseRepository(ctrl), ticketRepository.NewMockTicketRepository(ctrl), seatRepository.NewMockSeatRepository(ctrl), eventbus.New())
+	assert.NotNil(t, service)
+}
+
+func TestService_Refund(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockPurchaseRepository(ctrl)
+	mockTicketRepo := ticketRepository.NewMockTicketRepository(ctrl)
+	mockUow := mockdb.NewMockUnitOfWork(ctrl)
+	// Purchases of tickets with general seating have no seats.
+	mockSeatRepo := seatRepository.NewMockSeatRepository(ctrl)
+	mockSeatRepo.EXPECT().ListByPurchase(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	service := NewPurchaseService(mockUow, mockRepo, mockTicketRepo, mockSeatRepo, eventbus.New())
+
+	newPurchase := func() *purchase.Purchase {
+		unitPrice, _ := valueobject.NewMoney(1000, "EUR")
+		p, _ := purchase.NewPurchase(1, "1250052d-c061-4a1f-81f0-d88af3dcb3d5", 4, unitPrice)
+		p.ID = 1
+		return p
+	}
+
+	newTicket := func() *ticket.Ticket {
+		tk, _ := ticket.NewTicket("Test Ticket", "Test Description", 10, 1000, "EUR", time.Now())
+		tk.ID = 1
+		tk.Status = ticket.StatusPublished
+		_ = tk.DecrementAllocation(context.Background(), 4, time.Now())
+		return tk
+	}
+
+	one := 1
+	five := 5
+
+	tests := []struct {
+		name         string
+		req          request.RefundPurchaseRequest
+		mock         func()
+		wantQuantity int
+		wantAmount   int64
+		wantStatus   string
+		wantErr      error
+	}{
+		{
+			name: "full refund",
+			req:  request.RefundPurchaseRequest{},
+			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newPurchase(), nil)
+				mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newTicket(), nil)
+				mockTicketRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, tk *ticket.Ticket) error {
+					assert.Equal(t, 10, tk.Allocation.GetValue())
+					assert.Equal(t, 0, tk.Sold)
+					return nil
+				})
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CreateRefund(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantQuantity: 4,
+			wantAmount:   4000,
+			wantStatus:   "refunded",
+		},
+		{
+			name: "partial refund",
+			req:  request.RefundPurchaseRequest{Quantity: &one},
+			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newPurchase(), nil)
+				mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newTicket(), nil)
+				mockTicketRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, tk *ticket.Ticket) error {
+					assert.Equal(t, 7, tk.Allocation.GetValue())
+					assert.Equal(t, 3, tk.Sold)
+					return nil
+				})
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CreateRefund(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantQuantity: 1,
+			wantAmount:   1000,
+			wantStatus:   "partially_refunded",
+		},
+		{
+			name: "purchase not found error",
+			req:  request.RefundPurchaseRequest{},
+			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(nil, purchase.ErrPurchaseNotFound)
+			},
+			wantErr: purchase.ErrPurchaseNotFound,
+		},
+		{
+			name: "already refunded error",
+			req:  request.RefundPurchaseRequest{},
+			mock: func() {
+				p := newPurchase()
+				_, _ = p.Refund(4)
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(p, nil)
+			},
+			wantErr: purchase.ErrPurchaseAlreadyRefunded,
+		},
+		{
+			name: "refund exceeds quantity error",
+			req:  request.RefundPurchaseRequest{Quantity: &five},
+			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newPurchase(), nil)
+			},
+			wantErr: purchase.ErrRefundExceedsQuantity,
+		},
+		{
+			name: "ticket not found error",
+			req:  request.RefundPurchaseRequest{},
+			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newPurchase(), nil)
+				mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(nil, ticket.ErrTicketNotFound)
+			},
+			wantErr: ticket.ErrTicketNotFound,
+		},
+		{
+			name: "archived ticket error",
+			req:  request.RefundPurchaseRequest{},
+			mock: func() {
+				tk := newTicket()
+				_ = tk.Archive(time.Now())
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newPurchase(), nil)
+				mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(tk, nil)
+			},
+			wantErr: ticket.ErrTicketArchived,
+		},
+		{
+			name: "create refund error",
+			req:  request.RefundPurchaseRequest{},
+			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newPurchase(), nil)
+				mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newTicket(), nil)
+				mockTicketRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CreateRefund(gomock.Any(), gomock.Any()).Return(errors.New("create error"))
+			},
+			wantErr: errors.New("create error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := service.Refund(context.Background(), 1, tt.req)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantQuantity, got.Quantity)
+			assert.Equal(t, tt.wantAmount, got.Amount.Amount)
+			assert.Equal(t, tt.wantStatus, got.Purchase.Status)
+		})
+	}
+}
+
+func TestService_RefundDeletedTicket(t *testing.T) {
+	ctx := context.Background()
+	purchases := purchaseRepositoryImpl.NewMemoryPurchaseRepository()
+	tickets := ticketRepositoryImpl.NewMemoryTicketRepository()
+	service := NewPurchaseService(db.NewMemoryUnitOfWork(), purchases, tickets, seatRepositoryImpl.NewMemorySeatRepository(), eventbus.New())
+
+	tk, _ := ticket.NewTicket("Test Ticket", "Test Description", 10, 1000, "EUR", time.Now())
+	assert.NoError(t, tk.Publish(time.Now()))
+	assert.NoError(t, tk.DecrementAllocation(ctx, 4, time.Now()))
+	assert.NoError(t, tickets.Create(ctx, tk))
+	p, _ := purchase.NewPurchase(tk.ID, "1250052d-c061-4a1f-81f0-d88af3dcb3d5", 4, tk.Price)
+	assert.NoError(t, purchases.Create(ctx, p))
+	assert.NoError(t, tickets.Delete(ctx, tk))
+
+	_, err := service.Refund(ctx, p.ID, request.RefundPurchaseRequest{})
+	assert.ErrorIs(t, err, ticket.ErrTicketNotFound)
+	found, err := purchases.FindByIDForUpdate(ctx, p.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, found.RefundableQuantity())
+
+	assert.NoError(t, tk.Restore())
+	assert.NoError(t, tickets.Restore(ctx, tk))
+	refund, err := service.Refund(ctx, p.ID, request.RefundPurchaseRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, refund.Quantity)
+
+	restored, err := tickets.FindByID(ctx, tk.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, restored.Allocation.GetValue())
+}
+
+func TestService_RefundSeats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockPurchaseRepository(ctrl)
+	mockTicketRepo := ticketRepository.NewMockTicketRepository(ctrl)
+	mockSeatRepo := seatRepository.NewMockSeatRepository(ctrl)
+	mockUow := mockdb.NewMockUnitOfWork(ctrl)
+	service := NewPurchaseService(mockUow, mockRepo, mockTicketRepo, mockSeatRepo, eventbus.New())
+
+	newPurchase := func() *purchase.Purchase {
+		unitPrice, _ := valueobject.NewMoney(1000, "EUR")
+		p, _ := purchase.NewPurchase(1, "1250052d-c061-4a1f-81f0-d88af3dcb3d5", 2, unitPrice)
+		p.ID = 1
+		return p
+	}
+
+	newTicket := func() *ticket.Ticket {
+		tk, _ := ticket.NewTicket("Test Ticket", "Test Description", 1, 1000, "EUR", time.Now())
+		tk.ID = 1
+		tk.Status = ticket.StatusPublished
+		_ = tk.AssignSeats(10, time.Now())
+		_ = tk.DecrementAllocation(context.Background(), 2, time.Now())
+		return tk
+	}
+
+	sold := func() []*seat.Seat {
+		purchaseID := 1
+		return []*seat.Seat{
+			{ID: 1, TicketID: 1, Status: seat.StatusSold, PurchaseID: &purchaseID},
+			{ID: 2, TicketID: 1, Status: seat.StatusSold, PurchaseID: &purchaseID},
+		}
+	}
+
+	one := 1
+
+	t.Run("should return the refunded seats", func(t *testing.T) {
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+		mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newPurchase(), nil)
+		mockSeatRepo.EXPECT().ListByPurchase(gomock.Any(), 1).Return(sold(), nil)
+		mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newTicket(), nil)
+		mockSeatRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *seat.Seat) error {
+			assert.Equal(t, 2, s.ID)
+			assert.True(t, s.IsAvailable())
+			assert.Nil(t, s.PurchaseID)
+			return nil
+		})
+		mockTicketRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, tk *ticket.Ticket) error {
+			assert.Equal(t, 9, tk.Allocation.GetValue())
+			assert.Equal(t, 1, tk.Sold)
+			return nil
+		})
+		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().CreateRefund(gomock.Any(), gomock.Any()).Return(nil)
+
+		got, err := service.Refund(context.Background(), 1, request.RefundPurchaseRequest{SeatIDs: []int{2}})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, got.Quantity)
+		assert.Equal(t, []int{2}, got.SeatIDs)
+	})
+
+	t.Run("should refund all seats without seat ids", func(t *testing.T) {
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+		mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newPurchase(), nil)
+		mockSeatRepo.EXPECT().ListByPurchase(gomock.Any(), 1).Return(sold(), nil)
+		mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newTicket(), nil)
+		mockSeatRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		mockTicketRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().CreateRefund(gomock.Any(), gomock.Any()).Return(nil)
+
+		got, err := service.Refund(context.Background(), 1, request.RefundPurchaseRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, got.Quantity)
+		assert.Equal(t, []int{1, 2}, got.SeatIDs)
+	})
+
+	t.Run("should require seat ids for a partial refund", func(t *testing.T) {
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+		mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newPurchase(), nil)
+		mockSeatRepo.EXPECT().ListByPurchase(gomock.Any(), 1).Return(sold(), nil)
+
+		_, err := service.Refund(context.Background(), 1, request.RefundPurchaseRequest{Quantity: &one})
+		assert.ErrorIs(t, err, seat.ErrSeatIDsRequired)
+	})
+
+	t.Run("should reject seats of another purchase", func(t *testing.T) {
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+		mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newPurchase(), nil)
+		mockSeatRepo.EXPECT().ListByPurchase(gomock.Any(), 1).Return(sold(), nil)
+
+		_, err := service.Refund(context.Background(), 1, request.RefundPurchaseRequest{SeatIDs: []int{3}})
+		assert.ErrorIs(t, err, seat.ErrSeatNotFound)
+	})
+}
@@ -0,0 +1,424 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/purchase"
+	purchaseRepository "github.com/aaydin-tr/ddd-api-example/domain/purchase/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/reservation"
+	"github.com/aaydin-tr/ddd-api-example/domain/reservation/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/seat"
+	seatRepository "github.com/aaydin-tr/ddd-api-example/domain/seat/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	ticketRepository "github.com/aaydin-tr/ddd-api-example/domain/ticket/repository"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	"github.com/aaydin-tr/ddd-api-example/pkg/audit"
+	"github.com/aaydin-tr/ddd-api-example/pkg/eventbus"
+)
+
+const releaseBatchSize = 100
+
+//go:generate mockgen -destination=../../mock/service/reservation/reservation.go -package=service github.com/aaydin-tr/ddd-api-example/service/reservation ReservationService
+type ReservationService interface {
+	Create(ctx context.Context, ticketID int, req request.CreateReservationRequest) (*reservation.ReservationDTO, error)
+	FindByID(ctx context.Context, id int) (*reservation.ReservationDTO, error)
+	Confirm(ctx context.Context, id int) (*reservation.ReservationDTO, error)
+	Cancel(ctx context.Context, id int) (*reservation.ReservationDTO, error)
+	ReleaseExpired(ctx context.Context) (int, error)
+}
+
+type Service struct {
+	uow          db.UnitOfWork
+	repo         repository.ReservationRepository
+	ticketRepo   ticketRepository.TicketRepository
+	purchaseRepo purchaseRepository.PurchaseRepository
+	seatRepo     seatRepository.SeatRepository
+	events       eventbus.Publisher
+	ttl          time.Duration
+	now          func() time.Time
+}
+
+func NewReservationService(uow db.UnitOfWork, repo repository.ReservationRepository, ticketRepo ticketRepository.TicketRepository, purchaseRepo purchaseRepository.PurchaseRepository, seatRepo seatRepository.SeatRepository, events eventbus.Publisher, ttl time.Duration) ReservationService {
+	return &Service{uow: uow, repo: repo, ticketRepo: ticketRepo, purchaseRepo: purchaseRepo, seatRepo: seatRepo, events: events, ttl: ttl, now: time.Now}
+}
+
+func (s *Service) Create(ctx context.Context, ticketID int, req request.CreateReservationRequest) (*reservation.ReservationDTO, error) {
+	ctx = audit.WithActor(ctx, req.UserID)
+	quantity, err := seat.Quantity(req.Quantity, req.SeatIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		r     *reservation.Reservation
+		seats []*seat.Seat
+	)
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		t, err := s.ticketRepo.FindByIDForUpdate(ctx, ticketID)
+		if err != nil {
+			return err
+		}
+
+		now := s.now()
+		r, err = reservation.NewReservation(t.ID, req.UserID, quantity, now, s.ttl)
+		if err != nil {
+			return err
+		}
+
+		seats, err = s.selectSeats(ctx, t, req.SeatIDs, quantity)
+		if err != nil {
+			return err
+		}
+
+		if err := s.checkPurchaseLimit(ctx, t, req.UserID, quantity, true); err != nil {
+			return err
+		}
+
+		if err := t.Hold(ctx, quantity, now); err != nil {
+			return err
+		}
+
+		if err := s.ticketRepo.Update(ctx, t); err != nil {
+			return err
+		}
+
+		if err := s.events.Publish(ctx, t.PullEvents()...); err != nil {
+			return err
+		}
+
+		if err := s.repo.Create(ctx, r); err != nil {
+			return err
+		}
+
+		for _, st := range seats {
+			if err := st.Hold(r.ID); err != nil {
+				return err
+			}
+
+			if err := s.seatRepo.Update(ctx, st); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dto := reservation.NewReservationDTOFromEntity(r)
+	dto.SeatIDs = seat.IDs(seats)
+	return dto, nil
+}
+
+// selectSeats returns the seats ids of t, or its best available seats when
+// no ids are given. Tickets with general seating have no seats.
+func (s *Service) selectSeats(ctx context.Context, t *ticket.Ticket, ids []int, quantity int) ([]*seat.Seat, error) {
+	if !t.IsSeated() {
+		if len(ids) > 0 {
+			return nil, ticket.ErrNoSeatMap
+		}
+
+		return nil, nil
+	}
+
+	seats, err := s.seatRepo.ListByTicket(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return seat.Select(seats, ids, quantity)
+}
+
+func (s *Service) FindByID(ctx context.Context, id int) (*reservation.ReservationDTO, error) {
+	r, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	seats, err := s.seatRepo.ListByReservation(ctx, r.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := reservation.NewReservationDTOFromEntity(r)
+	dto.SeatIDs = seat.IDs(seats)
+	return dto, nil
+}
+
+// Confirm turns an active reservation into a purchase. A reservation that is
+// found expired is released right away and ErrReservationExpired is returned.
+func (s *Service) Confirm(ctx context.Context, id int) (*reservation.ReservationDTO, error) {
+	var (
+		r       *reservation.Reservation
+		seats   []*seat.Seat
+		expired bool
+	)
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		r, err = s.repo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		t, err := s.ticketRepo.FindByIDForUpdate(ctx, r.TicketID)
+		if err != nil {
+			return err
+		}
+
+		now := s.now()
+		err = r.Confirm(now)
+		if errors.Is(err, reservation.ErrReservationExpired) {
+			// The release has to be committed, so the error is only
+			// reported once the unit of work is done.
+			expired = true
+			return s.release(ctx, r, t, now)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := s.checkPurchaseLimit(ctx, t, r.UserID, r.Quantity, false); err != nil {
+			return err
+		}
+
+		if err := t.ConfirmHold(ctx, r.Quantity); err != nil {
+			return err
+		}
+
+		p, err := purchase.NewPurchase(t.ID, r.UserID, r.Quantity, t.Price)
+		if err != nil {
+			return err
+		}
+
+		if err := s.ticketRepo.Update(ctx, t); err != nil {
+			return err
+		}
+
+		if err := s.events.Publish(ctx, t.PullEvents()...); err != nil {
+			return err
+		}
+
+		if err := s.purchaseRepo.Create(ctx, p); err != nil {
+			return err
+		}
+
+		if err := s.events.Publish(ctx, p.PullEvents()...); err != nil {
+			return err
+		}
+
+		if t.IsSeated() {
+			seats, err = s.seatRepo.ListByReservation(ctx, r.ID)
+			if err != nil {
+				return err
+			}
+
+			for _, st := range seats {
+				if err := st.ConfirmHold(r.ID, p.ID); err != nil {
+					return err
+				}
+
+				if err := s.seatRepo.Update(ctx, st); err != nil {
+					return err
+				}
+			}
+		}
+
+		r.LinkPurchase(p.ID)
+		return s.repo.Update(ctx, r)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if expired {
+		return nil, reservation.ErrReservationExpired
+	}
+
+	dto := reservation.NewReservationDTOFromEntity(r)
+	dto.SeatIDs = seat.IDs(seats)
+	return dto, nil
+}
+
+// checkPurchaseLimit counts the units a user bought and, when a new hold is
+// made, the units the user holds. A confirmed reservation is counted again
+// only against the purchases, as purchases made since the hold may have
+// used up the limit.
+func (s *Service) checkPurchaseLimit(ctx context.Context, t *ticket.Ticket, userID string, quantity int, withHolds bool) error {
+	if !t.HasPurchaseLimit() {
+		return nil
+	}
+
+	owned, err := s.purchaseRepo.OwnedQuantity(ctx, t.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	if withHolds {
+		held, err := s.repo.HeldQuantity(ctx, t.ID, userID, s.now())
+		if err != nil {
+			return err
+		}
+		owned += held
+	}
+
+	return t.CheckPurchaseLimit(owned, quantity)
+}
+
+func (s *Service) Cancel(ctx context.Context, id int) (*reservation.ReservationDTO, error) {
+	var r *reservation.Reservation
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		r, err = s.repo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		t, err := s.ticketRepo.FindByIDForUpdate(ctx, r.TicketID)
+		if err != nil {
+			return err
+		}
+
+		if err := r.Cancel(); err != nil {
+			return err
+		}
+
+		if err := t.ReleaseHold(ctx, r.Quantity, s.now()); err != nil {
+			return err
+		}
+
+		if err := s.releaseSeats(ctx, r, t); err != nil {
+			return err
+		}
+
+		if err := s.ticketRepo.Update(ctx, t); err != nil {
+			return err
+		}
+
+		if err := s.events.Publish(ctx, t.PullEvents()...); err != nil {
+			return err
+		}
+
+		return s.repo.Update(ctx, r)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reservation.NewReservationDTOFromEntity(r), nil
+}
+
+// ReleaseExpired expires a batch of overdue reservations and returns their
+// units to the ticket allocation. Every reservation is released in its own
+// nested unit of work, one that fails is logged and left for the next run
+// so that it does not hold up the others. It returns the number of released
+// holds.
+func (s *Service) ReleaseExpired(ctx context.Context) (int, error) {
+	var released int
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		now := s.now()
+		reservations, err := s.repo.FindExpiredForUpdate(ctx, now, releaseBatchSize)
+		if err != nil {
+			return err
+		}
+
+		// The tickets are locked in the order of their ID like orders do, so
+		// that a sweep and a checkout can not deadlock.
+		sort.SliceStable(reservations, func(a, b int) bool {
+			return reservations[a].TicketID < reservations[b].TicketID
+		})
+
+		for _, r := range reservations {
+			err := s.uow.Do(ctx, func(ctx context.Context) error {
+				return s.releaseExpired(ctx, r, now)
+			})
+			if err != nil {
+				log.Printf("failed to release expired reservation %d: %s", r.ID, err)
+				continue
+			}
+
+			released++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return released, nil
+}
+
+func (s *Service) releaseExpired(ctx context.Context, r *reservation.Reservation, now time.Time) error {
+	t, err := s.ticketRepo.FindByIDForUpdate(ctx, r.TicketID)
+	if errors.Is(err, ticket.ErrTicketNotFound) {
+		// Tickets with held units can not be deleted, this only happens
+		// for holds made before that was checked, only the reservation
+		// is expired then.
+		if err := r.Expire(now); err != nil {
+			return err
+		}
+
+		return s.repo.Update(ctx, r)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return s.release(ctx, r, t, now)
+}
+
+func (s *Service) release(ctx context.Context, r *reservation.Reservation, t *ticket.Ticket, now time.Time) error {
+	if err := r.Expire(now); err != nil {
+		return err
+	}
+
+	if err := t.ReleaseHold(ctx, r.Quantity, now); err != nil {
+		return err
+	}
+
+	if err := s.releaseSeats(ctx, r, t); err != nil {
+		return err
+	}
+
+	if err := s.ticketRepo.Update(ctx, t); err != nil {
+		return err
+	}
+
+	if err := s.events.Publish(ctx, t.PullEvents()...); err != nil {
+		return err
+	}
+
+	return s.repo.Update(ctx, r)
+}
+
+// releaseSeats makes the seats r holds of t available again.
+func (s *Service) releaseSeats(ctx context.Context, r *reservation.Reservation, t *ticket.Ticket) error {
+	if !t.IsSeated() {
+		return nil
+	}
+
+	seats, err := s.seatRepo.ListByReservation(ctx, r.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, st := range seats {
+		if err := st.Release(r.ID); err != nil {
+			return err
+		}
+
+		if err := s.seatRepo.Update(ctx, st); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
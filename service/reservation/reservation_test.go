@@ -0,0 +1,549 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	eventRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/event/repository"
+	promotionRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/promotion/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/purchase"
+	purchaseRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/purchase/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/reservation"
+	reservationRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/reservation/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/seat"
+	seatRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/seat/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	ticketRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/ticket/repository"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	mockdb "github.com/aaydin-tr/ddd-api-example/mock/db"
+	purchaseRepository "github.com/aaydin-tr/ddd-api-example/mock/repository/purchase"
+	repository "github.com/aaydin-tr/ddd-api-example/mock/repository/reservation"
+	seatRepository "github.com/aaydin-tr/ddd-api-example/mock/repository/seat"
+	ticketRepository "github.com/aaydin-tr/ddd-api-example/mock/repository/ticket"
+	"github.com/aaydin-tr/ddd-api-example/pkg/eventbus"
+	ticketService "github.com/aaydin-tr/ddd-api-example/service/ticket"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+const userID = "1250052d-c061-4a1f-81f0-d88af3dcb3d5"
+
+// runInTransaction stands in for the unit of work and runs fn directly.
+func runInTransaction(ctx context.Context, fn func(ctx context.Context) error, _ ...db.Option) error {
+	return fn(ctx)
+}
+
+func newTicket(allocation, held int) *ticket.Ticket {
+	tk, _ := ticket.NewTicket("Test Ticket", "Test Description", allocation, 1500, "EUR", time.Now())
+	tk.ID = 1
+	tk.Held = held
+	tk.Status = ticket.StatusPublished
+	return tk
+}
+
+func newReservation(expiresAt time.Time) *reservation.Reservation {
+	return &reservation.Reservation{
+		ID:        1,
+		TicketID:  1,
+		UserID:    userID,
+		Quantity:  2,
+		Status:    reservation.StatusActive,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func TestService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockReservationRepository(ctrl)
+	mockTicketRepo := ticketRepository.NewMockTicketRepository(ctrl)
+	mockPurchaseRepo := purchaseRepository.NewMockPurchaseRepository(ctrl)
+	mockSeatRepo := seatRepository.NewMockSeatRepository(ctrl)
+	mockUow := mockdb.NewMockUnitOfWork(ctrl)
+	service := NewReservationService(mockUow, mockRepo, mockTicketRepo, mockPurchaseRepo, mockSeatRepo, eventbus.New(), 10*time.Minute)
+
+	tests := []struct {
+		name     string
+		quantity int
+		mock     func()
+		wantErr  error
+	}{
+		{
+			name:     "success",
+			quantity: 2,
+			mock: func() {
+				tk := newTicket(10, 0)
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(tk, nil)
+				mockTicketRepo.EXPECT().Update(gomock.Any(), tk).DoAndReturn(func(_ context.Context, tk *ticket.Ticket) error {
+					assert.Equal(t, 8, tk.Allocation.GetValue())
+					assert.Equal(t, 2, tk.Held)
+					return nil
+				})
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:     "ticket not found error",
+			quantity: 2,
+			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(nil, ticket.ErrTicketNotFound)
+			},
+			wantErr: ticket.ErrTicketNotFound,
+		},
+		{
+			name:     "insufficient allocation error",
+			quantity: 20,
+			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newTicket(10, 0), nil)
+			},
+			wantErr: ticket.ErrInsufficientAllocation,
+		},
+		{
+			name:     "create error",
+			quantity: 2,
+			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newTicket(10, 0), nil)
+				mockTicketRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("create error"))
+			},
+			wantErr: errors.New("create error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := service.Create(context.Background(), 1, request.CreateReservationRequest{Quantity: tt.quantity, UserID: userID})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "active", got.Status)
+			assert.Equal(t, tt.quantity, got.Quantity)
+			assert.True(t, got.ExpiresAt.After(time.Now()))
+		})
+	}
+}
+
+func TestService_Confirm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockReservationRepository(ctrl)
+	mockTicketRepo := ticketRepository.NewMockTicketRepository(ctrl)
+	mockPurchaseRepo := purchaseRepository.NewMockPurchaseRepository(ctrl)
+	mockSeatRepo := seatRepository.NewMockSeatRepository(ctrl)
+	mockUow := mockdb.NewMockUnitOfWork(ctrl)
+	service := NewReservationService(mockUow, mockRepo, mockTicketRepo, mockPurchaseRepo, mockSeatRepo, eventbus.New(), 10*time.Minute)
+
+	t.Run("success", func(t *testing.T) {
+		tk := newTicket(8, 2)
+		r := newReservation(time.Now().Add(time.Minute))
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+		mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(r, nil)
+		mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(tk, nil)
+		mockTicketRepo.EXPECT().Update(gomock.Any(), tk).Return(nil)
+		mockPurchaseRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().Update(gomock.Any(), r).Return(nil)
+
+		got, err := service.Confirm(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "confirmed", got.Status)
+		assert.NotNil(t, got.PurchaseID)
+		assert.Equal(t, 0, tk.Held)
+		assert.Equal(t, 2, tk.Sold)
+		assert.Equal(t, 8, tk.Allocation.GetValue())
+	})
+
+	t.Run("expired reservation is released", func(t *testing.T) {
+		tk := newTicket(8, 2)
+		r := newReservation(time.Now().Add(-time.Minute))
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+		mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(r, nil)
+		mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(tk, nil)
+		mockTicketRepo.EXPECT().Update(gomock.Any(), tk).Return(nil)
+		mockRepo.EXPECT().Update(gomock.Any(), r).Return(nil)
+
+		_, err := service.Confirm(context.Background(), 1)
+		assert.ErrorIs(t, err, reservation.ErrReservationExpired)
+		assert.Equal(t, reservation.StatusExpired, r.Status)
+		assert.Equal(t, 0, tk.Held)
+		assert.Equal(t, 10, tk.Allocation.GetValue())
+	})
+
+	t.Run("not active reservation", func(t *testing.T) {
+		r := newReservation(time.Now().Add(time.Minute))
+		r.Status = reservation.StatusCancelled
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+		mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(r, nil)
+		mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newTicket(8, 0), nil)
+
+		_, err := service.Confirm(context.Background(), 1)
+		assert.ErrorIs(t, err, reservation.ErrReservationNotActive)
+	})
+
+	t.Run("reservation not found", func(t *testing.T) {
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+		mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 2).Return(nil, reservation.ErrReservationNotFound)
+
+		_, err := service.Confirm(context.Background(), 2)
+		assert.ErrorIs(t, err, reservation.ErrReservationNotFound)
+	})
+}
+
+func TestService_PurchaseLimit(t *testing.T) {
+	ctx := context.Background()
+	tickets := ticketRepositoryImpl.NewMemoryTicketRepository()
+	purchases := purchaseRepositoryImpl.NewMemoryPurchaseRepository()
+	service := NewReservationService(db.NewMemoryUnitOfWork(), reservationRepositoryImpl.NewMemoryReservationRepository(), tickets, purchases, seatRepositoryImpl.NewMemorySeatRepository(), eventbus.New(), 10*time.Minute).(*Service)
+
+	tk := newTicket(100, 0)
+	tk.ID = 0
+	assert.NoError(t, tk.ChangeMaxPerUser(4))
+	assert.NoError(t, tickets.Create(ctx, tk))
+
+	reserve := func(quantity int) (*reservation.ReservationDTO, error) {
+		return service.Create(ctx, tk.ID, request.CreateReservationRequest{Quantity: quantity, UserID: userID})
+	}
+
+	first, err := reserve(3)
+	assert.NoError(t, err)
+
+	t.Run("should count the units a user holds", func(t *testing.T) {
+		_, err := reserve(2)
+		var limitErr *ticket.PurchaseLimitError
+		assert.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, 1, limitErr.Remaining)
+	})
+
+	t.Run("should check the purchases made since the hold on confirm", func(t *testing.T) {
+		_, err := service.Confirm(ctx, first.ID)
+		assert.NoError(t, err)
+
+		second, err := reserve(1)
+		assert.NoError(t, err)
+
+		bought, err := purchase.NewPurchase(tk.ID, userID, 1, tk.Price)
+		assert.NoError(t, err)
+		assert.NoError(t, purchases.Create(ctx, bought))
+
+		_, err = service.Confirm(ctx, second.ID)
+		var limitErr *ticket.PurchaseLimitError
+		assert.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, 0, limitErr.Remaining)
+
+		found, err := service.FindByID(ctx, second.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, string(reservation.StatusActive), found.Status)
+	})
+
+	t.Run("should not count holds that expired before they were released", func(t *testing.T) {
+		const otherUserID = "7c3e2f50-3b8a-4a47-9d8f-2a4c1f0e6b11"
+		_, err := service.Create(ctx, tk.ID, request.CreateReservationRequest{Quantity: 4, UserID: otherUserID})
+		assert.NoError(t, err)
+
+		service.now = func() time.Time { return time.Now().Add(time.Hour) }
+		defer func() { service.now = time.Now }()
+
+		_, err = service.Create(ctx, tk.ID, request.CreateReservationRequest{Quantity: 1, UserID: otherUserID})
+		assert.NoError(t, err)
+	})
+}
+
+func TestService_CreateOutsideSalesWindow(t *testing.T) {
+	ctx := context.Background()
+	tickets := ticketRepositoryImpl.NewMemoryTicketRepository()
+	service := NewReservationService(db.NewMemoryUnitOfWork(), reservationRepositoryImpl.NewMemoryReservationRepository(), tickets, purchaseRepositoryImpl.NewMemoryPurchaseRepository(), seatRepositoryImpl.NewMemorySeatRepository(), eventbus.New(), 10*time.Minute).(*Service)
+
+	start := time.Date(2026, 11, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(5 * time.Minute)
+	tk := newTicket(10, 0)
+	tk.ID = 0
+	assert.NoError(t, tk.ChangeSalesWindow(&start, &end))
+	assert.NoError(t, tickets.Create(ctx, tk))
+
+	reserve := func(now time.Time) (*reservation.ReservationDTO, error) {
+		service.now = func() time.Time { return now }
+		return service.Create(ctx, tk.ID, request.CreateReservationRequest{Quantity: 2, UserID: userID})
+	}
+
+	_, err := reserve(start.Add(-time.Minute))
+	assert.ErrorIs(t, err, ticket.ErrSalesNotOpen)
+
+	_, err = reserve(end)
+	assert.ErrorIs(t, err, ticket.ErrSalesClosed)
+
+	created, err := reserve(start)
+	assert.NoError(t, err)
+	assert.Equal(t, start.Add(10*time.Minute), created.ExpiresAt)
+
+	service.now = func() time.Time { return end.Add(time.Minute) }
+	confirmed, err := service.Confirm(ctx, created.ID)
+	if assert.NoError(t, err, "a hold made in time can be confirmed after the window closed") {
+		assert.Equal(t, string(reservation.StatusConfirmed), confirmed.Status)
+	}
+}
+
+func TestService_Cancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockReservationRepository(ctrl)
+	mockTicketRepo := ticketRepository.NewMockTicketRepository(ctrl)
+	mockPurchaseRepo := purchaseRepository.NewMockPurchaseRepository(ctrl)
+	mockSeatRepo := seatRepository.NewMockSeatRepository(ctrl)
+	mockUow := mockdb.NewMockUnitOfWork(ctrl)
+	service := NewReservationService(mockUow, mockRepo, mockTicketRepo, mockPurchaseRepo, mockSeatRepo, eventbus.New(), 10*time.Minute)
+
+	t.Run("success", func(t *testing.T) {
+		tk := newTicket(8, 2)
+		r := newReservation(time.Now().Add(time.Minute))
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+		mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(r, nil)
+		mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(tk, nil)
+		mockTicketRepo.EXPECT().Update(gomock.Any(), tk).Return(nil)
+		mockRepo.EXPECT().Update(gomock.Any(), r).Return(nil)
+
+		got, err := service.Cancel(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "cancelled", got.Status)
+		assert.Equal(t, 10, tk.Allocation.GetValue())
+		assert.Equal(t, 0, tk.Held)
+	})
+
+	t.Run("not active reservation", func(t *testing.T) {
+		r := newReservation(time.Now().Add(time.Minute))
+		r.Status = reservation.StatusConfirmed
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+		mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(r, nil)
+		mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newTicket(8, 2), nil)
+
+		_, err := service.Cancel(context.Background(), 1)
+		assert.ErrorIs(t, err, reservation.ErrReservationNotActive)
+	})
+}
+
+func TestService_ReleaseExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockReservationRepository(ctrl)
+	mockTicketRepo := ticketRepository.NewMockTicketRepository(ctrl)
+	mockPurchaseRepo := purchaseRepository.NewMockPurchaseRepository(ctrl)
+	mockSeatRepo := seatRepository.NewMockSeatRepository(ctrl)
+	mockUow := mockdb.NewMockUnitOfWork(ctrl)
+	service := NewReservationService(mockUow, mockRepo, mockTicketRepo, mockPurchaseRepo, mockSeatRepo, eventbus.New(), 10*time.Minute)
+
+	t.Run("success", func(t *testing.T) {
+		tk := newTicket(6, 4)
+		first := newReservation(time.Now().Add(-time.Minute))
+		second := newReservation(time.Now().Add(-time.Second))
+		second.ID = 2
+		orphan := newReservation(time.Now().Add(-time.Second))
+		orphan.ID = 3
+		orphan.TicketID = 2
+
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction).Times(4)
+		mockRepo.EXPECT().FindExpiredForUpdate(gomock.Any(), gomock.Any(), releaseBatchSize).Return([]*reservation.Reservation{first, second, orphan}, nil)
+		mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(tk, nil).Times(2)
+		mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 2).Return(nil, ticket.ErrTicketNotFound)
+		mockTicketRepo.EXPECT().Update(gomock.Any(), tk).Return(nil).Times(2)
+		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+
+		released, err := service.ReleaseExpired(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 3, released)
+		assert.Equal(t, 10, tk.Allocation.GetValue())
+		assert.Equal(t, 0, tk.Held)
+		assert.Equal(t, reservation.StatusExpired, first.Status)
+		assert.Equal(t, reservation.StatusExpired, second.Status)
+		assert.Equal(t, reservation.StatusExpired, orphan.Status)
+	})
+
+	t.Run("should skip a reservation that fails and lock tickets in ID order", func(t *testing.T) {
+		tk := newTicket(6, 2)
+		other := newTicket(6, 2)
+		other.ID = 2
+		failing := newReservation(time.Now().Add(-time.Minute))
+		failing.TicketID = 2
+		expiring := newReservation(time.Now().Add(-time.Second))
+		expiring.ID = 2
+
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction).Times(3)
+		mockRepo.EXPECT().FindExpiredForUpdate(gomock.Any(), gomock.Any(), releaseBatchSize).Return([]*reservation.Reservation{failing, expiring}, nil)
+		gomock.InOrder(
+			mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(tk, nil),
+			mockTicketRepo.EXPECT().Update(gomock.Any(), tk).Return(nil),
+			mockRepo.EXPECT().Update(gomock.Any(), expiring).Return(nil),
+			mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 2).Return(other, nil),
+			mockTicketRepo.EXPECT().Update(gomock.Any(), other).Return(errors.New("update error")),
+		)
+
+		released, err := service.ReleaseExpired(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, released)
+		assert.Equal(t, 0, tk.Held)
+	})
+
+	t.Run("find error", func(t *testing.T) {
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+		mockRepo.EXPECT().FindExpiredForUpdate(gomock.Any(), gomock.Any(), releaseBatchSize).Return(nil, errors.New("find error"))
+
+		_, err := service.ReleaseExpired(context.Background())
+		assert.Error(t, err)
+	})
+}
+
+func TestService_DeleteTicketWithHolds(t *testing.T) {
+	ctx := context.Background()
+	uow := db.NewMemoryUnitOfWork()
+	tickets := ticketRepositoryImpl.NewMemoryTicketRepository()
+	purchases := purchaseRepositoryImpl.NewMemoryPurchaseRepository()
+	seats := seatRepositoryImpl.NewMemorySeatRepository()
+	service := NewReservationService(uow, reservationRepositoryImpl.NewMemoryReservationRepository(), tickets, purchases, seats, eventbus.New(), 10*time.Minute).(*Service)
+	ticketsService := ticketService.NewTicketService(uow, tickets, purchases, eventRepositoryImpl.NewMemoryEventRepository(), seats, promotionRepositoryImpl.NewMemoryPromotionRepository(), eventbus.New(), ticketService.LockingPessimistic)
+
+	tk := newTicket(10, 0)
+	tk.ID = 0
+	assert.NoError(t, tickets.Create(ctx, tk))
+
+	_, err := service.Create(ctx, tk.ID, request.CreateReservationRequest{Quantity: 2, UserID: userID})
+	assert.NoError(t, err)
+	assert.ErrorIs(t, ticketsService.Delete(ctx, tk.ID, nil), ticket.ErrTicketHasHolds)
+
+	service.now = func() time.Time { return time.Now().Add(time.Hour) }
+	defer func() { service.now = time.Now }()
+	_, err = service.ReleaseExpired(ctx)
+	assert.NoError(t, err)
+
+	assert.NoError(t, ticketsService.Delete(ctx, tk.ID, nil))
+	restored, err := ticketsService.Restore(ctx, tk.ID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, restored.Held)
+	assert.Equal(t, 10, restored.Allocation)
+}
+
+func TestService_ArchiveTicketWithHolds(t *testing.T) {
+	ctx := context.Background()
+	uow := db.NewMemoryUnitOfWork()
+	tickets := ticketRepositoryImpl.NewMemoryTicketRepository()
+	purchases := purchaseRepositoryImpl.NewMemoryPurchaseRepository()
+	seats := seatRepositoryImpl.NewMemorySeatRepository()
+	service := NewReservationService(uow, reservationRepositoryImpl.NewMemoryReservationRepository(), tickets, purchases, seats, eventbus.New(), 10*time.Minute).(*Service)
+	ticketsService := ticketService.NewTicketService(uow, tickets, purchases, eventRepositoryImpl.NewMemoryEventRepository(), seats, promotionRepositoryImpl.NewMemoryPromotionRepository(), eventbus.New(), ticketService.LockingPessimistic)
+
+	tk := newTicket(10, 0)
+	tk.ID = 0
+	assert.NoError(t, tickets.Create(ctx, tk))
+
+	confirmed, err := service.Create(ctx, tk.ID, request.CreateReservationRequest{Quantity: 2, UserID: userID})
+	assert.NoError(t, err)
+	_, err = service.Create(ctx, tk.ID, request.CreateReservationRequest{Quantity: 3, UserID: userID})
+	assert.NoError(t, err)
+
+	t.Run("should not archive while a hold can still be confirmed", func(t *testing.T) {
+		_, err := ticketsService.Transition(ctx, tk.ID, ticket.TransitionArchive, nil)
+		assert.ErrorIs(t, err, ticket.ErrTicketHasHolds)
+
+		_, err = service.Confirm(ctx, confirmed.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should not archive while a hold can still expire", func(t *testing.T) {
+		_, err := ticketsService.Transition(ctx, tk.ID, ticket.TransitionArchive, nil)
+		assert.ErrorIs(t, err, ticket.ErrTicketHasHolds)
+
+		service.now = func() time.Time { return time.Now().Add(time.Hour) }
+		defer func() { service.now = time.Now }()
+		released, err := service.ReleaseExpired(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, released)
+	})
+
+	archived, err := ticketsService.Transition(ctx, tk.ID, ticket.TransitionArchive, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "archived", archived.Status)
+	assert.Equal(t, 0, archived.Held)
+	assert.Equal(t, 2, archived.Sold)
+	assert.Equal(t, 8, archived.Allocation)
+}
+
+func TestService_Seats(t *testing.T) {
+	ctx := context.Background()
+	tickets := ticketRepositoryImpl.NewMemoryTicketRepository()
+	seats := seatRepositoryImpl.NewMemorySeatRepository()
+	service := NewReservationService(db.NewMemoryUnitOfWork(), reservationRepositoryImpl.NewMemoryReservationRepository(), tickets, purchaseRepositoryImpl.NewMemoryPurchaseRepository(), seats, eventbus.New(), 10*time.Minute).(*Service)
+
+	tk := newTicket(1, 0)
+	tk.ID = 0
+	assert.NoError(t, tk.AssignSeats(5, time.Now()))
+	assert.NoError(t, tickets.Create(ctx, tk))
+	layout, err := seat.NewSeatMap(tk.ID, []seat.Section{{Name: "Stalls", Rows: []seat.Row{{Name: "A", Seats: 5}}}})
+	assert.NoError(t, err)
+	assert.NoError(t, seats.CreateMany(ctx, layout))
+	ids := seat.IDs(layout)
+
+	status := func(id int) seat.Status {
+		all, _ := seats.ListByTicket(ctx, tk.ID)
+		for _, s := range all {
+			if s.ID == id {
+				return s.Status
+			}
+		}
+
+		return ""
+	}
+
+	held, err := service.Create(ctx, tk.ID, request.CreateReservationRequest{SeatIDs: []int{ids[0], ids[1]}, UserID: userID})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, held.Quantity)
+	assert.Equal(t, []int{ids[0], ids[1]}, held.SeatIDs)
+	assert.Equal(t, seat.StatusHeld, status(ids[0]))
+
+	_, err = service.Create(ctx, tk.ID, request.CreateReservationRequest{SeatIDs: []int{ids[1]}, UserID: userID})
+	assert.ErrorIs(t, err, seat.ErrSeatUnavailable)
+
+	found, err := service.FindByID(ctx, held.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, held.SeatIDs, found.SeatIDs)
+
+	t.Run("should release the seats of a cancelled reservation", func(t *testing.T) {
+		_, err := service.Cancel(ctx, held.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, seat.StatusAvailable, status(ids[0]))
+		assert.Equal(t, seat.StatusAvailable, status(ids[1]))
+	})
+
+	t.Run("should sell the seats of a confirmed reservation", func(t *testing.T) {
+		best, err := service.Create(ctx, tk.ID, request.CreateReservationRequest{Quantity: 3, UserID: userID})
+		assert.NoError(t, err)
+		assert.Equal(t, []int{ids[1], ids[2], ids[3]}, best.SeatIDs)
+
+		confirmed, err := service.Confirm(ctx, best.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, best.SeatIDs, confirmed.SeatIDs)
+		assert.Equal(t, seat.StatusSold, status(ids[2]))
+	})
+
+	t.Run("should release the seats of an expired reservation", func(t *testing.T) {
+		expiring, err := service.Create(ctx, tk.ID, request.CreateReservationRequest{Quantity: 1, UserID: userID})
+		assert.NoError(t, err)
+		assert.Equal(t, seat.StatusHeld, status(expiring.SeatIDs[0]))
+
+		service.now = func() time.Time { return time.Now().Add(time.Hour) }
+		defer func() { service.now = time.Now }()
+		released, err := service.ReleaseExpired(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, released)
+		assert.Equal(t, seat.StatusAvailable, status(expiring.SeatIDs[0]))
+	})
+}
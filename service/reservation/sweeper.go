@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Sweeper periodically releases reservations whose hold has expired.
+type Sweeper struct {
+	service  ReservationService
+	interval time.Duration
+}
+
+func NewSweeper(service ReservationService, interval time.Duration) *Sweeper {
+	return &Sweeper{service: service, interval: interval}
+}
+
+func (s *Sweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sweep(ctx)
+		}
+	}
+}
+
+func (s *Sweeper) sweep(ctx context.Context) {
+	for {
+		released, err := s.service.ReleaseExpired(ctx)
+		if err != nil {
+			log.Printf("failed to release expired reservations: %s", err)
+			return
+		}
+
+		if released < releaseBatchSize {
+			return
+		}
+	}
+}
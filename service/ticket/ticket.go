@@ -2,39 +2,129 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	eventRepository "github.com/aaydin-tr/ddd-api-example/domain/event/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/promotion"
+	promotionRepository "github.com/aaydin-tr/ddd-api-example/domain/promotion/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/purchase"
+	purchaseRepository "github.com/aaydin-tr/ddd-api-example/domain/purchase/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/seat"
+	seatRepository "github.com/aaydin-tr/ddd-api-example/domain/seat/repository"
 	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
 	"github.com/aaydin-tr/ddd-api-example/domain/ticket/repository"
 	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
 	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	"github.com/aaydin-tr/ddd-api-example/pkg/audit"
+	"github.com/aaydin-tr/ddd-api-example/pkg/eventbus"
+	"github.com/aaydin-tr/ddd-api-example/pkg/pagination"
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
 )
 
+// LockingMode selects how concurrent purchases of the same ticket are
+// serialized.
+type LockingMode string
+
+const (
+	// LockingPessimistic locks the ticket row for the whole purchase.
+	LockingPessimistic LockingMode = "pessimistic"
+	// LockingOptimistic reads the ticket without a lock and retries the
+	// purchase when the version check of the update fails.
+	LockingOptimistic LockingMode = "optimistic"
+)
+
+const maxOptimisticAttempts = 3
+
+// ledgerCheckPageSize is the number of tickets and ledger entries CheckLedger
+// reads at once.
+const ledgerCheckPageSize = 100
+
+func ParseLockingMode(mode string) (LockingMode, error) {
+	switch LockingMode(mode) {
+	case LockingPessimistic, LockingOptimistic:
+		return LockingMode(mode), nil
+	}
+
+	return "", fmt.Errorf("unknown locking mode %q", mode)
+}
+
 //go:generate mockgen -destination=../../mock/service/ticket/ticket.go -package=service github.com/aaydin-tr/ddd-api-example/service/ticket TicketService
 type TicketService interface {
 	Create(ctx context.Context, req request.CreateTicketRequest) (*ticket.TicketDTO, error)
 	FindByID(ctx context.Context, id int) (*ticket.TicketDTO, error)
-	DecrementAllocation(ctx context.Context, ticketID, amount int) error
+	List(ctx context.Context, req request.ListTicketsRequest) (*ticket.TicketListDTO, error)
+	Update(ctx context.Context, id int, req request.UpdateTicketRequest, version *int) (*ticket.TicketDTO, error)
+	Delete(ctx context.Context, id int, version *int) error
+	Restore(ctx context.Context, id int, version *int) (*ticket.TicketDTO, error)
+	Transition(ctx context.Context, id int, transition ticket.Transition, version *int) (*ticket.TicketDTO, error)
+	Purchase(ctx context.Context, ticketID int, req request.PurchaseTicketRequest) (*purchase.PurchaseDTO, error)
+	Ledger(ctx context.Context, id int, req request.ListLedgerRequest) (*ticket.LedgerDTO, error)
+	CheckLedger(ctx context.Context) (*ticket.LedgerCheckDTO, error)
+	CreateSeatMap(ctx context.Context, id int, req request.CreateSeatMapRequest) (*seat.SeatMapDTO, error)
+	SeatMap(ctx context.Context, id int) (*seat.SeatMapDTO, error)
+	BestAvailableSeats(ctx context.Context, id int, req request.BestAvailableSeatsRequest) (*seat.SeatListDTO, error)
 }
 
 type Service struct {
-	repo repository.TicketRepository
+	uow           db.UnitOfWork
+	repo          repository.TicketRepository
+	purchaseRepo  purchaseRepository.PurchaseRepository
+	eventRepo     eventRepository.EventRepository
+	seatRepo      seatRepository.SeatRepository
+	promotionRepo promotionRepository.PromotionRepository
+	events        eventbus.Publisher
+	locking       LockingMode
+	now           func() time.Time
 }
 
-func NewTicketService(repo repository.TicketRepository) TicketService {
-	return &Service{repo: repo}
+func NewTicketService(uow db.UnitOfWork, repo repository.TicketRepository, purchaseRepo purchaseRepository.PurchaseRepository, eventRepo eventRepository.EventRepository, seatRepo seatRepository.SeatRepository, promotionRepo promotionRepository.PromotionRepository, events eventbus.Publisher, locking LockingMode) TicketService {
+	return &Service{uow: uow, repo: repo, purchaseRepo: purchaseRepo, eventRepo: eventRepo, seatRepo: seatRepo, promotionRepo: promotionRepo, events: events, locking: locking, now: time.Now}
 }
 
 func (s *Service) Create(ctx context.Context, req request.CreateTicketRequest) (*ticket.TicketDTO, error) {
-	t, err := ticket.NewTicket(req.Name, req.Description, req.Allocation)
+	if req.Price == nil {
+		return nil, ticket.ErrPriceIsRequired
+	}
+
+	now := s.now()
+	t, err := ticket.NewTicket(req.Name, req.Description, req.Allocation, req.Price.Amount, req.Price.Currency, now)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := s.repo.Create(ctx, t); err != nil {
+	if err := t.ChangeMaxPerUser(req.MaxPerUser); err != nil {
+		return nil, err
+	}
+
+	if err := t.ChangeSalesWindow(req.SalesStartAt, req.SalesEndAt); err != nil {
 		return nil, err
 	}
 
-	return ticket.NewTicketDTOFromEntity(t), nil
+	if ticket.Status(req.Status) != ticket.StatusDraft {
+		if err := t.Publish(now); err != nil {
+			return nil, err
+		}
+	}
+
+	t.EventID = req.EventID
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.checkEventCapacity(ctx, t, 0); err != nil {
+			return err
+		}
+
+		if err := s.repo.Create(ctx, t); err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, t.PullEvents()...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ticket.NewTicketDTOFromEntity(t, s.now()), nil
 }
 
 func (s *Service) FindByID(ctx context.Context, id int) (*ticket.TicketDTO, error) {
@@ -43,33 +133,578 @@ func (s *Service) FindByID(ctx context.Context, id int) (*ticket.TicketDTO, erro
 		return nil, err
 	}
 
-	return ticket.NewTicketDTOFromEntity(t), nil
+	return ticket.NewTicketDTOFromEntity(t, s.now()), nil
 }
 
-func (s *Service) DecrementAllocation(ctx context.Context, ticketID, amount int) error {
-	txManager := db.NewTransactionManager(s.repo.GetDB(ctx))
-	tx, err := txManager.Begin(ctx)
+func (s *Service) List(ctx context.Context, req request.ListTicketsRequest) (*ticket.TicketListDTO, error) {
+	offset, err := pagination.DecodeCursor(req.Cursor)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = pagination.DefaultLimit
+	}
+
+	filter := ticket.ListFilter{
+		EventID:       req.EventID,
+		Name:          req.Name,
+		MinAllocation: req.MinAllocation,
+		MaxAllocation: req.MaxAllocation,
+		SoldOut:       req.SoldOut,
+		CreatedAfter:  req.CreatedAfter,
+		CreatedBefore: req.CreatedBefore,
+		SortBy:        ticket.SortField(req.SortBy),
+		SortDesc:      req.Order == "desc",
+		Offset:        offset,
+		Limit:         limit,
+	}
+
+	tickets, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return ticket.NewTicketListDTOFromEntities(tickets, total, pagination.NextCursor(offset, limit, total), s.now()), nil
+}
+
+func (s *Service) Update(ctx context.Context, id int, req request.UpdateTicketRequest, version *int) (*ticket.TicketDTO, error) {
+	var t *ticket.Ticket
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		t, err = s.repo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := t.MatchVersion(version); err != nil {
+			return err
+		}
+
+		previous := t.Total()
+		if req.Name != nil {
+			if err := t.Rename(*req.Name); err != nil {
+				return err
+			}
+		}
+
+		if req.Description != nil {
+			if err := t.ChangeDescription(*req.Description); err != nil {
+				return err
+			}
+		}
+
+		if req.TotalAllocation != nil {
+			if err := t.ChangeAllocation(*req.TotalAllocation, s.now()); err != nil {
+				return err
+			}
+
+			if err := s.checkEventCapacity(ctx, t, previous); err != nil {
+				return err
+			}
+		}
+
+		if req.Price != nil {
+			if err := t.ChangePrice(req.Price.Amount, req.Price.Currency); err != nil {
+				return err
+			}
+		}
+
+		if req.MaxPerUser != nil {
+			if err := t.ChangeMaxPerUser(*req.MaxPerUser); err != nil {
+				return err
+			}
+		}
+
+		if req.SalesStartAt != nil || req.SalesEndAt != nil {
+			start, end := t.SalesStartAt, t.SalesEndAt
+			if req.SalesStartAt != nil {
+				start = req.SalesStartAt
+			}
+
+			if req.SalesEndAt != nil {
+				end = req.SalesEndAt
+			}
+
+			if err := t.ChangeSalesWindow(start, end); err != nil {
+				return err
+			}
+		}
+
+		if err := s.repo.Update(ctx, t); err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, t.PullEvents()...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ticket.NewTicketDTOFromEntity(t, s.now()), nil
+}
+
+func (s *Service) Delete(ctx context.Context, id int, version *int) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		t, err := s.repo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := t.MatchVersion(version); err != nil {
+			return err
+		}
+
+		if err := t.CheckNoHolds(); err != nil {
+			return err
+		}
+
+		return s.repo.Delete(ctx, t)
+	})
+}
+
+// Restore counts the ticket against the capacity of its event again.
+func (s *Service) Restore(ctx context.Context, id int, version *int) (*ticket.TicketDTO, error) {
+	var t *ticket.Ticket
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		t, err = s.repo.FindByIDUnscoped(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := t.MatchVersion(version); err != nil {
+			return err
+		}
+
+		if err := t.Restore(); err != nil {
+			return err
+		}
+
+		if err := s.checkEventCapacity(ctx, t, 0); err != nil {
+			return err
+		}
+
+		return s.repo.Restore(ctx, t)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ticket.NewTicketDTOFromEntity(t, s.now()), nil
+}
+
+func (s *Service) Transition(ctx context.Context, id int, transition ticket.Transition, version *int) (*ticket.TicketDTO, error) {
+	var t *ticket.Ticket
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		t, err = s.repo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := t.MatchVersion(version); err != nil {
+			return err
+		}
+
+		if err := t.Apply(transition, s.now()); err != nil {
+			return err
+		}
+
+		if err := s.repo.Update(ctx, t); err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, t.PullEvents()...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ticket.NewTicketDTOFromEntity(t, s.now()), nil
+}
+
+func (s *Service) Purchase(ctx context.Context, ticketID int, req request.PurchaseTicketRequest) (*purchase.PurchaseDTO, error) {
+	ctx = audit.WithActor(ctx, req.UserID)
+	if s.locking != LockingOptimistic {
+		return s.purchase(ctx, ticketID, req, s.repo.FindByIDForUpdate)
+	}
+
+	var err error
+	for attempt := 0; attempt < maxOptimisticAttempts; attempt++ {
+		var p *purchase.PurchaseDTO
+		p, err = s.purchase(ctx, ticketID, req, s.repo.FindByID)
+		if !errors.Is(err, ticket.ErrVersionConflict) {
+			return p, err
+		}
+	}
+
+	return nil, err
+}
+
+type findTicketFunc func(ctx context.Context, id int) (*ticket.Ticket, error)
+
+func (s *Service) purchase(ctx context.Context, ticketID int, req request.PurchaseTicketRequest, find findTicketFunc) (*purchase.PurchaseDTO, error) {
+	quantity, err := seat.Quantity(req.Quantity, req.SeatIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		p     *purchase.Purchase
+		seats []*seat.Seat
+	)
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		t, err := find(ctx, ticketID)
+		if err != nil {
+			return err
+		}
+
+		seats, err = s.selectSeats(ctx, t, req.SeatIDs, quantity)
+		if err != nil {
+			return err
+		}
+
+		if err := s.checkPurchaseLimit(ctx, t, req.UserID, quantity); err != nil {
+			return err
+		}
+
+		if err := t.DecrementAllocation(ctx, quantity, s.now()); err != nil {
+			return err
+		}
+
+		p, err = purchase.NewPurchase(t.ID, req.UserID, quantity, t.Price)
+		if err != nil {
+			return err
+		}
+
+		var redemption *promotion.Redemption
+		if req.PromoCode != "" {
+			redemption, err = s.redeem(ctx, req.PromoCode, p)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := s.repo.Update(ctx, t); err != nil {
+			return err
+		}
+
+		if err := s.events.Publish(ctx, t.PullEvents()...); err != nil {
+			return err
+		}
+
+		if err := s.purchaseRepo.Create(ctx, p); err != nil {
+			return err
+		}
+
+		if redemption != nil {
+			redemption.PurchaseID = &p.ID
+			if err := s.promotionRepo.CreateRedemption(ctx, redemption); err != nil {
+				return err
+			}
+		}
+
+		for _, st := range seats {
+			if err := st.Sell(p.ID); err != nil {
+				return err
+			}
+
+			if err := s.seatRepo.Update(ctx, st); err != nil {
+				return err
+			}
+		}
+
+		return s.events.Publish(ctx, p.PullEvents()...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dto := purchase.NewPurchaseDTOFromEntity(p)
+	dto.SeatIDs = seat.IDs(seats)
+	return dto, nil
+}
+
+// redeem applies the promotion code to p. The promotion is locked after the
+// ticket, its redemption is stored in the unit of work of the purchase so
+// that it is given back when the purchase fails.
+func (s *Service) redeem(ctx context.Context, code string, p *purchase.Purchase) (*promotion.Redemption, error) {
+	promo, err := s.promotionRepo.FindByCodeForUpdate(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	redeemed, err := s.promotionRepo.RedemptionCount(ctx, promo.ID, p.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	discounts, err := promo.Allocate([]int{p.TicketID}, []*valueobject.Money{p.Total})
+	if err != nil {
+		return nil, err
+	}
+
+	redemption, err := promo.Redeem(p.UserID, redeemed, discounts[0], s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.ApplyDiscount(discounts[0]); err != nil {
+		return nil, err
+	}
+
+	if err := s.promotionRepo.Update(ctx, promo); err != nil {
+		return nil, err
+	}
+
+	return redemption, nil
+}
+
+// selectSeats returns the seats ids of t, or its best available seats when
+// no ids are given. Tickets with general seating have no seats.
+func (s *Service) selectSeats(ctx context.Context, t *ticket.Ticket, ids []int, quantity int) ([]*seat.Seat, error) {
+	if !t.IsSeated() {
+		if len(ids) > 0 {
+			return nil, ticket.ErrNoSeatMap
+		}
+
+		return nil, nil
 	}
 
-	t, err := s.repo.FindByIDForUpdate(ctx, ticketID, tx)
+	seats, err := s.seatRepo.ListByTicket(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return seat.Select(seats, ids, quantity)
+}
+
+// CreateSeatMap gives a ticket assigned seating, its seats replace the
+// allocation and are checked against the capacity of its event.
+func (s *Service) CreateSeatMap(ctx context.Context, id int, req request.CreateSeatMapRequest) (*seat.SeatMapDTO, error) {
+	sections := make([]seat.Section, 0, len(req.Sections))
+	for _, section := range req.Sections {
+		rows := make([]seat.Row, 0, len(section.Rows))
+		for _, row := range section.Rows {
+			rows = append(rows, seat.Row{Name: row.Name, Seats: row.Seats})
+		}
+
+		sections = append(sections, seat.Section{Name: section.Name, Rows: rows})
+	}
+
+	var seats []*seat.Seat
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		t, err := s.repo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		seats, err = seat.NewSeatMap(t.ID, sections)
+		if err != nil {
+			return err
+		}
+
+		previous := t.Total()
+		if err := t.AssignSeats(len(seats), s.now()); err != nil {
+			return err
+		}
+
+		if err := s.checkEventCapacity(ctx, t, previous); err != nil {
+			return err
+		}
+
+		if err := s.repo.Update(ctx, t); err != nil {
+			return err
+		}
+
+		return s.seatRepo.CreateMany(ctx, seats)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return seat.NewSeatMapDTOFromEntities(id, seats), nil
+}
+
+func (s *Service) SeatMap(ctx context.Context, id int) (*seat.SeatMapDTO, error) {
+	seats, err := s.seats(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return seat.NewSeatMapDTOFromEntities(id, seats), nil
+}
+
+// BestAvailableSeats only suggests seats, they are not held and can be
+// bought by someone else before they are purchased.
+func (s *Service) BestAvailableSeats(ctx context.Context, id int, req request.BestAvailableSeatsRequest) (*seat.SeatListDTO, error) {
+	seats, err := s.seats(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	best, err := seat.BestAvailable(seats, req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	return seat.NewSeatListDTOFromEntities(best), nil
+}
+
+func (s *Service) seats(ctx context.Context, id int) ([]*seat.Seat, error) {
+	t, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.IsSeated() {
+		return nil, ticket.ErrNoSeatMap
+	}
+
+	return s.seatRepo.ListByTicket(ctx, t.ID)
+}
+
+// checkEventCapacity has to run in the unit of work that writes t, before
+// it is written. previous is what t counted against the capacity of its
+// event so far. The event stays locked until the unit of work ends, so the
+// tickets of an event change their allocation one at a time.
+func (s *Service) checkEventCapacity(ctx context.Context, t *ticket.Ticket, previous int) error {
+	if t.EventID == nil {
+		return nil
+	}
+
+	e, err := s.eventRepo.FindByIDForUpdate(ctx, *t.EventID)
 	if err != nil {
-		txManager.Rollback(ctx)
 		return err
 	}
 
-	err = t.DecrementAllocation(ctx, amount)
+	allocated, err := s.repo.EventAllocation(ctx, e.ID)
 	if err != nil {
-		txManager.Rollback(ctx)
 		return err
 	}
 
-	err = s.repo.Update(ctx, t, tx)
+	return e.CheckCapacity(allocated - previous + t.Total())
+}
+
+// checkPurchaseLimit has to run in the unit of work that writes the ticket.
+// Purchases of a ticket are serialized by its row lock or version, so the
+// owned quantity cannot change before the purchase is written.
+func (s *Service) checkPurchaseLimit(ctx context.Context, t *ticket.Ticket, userID string, quantity int) error {
+	if !t.HasPurchaseLimit() {
+		return nil
+	}
+
+	owned, err := s.purchaseRepo.OwnedQuantity(ctx, t.ID, userID)
 	if err != nil {
-		txManager.Rollback(ctx)
 		return err
 	}
 
-	return txManager.Commit(ctx)
+	return t.CheckPurchaseLimit(owned, quantity)
+}
+
+func (s *Service) Ledger(ctx context.Context, id int, req request.ListLedgerRequest) (*ticket.LedgerDTO, error) {
+	offset, err := pagination.DecodeCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = pagination.DefaultLimit
+	}
+
+	// The ledger of a deleted ticket is still readable.
+	if _, err := s.repo.FindByIDUnscoped(ctx, id); err != nil {
+		return nil, err
+	}
+
+	entries, total, err := s.repo.ListLedger(ctx, id, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return ticket.NewLedgerDTOFromEntities(id, entries, total, pagination.NextCursor(offset, limit, total)), nil
+}
+
+// CheckLedger replays the ledger of every ticket and reports the tickets
+// whose ledger is broken or does not add up to their current allocation.
+// Every ticket is read again under a row lock while its ledger is replayed,
+// so that a purchase made during the check is not reported as a mismatch.
+func (s *Service) CheckLedger(ctx context.Context) (*ticket.LedgerCheckDTO, error) {
+	report := &ticket.LedgerCheckDTO{Mismatches: []*ticket.LedgerMismatchDTO{}}
+
+	for offset := 0; ; offset += ledgerCheckPageSize {
+		tickets, total, err := s.repo.List(ctx, ticket.ListFilter{Offset: offset, Limit: ledgerCheckPageSize})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, listed := range tickets {
+			var mismatch *ticket.LedgerMismatchDTO
+			err := s.uow.Do(ctx, func(ctx context.Context) error {
+				t, err := s.repo.FindByIDForUpdate(ctx, listed.ID)
+				if err != nil {
+					return err
+				}
+
+				mismatch, err = s.checkLedger(ctx, t)
+				return err
+			})
+			if errors.Is(err, ticket.ErrTicketNotFound) {
+				// Deleted since the page was listed.
+				continue
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			report.Checked++
+			if mismatch != nil {
+				report.Mismatches = append(report.Mismatches, mismatch)
+			}
+		}
+
+		if int64(offset+ledgerCheckPageSize) >= total {
+			return report, nil
+		}
+	}
+}
+
+func (s *Service) checkLedger(ctx context.Context, t *ticket.Ticket) (*ticket.LedgerMismatchDTO, error) {
+	var replay ticket.LedgerReplay
+	mismatch := func(err error) *ticket.LedgerMismatchDTO {
+		return &ticket.LedgerMismatchDTO{
+			TicketID:         t.ID,
+			Allocation:       t.Allocation.GetValue(),
+			LedgerAllocation: replay.Allocation,
+			Entries:          replay.Entries,
+			Error:            err.Error(),
+		}
+	}
+
+	for offset := 0; ; offset += ledgerCheckPageSize {
+		entries, total, err := s.repo.ListLedger(ctx, t.ID, offset, ledgerCheckPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range entries {
+			if err := replay.Apply(e); err != nil {
+				return mismatch(err), nil
+			}
+		}
+
+		if int64(offset+ledgerCheckPageSize) >= total {
+			break
+		}
+	}
+
+	if err := replay.Check(t); err != nil {
+		return mismatch(err), nil
+	}
+
+	return nil, nil
 }
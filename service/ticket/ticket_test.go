@@ -3,25 +3,53 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
-	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aaydin-tr/ddd-api-example/domain/event"
+	eventRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/event/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/promotion"
+	promotionRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/promotion/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/purchase"
+	purchaseRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/purchase/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/seat"
+	seatRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/seat/repository"
 	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	ticketRepository "github.com/aaydin-tr/ddd-api-example/domain/ticket/repository"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
 	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	mockdb "github.com/aaydin-tr/ddd-api-example/mock/db"
+	eventRepository "github.com/aaydin-tr/ddd-api-example/mock/repository/event"
+	promotionRepository "github.com/aaydin-tr/ddd-api-example/mock/repository/promotion"
+	purchaseRepository "github.com/aaydin-tr/ddd-api-example/mock/repository/purchase"
+	seatRepository "github.com/aaydin-tr/ddd-api-example/mock/repository/seat"
 	repository "github.com/aaydin-tr/ddd-api-example/mock/repository/ticket"
+	"github.com/aaydin-tr/ddd-api-example/pkg/eventbus"
+	"github.com/aaydin-tr/ddd-api-example/pkg/pagination"
 	"github.com/aaydin-tr/ddd-api-example/valueobject"
 	"github.com/stretchr/testify/assert"
 	gomock "go.uber.org/mock/gomock"
-	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
+// runInTransaction stands in for the unit of work and runs fn directly.
+func runInTransaction(ctx context.Context, fn func(ctx context.Context) error, _ ...db.Option) error {
+	return fn(ctx)
+}
+
 func TestNewTicketService(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := repository.NewMockTicketRepository(ctrl)
-	service := NewTicketService(mockRepo)
+	mockPurchaseRepo := purchaseRepository.NewMockPurchaseRepository(ctrl)
+	mockEventRepo := eventRepository.NewMockEventRepository(ctrl)
+	mockSeatRepo := seatRepository.NewMockSeatRepository(ctrl)
+	mockPromotionRepo := promotionRepository.NewMockPromotionRepository(ctrl)
+	mockUow := mockdb.NewMockUnitOfWork(ctrl)
+	service := NewTicketService(mockUow, mockRepo, mockPurchaseRepo, mockEventRepo, mockSeatRepo, mockPromotionRepo, eventbus.New(), LockingPessimistic)
 
 	assert.NotNil(t, service)
 }
@@ -31,7 +59,12 @@ func TestService_Create(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := repository.NewMockTicketRepository(ctrl)
-	service := NewTicketService(mockRepo)
+	mockPurchaseRepo := purchaseRepository.NewMockPurchaseRepository(ctrl)
+	mockEventRepo := eventRepository.NewMockEventRepository(ctrl)
+	mockSeatRepo := seatRepository.NewMockSeatRepository(ctrl)
+	mockPromotionRepo := promotionRepository.NewMockPromotionRepository(ctrl)
+	mockUow := mockdb.NewMockUnitOfWork(ctrl)
+	service := NewTicketService(mockUow, mockRepo, mockPurchaseRepo, mockEventRepo, mockSeatRepo, mockPromotionRepo, eventbus.New(), LockingPessimistic)
 
 	tests := []struct {
 		name    string
@@ -46,14 +79,17 @@ func TestService_Create(t *testing.T) {
 				Name:        "Test Ticket",
 				Description: "Test Description",
 				Allocation:  100,
+				Price:       &request.MoneyRequest{Amount: 1500, Currency: "EUR"},
 			},
 			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
 				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			want: &ticket.TicketDTO{
 				Name:        "Test Ticket",
 				Description: "Test Description",
 				Allocation:  100,
+				Price:       &valueobject.MoneyDTO{Amount: 1500, Currency: "EUR"},
 			},
 			wantErr: false,
 		},
@@ -63,6 +99,18 @@ func TestService_Create(t *testing.T) {
 				Name:        "",
 				Description: "Test Description",
 				Allocation:  10,
+				Price:       &request.MoneyRequest{Amount: 1500, Currency: "EUR"},
+			},
+			mock:    func() {},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "missing price error",
+			req: request.CreateTicketRequest{
+				Name:        "Test Ticket",
+				Description: "Test Description",
+				Allocation:  10,
 			},
 			mock:    func() {},
 			want:    nil,
@@ -74,8 +122,10 @@ func TestService_Create(t *testing.T) {
 				Name:        "Test Ticket",
 				Description: "Test Description",
 				Allocation:  100,
+				Price:       &request.MoneyRequest{Amount: 1500, Currency: "EUR"},
 			},
 			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
 				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("repo error"))
 			},
 			want:    nil,
@@ -95,6 +145,7 @@ func TestService_Create(t *testing.T) {
 			assert.Equal(t, tt.want.Name, got.Name)
 			assert.Equal(t, tt.want.Description, got.Description)
 			assert.Equal(t, tt.want.Allocation, got.Allocation)
+			assert.Equal(t, tt.want.Price, got.Price)
 		})
 	}
 }
@@ -103,7 +154,12 @@ func TestService_FindByID(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := repository.NewMockTicketRepository(ctrl)
-	service := NewTicketService(mockRepo)
+	mockPurchaseRepo := purchaseRepository.NewMockPurchaseRepository(ctrl)
+	mockEventRepo := eventRepository.NewMockEventRepository(ctrl)
+	mockSeatRepo := seatRepository.NewMockSeatRepository(ctrl)
+	mockPromotionRepo := promotionRepository.NewMockPromotionRepository(ctrl)
+	mockUow := mockdb.NewMockUnitOfWork(ctrl)
+	service := NewTicketService(mockUow, mockRepo, mockPurchaseRepo, mockEventRepo, mockSeatRepo, mockPromotionRepo, eventbus.New(), LockingPessimistic)
 
 	name, _ := valueobject.NewName("Test Ticket")
 	description, _ := valueobject.NewDescription("Test Description")
@@ -158,16 +214,117 @@ func TestService_FindByID(t *testing.T) {
 		})
 	}
 }
-func TestService_DecrementAllocation(t *testing.T) {
+func TestService_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockTicketRepository(ctrl)
+	mockPurchaseRepo := purchaseRepository.NewMockPurchaseRepository(ctrl)
+	mockEventRepo := eventRepository.NewMockEventRepository(ctrl)
+	mockSeatRepo := seatRepository.NewMockSeatRepository(ctrl)
+	mockPromotionRepo := promotionRepository.NewMockPromotionRepository(ctrl)
+	mockUow := mockdb.NewMockUnitOfWork(ctrl)
+	service := NewTicketService(mockUow, mockRepo, mockPurchaseRepo, mockEventRepo, mockSeatRepo, mockPromotionRepo, eventbus.New(), LockingPessimistic)
+
+	name, _ := valueobject.NewName("Test Ticket")
+	description, _ := valueobject.NewDescription("Test Description")
+	allocation, _ := valueobject.NewAllocation(100)
+	soldOut := true
+	tickets := []*ticket.Ticket{
+		{ID: 1, Name: name, Description: description, Allocation: allocation},
+		{ID: 2, Name: name, Description: description, Allocation: allocation},
+	}
+
+	tests := []struct {
+		name           string
+		req            request.ListTicketsRequest
+		mock           func()
+		wantTotal      int64
+		wantNextCursor string
+		wantErr        error
+	}{
+		{
+			name: "success with defaults",
+			req:  request.ListTicketsRequest{},
+			mock: func() {
+				mockRepo.EXPECT().List(gomock.Any(), ticket.ListFilter{
+					Offset: 0,
+					Limit:  pagination.DefaultLimit,
+				}).Return(tickets, int64(2), nil)
+			},
+			wantTotal:      2,
+			wantNextCursor: "",
+		},
+		{
+			name: "success with filters and next cursor",
+			req: request.ListTicketsRequest{
+				Name:    "test",
+				SoldOut: &soldOut,
+				SortBy:  "created_at",
+				Order:   "desc",
+				Limit:   2,
+				Cursor:  pagination.EncodeCursor(2),
+			},
+			mock: func() {
+				mockRepo.EXPECT().List(gomock.Any(), ticket.ListFilter{
+					Name:     "test",
+					SoldOut:  &soldOut,
+					SortBy:   ticket.SortByCreatedAt,
+					SortDesc: true,
+					Offset:   2,
+					Limit:    2,
+				}).Return(tickets, int64(10), nil)
+			},
+			wantTotal:      10,
+			wantNextCursor: pagination.EncodeCursor(4),
+		},
+		{
+			name:    "invalid cursor",
+			req:     request.ListTicketsRequest{Cursor: "invalid!"},
+			mock:    func() {},
+			wantErr: pagination.ErrInvalidCursor,
+		},
+		{
+			name: "repo error",
+			req:  request.ListTicketsRequest{},
+			mock: func() {
+				mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, int64(0), errors.New("repo error"))
+			},
+			wantErr: errors.New("repo error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := service.List(context.Background(), tt.req)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got.Items, len(tickets))
+			assert.Equal(t, tt.wantTotal, got.Total)
+			assert.Equal(t, tt.wantNextCursor, got.NextCursor)
+		})
+	}
+}
+func TestService_Purchase(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := repository.NewMockTicketRepository(ctrl)
-	service := NewTicketService(mockRepo)
+	mockPurchaseRepo := purchaseRepository.NewMockPurchaseRepository(ctrl)
+	mockEventRepo := eventRepository.NewMockEventRepository(ctrl)
+	mockSeatRepo := seatRepository.NewMockSeatRepository(ctrl)
+	mockPromotionRepo := promotionRepository.NewMockPromotionRepository(ctrl)
+	mockUow := mockdb.NewMockUnitOfWork(ctrl)
+	service := NewTicketService(mockUow, mockRepo, mockPurchaseRepo, mockEventRepo, mockSeatRepo, mockPromotionRepo, eventbus.New(), LockingPessimistic)
 
 	name, _ := valueobject.NewName("Test Ticket")
 	description, _ := valueobject.NewDescription("Test Description")
 	allocation, _ := valueobject.NewAllocation(100)
+	price, _ := valueobject.NewMoney(1500, "EUR")
 
 	tests := []struct {
 		name     string
@@ -181,23 +338,17 @@ func TestService_DecrementAllocation(t *testing.T) {
 			ticketID: 1,
 			amount:   50,
 			mock: func() {
-				mockDb, mock, _ := sqlmock.New()
-				mock.ExpectBegin()
-				mock.ExpectCommit()
-				mock.ExpectRollback()
-				dialector := postgres.New(postgres.Config{
-					Conn:       mockDb,
-					DriverName: "postgres",
-				})
-				db, _ := gorm.Open(dialector, &gorm.Config{})
-
-				mockRepo.EXPECT().GetDB(gomock.Any()).Return(db)
-				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1, gomock.Any()).Return(&ticket.Ticket{
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(&ticket.Ticket{
+					ID:          1,
+					Status:      ticket.StatusPublished,
 					Name:        name,
 					Description: description,
 					Allocation:  allocation,
+					Price:       price,
 				}, nil)
-				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+				mockPurchaseRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -206,17 +357,8 @@ func TestService_DecrementAllocation(t *testing.T) {
 			ticketID: 2,
 			amount:   50,
 			mock: func() {
-				mockDb, mock, _ := sqlmock.New()
-				mock.ExpectBegin()
-				mock.ExpectRollback()
-				dialector := postgres.New(postgres.Config{
-					Conn:       mockDb,
-					DriverName: "postgres",
-				})
-				db, _ := gorm.Open(dialector, &gorm.Config{})
-
-				mockRepo.EXPECT().GetDB(gomock.Any()).Return(db)
-				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 2, gomock.Any()).Return(nil, errors.New("not found"))
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 2).Return(nil, errors.New("not found"))
 			},
 			wantErr: true,
 		},
@@ -225,20 +367,14 @@ func TestService_DecrementAllocation(t *testing.T) {
 			ticketID: 1,
 			amount:   150,
 			mock: func() {
-				mockDb, mock, _ := sqlmock.New()
-				mock.ExpectBegin()
-				mock.ExpectRollback()
-				dialector := postgres.New(postgres.Config{
-					Conn:       mockDb,
-					DriverName: "postgres",
-				})
-				db, _ := gorm.Open(dialector, &gorm.Config{})
-
-				mockRepo.EXPECT().GetDB(gomock.Any()).Return(db)
-				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1, gomock.Any()).Return(&ticket.Ticket{
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(&ticket.Ticket{
+					ID:          1,
+					Status:      ticket.StatusPublished,
 					Name:        name,
 					Description: description,
 					Allocation:  allocation,
+					Price:       price,
 				}, nil)
 			},
 			wantErr: true,
@@ -248,22 +384,35 @@ func TestService_DecrementAllocation(t *testing.T) {
 			ticketID: 1,
 			amount:   50,
 			mock: func() {
-				mockDb, mock, _ := sqlmock.New()
-				mock.ExpectBegin()
-				mock.ExpectRollback()
-				dialector := postgres.New(postgres.Config{
-					Conn:       mockDb,
-					DriverName: "postgres",
-				})
-				db, _ := gorm.Open(dialector, &gorm.Config{})
-
-				mockRepo.EXPECT().GetDB(gomock.Any()).Return(db)
-				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1, gomock.Any()).Return(&ticket.Ticket{
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(&ticket.Ticket{
+					ID:          1,
+					Status:      ticket.StatusPublished,
+					Name:        name,
+					Description: description,
+					Allocation:  allocation,
+					Price:       price,
+				}, nil)
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("update error"))
+			},
+			wantErr: true,
+		},
+		{
+			name:     "purchase create error",
+			ticketID: 1,
+			amount:   50,
+			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(&ticket.Ticket{
+					ID:          1,
+					Status:      ticket.StatusPublished,
 					Name:        name,
 					Description: description,
 					Allocation:  allocation,
+					Price:       price,
 				}, nil)
-				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("update error"))
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+				mockPurchaseRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("create error"))
 			},
 			wantErr: true,
 		},
@@ -272,12 +421,1005 @@ func TestService_DecrementAllocation(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
-			err := service.DecrementAllocation(context.Background(), tt.ticketID, tt.amount)
+			got, err := service.Purchase(context.Background(), tt.ticketID, request.PurchaseTicketRequest{
+				Quantity: tt.amount,
+				UserID:   "1250052d-c061-4a1f-81f0-d88af3dcb3d5",
+			})
 			if tt.wantErr {
 				assert.Error(t, err)
 				return
 			}
 			assert.NoError(t, err)
+			assert.Equal(t, tt.ticketID, got.TicketID)
+			assert.Equal(t, tt.amount, got.Quantity)
+			assert.Equal(t, &valueobject.MoneyDTO{Amount: 1500, Currency: "EUR"}, got.UnitPrice)
+			assert.Equal(t, &valueobject.MoneyDTO{Amount: int64(1500 * tt.amount), Currency: "EUR"}, got.Total)
+		})
+	}
+}
+
+func TestService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockTicketRepository(ctrl)
+	mockPurchaseRepo := purchaseRepository.NewMockPurchaseRepository(ctrl)
+	mockEventRepo := eventRepository.NewMockEventRepository(ctrl)
+	mockSeatRepo := seatRepository.NewMockSeatRepository(ctrl)
+	mockPromotionRepo := promotionRepository.NewMockPromotionRepository(ctrl)
+	mockUow := mockdb.NewMockUnitOfWork(ctrl)
+	service := NewTicketService(mockUow, mockRepo, mockPurchaseRepo, mockEventRepo, mockSeatRepo, mockPromotionRepo, eventbus.New(), LockingPessimistic)
+
+	newName := "Renamed Ticket"
+	newAllocation := 150
+	lowAllocation := 5
+	newPrice := &request.MoneyRequest{Amount: 2500, Currency: "USD"}
+	currentVersion := 1
+	staleVersion := 0
+
+	newTicket := func() *ticket.Ticket {
+		tk, _ := ticket.NewTicket("Test Ticket", "Test Description", 100, 1500, "EUR", time.Now())
+		tk.ID = 1
+		tk.Sold = 10
+		return tk
+	}
+
+	tests := []struct {
+		name           string
+		req            request.UpdateTicketRequest
+		version        *int
+		mock           func()
+		wantName       string
+		wantAllocation int
+		wantPrice      *valueobject.MoneyDTO
+		wantErr        error
+	}{
+		{
+			name: "success",
+			req:  request.UpdateTicketRequest{Name: &newName, TotalAllocation: &newAllocation},
+			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newTicket(), nil)
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantName:       newName,
+			wantAllocation: newAllocation - 10,
+			wantPrice:      &valueobject.MoneyDTO{Amount: 1500, Currency: "EUR"},
+		},
+		{
+			name: "price change",
+			req:  request.UpdateTicketRequest{Price: newPrice},
+			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newTicket(), nil)
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantName:       "Test Ticket",
+			wantAllocation: 100,
+			wantPrice:      &valueobject.MoneyDTO{Amount: 2500, Currency: "USD"},
+		},
+		{
+			name: "invalid price error",
+			req:  request.UpdateTicketRequest{Price: &request.MoneyRequest{Amount: -1, Currency: "USD"}},
+			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newTicket(), nil)
+			},
+			wantErr: valueobject.ErrInvalidAmount,
+		},
+		{
+			name:    "matching version",
+			req:     request.UpdateTicketRequest{Name: &newName},
+			version: &currentVersion,
+			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newTicket(), nil)
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantName:       newName,
+			wantAllocation: 100,
+			wantPrice:      &valueobject.MoneyDTO{Amount: 1500, Currency: "EUR"},
+		},
+		{
+			name:    "version mismatch error",
+			req:     request.UpdateTicketRequest{Name: &newName},
+			version: &staleVersion,
+			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newTicket(), nil)
+			},
+			wantErr: ticket.ErrVersionMismatch,
+		},
+		{
+			name: "ticket not found error",
+			req:  request.UpdateTicketRequest{Name: &newName},
+			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(nil, ticket.ErrTicketNotFound)
+			},
+			wantErr: ticket.ErrTicketNotFound,
+		},
+		{
+			name: "allocation below sold error",
+			req:  request.UpdateTicketRequest{TotalAllocation: &lowAllocation},
+			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newTicket(), nil)
+			},
+			wantErr: ticket.ErrAllocationBelowSold,
+		},
+		{
+			name: "update error",
+			req:  request.UpdateTicketRequest{Name: &newName},
+			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newTicket(), nil)
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("update error"))
+			},
+			wantErr: errors.New("update error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := service.Update(context.Background(), 1, tt.req, tt.version)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantName, got.Name)
+			assert.Equal(t, tt.wantAllocation, got.Allocation)
+			assert.Equal(t, tt.wantPrice, got.Price)
 		})
 	}
 }
+
+func TestService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockTicketRepository(ctrl)
+	mockPurchaseRepo := purchaseRepository.NewMockPurchaseRepository(ctrl)
+	mockEventRepo := eventRepository.NewMockEventRepository(ctrl)
+	mockSeatRepo := seatRepository.NewMockSeatRepository(ctrl)
+	mockPromotionRepo := promotionRepository.NewMockPromotionRepository(ctrl)
+	mockUow := mockdb.NewMockUnitOfWork(ctrl)
+	service := NewTicketService(mockUow, mockRepo, mockPurchaseRepo, mockEventRepo, mockSeatRepo, mockPromotionRepo, eventbus.New(), LockingPessimistic)
+
+	tk, _ := ticket.NewTicket("Test Ticket", "Test Description", 100, 1500, "EUR", time.Now())
+
+	t.Run("success", func(t *testing.T) {
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+		mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(tk, nil)
+		mockRepo.EXPECT().Delete(gomock.Any(), tk).Return(nil)
+
+		assert.NoError(t, service.Delete(context.Background(), 1, nil))
+	})
+
+	t.Run("not found error", func(t *testing.T) {
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+		mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 2).Return(nil, ticket.ErrTicketNotFound)
+
+		assert.ErrorIs(t, service.Delete(context.Background(), 2, nil), ticket.ErrTicketNotFound)
+	})
+
+	t.Run("version mismatch error", func(t *testing.T) {
+		stale := tk.Version + 1
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+		mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(tk, nil)
+
+		assert.ErrorIs(t, service.Delete(context.Background(), 1, &stale), ticket.ErrVersionMismatch)
+	})
+
+	t.Run("held units error", func(t *testing.T) {
+		held, _ := ticket.NewTicket("Test Ticket", "Test Description", 100, 1500, "EUR", time.Now())
+		held.Held = 2
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+		mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(held, nil)
+
+		assert.ErrorIs(t, service.Delete(context.Background(), 1, nil), ticket.ErrTicketHasHolds)
+	})
+
+	t.Run("version conflict error", func(t *testing.T) {
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+		mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(tk, nil)
+		mockRepo.EXPECT().Delete(gomock.Any(), tk).Return(ticket.ErrVersionConflict)
+
+		assert.ErrorIs(t, service.Delete(context.Background(), 1, nil), ticket.ErrVersionConflict)
+	})
+}
+
+func TestService_Restore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockTicketRepository(ctrl)
+	mockPurchaseRepo := purchaseRepository.NewMockPurchaseRepository(ctrl)
+	mockEventRepo := eventRepository.NewMockEventRepository(ctrl)
+	mockSeatRepo := seatRepository.NewMockSeatRepository(ctrl)
+	mockPromotionRepo := promotionRepository.NewMockPromotionRepository(ctrl)
+	mockUow := mockdb.NewMockUnitOfWork(ctrl)
+	service := NewTicketService(mockUow, mockRepo, mockPurchaseRepo, mockEventRepo, mockSeatRepo, mockPromotionRepo, eventbus.New(), LockingPessimistic)
+
+	t.Run("success", func(t *testing.T) {
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+		tk, _ := ticket.NewTicket("Test Ticket", "Test Description", 100, 1500, "EUR", time.Now())
+		tk.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+		mockRepo.EXPECT().FindByIDUnscoped(gomock.Any(), 1).Return(tk, nil)
+		mockRepo.EXPECT().Restore(gomock.Any(), tk).Return(nil)
+
+		got, err := service.Restore(context.Background(), 1, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "Test Ticket", got.Name)
+		assert.False(t, tk.IsDeleted())
+	})
+
+	t.Run("not deleted error", func(t *testing.T) {
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+		tk, _ := ticket.NewTicket("Test Ticket", "Test Description", 100, 1500, "EUR", time.Now())
+		mockRepo.EXPECT().FindByIDUnscoped(gomock.Any(), 1).Return(tk, nil)
+
+		_, err := service.Restore(context.Background(), 1, nil)
+		assert.ErrorIs(t, err, ticket.ErrTicketNotDeleted)
+	})
+
+	t.Run("not found error", func(t *testing.T) {
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+		mockRepo.EXPECT().FindByIDUnscoped(gomock.Any(), 2).Return(nil, ticket.ErrTicketNotFound)
+
+		_, err := service.Restore(context.Background(), 2, nil)
+		assert.ErrorIs(t, err, ticket.ErrTicketNotFound)
+	})
+
+	t.Run("version mismatch error", func(t *testing.T) {
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+		tk, _ := ticket.NewTicket("Test Ticket", "Test Description", 100, 1500, "EUR", time.Now())
+		tk.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+		stale := tk.Version + 1
+		mockRepo.EXPECT().FindByIDUnscoped(gomock.Any(), 1).Return(tk, nil)
+
+		_, err := service.Restore(context.Background(), 1, &stale)
+		assert.ErrorIs(t, err, ticket.ErrVersionMismatch)
+		assert.True(t, tk.IsDeleted())
+	})
+}
+
+func TestService_PurchaseOptimistic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockTicketRepository(ctrl)
+	mockPurchaseRepo := purchaseRepository.NewMockPurchaseRepository(ctrl)
+	mockEventRepo := eventRepository.NewMockEventRepository(ctrl)
+	mockSeatRepo := seatRepository.NewMockSeatRepository(ctrl)
+	mockPromotionRepo := promotionRepository.NewMockPromotionRepository(ctrl)
+	mockUow := mockdb.NewMockUnitOfWork(ctrl)
+	service := NewTicketService(mockUow, mockRepo, mockPurchaseRepo, mockEventRepo, mockSeatRepo, mockPromotionRepo, eventbus.New(), LockingOptimistic)
+
+	newTicket := func() *ticket.Ticket {
+		tk, _ := ticket.NewTicket("Test Ticket", "Test Description", 10, 1500, "EUR", time.Now())
+		tk.ID = 1
+		tk.Status = ticket.StatusPublished
+		return tk
+	}
+
+	req := request.PurchaseTicketRequest{Quantity: 2, UserID: "1250052d-c061-4a1f-81f0-d88af3dcb3d5"}
+
+	t.Run("retries after a version conflict", func(t *testing.T) {
+		gomock.InOrder(
+			mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction),
+			mockRepo.EXPECT().FindByID(gomock.Any(), 1).Return(newTicket(), nil),
+			mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(ticket.ErrVersionConflict),
+			mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction),
+			mockRepo.EXPECT().FindByID(gomock.Any(), 1).Return(newTicket(), nil),
+			mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil),
+		)
+		mockPurchaseRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		got, err := service.Purchase(context.Background(), 1, req)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, got.Quantity)
+	})
+
+	t.Run("gives up after too many conflicts", func(t *testing.T) {
+		for i := 0; i < maxOptimisticAttempts; i++ {
+			mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+			mockRepo.EXPECT().FindByID(gomock.Any(), 1).Return(newTicket(), nil)
+			mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(ticket.ErrVersionConflict)
+		}
+
+		_, err := service.Purchase(context.Background(), 1, req)
+		assert.ErrorIs(t, err, ticket.ErrVersionConflict)
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+		mockRepo.EXPECT().FindByID(gomock.Any(), 1).Return(newTicket(), nil)
+
+		_, err := service.Purchase(context.Background(), 1, request.PurchaseTicketRequest{Quantity: 20, UserID: req.UserID})
+		assert.ErrorIs(t, err, ticket.ErrInsufficientAllocation)
+	})
+}
+
+func TestService_PurchasePublishesEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockTicketRepository(ctrl)
+	mockPurchaseRepo := purchaseRepository.NewMockPurchaseRepository(ctrl)
+	mockEventRepo := eventRepository.NewMockEventRepository(ctrl)
+	mockSeatRepo := seatRepository.NewMockSeatRepository(ctrl)
+	mockPromotionRepo := promotionRepository.NewMockPromotionRepository(ctrl)
+	bus := eventbus.New()
+	service := NewTicketService(db.NewMemoryUnitOfWork(), mockRepo, mockPurchaseRepo, mockEventRepo, mockSeatRepo, mockPromotionRepo, db.PublishAfterCommit(bus), LockingPessimistic)
+
+	var published []string
+	bus.SubscribeAll(func(ctx context.Context, e eventbus.Event) error {
+		published = append(published, e.EventName())
+		return nil
+	})
+
+	newTicket := func() *ticket.Ticket {
+		allocation, _ := valueobject.NewAllocation(2)
+		price, _ := valueobject.NewMoney(1500, "EUR")
+		return &ticket.Ticket{ID: 1, Status: ticket.StatusPublished, Allocation: allocation, Price: price}
+	}
+
+	req := request.PurchaseTicketRequest{Quantity: 2, UserID: "1250052d-c061-4a1f-81f0-d88af3dcb3d5"}
+
+	t.Run("publishes after the purchase committed", func(t *testing.T) {
+		published = nil
+		mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newTicket(), nil)
+		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		mockPurchaseRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, p *purchase.Purchase) error {
+			assert.Empty(t, published, "events must not be published before the commit")
+			p.ID = 7
+			return nil
+		})
+
+		var completed purchase.PurchaseCompleted
+		eventbus.Subscribe(bus, func(ctx context.Context, e purchase.PurchaseCompleted) error {
+			completed = e
+			return nil
+		})
+
+		_, err := service.Purchase(context.Background(), 1, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{ticket.EventAllocationDecremented, ticket.EventTicketSoldOut, purchase.EventPurchaseCompleted}, published)
+		assert.Equal(t, 7, completed.PurchaseID)
+		assert.Equal(t, 2, completed.Quantity)
+		assert.Equal(t, &valueobject.MoneyDTO{Amount: 3000, Currency: "EUR"}, completed.Total)
+	})
+
+	t.Run("does not publish when the purchase is rolled back", func(t *testing.T) {
+		published = nil
+		mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(newTicket(), nil)
+		mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		mockPurchaseRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("insert failed"))
+
+		_, err := service.Purchase(context.Background(), 1, req)
+		assert.EqualError(t, err, "insert failed")
+		assert.Empty(t, published)
+	})
+}
+
+func TestService_UpdatePublishesEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockTicketRepository(ctrl)
+	bus := eventbus.New()
+	service := NewTicketService(db.NewMemoryUnitOfWork(), mockRepo, purchaseRepository.NewMockPurchaseRepository(ctrl), eventRepository.NewMockEventRepository(ctrl), seatRepository.NewMockSeatRepository(ctrl), promotionRepository.NewMockPromotionRepository(ctrl), db.PublishAfterCommit(bus), LockingPessimistic)
+
+	var restored []ticket.AllocationRestored
+	eventbus.Subscribe(bus, func(ctx context.Context, e ticket.AllocationRestored) error {
+		restored = append(restored, e)
+		return nil
+	})
+
+	tk, err := ticket.NewTicket("Test Ticket", "Test Description", 2, 1500, "EUR", time.Now())
+	assert.NoError(t, err)
+	tk.ID = 1
+	assert.NoError(t, tk.Publish(time.Now()))
+	assert.NoError(t, tk.DecrementAllocation(context.Background(), 2, time.Now()))
+	tk.PullEvents()
+	mockRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(tk, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), tk).Return(nil)
+
+	total := 5
+	_, err = service.Update(context.Background(), 1, request.UpdateTicketRequest{TotalAllocation: &total}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []ticket.AllocationRestored{{TicketID: 1, Quantity: 3, Remaining: 3, At: restored[0].At}}, restored)
+}
+
+func TestService_PurchaseLimit(t *testing.T) {
+	ctx := context.Background()
+	purchases := purchaseRepositoryImpl.NewMemoryPurchaseRepository()
+	service := NewTicketService(db.NewMemoryUnitOfWork(), ticketRepository.NewMemoryTicketRepository(), purchases, eventRepositoryImpl.NewMemoryEventRepository(), seatRepositoryImpl.NewMemorySeatRepository(), promotionRepositoryImpl.NewMemoryPromotionRepository(), eventbus.New(), LockingPessimistic)
+
+	created, err := service.Create(ctx, request.CreateTicketRequest{
+		Name:        "Test Ticket",
+		Description: "Test Description",
+		Allocation:  100,
+		Price:       &request.MoneyRequest{Amount: 1500, Currency: "EUR"},
+		MaxPerUser:  4,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, *created.MaxPerUser)
+
+	buyer := "1250052d-c061-4a1f-81f0-d88af3dcb3d5"
+	other := "8f0c5b1e-4a43-4d6e-9a3c-5f2b1d7e9c10"
+	buy := func(userID string, quantity int) error {
+		_, err := service.Purchase(ctx, created.ID, request.PurchaseTicketRequest{Quantity: quantity, UserID: userID})
+		return err
+	}
+
+	t.Run("should add up the purchases of a user", func(t *testing.T) {
+		assert.NoError(t, buy(buyer, 3))
+
+		err := buy(buyer, 2)
+		assert.ErrorIs(t, err, ticket.ErrPurchaseLimitExceeded)
+		var limitErr *ticket.PurchaseLimitError
+		assert.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, 1, limitErr.Remaining)
+
+		found, err := service.FindByID(ctx, created.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, found.Sold, "a rejected purchase must not change the allocation")
+
+		assert.NoError(t, buy(buyer, 1))
+	})
+
+	t.Run("should count every user on their own", func(t *testing.T) {
+		assert.NoError(t, buy(other, 4))
+	})
+
+	t.Run("should not count refunded units", func(t *testing.T) {
+		owned, err := purchases.OwnedQuantity(ctx, created.ID, buyer)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, owned)
+
+		p, err := purchases.FindByIDForUpdate(ctx, 1)
+		assert.NoError(t, err)
+		_, err = p.Refund(2)
+		assert.NoError(t, err)
+		assert.NoError(t, purchases.Update(ctx, p))
+
+		assert.NoError(t, buy(buyer, 2))
+	})
+
+	t.Run("should not limit purchases once the limit is removed", func(t *testing.T) {
+		noLimit := 0
+		updated, err := service.Update(ctx, created.ID, request.UpdateTicketRequest{MaxPerUser: &noLimit}, nil)
+		assert.NoError(t, err)
+		assert.Nil(t, updated.MaxPerUser)
+
+		assert.NoError(t, buy(buyer, 10))
+	})
+}
+
+func TestService_SalesWindow(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 11, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	clock := start.Add(-time.Hour)
+
+	service := &Service{
+		uow:          db.NewMemoryUnitOfWork(),
+		repo:         ticketRepository.NewMemoryTicketRepository(),
+		purchaseRepo: purchaseRepositoryImpl.NewMemoryPurchaseRepository(),
+		events:       eventbus.New(),
+		locking:      LockingPessimistic,
+		now:          func() time.Time { return clock },
+	}
+
+	created, err := service.Create(ctx, request.CreateTicketRequest{
+		Name:         "Test Ticket",
+		Description:  "Test Description",
+		Allocation:   2,
+		Price:        &request.MoneyRequest{Amount: 1500, Currency: "EUR"},
+		SalesStartAt: &start,
+		SalesEndAt:   &end,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "upcoming", created.SaleStatus)
+
+	buy := func(quantity int) error {
+		_, err := service.Purchase(ctx, created.ID, request.PurchaseTicketRequest{Quantity: quantity, UserID: "1250052d-c061-4a1f-81f0-d88af3dcb3d5"})
+		return err
+	}
+	status := func() string {
+		found, err := service.FindByID(ctx, created.ID)
+		assert.NoError(t, err)
+		return found.SaleStatus
+	}
+
+	assert.ErrorIs(t, buy(1), ticket.ErrSalesNotOpen)
+
+	clock = start
+	assert.Equal(t, "on_sale", status())
+	assert.NoError(t, buy(2))
+	assert.Equal(t, "sold_out", status())
+
+	clock = end
+	assert.Equal(t, "closed", status())
+	assert.ErrorIs(t, buy(1), ticket.ErrSalesClosed)
+
+	t.Run("should move the end of the window", func(t *testing.T) {
+		later := end.Add(time.Hour)
+		updated, err := service.Update(ctx, created.ID, request.UpdateTicketRequest{SalesEndAt: &later}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, &start, updated.SalesStartAt)
+		assert.Equal(t, &later, updated.SalesEndAt)
+		assert.Equal(t, "sold_out", updated.SaleStatus)
+	})
+
+	t.Run("should reject a window that ends before it starts", func(t *testing.T) {
+		before := start.Add(-time.Hour)
+		_, err := service.Update(ctx, created.ID, request.UpdateTicketRequest{SalesEndAt: &before}, nil)
+		assert.ErrorIs(t, err, ticket.ErrInvalidSalesWindow)
+	})
+}
+
+func TestService_Transition(t *testing.T) {
+	ctx := context.Background()
+	bus := eventbus.New()
+	var changes []ticket.TicketStatusChanged
+	eventbus.Subscribe(bus, func(ctx context.Context, e ticket.TicketStatusChanged) error {
+		changes = append(changes, e)
+		return nil
+	})
+	service := NewTicketService(db.NewMemoryUnitOfWork(), ticketRepository.NewMemoryTicketRepository(), purchaseRepositoryImpl.NewMemoryPurchaseRepository(), eventRepositoryImpl.NewMemoryEventRepository(), seatRepositoryImpl.NewMemorySeatRepository(), promotionRepositoryImpl.NewMemoryPromotionRepository(), bus, LockingPessimistic)
+
+	created, err := service.Create(ctx, request.CreateTicketRequest{
+		Name:        "Test Ticket",
+		Description: "Test Description",
+		Allocation:  10,
+		Price:       &request.MoneyRequest{Amount: 1500, Currency: "EUR"},
+		Status:      "draft",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "draft", created.Status)
+	assert.Empty(t, changes)
+
+	buy := func() error {
+		_, err := service.Purchase(ctx, created.ID, request.PurchaseTicketRequest{Quantity: 1, UserID: "1250052d-c061-4a1f-81f0-d88af3dcb3d5"})
+		return err
+	}
+	assert.ErrorIs(t, buy(), ticket.ErrTicketNotPublished)
+
+	published, err := service.Transition(ctx, created.ID, ticket.TransitionPublish, &created.Version)
+	assert.NoError(t, err)
+	assert.Equal(t, "published", published.Status)
+	assert.Equal(t, created.Version+1, published.Version)
+	assert.NoError(t, buy())
+
+	_, err = service.Transition(ctx, created.ID, ticket.TransitionPause, &created.Version)
+	assert.ErrorIs(t, err, ticket.ErrVersionMismatch)
+
+	paused, err := service.Transition(ctx, created.ID, ticket.TransitionPause, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "closed", paused.SaleStatus)
+	assert.ErrorIs(t, buy(), ticket.ErrTicketNotPublished)
+
+	_, err = service.Transition(ctx, created.ID, ticket.TransitionPublish, nil)
+	assert.ErrorIs(t, err, ticket.ErrInvalidTransition)
+
+	_, err = service.Transition(ctx, created.ID, ticket.TransitionResume, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, buy())
+
+	archived, err := service.Transition(ctx, created.ID, ticket.TransitionArchive, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "archived", archived.Status)
+
+	_, err = service.Transition(ctx, 999, ticket.TransitionPublish, nil)
+	assert.ErrorIs(t, err, ticket.ErrTicketNotFound)
+
+	assert.Equal(t, []ticket.TicketStatusChanged{
+		{TicketID: created.ID, From: "draft", To: "published", At: changes[0].At},
+		{TicketID: created.ID, From: "published", To: "paused", At: changes[1].At},
+		{TicketID: created.ID, From: "paused", To: "published", At: changes[2].At},
+		{TicketID: created.ID, From: "published", To: "archived", At: changes[3].At},
+	}, changes)
+}
+
+func TestService_Ledger(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockTicketRepository(ctrl)
+	mockPurchaseRepo := purchaseRepository.NewMockPurchaseRepository(ctrl)
+	mockEventRepo := eventRepository.NewMockEventRepository(ctrl)
+	mockSeatRepo := seatRepository.NewMockSeatRepository(ctrl)
+	mockPromotionRepo := promotionRepository.NewMockPromotionRepository(ctrl)
+	mockUow := mockdb.NewMockUnitOfWork(ctrl)
+	service := NewTicketService(mockUow, mockRepo, mockPurchaseRepo, mockEventRepo, mockSeatRepo, mockPromotionRepo, eventbus.New(), LockingPessimistic)
+
+	t.Run("success", func(t *testing.T) {
+		tk := &ticket.Ticket{ID: 1}
+		entries := []*ticket.LedgerEntry{
+			{ID: 3, TicketID: 1, Delta: 10, Allocation: 10, Reason: ticket.ReasonCreate},
+			{ID: 4, TicketID: 1, Delta: -2, Allocation: 8, Reason: ticket.ReasonPurchase, ActorID: "user-1", RequestID: "req-1"},
+		}
+		mockRepo.EXPECT().FindByIDUnscoped(gomock.Any(), 1).Return(tk, nil)
+		mockRepo.EXPECT().ListLedger(gomock.Any(), 1, 0, 2).Return(entries, int64(3), nil)
+
+		got, err := service.Ledger(context.Background(), 1, request.ListLedgerRequest{Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, got.TicketID)
+		assert.Equal(t, int64(3), got.Total)
+		assert.Equal(t, pagination.EncodeCursor(2), got.NextCursor)
+		assert.Len(t, got.Items, 2)
+		assert.Equal(t, "purchase", got.Items[1].Reason)
+		assert.Equal(t, "user-1", got.Items[1].ActorID)
+		assert.Equal(t, "req-1", got.Items[1].RequestID)
+	})
+
+	t.Run("not found error", func(t *testing.T) {
+		mockRepo.EXPECT().FindByIDUnscoped(gomock.Any(), 2).Return(nil, ticket.ErrTicketNotFound)
+
+		_, err := service.Ledger(context.Background(), 2, request.ListLedgerRequest{})
+		assert.ErrorIs(t, err, ticket.ErrTicketNotFound)
+	})
+
+	t.Run("invalid cursor error", func(t *testing.T) {
+		_, err := service.Ledger(context.Background(), 1, request.ListLedgerRequest{Cursor: "!"})
+		assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+	})
+}
+
+func TestService_CheckLedger(t *testing.T) {
+	ctx := context.Background()
+	repo := ticketRepository.NewMemoryTicketRepository()
+	service := NewTicketService(db.NewMemoryUnitOfWork(), repo, purchaseRepositoryImpl.NewMemoryPurchaseRepository(), eventRepositoryImpl.NewMemoryEventRepository(), seatRepositoryImpl.NewMemorySeatRepository(), promotionRepositoryImpl.NewMemoryPromotionRepository(), eventbus.New(), LockingPessimistic)
+
+	for i := 0; i < ledgerCheckPageSize+1; i++ {
+		_, err := service.Create(ctx, request.CreateTicketRequest{
+			Name:        fmt.Sprintf("Ticket %d", i),
+			Description: "Test Description",
+			Allocation:  10,
+			Price:       &request.MoneyRequest{Amount: 1500, Currency: "EUR"},
+		})
+		assert.NoError(t, err)
+	}
+
+	userID := "1250052d-c061-4a1f-81f0-d88af3dcb3d5"
+	_, err := service.Purchase(ctx, 1, request.PurchaseTicketRequest{Quantity: 3, UserID: userID})
+	assert.NoError(t, err)
+
+	ledger, err := service.Ledger(ctx, 1, request.ListLedgerRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, ledger.Items, 2)
+	assert.Equal(t, userID, ledger.Items[1].ActorID, "the purchase is made for the buying user")
+
+	t.Run("should pass when every ledger adds up", func(t *testing.T) {
+		report, err := service.CheckLedger(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, ledgerCheckPageSize+1, report.Checked)
+		assert.Empty(t, report.Mismatches)
+	})
+
+	t.Run("should report a ticket changed without a ledger entry", func(t *testing.T) {
+		tk, err := repo.FindByID(ctx, 2)
+		assert.NoError(t, err)
+		assert.NoError(t, tk.DecrementAllocation(ctx, 4, time.Now()))
+		tk.PullLedger()
+		assert.NoError(t, repo.Update(ctx, tk))
+
+		report, err := service.CheckLedger(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, ledgerCheckPageSize+1, report.Checked)
+		assert.Len(t, report.Mismatches, 1)
+
+		mismatch := report.Mismatches[0]
+		assert.Equal(t, 2, mismatch.TicketID)
+		assert.Equal(t, 6, mismatch.Allocation)
+		assert.Equal(t, 10, mismatch.LedgerAllocation)
+		assert.Equal(t, 1, mismatch.Entries)
+		assert.Contains(t, mismatch.Error, ticket.ErrLedgerMismatch.Error())
+	})
+
+	t.Run("should check the ticket as it is when its ledger is read", func(t *testing.T) {
+		stale := NewTicketService(db.NewMemoryUnitOfWork(), &sellAfterList{TicketRepository: repo}, purchaseRepositoryImpl.NewMemoryPurchaseRepository(), eventRepositoryImpl.NewMemoryEventRepository(), seatRepositoryImpl.NewMemorySeatRepository(), promotionRepositoryImpl.NewMemoryPromotionRepository(), eventbus.New(), LockingPessimistic)
+
+		report, err := stale.CheckLedger(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, report.Mismatches, 1, "only the ticket changed without a ledger entry")
+	})
+}
+
+// sellAfterList sells a unit of every listed ticket after the page was read,
+// like a purchase that commits while the ledger is checked.
+type sellAfterList struct {
+	ticketRepository.TicketRepository
+}
+
+func (r *sellAfterList) List(ctx context.Context, filter ticket.ListFilter) ([]*ticket.Ticket, int64, error) {
+	tickets, total, err := r.TicketRepository.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, listed := range tickets {
+		tk, err := r.TicketRepository.FindByID(ctx, listed.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if err := tk.DecrementAllocation(ctx, 1, time.Now()); err != nil {
+			return nil, 0, err
+		}
+
+		if err := r.TicketRepository.Update(ctx, tk); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return tickets, total, nil
+}
+
+func TestService_EventCapacity(t *testing.T) {
+	ctx := context.Background()
+	events := eventRepositoryImpl.NewMemoryEventRepository()
+	service := NewTicketService(db.NewMemoryUnitOfWork(), ticketRepository.NewMemoryTicketRepository(), purchaseRepositoryImpl.NewMemoryPurchaseRepository(), events, seatRepositoryImpl.NewMemorySeatRepository(), promotionRepositoryImpl.NewMemoryPromotionRepository(), eventbus.New(), LockingPessimistic)
+
+	start := time.Date(2026, 11, 1, 19, 0, 0, 0, time.UTC)
+	e, _ := event.NewEvent(1, "Test Event", "Test Description", start, start.Add(3*time.Hour), 10)
+	assert.NoError(t, events.Create(ctx, e))
+
+	create := func(allocation int) (*ticket.TicketDTO, error) {
+		return service.Create(ctx, request.CreateTicketRequest{
+			EventID:     &e.ID,
+			Name:        "Test Ticket",
+			Description: "Test Description",
+			Allocation:  allocation,
+			Price:       &request.MoneyRequest{Amount: 1500, Currency: "EUR"},
+		})
+	}
+
+	first, err := create(6)
+	assert.NoError(t, err)
+	assert.Equal(t, &e.ID, first.EventID)
+
+	_, err = create(5)
+	assert.ErrorIs(t, err, event.ErrCapacityExceeded)
+
+	second, err := create(4)
+	assert.NoError(t, err)
+
+	_, err = service.Purchase(ctx, first.ID, request.PurchaseTicketRequest{Quantity: 2, UserID: "1250052d-c061-4a1f-81f0-d88af3dcb3d5"})
+	assert.NoError(t, err)
+
+	allocation := 7
+	_, err = service.Update(ctx, first.ID, request.UpdateTicketRequest{TotalAllocation: &allocation}, nil)
+	assert.ErrorIs(t, err, event.ErrCapacityExceeded)
+
+	allocation = 2
+	_, err = service.Update(ctx, first.ID, request.UpdateTicketRequest{TotalAllocation: &allocation}, nil)
+	assert.NoError(t, err)
+
+	assert.NoError(t, service.Delete(ctx, second.ID, nil))
+	_, err = create(6)
+	assert.NoError(t, err)
+
+	_, err = service.Restore(ctx, second.ID, nil)
+	assert.ErrorIs(t, err, event.ErrCapacityExceeded)
+
+	missing := 999
+	_, err = service.Create(ctx, request.CreateTicketRequest{
+		EventID:     &missing,
+		Name:        "Test Ticket",
+		Description: "Test Description",
+		Allocation:  1,
+		Price:       &request.MoneyRequest{Amount: 1500, Currency: "EUR"},
+	})
+	assert.ErrorIs(t, err, event.ErrEventNotFound)
+}
+
+func TestParseLockingMode(t *testing.T) {
+	mode, err := ParseLockingMode("optimistic")
+	assert.NoError(t, err)
+	assert.Equal(t, LockingOptimistic, mode)
+
+	mode, err = ParseLockingMode("pessimistic")
+	assert.NoError(t, err)
+	assert.Equal(t, LockingPessimistic, mode)
+
+	_, err = ParseLockingMode("none")
+	assert.Error(t, err)
+}
+
+func TestService_SeatMap(t *testing.T) {
+	ctx := context.Background()
+	seats := seatRepositoryImpl.NewMemorySeatRepository()
+	service := NewTicketService(db.NewMemoryUnitOfWork(), ticketRepository.NewMemoryTicketRepository(), purchaseRepositoryImpl.NewMemoryPurchaseRepository(), eventRepositoryImpl.NewMemoryEventRepository(), seats, promotionRepositoryImpl.NewMemoryPromotionRepository(), eventbus.New(), LockingPessimistic)
+	userID := "1250052d-c061-4a1f-81f0-d88af3dcb3d5"
+
+	created, err := service.Create(ctx, request.CreateTicketRequest{
+		Name:        "Test Ticket",
+		Description: "Test Description",
+		Allocation:  100,
+		Price:       &request.MoneyRequest{Amount: 1500, Currency: "EUR"},
+	})
+	assert.NoError(t, err)
+
+	_, err = service.SeatMap(ctx, created.ID)
+	assert.ErrorIs(t, err, ticket.ErrNoSeatMap)
+
+	_, err = service.Purchase(ctx, created.ID, request.PurchaseTicketRequest{SeatIDs: []int{1}, UserID: userID})
+	assert.ErrorIs(t, err, ticket.ErrNoSeatMap)
+
+	m, err := service.CreateSeatMap(ctx, created.ID, request.CreateSeatMapRequest{Sections: []request.SeatSectionRequest{
+		{Name: "Stalls", Rows: []request.SeatRowRequest{{Name: "A", Seats: 5}, {Name: "B", Seats: 5}}},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, 10, m.Available)
+
+	found, err := service.FindByID(ctx, created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "assigned", found.Seating)
+	assert.Equal(t, 10, found.Allocation)
+
+	_, err = service.CreateSeatMap(ctx, created.ID, request.CreateSeatMapRequest{Sections: []request.SeatSectionRequest{
+		{Name: "Stalls", Rows: []request.SeatRowRequest{{Name: "A", Seats: 5}}},
+	}})
+	assert.ErrorIs(t, err, ticket.ErrSeatMapExists)
+
+	allocation := 20
+	_, err = service.Update(ctx, created.ID, request.UpdateTicketRequest{TotalAllocation: &allocation}, nil)
+	assert.ErrorIs(t, err, ticket.ErrAssignedSeating)
+
+	all, _ := seats.ListByTicket(ctx, created.ID)
+	p, err := service.Purchase(ctx, created.ID, request.PurchaseTicketRequest{SeatIDs: []int{all[0].ID, all[1].ID}, UserID: userID})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, p.Quantity)
+	assert.Equal(t, []int{all[0].ID, all[1].ID}, p.SeatIDs)
+
+	_, err = service.Purchase(ctx, created.ID, request.PurchaseTicketRequest{SeatIDs: []int{all[1].ID, all[2].ID}, UserID: userID})
+	assert.ErrorIs(t, err, seat.ErrSeatUnavailable)
+
+	_, err = service.Purchase(ctx, created.ID, request.PurchaseTicketRequest{Quantity: 3, SeatIDs: []int{all[2].ID}, UserID: userID})
+	assert.ErrorIs(t, err, seat.ErrQuantityMismatch)
+
+	best, err := service.BestAvailableSeats(ctx, created.ID, request.BestAvailableSeatsRequest{Quantity: 3})
+	assert.NoError(t, err)
+	assert.Len(t, best.Items, 3)
+	assert.Equal(t, "A", best.Items[0].Row)
+	assert.Equal(t, 3, best.Items[0].Number)
+
+	p, err = service.Purchase(ctx, created.ID, request.PurchaseTicketRequest{Quantity: 4, UserID: userID})
+	assert.NoError(t, err)
+	assert.Len(t, p.SeatIDs, 4)
+
+	m, err = service.SeatMap(ctx, created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, m.Available)
+
+	found, err = service.FindByID(ctx, created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, found.Allocation)
+	assert.Equal(t, 6, found.Sold)
+
+	other, err := service.Create(ctx, request.CreateTicketRequest{
+		Name:        "Other Ticket",
+		Description: "Test Description",
+		Allocation:  10,
+		Price:       &request.MoneyRequest{Amount: 1500, Currency: "EUR"},
+	})
+	assert.NoError(t, err)
+	_, err = service.Purchase(ctx, other.ID, request.PurchaseTicketRequest{Quantity: 1, UserID: userID})
+	assert.NoError(t, err)
+	_, err = service.CreateSeatMap(ctx, other.ID, request.CreateSeatMapRequest{Sections: []request.SeatSectionRequest{
+		{Name: "Stalls", Rows: []request.SeatRowRequest{{Name: "A", Seats: 5}}},
+	}})
+	assert.ErrorIs(t, err, ticket.ErrSeatMapAfterSales)
+
+	archived, err := service.Create(ctx, request.CreateTicketRequest{
+		Name:        "Archived Ticket",
+		Description: "Test Description",
+		Allocation:  10,
+		Price:       &request.MoneyRequest{Amount: 1500, Currency: "EUR"},
+	})
+	assert.NoError(t, err)
+	_, err = service.Transition(ctx, archived.ID, ticket.TransitionArchive, nil)
+	assert.NoError(t, err)
+	_, err = service.CreateSeatMap(ctx, archived.ID, request.CreateSeatMapRequest{Sections: []request.SeatSectionRequest{
+		{Name: "Stalls", Rows: []request.SeatRowRequest{{Name: "A", Seats: 5}}},
+	}})
+	assert.ErrorIs(t, err, ticket.ErrTicketArchived)
+
+	name := "Renamed Ticket"
+	_, err = service.Update(ctx, archived.ID, request.UpdateTicketRequest{Name: &name}, nil)
+	assert.ErrorIs(t, err, ticket.ErrTicketArchived)
+
+	found, err = service.FindByID(ctx, archived.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Archived Ticket", found.Name)
+	assert.Equal(t, "general", found.Seating)
+}
+
+func TestService_PromoCode(t *testing.T) {
+	ctx := context.Background()
+	tickets := ticketRepository.NewMemoryTicketRepository()
+	purchases := purchaseRepositoryImpl.NewMemoryPurchaseRepository()
+	promotions := promotionRepositoryImpl.NewMemoryPromotionRepository()
+	service := NewTicketService(db.NewMemoryUnitOfWork(), tickets, purchases, eventRepositoryImpl.NewMemoryEventRepository(), seatRepositoryImpl.NewMemorySeatRepository(), promotions, eventbus.New(), LockingPessimistic)
+	userID := "1250052d-c061-4a1f-81f0-d88af3dcb3d5"
+
+	created, err := service.Create(ctx, request.CreateTicketRequest{
+		Name:        "Test Ticket",
+		Description: "Test Description",
+		Allocation:  100,
+		Price:       &request.MoneyRequest{Amount: 1500, Currency: "EUR"},
+	})
+	assert.NoError(t, err)
+
+	promo, _ := promotion.NewPromotion("spring10", promotion.KindPercentage, 10, "")
+	maxPerUser := 1
+	assert.NoError(t, promo.ChangeMaxPerUser(&maxPerUser))
+	assert.NoError(t, promotions.Create(ctx, promo))
+
+	t.Run("should discount the purchase and count the redemption", func(t *testing.T) {
+		p, err := service.Purchase(ctx, created.ID, request.PurchaseTicketRequest{Quantity: 3, UserID: userID, PromoCode: "Spring10"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(450), p.Discount.Amount)
+		assert.Equal(t, int64(4050), p.Total.Amount)
+
+		found, _ := promotions.FindByID(ctx, promo.ID)
+		assert.Equal(t, 1, found.Redemptions)
+
+		redeemed, _ := promotions.RedemptionCount(ctx, promo.ID, userID)
+		assert.Equal(t, 1, redeemed)
+	})
+
+	t.Run("should fail the whole purchase when the code cannot be redeemed", func(t *testing.T) {
+		_, err := service.Purchase(ctx, created.ID, request.PurchaseTicketRequest{Quantity: 1, UserID: userID, PromoCode: "SPRING10"})
+		assert.ErrorIs(t, err, promotion.ErrUserLimitReached)
+
+		_, err = service.Purchase(ctx, created.ID, request.PurchaseTicketRequest{Quantity: 1, UserID: userID, PromoCode: "UNKNOWN"})
+		assert.ErrorIs(t, err, promotion.ErrPromotionNotFound)
+
+		found, _ := tickets.FindByID(ctx, created.ID)
+		assert.Equal(t, 97, found.Allocation.GetValue())
+	})
+
+	t.Run("should not redeem a code more often than allowed under concurrency", func(t *testing.T) {
+		limited, _ := promotion.NewPromotion("LIMITED", promotion.KindFixed, 100, "EUR")
+		maxRedemptions := 5
+		assert.NoError(t, limited.ChangeMaxRedemptions(&maxRedemptions))
+		assert.NoError(t, promotions.Create(ctx, limited))
+
+		var (
+			wg        sync.WaitGroup
+			mu        sync.Mutex
+			succeeded int
+		)
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				buyer := fmt.Sprintf("00000000-0000-4000-8000-%012d", i)
+				_, err := service.Purchase(ctx, created.ID, request.PurchaseTicketRequest{Quantity: 1, UserID: buyer, PromoCode: "limited"})
+				if err == nil {
+					mu.Lock()
+					succeeded++
+					mu.Unlock()
+					return
+				}
+
+				assert.ErrorIs(t, err, promotion.ErrPromotionExhausted)
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Equal(t, 5, succeeded)
+		found, _ := promotions.FindByID(ctx, limited.ID)
+		assert.Equal(t, 5, found.Redemptions)
+
+		tk, _ := tickets.FindByID(ctx, created.ID)
+		assert.Equal(t, 92, tk.Allocation.GetValue(), "failed redemptions give the allocation back")
+	})
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+
+	eventRepository "github.com/aaydin-tr/ddd-api-example/domain/event/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/venue"
+	"github.com/aaydin-tr/ddd-api-example/domain/venue/repository"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	"github.com/aaydin-tr/ddd-api-example/pkg/pagination"
+)
+
+//go:generate mockgen -destination=../../mock/service/venue/venue.go -package=service github.com/aaydin-tr/ddd-api-example/service/venue VenueService
+type VenueService interface {
+	Create(ctx context.Context, req request.CreateVenueRequest) (*venue.VenueDTO, error)
+	FindByID(ctx context.Context, id int) (*venue.VenueDTO, error)
+	List(ctx context.Context, req request.ListVenuesRequest) (*venue.VenueListDTO, error)
+	Update(ctx context.Context, id int, req request.UpdateVenueRequest) (*venue.VenueDTO, error)
+	Delete(ctx context.Context, id int) error
+}
+
+type Service struct {
+	uow       db.UnitOfWork
+	repo      repository.VenueRepository
+	eventRepo eventRepository.EventRepository
+}
+
+func NewVenueService(uow db.UnitOfWork, repo repository.VenueRepository, eventRepo eventRepository.EventRepository) VenueService {
+	return &Service{uow: uow, repo: repo, eventRepo: eventRepo}
+}
+
+func (s *Service) Create(ctx context.Context, req request.CreateVenueRequest) (*venue.VenueDTO, error) {
+	if req.Address == nil {
+		return nil, venue.ErrAddressIsRequired
+	}
+
+	v, err := venue.NewVenue(req.Name, req.Address.Street, req.Address.City, req.Address.PostalCode, req.Address.Country, req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, v); err != nil {
+		return nil, err
+	}
+
+	return venue.NewVenueDTOFromEntity(v), nil
+}
+
+func (s *Service) FindByID(ctx context.Context, id int) (*venue.VenueDTO, error) {
+	v, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return venue.NewVenueDTOFromEntity(v), nil
+}
+
+func (s *Service) List(ctx context.Context, req request.ListVenuesRequest) (*venue.VenueListDTO, error) {
+	offset, err := pagination.DecodeCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = pagination.DefaultLimit
+	}
+
+	venues, total, err := s.repo.List(ctx, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return venue.NewVenueListDTOFromEntities(venues, total, pagination.NextCursor(offset, limit, total)), nil
+}
+
+func (s *Service) Update(ctx context.Context, id int, req request.UpdateVenueRequest) (*venue.VenueDTO, error) {
+	var v *venue.Venue
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		v, err = s.repo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if req.Name != nil {
+			if err := v.Rename(*req.Name); err != nil {
+				return err
+			}
+		}
+
+		if req.Address != nil {
+			if err := v.Move(req.Address.Street, req.Address.City, req.Address.PostalCode, req.Address.Country); err != nil {
+				return err
+			}
+		}
+
+		if req.Timezone != nil {
+			if err := v.ChangeTimezone(*req.Timezone); err != nil {
+				return err
+			}
+		}
+
+		return s.repo.Update(ctx, v)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return venue.NewVenueDTOFromEntity(v), nil
+}
+
+// Delete only deletes venues without events.
+func (s *Service) Delete(ctx context.Context, id int) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.repo.FindByID(ctx, id); err != nil {
+			return err
+		}
+
+		events, err := s.eventRepo.CountByVenue(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if events > 0 {
+			return venue.ErrVenueHasEvents
+		}
+
+		return s.repo.Delete(ctx, id)
+	})
+}
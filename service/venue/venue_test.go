@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/event"
+	eventRepository "github.com/aaydin-tr/ddd-api-example/domain/event/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/venue"
+	"github.com/aaydin-tr/ddd-api-example/domain/venue/repository"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	"github.com/aaydin-tr/ddd-api-example/pkg/pagination"
+	"github.com/aaydin-tr/ddd-api-example/valueobject"
+	"github.com/stretchr/testify/assert"
+)
+
+func newAddress() *request.AddressRequest {
+	return &request.AddressRequest{Street: "Karl-Marx-Allee 1", City: "Berlin", PostalCode: "10178", Country: "DE"}
+}
+
+func TestService_Create(t *testing.T) {
+	service := NewVenueService(db.NewMemoryUnitOfWork(), repository.NewMemoryVenueRepository(), eventRepository.NewMemoryEventRepository())
+
+	t.Run("success", func(t *testing.T) {
+		got, err := service.Create(context.Background(), request.CreateVenueRequest{Name: "Arena", Address: newAddress(), Timezone: "Europe/Berlin"})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, got.ID)
+		assert.Equal(t, "Arena", got.Name)
+		assert.Equal(t, "Berlin", got.Address.City)
+		assert.Equal(t, "Europe/Berlin", got.Timezone)
+	})
+
+	t.Run("missing address error", func(t *testing.T) {
+		_, err := service.Create(context.Background(), request.CreateVenueRequest{Name: "Arena", Timezone: "Europe/Berlin"})
+		assert.ErrorIs(t, err, venue.ErrAddressIsRequired)
+	})
+
+	t.Run("invalid timezone error", func(t *testing.T) {
+		_, err := service.Create(context.Background(), request.CreateVenueRequest{Name: "Arena", Address: newAddress(), Timezone: "Mars/Olympus"})
+		assert.ErrorIs(t, err, valueobject.ErrInvalidTimezone)
+	})
+}
+
+func TestService_List(t *testing.T) {
+	ctx := context.Background()
+	service := NewVenueService(db.NewMemoryUnitOfWork(), repository.NewMemoryVenueRepository(), eventRepository.NewMemoryEventRepository())
+	for _, name := range []string{"Arena", "Club", "Hall"} {
+		_, err := service.Create(ctx, request.CreateVenueRequest{Name: name, Address: newAddress(), Timezone: "Europe/Berlin"})
+		assert.NoError(t, err)
+	}
+
+	first, err := service.List(ctx, request.ListVenuesRequest{Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), first.Total)
+	assert.Len(t, first.Items, 2)
+	assert.Equal(t, pagination.EncodeCursor(2), first.NextCursor)
+
+	second, err := service.List(ctx, request.ListVenuesRequest{Limit: 2, Cursor: first.NextCursor})
+	assert.NoError(t, err)
+	assert.Len(t, second.Items, 1)
+	assert.Equal(t, "Hall", second.Items[0].Name)
+	assert.Empty(t, second.NextCursor)
+
+	_, err = service.List(ctx, request.ListVenuesRequest{Cursor: "!"})
+	assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+}
+
+func TestService_Update(t *testing.T) {
+	ctx := context.Background()
+	service := NewVenueService(db.NewMemoryUnitOfWork(), repository.NewMemoryVenueRepository(), eventRepository.NewMemoryEventRepository())
+	created, err := service.Create(ctx, request.CreateVenueRequest{Name: "Arena", Address: newAddress(), Timezone: "Europe/Berlin"})
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		name, timezone := "Stadium", "Europe/Istanbul"
+		address := &request.AddressRequest{Street: "Istiklal Cd. 1", City: "Istanbul", Country: "TR"}
+		got, err := service.Update(ctx, created.ID, request.UpdateVenueRequest{Name: &name, Address: address, Timezone: &timezone})
+		assert.NoError(t, err)
+		assert.Equal(t, "Stadium", got.Name)
+		assert.Equal(t, "Istanbul", got.Address.City)
+		assert.Empty(t, got.Address.PostalCode)
+		assert.Equal(t, "Europe/Istanbul", got.Timezone)
+	})
+
+	t.Run("invalid timezone error", func(t *testing.T) {
+		timezone := "Local"
+		_, err := service.Update(ctx, created.ID, request.UpdateVenueRequest{Timezone: &timezone})
+		assert.ErrorIs(t, err, valueobject.ErrInvalidTimezone)
+
+		got, err := service.FindByID(ctx, created.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Europe/Istanbul", got.Timezone)
+	})
+
+	t.Run("not found error", func(t *testing.T) {
+		name := "Stadium"
+		_, err := service.Update(ctx, 999, request.UpdateVenueRequest{Name: &name})
+		assert.ErrorIs(t, err, venue.ErrVenueNotFound)
+	})
+}
+
+func TestService_Delete(t *testing.T) {
+	ctx := context.Background()
+	events := eventRepository.NewMemoryEventRepository()
+	service := NewVenueService(db.NewMemoryUnitOfWork(), repository.NewMemoryVenueRepository(), events)
+	created, err := service.Create(ctx, request.CreateVenueRequest{Name: "Arena", Address: newAddress(), Timezone: "Europe/Berlin"})
+	assert.NoError(t, err)
+
+	start := time.Date(2026, 11, 1, 19, 0, 0, 0, time.UTC)
+	e, _ := event.NewEvent(created.ID, "Concert", "Test Description", start, start.Add(3*time.Hour), 100)
+	assert.NoError(t, events.Create(ctx, e))
+
+	assert.ErrorIs(t, service.Delete(ctx, created.ID), venue.ErrVenueHasEvents)
+
+	assert.NoError(t, events.Delete(ctx, e.ID))
+	assert.NoError(t, service.Delete(ctx, created.ID))
+
+	_, err = service.FindByID(ctx, created.ID)
+	assert.ErrorIs(t, err, venue.ErrVenueNotFound)
+	assert.ErrorIs(t, service.Delete(ctx, created.ID), venue.ErrVenueNotFound)
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Sweeper periodically serves the waitlists, offers are normally made when
+// units are restored but a restore whose event was not handled would
+// otherwise leave the waitlist stuck.
+type Sweeper struct {
+	service  WaitlistService
+	interval time.Duration
+}
+
+func NewSweeper(service WaitlistService, interval time.Duration) *Sweeper {
+	return &Sweeper{service: service, interval: interval}
+}
+
+func (s *Sweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.service.OfferAll(ctx); err != nil {
+				log.Printf("failed to serve waitlists: %s", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,392 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	purchaseRepository "github.com/aaydin-tr/ddd-api-example/domain/purchase/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/reservation"
+	reservationRepository "github.com/aaydin-tr/ddd-api-example/domain/reservation/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/seat"
+	seatRepository "github.com/aaydin-tr/ddd-api-example/domain/seat/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	ticketRepository "github.com/aaydin-tr/ddd-api-example/domain/ticket/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/waitlist"
+	"github.com/aaydin-tr/ddd-api-example/domain/waitlist/repository"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	"github.com/aaydin-tr/ddd-api-example/pkg/audit"
+	"github.com/aaydin-tr/ddd-api-example/pkg/eventbus"
+)
+
+//go:generate mockgen -destination=../../mock/service/waitlist/waitlist.go -package=service github.com/aaydin-tr/ddd-api-example/service/waitlist WaitlistService
+type WaitlistService interface {
+	Join(ctx context.Context, ticketID int, req request.JoinWaitlistRequest) (*waitlist.EntryDTO, error)
+	FindByUser(ctx context.Context, ticketID int, userID string) (*waitlist.EntryDTO, error)
+	Leave(ctx context.Context, ticketID int, userID string) error
+	// Offer holds available units of a ticket for its waitlist, in the order
+	// the entries joined. It returns the number of offers made.
+	Offer(ctx context.Context, ticketID int) (int, error)
+	// OfferAll runs Offer for every ticket with waiting entries.
+	OfferAll(ctx context.Context) (int, error)
+}
+
+type Service struct {
+	uow             db.UnitOfWork
+	repo            repository.WaitlistRepository
+	ticketRepo      ticketRepository.TicketRepository
+	reservationRepo reservationRepository.ReservationRepository
+	purchaseRepo    purchaseRepository.PurchaseRepository
+	seatRepo        seatRepository.SeatRepository
+	events          eventbus.Publisher
+	ttl             time.Duration
+	now             func() time.Time
+}
+
+func NewWaitlistService(uow db.UnitOfWork, repo repository.WaitlistRepository, ticketRepo ticketRepository.TicketRepository, reservationRepo reservationRepository.ReservationRepository, purchaseRepo purchaseRepository.PurchaseRepository, seatRepo seatRepository.SeatRepository, events eventbus.Publisher, ttl time.Duration) WaitlistService {
+	return &Service{uow: uow, repo: repo, ticketRepo: ticketRepo, reservationRepo: reservationRepo, purchaseRepo: purchaseRepo, seatRepo: seatRepo, events: events, ttl: ttl, now: time.Now}
+}
+
+// Join puts a user at the end of the waitlist of a ticket. It fails with
+// ErrTicketAvailable when nobody is waiting and the quantity can be bought
+// right away.
+func (s *Service) Join(ctx context.Context, ticketID int, req request.JoinWaitlistRequest) (*waitlist.EntryDTO, error) {
+	ctx = audit.WithActor(ctx, req.UserID)
+
+	var (
+		e        *waitlist.Entry
+		position int
+	)
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		t, err := s.ticketRepo.FindByIDForUpdate(ctx, ticketID)
+		if err != nil {
+			return err
+		}
+
+		if t.Status == ticket.StatusArchived {
+			return ticket.ErrTicketArchived
+		}
+
+		if err := t.CheckSalesWindow(s.now()); errors.Is(err, ticket.ErrSalesClosed) {
+			return err
+		}
+
+		e, err = waitlist.NewEntry(t.ID, req.UserID, req.Quantity)
+		if err != nil {
+			return err
+		}
+
+		latest, err := s.repo.FindLatestByUser(ctx, t.ID, req.UserID)
+		if err != nil && !errors.Is(err, waitlist.ErrEntryNotFound) {
+			return err
+		}
+
+		if latest != nil {
+			// An offer that was claimed or lapsed since the waitlist was
+			// last served does not keep the user from joining again.
+			changed, err := s.settleEntry(ctx, latest)
+			if err != nil {
+				return err
+			}
+
+			if changed {
+				if err := s.repo.Update(ctx, latest); err != nil {
+					return err
+				}
+			}
+
+			if latest.IsActive() {
+				return waitlist.ErrAlreadyWaiting
+			}
+		}
+
+		entries, err := s.repo.ListActive(ctx, t.ID)
+		if err != nil {
+			return err
+		}
+
+		if !hasWaiting(entries) && t.Allocation.GetValue() >= req.Quantity {
+			return waitlist.ErrTicketAvailable
+		}
+
+		if err := s.checkPurchaseLimit(ctx, t, req.UserID, req.Quantity); err != nil {
+			return err
+		}
+
+		if err := s.repo.Create(ctx, e); err != nil {
+			return err
+		}
+
+		position, err = s.repo.Position(ctx, e)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return waitlist.NewEntryDTOFromEntity(e, position), nil
+}
+
+// checkPurchaseLimit counts the units a user bought and holds, like a new
+// reservation does. The limit is checked again when the offer is claimed.
+func (s *Service) checkPurchaseLimit(ctx context.Context, t *ticket.Ticket, userID string, quantity int) error {
+	if !t.HasPurchaseLimit() {
+		return nil
+	}
+
+	owned, err := s.purchaseRepo.OwnedQuantity(ctx, t.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	held, err := s.reservationRepo.HeldQuantity(ctx, t.ID, userID, s.now())
+	if err != nil {
+		return err
+	}
+
+	return t.CheckPurchaseLimit(owned+held, quantity)
+}
+
+// FindByUser shows the latest entry of a user, an offer whose reservation
+// was confirmed, cancelled or expired is shown as claimed or lapsed even if
+// the waitlist was not served since.
+func (s *Service) FindByUser(ctx context.Context, ticketID int, userID string) (*waitlist.EntryDTO, error) {
+	e, err := s.repo.FindLatestByUser(ctx, ticketID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.settleEntry(ctx, e); err != nil {
+		return nil, err
+	}
+
+	var position int
+	if e.Status == waitlist.StatusWaiting {
+		position, err = s.repo.Position(ctx, e)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return waitlist.NewEntryDTOFromEntity(e, position), nil
+}
+
+func (s *Service) Leave(ctx context.Context, ticketID int, userID string) error {
+	ctx = audit.WithActor(ctx, userID)
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		// The ticket lock keeps Offer from serving the entry while it leaves.
+		t, err := s.ticketRepo.FindByIDForUpdate(ctx, ticketID)
+		if err != nil {
+			return err
+		}
+
+		e, err := s.repo.FindLatestByUser(ctx, t.ID, userID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.settleEntry(ctx, e); err != nil {
+			return err
+		}
+
+		if err := e.Leave(); err != nil {
+			return err
+		}
+
+		return s.repo.Update(ctx, e)
+	})
+}
+
+func (s *Service) Offer(ctx context.Context, ticketID int) (int, error) {
+	var offered int
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		offered = 0
+		t, err := s.ticketRepo.FindByIDForUpdate(ctx, ticketID)
+		if errors.Is(err, ticket.ErrTicketNotFound) {
+			// Deleted tickets can not be sold, their entries keep waiting
+			// in case the ticket is restored.
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		entries, err := s.repo.ListActive(ctx, t.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.settle(ctx, entries); err != nil {
+			return err
+		}
+
+		var seats []*seat.Seat
+		if t.IsSeated() {
+			seats, err = s.seatRepo.ListByTicket(ctx, t.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		now := s.now()
+		for _, e := range entries {
+			if e.Status != waitlist.StatusWaiting {
+				continue
+			}
+
+			// Later entries are not served before an entry that does not
+			// fit, so that large requests are not starved by small ones.
+			ok, err := s.offer(ctx, t, e, seats, now)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				break
+			}
+
+			offered++
+		}
+
+		if offered == 0 {
+			return nil
+		}
+
+		if err := s.ticketRepo.Update(ctx, t); err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, t.PullEvents()...)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return offered, nil
+}
+
+// settle updates offered entries whose reservation was confirmed, cancelled
+// or expired since the offer was made.
+func (s *Service) settle(ctx context.Context, entries []*waitlist.Entry) error {
+	for _, e := range entries {
+		changed, err := s.settleEntry(ctx, e)
+		if err != nil {
+			return err
+		}
+
+		if !changed {
+			continue
+		}
+
+		if err := s.repo.Update(ctx, e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// settleEntry settles an offered entry with the status of its reservation
+// without storing it, it reports whether the entry changed.
+func (s *Service) settleEntry(ctx context.Context, e *waitlist.Entry) (bool, error) {
+	if e.Status != waitlist.StatusOffered {
+		return false, nil
+	}
+
+	r, err := s.reservationRepo.FindByID(ctx, *e.ReservationID)
+	if err != nil {
+		return false, err
+	}
+
+	return e.Settle(r.Status), nil
+}
+
+// offer holds the units of e in a reservation that expires after the offer
+// TTL. It reports false without an error when t can not serve e, because
+// there are not enough units or adjacent seats or the ticket is not on sale.
+func (s *Service) offer(ctx context.Context, t *ticket.Ticket, e *waitlist.Entry, seats []*seat.Seat, now time.Time) (bool, error) {
+	if t.Allocation.GetValue() < e.Quantity {
+		return false, nil
+	}
+
+	var selected []*seat.Seat
+	if t.IsSeated() {
+		var err error
+		selected, err = seat.Select(seats, nil, e.Quantity)
+		if err != nil {
+			return false, nil
+		}
+	}
+
+	if err := t.Hold(audit.WithActor(ctx, e.UserID), e.Quantity, now); err != nil {
+		return false, nil
+	}
+
+	r, err := reservation.NewReservation(t.ID, e.UserID, e.Quantity, now, s.ttl)
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.reservationRepo.Create(ctx, r); err != nil {
+		return false, err
+	}
+
+	for _, st := range selected {
+		if err := st.Hold(r.ID); err != nil {
+			return false, err
+		}
+
+		if err := s.seatRepo.Update(ctx, st); err != nil {
+			return false, err
+		}
+	}
+
+	if err := e.Offer(r); err != nil {
+		return false, err
+	}
+
+	if err := s.repo.Update(ctx, e); err != nil {
+		return false, err
+	}
+
+	return true, s.events.Publish(ctx, e.PullEvents()...)
+}
+
+func (s *Service) OfferAll(ctx context.Context) (int, error) {
+	ids, err := s.repo.TicketsWithWaiting(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var offered int
+	for _, id := range ids {
+		n, err := s.Offer(ctx, id)
+		if err != nil {
+			return offered, err
+		}
+		offered += n
+	}
+
+	return offered, nil
+}
+
+// OfferOnRestore returns a handler that offers units returned to the
+// allocation of a ticket to its waitlist.
+func OfferOnRestore(service WaitlistService) func(ctx context.Context, e ticket.AllocationRestored) error {
+	return func(ctx context.Context, e ticket.AllocationRestored) error {
+		_, err := service.Offer(ctx, e.TicketID)
+		return err
+	}
+}
+
+func hasWaiting(entries []*waitlist.Entry) bool {
+	for _, e := range entries {
+		if e.Status == waitlist.StatusWaiting {
+			return true
+		}
+	}
+
+	return false
+}
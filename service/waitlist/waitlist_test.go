@@ -0,0 +1,476 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/purchase"
+	purchaseRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/purchase/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/reservation"
+	reservationRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/reservation/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/seat"
+	seatRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/seat/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/ticket"
+	ticketRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/ticket/repository"
+	"github.com/aaydin-tr/ddd-api-example/domain/waitlist"
+	waitlistRepositoryImpl "github.com/aaydin-tr/ddd-api-example/domain/waitlist/repository"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	mockdb "github.com/aaydin-tr/ddd-api-example/mock/db"
+	purchaseRepository "github.com/aaydin-tr/ddd-api-example/mock/repository/purchase"
+	reservationRepository "github.com/aaydin-tr/ddd-api-example/mock/repository/reservation"
+	seatRepository "github.com/aaydin-tr/ddd-api-example/mock/repository/seat"
+	ticketRepository "github.com/aaydin-tr/ddd-api-example/mock/repository/ticket"
+	repository "github.com/aaydin-tr/ddd-api-example/mock/repository/waitlist"
+	"github.com/aaydin-tr/ddd-api-example/pkg/eventbus"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+const (
+	firstUser  = "1250052d-c061-4a1f-81f0-d88af3dcb3d5"
+	secondUser = "6f1c2a7e-58a4-4c2e-9d0b-3b4f0e5d7a11"
+	thirdUser  = "b3e0f9a2-7c1d-4e6f-8a5b-2d9c4e7f1a30"
+)
+
+// runInTransaction stands in for the unit of work and runs fn directly.
+func runInTransaction(ctx context.Context, fn func(ctx context.Context) error, _ ...db.Option) error {
+	return fn(ctx)
+}
+
+type fixture struct {
+	service      *Service
+	tickets      *ticketRepositoryImpl.MemoryRepository
+	reservations *reservationRepositoryImpl.MemoryRepository
+	purchases    *purchaseRepositoryImpl.MemoryRepository
+	seats        *seatRepositoryImpl.MemoryRepository
+	published    []eventbus.Event
+}
+
+func newFixture() *fixture {
+	f := &fixture{
+		tickets:      ticketRepositoryImpl.NewMemoryTicketRepository().(*ticketRepositoryImpl.MemoryRepository),
+		reservations: reservationRepositoryImpl.NewMemoryReservationRepository().(*reservationRepositoryImpl.MemoryRepository),
+		purchases:    purchaseRepositoryImpl.NewMemoryPurchaseRepository().(*purchaseRepositoryImpl.MemoryRepository),
+		seats:        seatRepositoryImpl.NewMemorySeatRepository().(*seatRepositoryImpl.MemoryRepository),
+	}
+
+	bus := eventbus.New()
+	bus.SubscribeAll(func(ctx context.Context, e eventbus.Event) error {
+		f.published = append(f.published, e)
+		return nil
+	})
+
+	f.service = NewWaitlistService(db.NewMemoryUnitOfWork(), waitlistRepositoryImpl.NewMemoryWaitlistRepository(), f.tickets, f.reservations, f.purchases, f.seats, bus, 15*time.Minute).(*Service)
+	return f
+}
+
+// soldOut stores a published ticket whose allocation was bought up.
+func (f *fixture) soldOut(t *testing.T, allocation int) *ticket.Ticket {
+	ctx := context.Background()
+	tk, err := ticket.NewTicket("Test Ticket", "Test Description", allocation, 1500, "EUR", time.Now())
+	assert.NoError(t, err)
+	assert.NoError(t, tk.Publish(time.Now()))
+	assert.NoError(t, tk.DecrementAllocation(ctx, allocation, time.Now()))
+	assert.NoError(t, f.tickets.Create(ctx, tk))
+	return tk
+}
+
+// restore returns quantity sold units of a ticket to its allocation.
+func (f *fixture) restore(t *testing.T, ticketID, quantity int) {
+	ctx := context.Background()
+	tk, err := f.tickets.FindByID(ctx, ticketID)
+	assert.NoError(t, err)
+	assert.NoError(t, tk.ReturnAllocation(ctx, quantity, time.Now()))
+	assert.NoError(t, f.tickets.Update(ctx, tk))
+}
+
+func (f *fixture) join(ticketID int, userID string, quantity int) (*waitlist.EntryDTO, error) {
+	return f.service.Join(context.Background(), ticketID, request.JoinWaitlistRequest{Quantity: quantity, UserID: userID})
+}
+
+func TestService_Join(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	tk := f.soldOut(t, 4)
+
+	first, err := f.join(tk.ID, firstUser, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, string(waitlist.StatusWaiting), first.Status)
+	assert.Equal(t, 1, first.Position)
+
+	second, err := f.join(tk.ID, secondUser, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, second.Position)
+
+	t.Run("should reject a second active entry", func(t *testing.T) {
+		_, err := f.join(tk.ID, firstUser, 1)
+		assert.ErrorIs(t, err, waitlist.ErrAlreadyWaiting)
+	})
+
+	t.Run("should move up when an earlier entry leaves", func(t *testing.T) {
+		assert.NoError(t, f.service.Leave(ctx, tk.ID, firstUser))
+
+		found, err := f.service.FindByUser(ctx, tk.ID, secondUser)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, found.Position)
+
+		left, err := f.service.FindByUser(ctx, tk.ID, firstUser)
+		assert.NoError(t, err)
+		assert.Equal(t, string(waitlist.StatusLeft), left.Status)
+		assert.Zero(t, left.Position)
+
+		assert.ErrorIs(t, f.service.Leave(ctx, tk.ID, firstUser), waitlist.ErrNotWaiting)
+	})
+
+	t.Run("should join again after leaving", func(t *testing.T) {
+		again, err := f.join(tk.ID, firstUser, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, again.Position)
+	})
+
+	t.Run("should reject tickets that are not sold out", func(t *testing.T) {
+		available, err := ticket.NewTicket("Available", "Test Description", 5, 1500, "EUR", time.Now())
+		assert.NoError(t, err)
+		assert.NoError(t, available.Publish(time.Now()))
+		assert.NoError(t, f.tickets.Create(ctx, available))
+
+		_, err = f.join(available.ID, firstUser, 5)
+		assert.ErrorIs(t, err, waitlist.ErrTicketAvailable)
+
+		_, err = f.join(available.ID, firstUser, 6)
+		assert.NoError(t, err, "more units than are left have to wait")
+	})
+
+	t.Run("should reject archived tickets", func(t *testing.T) {
+		archived := f.soldOut(t, 1)
+		assert.NoError(t, archived.Archive(time.Now()))
+		assert.NoError(t, f.tickets.Update(ctx, archived))
+
+		_, err := f.join(archived.ID, firstUser, 1)
+		assert.ErrorIs(t, err, ticket.ErrTicketArchived)
+	})
+
+	t.Run("should check the purchase limit", func(t *testing.T) {
+		limited := f.soldOut(t, 10)
+		assert.NoError(t, limited.ChangeMaxPerUser(3))
+		assert.NoError(t, f.tickets.Update(ctx, limited))
+
+		bought, err := purchase.NewPurchase(limited.ID, thirdUser, 2, limited.Price)
+		assert.NoError(t, err)
+		assert.NoError(t, f.purchases.Create(ctx, bought))
+
+		_, err = f.join(limited.ID, thirdUser, 2)
+		var limitErr *ticket.PurchaseLimitError
+		assert.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, 1, limitErr.Remaining)
+
+		_, err = f.join(limited.ID, thirdUser, 1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return not found for users that never joined", func(t *testing.T) {
+		_, err := f.service.FindByUser(ctx, tk.ID, thirdUser)
+		assert.ErrorIs(t, err, waitlist.ErrEntryNotFound)
+		assert.ErrorIs(t, f.service.Leave(ctx, tk.ID, thirdUser), waitlist.ErrEntryNotFound)
+	})
+}
+
+func TestService_Offer(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	tk := f.soldOut(t, 5)
+
+	for _, join := range []struct {
+		userID   string
+		quantity int
+	}{{firstUser, 2}, {secondUser, 3}, {thirdUser, 1}} {
+		_, err := f.join(tk.ID, join.userID, join.quantity)
+		assert.NoError(t, err)
+	}
+
+	t.Run("should not offer without units", func(t *testing.T) {
+		offered, err := f.service.Offer(ctx, tk.ID)
+		assert.NoError(t, err)
+		assert.Zero(t, offered)
+	})
+
+	var offer *waitlist.EntryDTO
+	t.Run("should hold restored units for the first entry", func(t *testing.T) {
+		f.restore(t, tk.ID, 4)
+		f.published = nil
+
+		offered, err := f.service.Offer(ctx, tk.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, offered, "the second entry blocks the third")
+
+		offer, err = f.service.FindByUser(ctx, tk.ID, firstUser)
+		assert.NoError(t, err)
+		assert.Equal(t, string(waitlist.StatusOffered), offer.Status)
+		assert.NotNil(t, offer.OfferExpiresAt)
+
+		r, err := f.reservations.FindByID(ctx, *offer.ReservationID)
+		assert.NoError(t, err)
+		assert.Equal(t, firstUser, r.UserID)
+		assert.Equal(t, 2, r.Quantity)
+		assert.Equal(t, reservation.StatusActive, r.Status)
+		assert.Equal(t, r.ExpiresAt, *offer.OfferExpiresAt)
+
+		found, err := f.tickets.FindByID(ctx, tk.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, found.Allocation.GetValue())
+		assert.Equal(t, 2, found.Held)
+
+		waiting, err := f.service.FindByUser(ctx, tk.ID, thirdUser)
+		assert.NoError(t, err)
+		assert.Equal(t, string(waitlist.StatusWaiting), waiting.Status)
+		assert.Equal(t, 2, waiting.Position)
+
+		var names []string
+		for _, e := range f.published {
+			names = append(names, e.EventName())
+		}
+		assert.Equal(t, []string{waitlist.EventWaitlistOffered, ticket.EventAllocationDecremented}, names)
+	})
+
+	t.Run("should not leave with a pending offer", func(t *testing.T) {
+		assert.ErrorIs(t, f.service.Leave(ctx, tk.ID, firstUser), waitlist.ErrOfferPending)
+	})
+
+	t.Run("should serve the next entries once an offer lapses", func(t *testing.T) {
+		r, err := f.reservations.FindByID(ctx, *offer.ReservationID)
+		assert.NoError(t, err)
+		assert.NoError(t, r.Cancel())
+		assert.NoError(t, f.reservations.Update(ctx, r))
+
+		found, err := f.tickets.FindByID(ctx, tk.ID)
+		assert.NoError(t, err)
+		assert.NoError(t, found.ReleaseHold(ctx, r.Quantity, time.Now()))
+		assert.NoError(t, f.tickets.Update(ctx, found))
+
+		offered, err := f.service.Offer(ctx, tk.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, offered)
+
+		lapsed, err := f.service.FindByUser(ctx, tk.ID, firstUser)
+		assert.NoError(t, err)
+		assert.Equal(t, string(waitlist.StatusLapsed), lapsed.Status)
+
+		for _, userID := range []string{secondUser, thirdUser} {
+			entry, err := f.service.FindByUser(ctx, tk.ID, userID)
+			assert.NoError(t, err)
+			assert.Equal(t, string(waitlist.StatusOffered), entry.Status)
+		}
+
+		found, err = f.tickets.FindByID(ctx, tk.ID)
+		assert.NoError(t, err)
+		assert.Zero(t, found.Allocation.GetValue())
+	})
+
+	t.Run("should show claimed offers", func(t *testing.T) {
+		entry, err := f.service.FindByUser(ctx, tk.ID, thirdUser)
+		assert.NoError(t, err)
+
+		r, err := f.reservations.FindByID(ctx, *entry.ReservationID)
+		assert.NoError(t, err)
+		assert.NoError(t, r.Confirm(time.Now()))
+		assert.NoError(t, f.reservations.Update(ctx, r))
+
+		claimed, err := f.service.FindByUser(ctx, tk.ID, thirdUser)
+		assert.NoError(t, err)
+		assert.Equal(t, string(waitlist.StatusClaimed), claimed.Status)
+		assert.Nil(t, claimed.ReservationID)
+
+		assert.ErrorIs(t, f.service.Leave(ctx, tk.ID, thirdUser), waitlist.ErrNotWaiting)
+
+		again, err := f.join(tk.ID, thirdUser, 1)
+		assert.NoError(t, err, "a claimed offer does not keep the user from joining again")
+		assert.Equal(t, string(waitlist.StatusWaiting), again.Status)
+	})
+}
+
+func TestService_OfferPausedTicket(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	tk := f.soldOut(t, 2)
+
+	_, err := f.join(tk.ID, firstUser, 1)
+	assert.NoError(t, err)
+
+	paused, err := f.tickets.FindByID(ctx, tk.ID)
+	assert.NoError(t, err)
+	assert.NoError(t, paused.Pause(time.Now()))
+	assert.NoError(t, f.tickets.Update(ctx, paused))
+	f.restore(t, tk.ID, 1)
+
+	offered, err := f.service.Offer(ctx, tk.ID)
+	assert.NoError(t, err)
+	assert.Zero(t, offered)
+
+	entry, err := f.service.FindByUser(ctx, tk.ID, firstUser)
+	assert.NoError(t, err)
+	assert.Equal(t, string(waitlist.StatusWaiting), entry.Status)
+}
+
+func TestService_OfferSeats(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+
+	tk, err := ticket.NewTicket("Test Ticket", "Test Description", 1, 1500, "EUR", time.Now())
+	assert.NoError(t, err)
+	assert.NoError(t, tk.Publish(time.Now()))
+	assert.NoError(t, tk.AssignSeats(3, time.Now()))
+	assert.NoError(t, f.tickets.Create(ctx, tk))
+	layout, err := seat.NewSeatMap(tk.ID, []seat.Section{{Name: "Stalls", Rows: []seat.Row{{Name: "A", Seats: 3}}}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.seats.CreateMany(ctx, layout))
+
+	assert.NoError(t, tk.Hold(ctx, 3, time.Now()))
+	assert.NoError(t, f.tickets.Update(ctx, tk))
+	for _, st := range layout {
+		assert.NoError(t, st.Hold(99))
+		assert.NoError(t, f.seats.Update(ctx, st))
+	}
+
+	_, err = f.join(tk.ID, firstUser, 2)
+	assert.NoError(t, err)
+
+	// The first and the last seat are released, they are not adjacent.
+	for _, st := range []*seat.Seat{layout[0], layout[2]} {
+		assert.NoError(t, st.Release(99))
+		assert.NoError(t, f.seats.Update(ctx, st))
+	}
+
+	found, err := f.tickets.FindByID(ctx, tk.ID)
+	assert.NoError(t, err)
+	assert.NoError(t, found.ReleaseHold(ctx, 2, time.Now()))
+	assert.NoError(t, f.tickets.Update(ctx, found))
+
+	offered, err := f.service.Offer(ctx, tk.ID)
+	assert.NoError(t, err)
+	assert.Zero(t, offered)
+
+	assert.NoError(t, layout[1].Release(99))
+	assert.NoError(t, f.seats.Update(ctx, layout[1]))
+	found, err = f.tickets.FindByID(ctx, tk.ID)
+	assert.NoError(t, err)
+	assert.NoError(t, found.ReleaseHold(ctx, 1, time.Now()))
+	assert.NoError(t, f.tickets.Update(ctx, found))
+
+	offered, err = f.service.Offer(ctx, tk.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, offered)
+
+	entry, err := f.service.FindByUser(ctx, tk.ID, firstUser)
+	assert.NoError(t, err)
+	held, err := f.seats.ListByReservation(ctx, *entry.ReservationID)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{layout[0].ID, layout[1].ID}, seat.IDs(held))
+}
+
+func TestOfferOnRestore(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	tk := f.soldOut(t, 3)
+
+	_, err := f.join(tk.ID, firstUser, 1)
+	assert.NoError(t, err)
+
+	bus := eventbus.New()
+	eventbus.Subscribe(bus, OfferOnRestore(f.service))
+
+	// Without a unit of work the event is handled right away.
+	events := db.PublishAfterCommit(bus)
+	f.restore(t, tk.ID, 1)
+	assert.NoError(t, events.Publish(ctx, ticket.AllocationRestored{TicketID: tk.ID, Quantity: 1, Remaining: 1, At: time.Now()}))
+
+	entry, err := f.service.FindByUser(ctx, tk.ID, firstUser)
+	assert.NoError(t, err)
+	assert.Equal(t, string(waitlist.StatusOffered), entry.Status)
+}
+
+func TestService_OfferAll(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	first := f.soldOut(t, 2)
+	second := f.soldOut(t, 2)
+
+	for _, tk := range []*ticket.Ticket{first, second} {
+		_, err := f.join(tk.ID, firstUser, 1)
+		assert.NoError(t, err)
+		f.restore(t, tk.ID, 1)
+	}
+
+	offered, err := f.service.OfferAll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, offered)
+
+	offered, err = f.service.OfferAll(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, offered)
+}
+
+func TestService_JoinErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockWaitlistRepository(ctrl)
+	mockTicketRepo := ticketRepository.NewMockTicketRepository(ctrl)
+	mockUow := mockdb.NewMockUnitOfWork(ctrl)
+	service := NewWaitlistService(mockUow, mockRepo, mockTicketRepo, reservationRepository.NewMockReservationRepository(ctrl), purchaseRepository.NewMockPurchaseRepository(ctrl), seatRepository.NewMockSeatRepository(ctrl), eventbus.New(), 15*time.Minute)
+
+	soldOut := func() *ticket.Ticket {
+		tk, _ := ticket.NewTicket("Test Ticket", "Test Description", 2, 1500, "EUR", time.Now())
+		tk.ID = 1
+		tk.Status = ticket.StatusPublished
+		tk.Sold = 2
+		_ = tk.ChangeAllocation(2, time.Now())
+		return tk
+	}
+
+	tests := []struct {
+		name    string
+		mock    func()
+		wantErr error
+	}{
+		{
+			name: "ticket not found error",
+			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(nil, ticket.ErrTicketNotFound)
+			},
+			wantErr: ticket.ErrTicketNotFound,
+		},
+		{
+			name: "find error",
+			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(soldOut(), nil)
+				mockRepo.EXPECT().FindLatestByUser(gomock.Any(), 1, firstUser).Return(nil, errors.New("find error"))
+			},
+			wantErr: errors.New("find error"),
+		},
+		{
+			name: "create error",
+			mock: func() {
+				mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+				mockTicketRepo.EXPECT().FindByIDForUpdate(gomock.Any(), 1).Return(soldOut(), nil)
+				mockRepo.EXPECT().FindLatestByUser(gomock.Any(), 1, firstUser).Return(nil, waitlist.ErrEntryNotFound)
+				mockRepo.EXPECT().ListActive(gomock.Any(), 1).Return(nil, nil)
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(waitlist.ErrAlreadyWaiting)
+			},
+			wantErr: waitlist.ErrAlreadyWaiting,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			entry, err := service.Join(context.Background(), 1, request.JoinWaitlistRequest{Quantity: 1, UserID: firstUser})
+			assert.Equal(t, tt.wantErr, err)
+			assert.Nil(t, entry)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/webhook"
+	"github.com/aaydin-tr/ddd-api-example/domain/webhook/repository"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/outbox"
+)
+
+type dispatcher struct {
+	repo repository.WebhookRepository
+	now  func() time.Time
+}
+
+// NewDispatcher returns an outbox publisher that queues a delivery of every
+// relayed message for each subscription filtering on its event. The
+// deliveries are stored in the unit of work of the relay and sent by the
+// Sender.
+func NewDispatcher(repo repository.WebhookRepository) outbox.Publisher {
+	return &dispatcher{repo: repo, now: time.Now}
+}
+
+func (d *dispatcher) Publish(ctx context.Context, m *outbox.Message) error {
+	subscriptions, err := d.repo.SubscriptionsFor(ctx, m.EventName)
+	if err != nil {
+		return err
+	}
+
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(m.Envelope())
+	if err != nil {
+		return err
+	}
+
+	now := d.now()
+	for _, s := range subscriptions {
+		if err := d.repo.CreateDelivery(ctx, webhook.NewDelivery(s.ID, m.ID, m.EventName, string(payload), now)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/webhook"
+	"github.com/aaydin-tr/ddd-api-example/domain/webhook/repository"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+)
+
+// Sender posts due deliveries to their subscriptions. A failed delivery is
+// tried again with the backoff of retry until retry.MaxAttempts attempts
+// were made, then it is marked failed and can only be replayed.
+type Sender struct {
+	uow       db.UnitOfWork
+	repo      repository.WebhookRepository
+	client    *http.Client
+	retry     db.RetryPolicy
+	batchSize int
+	now       func() time.Time
+}
+
+func NewSender(uow db.UnitOfWork, repo repository.WebhookRepository, client *http.Client, retry db.RetryPolicy, batchSize int) *Sender {
+	return &Sender{uow: uow, repo: repo, client: client, retry: retry, batchSize: batchSize, now: time.Now}
+}
+
+// Run sends all due deliveries every interval until ctx is cancelled.
+func (s *Sender) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.drain(ctx)
+		}
+	}
+}
+
+func (s *Sender) drain(ctx context.Context) {
+	for {
+		claimed, err := s.SendBatch(ctx)
+		if err != nil {
+			log.Printf("failed to send webhook deliveries: %s", err)
+			return
+		}
+
+		if claimed < s.batchSize {
+			return
+		}
+	}
+}
+
+// SendBatch sends one batch of due deliveries and returns how many were
+// claimed.
+func (s *Sender) SendBatch(ctx context.Context) (int, error) {
+	var claimed int
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		deliveries, err := s.repo.ClaimDueDeliveries(ctx, s.now(), s.batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, d := range deliveries {
+			if err := s.deliver(ctx, d); err != nil {
+				return err
+			}
+
+			if err := s.repo.UpdateDelivery(ctx, d); err != nil {
+				return err
+			}
+		}
+
+		claimed = len(deliveries)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return claimed, nil
+}
+
+func (s *Sender) deliver(ctx context.Context, d *webhook.Delivery) error {
+	subscription, err := s.repo.FindSubscription(ctx, d.SubscriptionID)
+	if errors.Is(err, webhook.ErrSubscriptionNotFound) {
+		d.MarkFailed(0, err)
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	status, err := s.post(ctx, subscription, d)
+	now := s.now()
+	switch {
+	case err == nil:
+		d.MarkSucceeded(status, now)
+	case d.Attempts+1 >= s.retry.MaxAttempts:
+		d.MarkFailed(status, err)
+		log.Printf("webhook delivery %d to %s failed for good after %d attempts: %s", d.ID, subscription.URL, d.Attempts, err)
+	default:
+		d.ScheduleRetry(status, err, now.Add(s.retry.Backoff(d.Attempts+1)))
+		log.Printf("webhook delivery %d to %s failed (attempt %d): %s", d.ID, subscription.URL, d.Attempts, err)
+	}
+
+	return nil
+}
+
+// post returns the status the receiver answered with, or 0 when it did not
+// answer.
+func (s *Sender) post(ctx context.Context, subscription *webhook.Subscription, d *webhook.Delivery) (int, error) {
+	body := []byte(d.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	sentAt := s.now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.HeaderEvent, d.Event)
+	req.Header.Set(webhook.HeaderMessageID, strconv.FormatInt(d.MessageID, 10))
+	req.Header.Set(webhook.HeaderDeliveryID, strconv.Itoa(d.ID))
+	req.Header.Set(webhook.HeaderTimestamp, strconv.FormatInt(sentAt.Unix(), 10))
+	req.Header.Set(webhook.HeaderSignature, webhook.Sign(subscription.Secret, sentAt, body))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	_, _ = io.Copy(io.Discard, res.Body)
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("webhook responded with %s", res.Status)
+	}
+
+	return res.StatusCode, nil
+}
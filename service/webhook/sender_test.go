@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/webhook"
+	"github.com/aaydin-tr/ddd-api-example/domain/webhook/repository"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/outbox"
+	"github.com/stretchr/testify/assert"
+)
+
+type soldOut struct {
+	TicketID int       `json:"ticket_id"`
+	At       time.Time `json:"occurred_at"`
+}
+
+func (e soldOut) EventName() string     { return "ticket.sold_out" }
+func (e soldOut) OccurredAt() time.Time { return e.At }
+
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+// receiver is a partner endpoint answering with the queued statuses, then
+// with 204.
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []received
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requests = append(r.requests, received{header: req.Header, body: body})
+	status := http.StatusNoContent
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+
+	w.WriteHeader(status)
+}
+
+func TestSender(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := now
+
+	partner := &receiver{statuses: []int{http.StatusServiceUnavailable}}
+	server := httptest.NewServer(partner)
+	defer server.Close()
+
+	uow := db.NewMemoryUnitOfWork()
+	repo := repository.NewMemoryWebhookRepository()
+	sender := NewSender(uow, repo, server.Client(), db.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: time.Minute}, 10)
+	sender.now = func() time.Time { return clock }
+
+	subscription, err := webhook.NewSubscription(server.URL+"/hooks", []string{"ticket.sold_out"})
+	assert.NoError(t, err)
+	assert.NoError(t, repo.CreateSubscription(ctx, subscription))
+
+	other, err := webhook.NewSubscription(server.URL+"/other", []string{"purchase.completed"})
+	assert.NoError(t, err)
+	assert.NoError(t, repo.CreateSubscription(ctx, other))
+
+	message, err := outbox.NewMessage(soldOut{TicketID: 1, At: now}, now)
+	assert.NoError(t, err)
+	message.ID = 42
+
+	dispatcher := &dispatcher{repo: repo, now: func() time.Time { return clock }}
+
+	t.Run("should queue a delivery for matching subscriptions once", func(t *testing.T) {
+		err := uow.Do(ctx, func(ctx context.Context) error {
+			return dispatcher.Publish(ctx, message)
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, dispatcher.Publish(ctx, message))
+
+		deliveries, err := repo.ListDeliveries(ctx, subscription.ID, 10)
+		assert.NoError(t, err)
+		assert.Len(t, deliveries, 1)
+		assert.Equal(t, int64(42), deliveries[0].MessageID)
+		assert.JSONEq(t, `{"id":42,"event":"ticket.sold_out","occurred_at":"2024-01-01T12:00:00Z","payload":{"ticket_id":1,"occurred_at":"2024-01-01T12:00:00Z"}}`, deliveries[0].Payload)
+
+		deliveries, err = repo.ListDeliveries(ctx, other.ID, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, deliveries)
+	})
+
+	t.Run("should retry a failed delivery after a backoff", func(t *testing.T) {
+		claimed, err := sender.SendBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, claimed)
+
+		d, err := repo.FindDelivery(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, webhook.DeliveryPending, d.Status)
+		assert.Equal(t, 1, d.Attempts)
+		assert.Equal(t, http.StatusServiceUnavailable, d.ResponseStatus)
+		assert.Equal(t, "webhook responded with 503 Service Unavailable", d.LastError)
+		assert.WithinRange(t, d.NextAttemptAt, now.Add(500*time.Millisecond), now.Add(time.Second))
+
+		claimed, err = sender.SendBatch(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, claimed)
+
+		clock = d.NextAttemptAt
+		claimed, err = sender.SendBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, claimed)
+
+		d, err = repo.FindDelivery(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, webhook.DeliverySucceeded, d.Status)
+		assert.Equal(t, 2, d.Attempts)
+		assert.Equal(t, http.StatusNoContent, d.ResponseStatus)
+		assert.Equal(t, clock, *d.DeliveredAt)
+	})
+
+	t.Run("should sign every request", func(t *testing.T) {
+		assert.Len(t, partner.requests, 2)
+		for _, req := range partner.requests {
+			assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+			assert.Equal(t, "ticket.sold_out", req.header.Get(webhook.HeaderEvent))
+			assert.Equal(t, "42", req.header.Get(webhook.HeaderMessageID))
+			assert.Equal(t, "1", req.header.Get(webhook.HeaderDeliveryID))
+
+			err := webhook.Verify(subscription.Secret, req.header.Get(webhook.HeaderSignature), req.header.Get(webhook.HeaderTimestamp), req.body, clock, 5*time.Minute)
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("should give up after the last attempt", func(t *testing.T) {
+		partner.statuses = []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError}
+		delivery := webhook.NewDelivery(subscription.ID, 43, "ticket.sold_out", `{}`, clock)
+		assert.NoError(t, repo.CreateDelivery(ctx, delivery))
+
+		for i := 0; i < 3; i++ {
+			claimed, err := sender.SendBatch(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, claimed)
+
+			d, err := repo.FindDelivery(ctx, delivery.ID)
+			assert.NoError(t, err)
+			clock = d.NextAttemptAt
+		}
+
+		d, err := repo.FindDelivery(ctx, delivery.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, webhook.DeliveryFailed, d.Status)
+		assert.Equal(t, 3, d.Attempts)
+		assert.Equal(t, http.StatusInternalServerError, d.ResponseStatus)
+
+		claimed, err := sender.SendBatch(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, claimed)
+	})
+
+	t.Run("should fail deliveries of deleted subscriptions", func(t *testing.T) {
+		d := webhook.NewDelivery(subscription.ID, 44, "ticket.sold_out", `{}`, clock)
+		assert.NoError(t, repo.CreateDelivery(ctx, d))
+		assert.NoError(t, repo.DeleteSubscription(ctx, subscription.ID))
+
+		requests := len(partner.requests)
+		claimed, err := sender.SendBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, claimed)
+		assert.Len(t, partner.requests, requests)
+
+		d, err = repo.FindDelivery(ctx, d.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, webhook.DeliveryFailed, d.Status)
+		assert.Equal(t, webhook.ErrSubscriptionNotFound.Error(), d.LastError)
+	})
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/webhook"
+	"github.com/aaydin-tr/ddd-api-example/domain/webhook/repository"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+)
+
+//go:generate mockgen -destination=../../mock/service/webhook/webhook.go -package=service github.com/aaydin-tr/ddd-api-example/service/webhook WebhookService
+type WebhookService interface {
+	Create(ctx context.Context, req request.CreateWebhookRequest) (*webhook.SubscriptionDTO, error)
+	List(ctx context.Context) (*webhook.SubscriptionListDTO, error)
+	Delete(ctx context.Context, id int) error
+	ListDeliveries(ctx context.Context, id int, limit int) (*webhook.DeliveryListDTO, error)
+	Replay(ctx context.Context, deliveryID int) (*webhook.DeliveryDTO, error)
+}
+
+type Service struct {
+	uow  db.UnitOfWork
+	repo repository.WebhookRepository
+}
+
+func NewWebhookService(uow db.UnitOfWork, repo repository.WebhookRepository) WebhookService {
+	return &Service{uow: uow, repo: repo}
+}
+
+// Create returns the secret of the new subscription, it is not shown
+// again.
+func (s *Service) Create(ctx context.Context, req request.CreateWebhookRequest) (*webhook.SubscriptionDTO, error) {
+	subscription, err := webhook.NewSubscription(req.URL, req.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	dto := webhook.NewSubscriptionDTOFromEntity(subscription)
+	dto.Secret = subscription.Secret
+	return dto, nil
+}
+
+func (s *Service) List(ctx context.Context) (*webhook.SubscriptionListDTO, error) {
+	subscriptions, err := s.repo.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhook.NewSubscriptionListDTOFromEntities(subscriptions), nil
+}
+
+// Delete stops sending events to the subscription. Its pending deliveries
+// fail when they are due and its delivery log is kept.
+func (s *Service) Delete(ctx context.Context, id int) error {
+	return s.repo.DeleteSubscription(ctx, id)
+}
+
+func (s *Service) ListDeliveries(ctx context.Context, id int, limit int) (*webhook.DeliveryListDTO, error) {
+	if _, err := s.repo.FindSubscription(ctx, id); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.repo.ListDeliveries(ctx, id, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhook.NewDeliveryListDTOFromEntities(deliveries), nil
+}
+
+// Replay sends the payload of a finished delivery again as a new delivery
+// that the sender picks up with its next batch.
+func (s *Service) Replay(ctx context.Context, deliveryID int) (*webhook.DeliveryDTO, error) {
+	var replay *webhook.Delivery
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		d, err := s.repo.FindDelivery(ctx, deliveryID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.repo.FindSubscription(ctx, d.SubscriptionID); err != nil {
+			return err
+		}
+
+		replay, err = d.Replay(time.Now())
+		if err != nil {
+			return err
+		}
+
+		return s.repo.CreateDelivery(ctx, replay)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return webhook.NewDeliveryDTOFromEntity(replay), nil
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aaydin-tr/ddd-api-example/domain/webhook"
+	"github.com/aaydin-tr/ddd-api-example/infrastructure/db"
+	"github.com/aaydin-tr/ddd-api-example/interface/http/request"
+	mockdb "github.com/aaydin-tr/ddd-api-example/mock/db"
+	repository "github.com/aaydin-tr/ddd-api-example/mock/repository/webhook"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// runInTransaction stands in for the unit of work and runs fn directly.
+func runInTransaction(ctx context.Context, fn func(ctx context.Context) error, _ ...db.Option) error {
+	return fn(ctx)
+}
+
+func TestService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockWebhookRepository(ctrl)
+	service := NewWebhookService(mockdb.NewMockUnitOfWork(ctrl), mockRepo)
+
+	t.Run("should return the secret once", func(t *testing.T) {
+		var stored *webhook.Subscription
+		mockRepo.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, s *webhook.Subscription) error {
+			s.ID = 1
+			stored = s
+			return nil
+		})
+
+		dto, err := service.Create(context.Background(), request.CreateWebhookRequest{URL: "http://localhost:9000/hooks", Events: []string{"ticket.sold_out"}})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, dto.ID)
+		assert.Equal(t, []string{"ticket.sold_out"}, dto.Events)
+		assert.Equal(t, stored.Secret, dto.Secret)
+
+		mockRepo.EXPECT().ListSubscriptions(gomock.Any()).Return([]*webhook.Subscription{stored}, nil)
+		list, err := service.List(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, list.Items, 1)
+		assert.Empty(t, list.Items[0].Secret)
+	})
+
+	t.Run("should reject unknown events", func(t *testing.T) {
+		_, err := service.Create(context.Background(), request.CreateWebhookRequest{URL: "http://localhost:9000/hooks", Events: []string{"ticket.renamed"}})
+		assert.ErrorIs(t, err, webhook.ErrUnknownEvent)
+	})
+}
+
+func TestService_ListDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockWebhookRepository(ctrl)
+	service := NewWebhookService(mockdb.NewMockUnitOfWork(ctrl), mockRepo)
+
+	t.Run("should list the deliveries of the subscription", func(t *testing.T) {
+		mockRepo.EXPECT().FindSubscription(gomock.Any(), 1).Return(&webhook.Subscription{ID: 1}, nil)
+		mockRepo.EXPECT().ListDeliveries(gomock.Any(), 1, 20).Return([]*webhook.Delivery{{ID: 2, Payload: `{}`}, {ID: 1, Payload: `{}`}}, nil)
+
+		list, err := service.ListDeliveries(context.Background(), 1, 20)
+		assert.NoError(t, err)
+		assert.Len(t, list.Items, 2)
+		assert.Equal(t, 2, list.Items[0].ID)
+	})
+
+	t.Run("should fail for unknown subscriptions", func(t *testing.T) {
+		mockRepo.EXPECT().FindSubscription(gomock.Any(), 2).Return(nil, webhook.ErrSubscriptionNotFound)
+
+		_, err := service.ListDeliveries(context.Background(), 2, 20)
+		assert.ErrorIs(t, err, webhook.ErrSubscriptionNotFound)
+	})
+}
+
+func TestService_Replay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockWebhookRepository(ctrl)
+	mockUow := mockdb.NewMockUnitOfWork(ctrl)
+	service := NewWebhookService(mockUow, mockRepo)
+
+	failed := func() *webhook.Delivery {
+		return &webhook.Delivery{ID: 3, SubscriptionID: 1, MessageID: 42, Event: "ticket.sold_out", Payload: `{"id":42}`, Status: webhook.DeliveryFailed, Attempts: 8}
+	}
+
+	tests := []struct {
+		name string
+		mock func()
+		err  error
+	}{
+		{
+			name: "success",
+			mock: func() {
+				mockRepo.EXPECT().FindDelivery(gomock.Any(), 3).Return(failed(), nil)
+				mockRepo.EXPECT().FindSubscription(gomock.Any(), 1).Return(&webhook.Subscription{ID: 1}, nil)
+				mockRepo.EXPECT().CreateDelivery(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, d *webhook.Delivery) error {
+					assert.Equal(t, 3, *d.ReplayOf)
+					assert.Equal(t, webhook.DeliveryPending, d.Status)
+					d.ID = 4
+					return nil
+				})
+			},
+		},
+		{
+			name: "delivery not found",
+			mock: func() {
+				mockRepo.EXPECT().FindDelivery(gomock.Any(), 3).Return(nil, webhook.ErrDeliveryNotFound)
+			},
+			err: webhook.ErrDeliveryNotFound,
+		},
+		{
+			name: "subscription deleted",
+			mock: func() {
+				mockRepo.EXPECT().FindDelivery(gomock.Any(), 3).Return(failed(), nil)
+				mockRepo.EXPECT().FindSubscription(gomock.Any(), 1).Return(nil, webhook.ErrSubscriptionNotFound)
+			},
+			err: webhook.ErrSubscriptionNotFound,
+		},
+		{
+			name: "delivery still pending",
+			mock: func() {
+				d := failed()
+				d.Status = webhook.DeliveryPending
+				mockRepo.EXPECT().FindDelivery(gomock.Any(), 3).Return(d, nil)
+				mockRepo.EXPECT().FindSubscription(gomock.Any(), 1).Return(&webhook.Subscription{ID: 1}, nil)
+			},
+			err: webhook.ErrDeliveryPending,
+		},
+		{
+			name: "create error",
+			mock: func() {
+				mockRepo.EXPECT().FindDelivery(gomock.Any(), 3).Return(failed(), nil)
+				mockRepo.EXPECT().FindSubscription(gomock.Any(), 1).Return(&webhook.Subscription{ID: 1}, nil)
+				mockRepo.EXPECT().CreateDelivery(gomock.Any(), gomock.Any()).Return(errors.New("insert failed"))
+			},
+			err: errors.New("insert failed"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(runInTransaction)
+			tt.mock()
+
+			dto, err := service.Replay(context.Background(), 3)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+				assert.Nil(t, dto)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, 4, dto.ID)
+			assert.Equal(t, 3, *dto.ReplayOf)
+			assert.JSONEq(t, `{"id":42}`, string(dto.Payload))
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package valueobject
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrStreetIsRequired = errors.New("street is required")
+	ErrCityIsRequired   = errors.New("city is required")
+	ErrInvalidCountry   = errors.New("country must be an ISO-3166 alpha-2 code")
+	ErrInvalidAddress   = errors.New("invalid address value")
+)
+
+// Address is stored in a single jsonb column.
+type Address struct {
+	street     string
+	city       string
+	postalCode string
+	country    string
+}
+
+func NewAddress(street, city, postalCode, country string) (*Address, error) {
+	street = strings.TrimSpace(street)
+	if street == "" {
+		return nil, ErrStreetIsRequired
+	}
+
+	city = strings.TrimSpace(city)
+	if city == "" {
+		return nil, ErrCityIsRequired
+	}
+
+	if !isCountryCode(country) {
+		return nil, ErrInvalidCountry
+	}
+
+	return &Address{street: street, city: city, postalCode: strings.TrimSpace(postalCode), country: country}, nil
+}
+
+func (a *Address) GetStreet() string {
+	return a.street
+}
+
+func (a *Address) GetCity() string {
+	return a.city
+}
+
+func (a *Address) GetPostalCode() string {
+	return a.postalCode
+}
+
+func (a *Address) GetCountry() string {
+	return a.country
+}
+
+func (a *Address) Equals(value ValueObject) bool {
+	if value == nil {
+		return false
+	}
+
+	address, ok := value.(*Address)
+	if !ok {
+		return false
+	}
+
+	return *a == *address
+}
+
+func (a *Address) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var raw []byte
+	switch v := value.(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return ErrInvalidAddress
+	}
+
+	var dto AddressDTO
+	if err := json.Unmarshal(raw, &dto); err != nil {
+		return ErrInvalidAddress
+	}
+
+	address, err := NewAddress(dto.Street, dto.City, dto.PostalCode, dto.Country)
+	if err != nil {
+		return err
+	}
+
+	*a = *address
+	return nil
+}
+
+func (a *Address) Value() (driver.Value, error) {
+	raw, err := json.Marshal(NewAddressDTO(a))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(raw), nil
+}
+
+func isCountryCode(code string) bool {
+	if len(code) != 2 {
+		return false
+	}
+
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+type AddressDTO struct {
+	Street     string `json:"street"`
+	City       string `json:"city"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+} // @Name AddressDTO
+
+func NewAddressDTO(address *Address) *AddressDTO {
+	if address == nil {
+		return nil
+	}
+
+	return &AddressDTO{
+		Street:     address.street,
+		City:       address.city,
+		PostalCode: address.postalCode,
+		Country:    address.country,
+	}
+}
@@ -0,0 +1,68 @@
+package valueobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		street  string
+		city    string
+		country string
+		wantErr error
+	}{
+		{name: "valid address", street: "Mercedes-Platz 1", city: "Berlin", country: "DE"},
+		{name: "empty street", street: " ", city: "Berlin", country: "DE", wantErr: ErrStreetIsRequired},
+		{name: "empty city", street: "Mercedes-Platz 1", city: "", country: "DE", wantErr: ErrCityIsRequired},
+		{name: "lowercase country", street: "Mercedes-Platz 1", city: "Berlin", country: "de", wantErr: ErrInvalidCountry},
+		{name: "alpha-3 country", street: "Mercedes-Platz 1", city: "Berlin", country: "DEU", wantErr: ErrInvalidCountry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAddress(tt.street, tt.city, "10243", tt.country)
+			if tt.wantErr != nil {
+				assert.Nil(t, got)
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.street, got.GetStreet())
+			assert.Equal(t, tt.city, got.GetCity())
+			assert.Equal(t, "10243", got.GetPostalCode())
+			assert.Equal(t, tt.country, got.GetCountry())
+		})
+	}
+}
+
+func TestAddress_Equals(t *testing.T) {
+	address1 := &Address{street: "Mercedes-Platz 1", city: "Berlin", country: "DE"}
+	address2 := &Address{street: "Mercedes-Platz 1", city: "Berlin", country: "DE"}
+	address3 := &Address{street: "Mercedes-Platz 1", city: "Hamburg", country: "DE"}
+
+	assert.True(t, address1.Equals(address2))
+	assert.False(t, address1.Equals(address3))
+	assert.False(t, address1.Equals(&Name{value: "Berlin"}))
+	assert.False(t, address1.Equals(nil))
+}
+
+func TestAddress_ScanAndValue(t *testing.T) {
+	address := &Address{street: "Mercedes-Platz 1", city: "Berlin", postalCode: "10243", country: "DE"}
+
+	val, err := address.Value()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"street": "Mercedes-Platz 1", "city": "Berlin", "postal_code": "10243", "country": "DE"}`, val.(string))
+
+	scanned := &Address{}
+	assert.NoError(t, scanned.Scan([]byte(val.(string))))
+	assert.Equal(t, address, scanned)
+
+	assert.Equal(t, ErrInvalidAddress, scanned.Scan("not json"))
+	assert.Equal(t, ErrInvalidAddress, scanned.Scan(42))
+	assert.Equal(t, ErrCityIsRequired, scanned.Scan(`{"street": "Mercedes-Platz 1", "country": "DE"}`))
+	assert.NoError(t, scanned.Scan(nil))
+}
@@ -0,0 +1,119 @@
+package valueobject
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidDateRange = errors.New("date range must end after it starts")
+	ErrInvalidRange     = errors.New("invalid date range value")
+)
+
+// rangeLayouts are the formats Postgres prints timestamptz bounds in, with
+// the offset of the session time zone.
+var rangeLayouts = []string{
+	"2006-01-02 15:04:05.999999-07",
+	"2006-01-02 15:04:05.999999-07:00",
+	"2006-01-02 15:04:05.999999-07:00:00",
+}
+
+// DateRange is the half-open interval [start, end). It is stored in a
+// single tstzrange column.
+type DateRange struct {
+	start time.Time
+	end   time.Time
+}
+
+func NewDateRange(start, end time.Time) (*DateRange, error) {
+	if !end.After(start) {
+		return nil, ErrInvalidDateRange
+	}
+
+	return &DateRange{start: start, end: end}, nil
+}
+
+func (d *DateRange) GetStart() time.Time {
+	return d.start
+}
+
+func (d *DateRange) GetEnd() time.Time {
+	return d.end
+}
+
+func (d *DateRange) Duration() time.Duration {
+	return d.end.Sub(d.start)
+}
+
+func (d *DateRange) Equals(value ValueObject) bool {
+	if value == nil {
+		return false
+	}
+
+	dates, ok := value.(*DateRange)
+	if !ok {
+		return false
+	}
+
+	return d.start.Equal(dates.start) && d.end.Equal(dates.end)
+}
+
+func (d *DateRange) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var raw string
+	switch v := value.(type) {
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	default:
+		return ErrInvalidRange
+	}
+
+	if len(raw) < 2 || raw[0] != '[' || raw[len(raw)-1] != ')' {
+		return ErrInvalidRange
+	}
+
+	bounds := strings.Split(raw[1:len(raw)-1], ",")
+	if len(bounds) != 2 {
+		return ErrInvalidRange
+	}
+
+	start, err := parseRangeBound(bounds[0])
+	if err != nil {
+		return err
+	}
+
+	end, err := parseRangeBound(bounds[1])
+	if err != nil {
+		return err
+	}
+
+	dates, err := NewDateRange(start, end)
+	if err != nil {
+		return err
+	}
+
+	*d = *dates
+	return nil
+}
+
+func (d *DateRange) Value() (driver.Value, error) {
+	return "[" + d.start.UTC().Format(time.RFC3339Nano) + "," + d.end.UTC().Format(time.RFC3339Nano) + ")", nil
+}
+
+func parseRangeBound(bound string) (time.Time, error) {
+	bound = strings.Trim(bound, `"`)
+	for _, layout := range append([]string{time.RFC3339Nano}, rangeLayouts...) {
+		if t, err := time.Parse(layout, bound); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, ErrInvalidRange
+}
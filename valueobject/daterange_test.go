@@ -0,0 +1,79 @@
+package valueobject
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDateRange(t *testing.T) {
+	start := time.Date(2026, 11, 1, 19, 0, 0, 0, time.UTC)
+
+	dates, err := NewDateRange(start, start.Add(3*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, start, dates.GetStart())
+	assert.Equal(t, start.Add(3*time.Hour), dates.GetEnd())
+	assert.Equal(t, 3*time.Hour, dates.Duration())
+
+	dates, err = NewDateRange(start, start)
+	assert.Nil(t, dates)
+	assert.Equal(t, ErrInvalidDateRange, err)
+
+	dates, err = NewDateRange(start, start.Add(-time.Hour))
+	assert.Nil(t, dates)
+	assert.Equal(t, ErrInvalidDateRange, err)
+}
+
+func TestDateRange_Equals(t *testing.T) {
+	start := time.Date(2026, 11, 1, 19, 0, 0, 0, time.UTC)
+	berlin := time.FixedZone("CET", 60*60)
+
+	dates1 := &DateRange{start: start, end: start.Add(time.Hour)}
+	dates2 := &DateRange{start: start.In(berlin), end: start.Add(time.Hour).In(berlin)}
+	dates3 := &DateRange{start: start, end: start.Add(2 * time.Hour)}
+
+	assert.True(t, dates1.Equals(dates2))
+	assert.False(t, dates1.Equals(dates3))
+	assert.False(t, dates1.Equals(nil))
+}
+
+func TestDateRange_ScanAndValue(t *testing.T) {
+	start := time.Date(2026, 11, 1, 19, 0, 0, 0, time.UTC)
+	dates := &DateRange{start: start, end: start.Add(90 * time.Minute)}
+
+	val, err := dates.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "[2026-11-01T19:00:00Z,2026-11-01T20:30:00Z)", val)
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		wantErr error
+	}{
+		{name: "value", value: val},
+		{name: "postgres utc", value: []byte(`["2026-11-01 19:00:00+00","2026-11-01 20:30:00+00")`)},
+		{name: "postgres offset", value: `["2026-11-02 00:30:00+05:30","2026-11-02 02:00:00+05:30")`},
+		{name: "postgres fraction", value: `["2026-11-01 20:00:00.000000+01","2026-11-01 21:30:00+01")`},
+		{name: "closed upper bound", value: `["2026-11-01 19:00:00+00","2026-11-01 20:30:00+00"]`, wantErr: ErrInvalidRange},
+		{name: "empty", value: "empty", wantErr: ErrInvalidRange},
+		{name: "unbounded", value: `["2026-11-01 19:00:00+00",)`, wantErr: ErrInvalidRange},
+		{name: "wrong type", value: 42, wantErr: ErrInvalidRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanned := &DateRange{}
+			err := scanned.Scan(tt.value)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, dates.Equals(scanned))
+		})
+	}
+
+	assert.NoError(t, dates.Scan(nil))
+}
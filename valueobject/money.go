@@ -0,0 +1,167 @@
+package valueobject
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidAmount     = errors.New("amount cannot be negative")
+	ErrInvalidCurrency   = errors.New("currency must be an ISO-4217 code")
+	ErrCurrencyMismatch  = errors.New("cannot mix different currencies")
+	ErrInvalidMoney      = errors.New("invalid money value")
+	ErrInvalidMultiplier = errors.New("multiplier cannot be negative")
+	ErrAmountOverflow    = errors.New("amount overflow")
+)
+
+// Money is an amount in the minor units of its currency, e.g. 1250 EUR is
+// 12.50 euro. It is stored in a single column as "<amount> <currency>".
+type Money struct {
+	amount   int64
+	currency string
+}
+
+func NewMoney(amount int64, currency string) (*Money, error) {
+	if amount < 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	if !isCurrencyCode(currency) {
+		return nil, ErrInvalidCurrency
+	}
+
+	return &Money{amount: amount, currency: currency}, nil
+}
+
+func (m *Money) GetAmount() int64 {
+	return m.amount
+}
+
+func (m *Money) GetCurrency() string {
+	return m.currency
+}
+
+func (m *Money) IsZero() bool {
+	return m.amount == 0
+}
+
+func (m *Money) Add(other *Money) (*Money, error) {
+	if m.currency != other.currency {
+		return nil, ErrCurrencyMismatch
+	}
+
+	if m.amount > math.MaxInt64-other.amount {
+		return nil, ErrAmountOverflow
+	}
+
+	return &Money{amount: m.amount + other.amount, currency: m.currency}, nil
+}
+
+func (m *Money) Subtract(other *Money) (*Money, error) {
+	if m.currency != other.currency {
+		return nil, ErrCurrencyMismatch
+	}
+
+	return NewMoney(m.amount-other.amount, m.currency)
+}
+
+func (m *Money) Multiply(multiplier int) (*Money, error) {
+	if multiplier < 0 {
+		return nil, ErrInvalidMultiplier
+	}
+
+	if multiplier != 0 && m.amount > math.MaxInt64/int64(multiplier) {
+		return nil, ErrAmountOverflow
+	}
+
+	return &Money{amount: m.amount * int64(multiplier), currency: m.currency}, nil
+}
+
+func (m *Money) String() string {
+	return fmt.Sprintf("%d %s", m.amount, m.currency)
+}
+
+func (m *Money) Equals(value ValueObject) bool {
+	if value == nil {
+		return false
+	}
+
+	money, ok := value.(*Money)
+	if !ok {
+		return false
+	}
+
+	return m.amount == money.amount && m.currency == money.currency
+}
+
+func (m *Money) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var raw string
+	switch v := value.(type) {
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	default:
+		return ErrInvalidMoney
+	}
+
+	parts := strings.Fields(raw)
+	if len(parts) != 2 {
+		return ErrInvalidMoney
+	}
+
+	amount, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return ErrInvalidMoney
+	}
+
+	money, err := NewMoney(amount, parts[1])
+	if err != nil {
+		return err
+	}
+
+	*m = *money
+	return nil
+}
+
+func (m *Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+type MoneyDTO struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+} // @Name MoneyDTO
+
+func NewMoneyDTO(money *Money) *MoneyDTO {
+	if money == nil {
+		return nil
+	}
+
+	return &MoneyDTO{
+		Amount:   money.amount,
+		Currency: money.currency,
+	}
+}
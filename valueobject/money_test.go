@@ -0,0 +1,124 @@
+package valueobject
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		currency string
+		wantErr  error
+	}{
+		{name: "valid money", amount: 1250, currency: "EUR"},
+		{name: "zero amount", amount: 0, currency: "USD"},
+		{name: "negative amount", amount: -1, currency: "EUR", wantErr: ErrInvalidAmount},
+		{name: "lowercase currency", amount: 1, currency: "eur", wantErr: ErrInvalidCurrency},
+		{name: "short currency", amount: 1, currency: "EU", wantErr: ErrInvalidCurrency},
+		{name: "empty currency", amount: 1, currency: "", wantErr: ErrInvalidCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewMoney(tt.amount, tt.currency)
+			if tt.wantErr != nil {
+				assert.Nil(t, got)
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.amount, got.GetAmount())
+			assert.Equal(t, tt.currency, got.GetCurrency())
+		})
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	eur := &Money{amount: 1250, currency: "EUR"}
+	usd := &Money{amount: 100, currency: "USD"}
+
+	t.Run("add", func(t *testing.T) {
+		got, err := eur.Add(&Money{amount: 50, currency: "EUR"})
+		assert.NoError(t, err)
+		assert.Equal(t, &Money{amount: 1300, currency: "EUR"}, got)
+
+		_, err = eur.Add(usd)
+		assert.Equal(t, ErrCurrencyMismatch, err)
+
+		_, err = (&Money{amount: math.MaxInt64, currency: "EUR"}).Add(eur)
+		assert.Equal(t, ErrAmountOverflow, err)
+	})
+
+	t.Run("subtract", func(t *testing.T) {
+		got, err := eur.Subtract(&Money{amount: 250, currency: "EUR"})
+		assert.NoError(t, err)
+		assert.Equal(t, &Money{amount: 1000, currency: "EUR"}, got)
+
+		_, err = eur.Subtract(usd)
+		assert.Equal(t, ErrCurrencyMismatch, err)
+
+		_, err = eur.Subtract(&Money{amount: 1251, currency: "EUR"})
+		assert.Equal(t, ErrInvalidAmount, err)
+	})
+
+	t.Run("multiply", func(t *testing.T) {
+		got, err := eur.Multiply(3)
+		assert.NoError(t, err)
+		assert.Equal(t, &Money{amount: 3750, currency: "EUR"}, got)
+
+		got, err = eur.Multiply(0)
+		assert.NoError(t, err)
+		assert.True(t, got.IsZero())
+
+		_, err = eur.Multiply(-1)
+		assert.Equal(t, ErrInvalidMultiplier, err)
+
+		_, err = (&Money{amount: math.MaxInt64, currency: "EUR"}).Multiply(2)
+		assert.Equal(t, ErrAmountOverflow, err)
+	})
+
+	assert.Equal(t, &Money{amount: 1250, currency: "EUR"}, eur)
+}
+
+func TestMoney_Equals(t *testing.T) {
+	money1 := &Money{amount: 100, currency: "EUR"}
+	money2 := &Money{amount: 100, currency: "EUR"}
+	money3 := &Money{amount: 100, currency: "USD"}
+
+	assert.True(t, money1.Equals(money2))
+	assert.False(t, money1.Equals(money3))
+	assert.False(t, money1.Equals(&Name{value: "100 EUR"}))
+	assert.False(t, money1.Equals(nil))
+}
+
+func TestMoney_ScanAndValue(t *testing.T) {
+	money := &Money{}
+
+	err := money.Scan("1250 EUR")
+	assert.NoError(t, err)
+	assert.Equal(t, &Money{amount: 1250, currency: "EUR"}, money)
+
+	err = money.Scan([]byte("99 USD"))
+	assert.NoError(t, err)
+	assert.Equal(t, &Money{amount: 99, currency: "USD"}, money)
+
+	val, err := money.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "99 USD", val)
+
+	assert.NoError(t, money.Scan(nil))
+	assert.Equal(t, ErrInvalidMoney, money.Scan("EUR"))
+	assert.Equal(t, ErrInvalidMoney, money.Scan("abc EUR"))
+	assert.Equal(t, ErrInvalidMoney, money.Scan(12))
+	assert.Equal(t, ErrInvalidCurrency, money.Scan("12 eur"))
+}
+
+func TestNewMoneyDTO(t *testing.T) {
+	assert.Nil(t, NewMoneyDTO(nil))
+	assert.Equal(t, &MoneyDTO{Amount: 1250, Currency: "EUR"}, NewMoneyDTO(&Money{amount: 1250, currency: "EUR"}))
+}
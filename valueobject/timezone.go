@@ -0,0 +1,83 @@
+package valueobject
+
+import (
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	// Timezones are validated against the embedded database, so a host
+	// without zoneinfo accepts the same names.
+	_ "time/tzdata"
+)
+
+var (
+	ErrInvalidTimezone = errors.New("timezone must be an IANA time zone name")
+)
+
+// Timezone is an IANA time zone name like "Europe/Berlin".
+type Timezone struct {
+	value    string
+	location *time.Location
+}
+
+func NewTimezone(value string) (*Timezone, error) {
+	if value == "" || value == "Local" {
+		return nil, ErrInvalidTimezone
+	}
+
+	location, err := time.LoadLocation(value)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+
+	return &Timezone{value: value, location: location}, nil
+}
+
+func (t *Timezone) GetValue() string {
+	return t.value
+}
+
+func (t *Timezone) Location() *time.Location {
+	return t.location
+}
+
+func (t *Timezone) Equals(value ValueObject) bool {
+	if value == nil {
+		return false
+	}
+
+	timezone, ok := value.(*Timezone)
+	if !ok {
+		return false
+	}
+
+	return t.value == timezone.value
+}
+
+func (t *Timezone) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var raw string
+	switch v := value.(type) {
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	default:
+		return ErrInvalidTimezone
+	}
+
+	timezone, err := NewTimezone(raw)
+	if err != nil {
+		return err
+	}
+
+	*t = *timezone
+	return nil
+}
+
+func (t *Timezone) Value() (driver.Value, error) {
+	return t.value, nil
+}
@@ -0,0 +1,53 @@
+package valueobject
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTimezone(t *testing.T) {
+	t.Run("should load the location", func(t *testing.T) {
+		tz, err := NewTimezone("Europe/Berlin")
+		assert.NoError(t, err)
+		assert.Equal(t, "Europe/Berlin", tz.GetValue())
+
+		summer := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC).In(tz.Location())
+		_, offset := summer.Zone()
+		assert.Equal(t, 2*60*60, offset)
+	})
+
+	for _, value := range []string{"", "Local", "Europe/Atlantis", "+02:00"} {
+		t.Run("should reject "+value, func(t *testing.T) {
+			tz, err := NewTimezone(value)
+			assert.Nil(t, tz)
+			assert.Equal(t, ErrInvalidTimezone, err)
+		})
+	}
+}
+
+func TestTimezone_Equals(t *testing.T) {
+	berlin, _ := NewTimezone("Europe/Berlin")
+	berlin2, _ := NewTimezone("Europe/Berlin")
+	istanbul, _ := NewTimezone("Europe/Istanbul")
+
+	assert.True(t, berlin.Equals(berlin2))
+	assert.False(t, berlin.Equals(istanbul))
+	assert.False(t, berlin.Equals(nil))
+}
+
+func TestTimezone_ScanAndValue(t *testing.T) {
+	tz := &Timezone{}
+
+	assert.NoError(t, tz.Scan([]byte("UTC")))
+	assert.Equal(t, "UTC", tz.GetValue())
+	assert.Equal(t, time.UTC.String(), tz.Location().String())
+
+	val, err := tz.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "UTC", val)
+
+	assert.Equal(t, ErrInvalidTimezone, tz.Scan("Nowhere/City"))
+	assert.NoError(t, tz.Scan(nil))
+}